@@ -3,29 +3,46 @@ package main
 import (
 	"context"
 	"log"
-	"time"
+	stdhttp "net/http"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/services"
-	"futuagro.com/pkg/http/rest"
+	"futuagro.com/pkg/health"
+	"futuagro.com/pkg/http"
+	"futuagro.com/pkg/logging"
 	"futuagro.com/pkg/store"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	chiadapter "github.com/awslabs/aws-lambda-go-api-proxy/chi"
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
 	"github.com/go-chi/chi"
-	"github.com/go-chi/chi/middleware"
-	"github.com/go-chi/cors"
 )
 
 var chiLambda *chiadapter.ChiLambda
 
 func init() {
-	conf := config.NewDefaultConfig()
-	mongoClient, err := store.NewDB(conf)
+	// A lambda takes no command-line flags, it is configured by its environment
+	conf, err := config.Load("lambda", nil)
 	if err != nil {
 		log.Fatalf("FATAL: %v\n", err)
 	}
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logging.SetDefault(logger)
+
+	mongoClient, err := store.NewDB(conf)
+	if err != nil {
+		logger.WithError(err).Fatal("Error connecting to the database")
+	}
+	checkCtx, cancel := context.WithTimeout(context.Background(), conf.Database.ConnectTimeout)
+	err = store.NewMigrator(conf, mongoClient).CheckApplied(checkCtx)
+	cancel()
+	if err != nil {
+		logger.WithError(err).Fatal("Error checking the migrations of the database")
+	}
 	supplierRepository := store.NewMongoSupplierRepository(conf, mongoClient)
 	countryRepository := store.NewMongoCountryRepository(conf, mongoClient)
 	cityRepository := store.NewMongoCityRepository(conf, mongoClient)
@@ -33,57 +50,72 @@ func init() {
 	variantRepository := store.NewMongoVariantRepository(conf, mongoClient)
 	cropRepository := store.NewMongoCropRepository(conf, mongoClient)
 	userRepository := store.NewMongoUserRepository(conf, mongoClient)
+	apiClientRepository := store.NewMongoAPIClientRepository(conf, mongoClient)
+	auditRepository := store.NewMongoAuditRepository(conf, mongoClient)
+	outboxRepository := store.NewMongoOutboxRepository(conf, mongoClient)
+	webhookRepository := store.NewMongoWebhookRepository(conf, mongoClient)
+	webhookDeliveryRepository := store.NewMongoWebhookDeliveryRepository(conf, mongoClient)
+	importJobRepository := store.NewMongoImportJobRepository(conf, mongoClient)
+	referenceRepository := store.NewMongoReferenceRepository(conf, mongoClient)
+	lookupRepository := store.NewMongoLookupRepository(conf, mongoClient)
+	organizationRepository := store.NewMongoOrganizationRepository(conf, mongoClient)
+	invitationRepository := store.NewMongoInvitationRepository(conf, mongoClient)
+	exchangeRateRepository := store.NewMongoExchangeRateRepository(conf, mongoClient)
+	sessionRepository := store.NewMongoSessionRepository(conf, mongoClient)
 
-	supplierService := services.NewSupplierService(supplierRepository)
-	countryService := services.NewCountryService(countryRepository)
-	cityService := services.NewCityService(cityRepository)
-	itemService := services.NewItemService(itemRepository)
-	variantService := services.NewVariantService(variantRepository)
-	cropService := services.NewCropService(cropRepository)
-	userService := services.NewUserService(userRepository)
-	authService := services.NewAuthService(userRepository)
-
-	// Setup chi router
-	r := chi.NewRouter()
-	// Setup CORS
-	cors := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"ETag", "Link", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-OAuth-Scopes", "X-Accepted-OAuth-Scopes"},
-		AllowCredentials: true,
-		MaxAge:           3600, // Maximum value not ignored by any of major browsers
-	})
-	r.Use(cors.Handler)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-
-	// Set a timeout value on the request context (ctx), that will signal
-	// through ctx.Done() that the request has timed out and further
-	// processing should be stopped.
-	r.Use(middleware.Timeout(5 * time.Minute))
+	healthRegistry := health.NewRegistry(conf.Health.CheckTimeout)
+	healthRegistry.Register("config", func(ctx context.Context) error { return conf.Validate() })
+	healthRegistry.Register("mongo", store.NewPingCheck(mongoClient))
+	healthRegistry.Register("indexes", store.NewIndexCheck(conf, mongoClient))
 
-	rSupplier := rest.SupplierHandler{Service: supplierService}
-	rCountry := rest.CountryHandler{Service: countryService}
-	rCity := rest.CityHandler{Service: cityService}
-	rItem := rest.ItemHandler{Service: itemService}
-	rVariant := rest.VariantHandler{Service: variantService}
-	rCrop := rest.CropHandler{Service: cropService}
-	rUser := rest.UserHandler{Service: userService}
-	rAuth := rest.AuthHandler{Service: authService}
+	auditService := services.NewAuditService(auditRepository)
+	eventBus := services.NewEventBus(1000)
+	eventService := services.NewEventService(outboxRepository, eventBus)
+	eventSource := services.NewEventSource(conf, outboxRepository, eventBus)
+	integrityService := services.NewIntegrityService(conf, referenceRepository, auditService, eventService)
+	unitOfWork := services.NewUnitOfWork(store.NewMongoTransactor(mongoClient))
+	supplierService := services.NewSupplierService(supplierRepository, auditService, eventService, integrityService, unitOfWork)
+	countryService := services.NewCountryService(countryRepository, auditService, eventService, integrityService, unitOfWork)
+	cityService := services.NewCityService(cityRepository, auditService, eventService, integrityService, unitOfWork)
+	itemService := services.NewItemService(itemRepository, auditService, eventService, integrityService, unitOfWork)
+	variantService := services.NewVariantService(variantRepository, auditService, eventService, integrityService, unitOfWork)
+	cropService := services.NewCropService(cropRepository, auditService, eventService, integrityService, unitOfWork)
+	userService := services.NewUserService(userRepository, auditService, integrityService, unitOfWork)
+	authService := services.NewAuthService(conf, userRepository, sessionRepository)
+	apiClientService := services.NewAPIClientService(apiClientRepository, auditService, unitOfWork)
+	webhookService := services.NewWebhookService(webhookRepository, webhookDeliveryRepository, outboxRepository, auditService, unitOfWork)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepository, auditService, unitOfWork)
+	importService := services.NewImportService(importJobRepository, supplierService, cropService, countryService, cityService, itemService, variantService, exchangeRateService)
+	lookupService := services.NewLookupService(lookupRepository)
+	organizationService := services.NewOrganizationService(organizationRepository, invitationRepository, auditService, integrityService, unitOfWork)
+	invitationService := services.NewInvitationService(conf, invitationRepository, organizationRepository, authService, auditService, unitOfWork)
+	reportService := services.NewReportService(cropService, organizationRepository)
 
-	r.Mount("/suppliers", rSupplier.NewRouter())
-	r.Mount("/countries", rCountry.NewRouter())
-	r.Mount("/country-states", rCity.NewRouter())
-	r.Mount("/items", rItem.NewRouter())
-	r.Mount("/items/{itemID}/variants", rVariant.NewRouter())
-	r.Mount("/crops", rCrop.NewRouter())
-	r.Mount("/users", rUser.NewRouter())
-	r.Mount("/auth", rAuth.NewRouter())
+	// The lambda serves the same router as the standalone HTTP server. Webhooks are dispatched
+	// by the standalone server only, a lambda is frozen between invocations. For the same reason
+	// the rows of a large import may be committed late, import them with the standalone server.
+	server := http.NewServer(conf, logger, supplierService, countryService, cityService,
+		itemService, variantService, cropService, userService, authService, apiClientService, auditService, webhookService, importService, lookupService,
+		organizationService, invitationService, reportService, exchangeRateService, eventSource, healthRegistry)
 
+	r := chi.NewRouter()
+	r.Use(apiGatewayRequestID)
+	r.Mount("/", server.Router())
 	chiLambda = chiadapter.New(r)
 }
 
+// apiGatewayRequestID uses the ID given to the request by API Gateway as the request ID, so that
+// the logs of the lambda can be correlated with the ones of the gateway
+func apiGatewayRequestID(next stdhttp.Handler) stdhttp.Handler {
+	fn := func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		if apiGwContext, ok := core.GetAPIGatewayContextFromContext(r.Context()); ok && r.Header.Get("X-Request-Id") == "" {
+			r.Header.Set("X-Request-Id", apiGwContext.RequestID)
+		}
+		next.ServeHTTP(w, r)
+	}
+	return stdhttp.HandlerFunc(fn)
+}
+
 // Handler is our lambda handler invoked by the `lambda.Start` function call
 func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	return chiLambda.ProxyWithContext(ctx, req)
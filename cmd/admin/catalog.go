@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"futuagro.com/pkg/domain/models"
+)
+
+func (a *app) runCountries(ctx context.Context, command string, args []string) error {
+	if command != "list" {
+		return errUsage
+	}
+	countries, err := a.countries.FindAllCountries()
+	if err != nil {
+		return err
+	}
+	return a.output.print(countries, "ID\tCODE\tNAME\tSTATES\tSTATUS", func(w io.Writer) {
+		for _, country := range countries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", country.ID.Hex(), orDash(country.CountryCode), country.CountryName,
+				len(country.States), recordStatus(country.RecordStatus))
+		}
+	})
+}
+
+func (a *app) runItems(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return a.listItems()
+	case "delete":
+		deleteFlags := flag.NewFlagSet("delete", flag.ExitOnError)
+		dryRun := deleteFlags.Bool("dry-run", false, "print what the delete would do without deleting")
+		yes := deleteFlags.Bool("yes", false, "delete without asking for a confirmation")
+		id, err := idArg(deleteFlags, args)
+		if err != nil {
+			return err
+		}
+		item, err := a.items.FindItemByID(id)
+		if err != nil {
+			return err
+		}
+		return a.delete(deleteTarget{
+			resource: models.ResourceItem,
+			id:       item.ID,
+			label:    fmt.Sprintf("the item %s (%s)", item.Name, id),
+			remove: func() error {
+				_, err := a.items.DeleteItemByID(ctx, id, nil)
+				return err
+			},
+		}, *dryRun, *yes)
+	}
+	return errUsage
+}
+
+func (a *app) listItems() error {
+	items, err := a.items.FindAllItems()
+	if err != nil {
+		return err
+	}
+	return a.output.print(items, "ID\tNAME\tVARIANTS\tSTATUS", func(w io.Writer) {
+		for _, item := range items {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", item.ID.Hex(), item.Name, len(item.Variants), recordStatus(item.RecordStatus))
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/models"
+)
+
+func (a *app) runCrops(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return a.listCrops()
+	case "harvest-report":
+		year := time.Date(time.Now().Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		reportFlags := flag.NewFlagSet("harvest-report", flag.ExitOnError)
+		from := reportFlags.String("from", year.Format("2006-01-02"), "first harvest `date` counted")
+		to := reportFlags.String("to", year.AddDate(1, 0, 0).Format("2006-01-02"), "harvest `date` the report stops before")
+		reportFlags.Parse(args)
+		fromDate, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			return fmt.Errorf("invalid -from date %q, expected YYYY-MM-DD", *from)
+		}
+		toDate, err := time.Parse("2006-01-02", *to)
+		if err != nil {
+			return fmt.Errorf("invalid -to date %q, expected YYYY-MM-DD", *to)
+		}
+		return a.harvestReport(ctx, fromDate, toDate)
+	}
+	return errUsage
+}
+
+func (a *app) listCrops() error {
+	crops, err := a.crops.FindAllCrops()
+	if err != nil {
+		return err
+	}
+	return a.output.print(crops, "ID\tVARIANT\tCITY\tSUPPLIER\tPLANTED\tHARVEST", func(w io.Writer) {
+		for _, crop := range crops {
+			variant, city, supplier := "", "", ""
+			if crop.Variant != nil {
+				variant = crop.Variant.Name
+			}
+			if crop.City != nil {
+				city = crop.City.CityName
+			}
+			if crop.Supplier != nil {
+				supplier = strings.TrimSpace(crop.Supplier.Name + " " + crop.Supplier.Surname)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", crop.ID.Hex(), orDash(variant), orDash(city), orDash(supplier),
+				formatDate(crop.PlantingDate), formatDate(crop.HarvestDate))
+		}
+	})
+}
+
+func (a *app) harvestReport(ctx context.Context, from time.Time, to time.Time) error {
+	report, err := a.crops.HarvestReport(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	if report == nil {
+		report = []*models.HarvestReportRow{}
+	}
+	return a.output.print(report, "MONTH\tVARIANT\tCROPS\tSUPPLIERS", func(w io.Writer) {
+		for _, row := range report {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", row.Month, orDash(row.Variant), row.Crops, row.Suppliers)
+		}
+	})
+}
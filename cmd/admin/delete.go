@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// deleteTarget is a record a command deletes through its service, remove deletes it and applies
+// the delete policies to the records referencing it
+type deleteTarget struct {
+	resource string
+	id       primitive.ObjectID
+	label    string
+	remove   func() error
+}
+
+// delete plans the delete of a record to print its effects on the records referencing it, then
+// deletes the record once the operator confirmed it. A delete restricted by the referencing
+// records fails before anything is asked.
+func (a *app) delete(target deleteTarget, dryRun bool, yes bool) error {
+	plan, err := a.integrity.PlanDelete(context.Background(), target.resource, target.id)
+	if err != nil {
+		return err
+	}
+	effects := plan.Effects()
+
+	verb := "Deleting"
+	if dryRun {
+		verb = "Would delete"
+	}
+	fmt.Printf("%s %s\n", verb, target.label)
+	for _, effect := range effects {
+		fmt.Printf("  and %s\n", effect)
+	}
+	if dryRun {
+		return nil
+	}
+	if !yes && !confirm("Delete "+target.label+"?") {
+		fmt.Fprintln(os.Stderr, "Cancelled")
+		return nil
+	}
+
+	if err := target.remove(); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %s\n", target.label)
+	return nil
+}
@@ -0,0 +1,137 @@
+// Command admin runs the operations of the platform that have no route in the API, such as
+// creating the first administrator, through the services of the server. It reads the
+// configuration of the server, the mutating commands accept -dry-run and the destructive ones ask
+// for a confirmation unless -yes is given:
+//
+//	go run ./cmd/admin users create-admin -name Ana -email ana@futuagro.com
+//	go run ./cmd/admin users reset-password 5d7a4f0e8c1b2a3d4e5f6a7b
+//	go run ./cmd/admin -output json suppliers list
+//	go run ./cmd/admin suppliers delete -dry-run 5d7a4f0e8c1b2a3d4e5f6a7b
+//	go run ./cmd/admin crops harvest-report -from 2026-01-01 -to 2027-01-01
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/store"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: %s [-output table|json] [configuration flags] resource command [flags] [id]
+
+Commands:
+  users list
+  users create-admin [-dry-run] -name name -email email [-surname surname]
+                     create an user with the admin role, the password is read from
+                     the standard input
+  users reset-password [-dry-run] id
+                     replace the password of an user, read from the standard input
+  suppliers list
+  suppliers deactivate [-dry-run] id
+  suppliers activate [-dry-run] id
+  suppliers delete [-dry-run] [-yes] id
+                     delete a supplier and apply the delete policies to its crops
+  crops list
+  crops harvest-report [-from date] [-to date]
+                     count the crops harvested from the first to before the second
+                     date, by month and variant, the current year by default
+  countries list
+  items list
+  items delete [-dry-run] [-yes] id
+                     delete an item and apply the delete policies to its variants
+
+Flags:
+`
+
+// app holds the services the commands run through
+type app struct {
+	output    *printer
+	users     *services.UserService
+	suppliers *services.SupplierService
+	crops     *services.CropService
+	countries *services.CountryService
+	items     *services.ItemService
+	integrity *services.IntegrityService
+}
+
+func main() {
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("FATAL: Error loading the .env file: %v\n", err)
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), usage, os.Args[0])
+		flags.PrintDefaults()
+	}
+	format := flags.String("output", formatTable, "`format` of the output, table or json")
+	conf, err := config.LoadFlags(flags, os.Args[1:])
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	if flags.NArg() < 2 || (*format != formatTable && *format != formatJSON) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logging.SetDefault(logger)
+
+	mongoClient, err := store.NewDB(conf)
+	if err != nil {
+		logger.WithError(err).Fatal("Error connecting to the database")
+	}
+	defer mongoClient.Disconnect(context.Background())
+
+	auditService := services.NewAuditService(store.NewMongoAuditRepository(conf, mongoClient))
+	eventService := services.NewEventService(store.NewMongoOutboxRepository(conf, mongoClient), services.NewEventBus(1000))
+	integrityService := services.NewIntegrityService(conf, store.NewMongoReferenceRepository(conf, mongoClient), auditService, eventService)
+	unitOfWork := services.NewUnitOfWork(store.NewMongoTransactor(mongoClient))
+	a := &app{
+		output:    &printer{format: *format, out: os.Stdout},
+		users:     services.NewUserService(store.NewMongoUserRepository(conf, mongoClient), auditService, integrityService, unitOfWork),
+		suppliers: services.NewSupplierService(store.NewMongoSupplierRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		crops:     services.NewCropService(store.NewMongoCropRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		countries: services.NewCountryService(store.NewMongoCountryRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		items:     services.NewItemService(store.NewMongoItemRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		integrity: integrityService,
+	}
+
+	ctx := services.WithPrincipal(context.Background(), &models.Principal{
+		Type: models.PrincipalSystem,
+		ID:   models.PrincipalSystem,
+		Name: "admin",
+	})
+	resource, command, args := flags.Arg(0), flags.Arg(1), flags.Args()[2:]
+	switch resource {
+	case "users":
+		err = a.runUsers(ctx, command, args)
+	case "suppliers":
+		err = a.runSuppliers(ctx, command, args)
+	case "crops":
+		err = a.runCrops(ctx, command, args)
+	case "countries":
+		err = a.runCountries(ctx, command, args)
+	case "items":
+		err = a.runItems(ctx, command, args)
+	default:
+		err = errUsage
+	}
+	if err == errUsage {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		logger.WithError(err).Fatalf("Error running %s %s", resource, command)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// errUsage is returned by the commands called with unknown or missing arguments
+var errUsage = errors.New("invalid usage")
+
+// printer writes the results of the commands as an aligned table or as indented JSON
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+// print writes value as JSON, or the rows written by table under a header otherwise
+func (p *printer) print(value interface{}, header string, table func(w io.Writer)) error {
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+	w := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, header)
+	table(w)
+	return w.Flush()
+}
+
+// idArg parses the flags of a command taking a single ID as argument
+func idArg(commandFlags *flag.FlagSet, args []string) (string, error) {
+	commandFlags.Parse(args)
+	if commandFlags.NArg() != 1 {
+		commandFlags.Usage()
+		return "", errUsage
+	}
+	return commandFlags.Arg(0), nil
+}
+
+// confirm asks the operator to confirm a destructive operation on the terminal, anything but an
+// explicit yes declines it
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// readSecret reads a line from the standard input, prompting for it without echo when it is a
+// terminal
+func readSecret(prompt string) (string, error) {
+	if fd := int(os.Stdin.Fd()); terminal.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "%s: ", prompt)
+		secret, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(secret), err
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+)
+
+func (a *app) runSuppliers(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return a.listSuppliers()
+	case "deactivate", "activate":
+		statusFlags := flag.NewFlagSet(command, flag.ExitOnError)
+		dryRun := statusFlags.Bool("dry-run", false, "print the supplier without changing its status")
+		id, err := idArg(statusFlags, args)
+		if err != nil {
+			return err
+		}
+		status := enums.Inactive
+		if command == "activate" {
+			status = enums.Active
+		}
+		return a.setSupplierStatus(ctx, id, status, *dryRun)
+	case "delete":
+		deleteFlags := flag.NewFlagSet("delete", flag.ExitOnError)
+		dryRun := deleteFlags.Bool("dry-run", false, "print what the delete would do without deleting")
+		yes := deleteFlags.Bool("yes", false, "delete without asking for a confirmation")
+		id, err := idArg(deleteFlags, args)
+		if err != nil {
+			return err
+		}
+		supplier, err := a.suppliers.FindSupplierByID(id)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSpace(supplier.Name + " " + supplier.Surname)
+		return a.delete(deleteTarget{
+			resource: models.ResourceSupplier,
+			id:       supplier.ID,
+			label:    fmt.Sprintf("the supplier %s (%s)", name, id),
+			remove: func() error {
+				_, err := a.suppliers.DeleteSupplier(ctx, id, nil)
+				return err
+			},
+		}, *dryRun, *yes)
+	}
+	return errUsage
+}
+
+func (a *app) listSuppliers() error {
+	suppliers, err := a.suppliers.FindAllSuppliers()
+	if err != nil {
+		return err
+	}
+	return a.output.print(suppliers, "ID\tNAME\tDOCUMENT\tEMAIL\tCITY\tSTATUS", func(w io.Writer) {
+		for _, supplier := range suppliers {
+			name := strings.TrimSpace(supplier.Name + " " + supplier.Surname)
+			document := strings.TrimSpace(supplier.DocumentType + " " + supplier.DocumentNumber)
+			city := ""
+			if supplier.City != nil {
+				city = supplier.City.CityName
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", supplier.ID.Hex(), name, orDash(document), orDash(supplier.Email), orDash(city), recordStatus(supplier.RecordStatus))
+		}
+	})
+}
+
+func (a *app) setSupplierStatus(ctx context.Context, id string, status enums.EnumRecordStatus, dryRun bool) error {
+	supplier, err := a.suppliers.FindSupplierByID(id)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(supplier.Name + " " + supplier.Surname)
+	if recordStatus(supplier.RecordStatus) == status.String() {
+		fmt.Printf("The supplier %s is already %s\n", name, status)
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("Would set the supplier %s %s\n", name, status)
+		return nil
+	}
+
+	if _, err := a.suppliers.SetSupplierStatus(ctx, id, status, nil); err != nil {
+		return err
+	}
+	fmt.Printf("Set the supplier %s %s\n", name, status)
+	return nil
+}
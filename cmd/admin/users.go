@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+)
+
+func (a *app) runUsers(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return a.listUsers()
+	case "create-admin":
+		createFlags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+		dryRun := createFlags.Bool("dry-run", false, "print the user without creating it")
+		dto := &dtos.UserDto{}
+		createFlags.StringVar(&dto.Name, "name", "", "`name` of the user")
+		createFlags.StringVar(&dto.Surname, "surname", "", "`surname` of the user")
+		createFlags.StringVar(&dto.Email, "email", "", "`email` the user logs in with")
+		createFlags.Parse(args)
+		if dto.Name == "" || dto.Email == "" || createFlags.NArg() != 0 {
+			createFlags.Usage()
+			return errUsage
+		}
+		return a.createAdmin(ctx, dto, *dryRun)
+	case "reset-password":
+		resetFlags := flag.NewFlagSet("reset-password", flag.ExitOnError)
+		dryRun := resetFlags.Bool("dry-run", false, "check the user without changing its password")
+		id, err := idArg(resetFlags, args)
+		if err != nil {
+			return err
+		}
+		return a.resetPassword(ctx, id, *dryRun)
+	}
+	return errUsage
+}
+
+func (a *app) listUsers() error {
+	users, err := a.users.FindAllUsers()
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		user.HashedPassword = ""
+	}
+	return a.output.print(users, "ID\tEMAIL\tNAME\tROLE\tSTATUS", func(w io.Writer) {
+		for _, user := range users {
+			name := strings.TrimSpace(user.Name + " " + user.Surname)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", user.ID.Hex(), orDash(user.Email), name, orDash(user.Role), recordStatus(user.RecordStatus))
+		}
+	})
+}
+
+func (a *app) createAdmin(ctx context.Context, dto *dtos.UserDto, dryRun bool) error {
+	if dryRun {
+		fmt.Printf("Would create the admin user %s\n", dto.Email)
+		return nil
+	}
+	password, err := readSecret("Password")
+	if err != nil {
+		return err
+	}
+	dto.Password = password
+
+	user, err := a.users.CreateAdmin(ctx, dto)
+	if err != nil {
+		return err
+	}
+	user.HashedPassword = ""
+	return a.output.print(user, "ID\tEMAIL\tROLE", func(w io.Writer) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", user.ID.Hex(), user.Email, user.Role)
+	})
+}
+
+func (a *app) resetPassword(ctx context.Context, id string, dryRun bool) error {
+	user, err := a.users.FindUserByID(id)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("Would reset the password of the user %s\n", user.Email)
+		return nil
+	}
+
+	password, err := readSecret("New password")
+	if err != nil {
+		return err
+	}
+	if _, err := a.users.ResetPassword(ctx, id, password); err != nil {
+		return err
+	}
+	fmt.Printf("Reset the password of the user %s\n", user.Email)
+	return nil
+}
+
+// recordStatus prints the status of a record, the records without one are active
+func recordStatus(status *enums.EnumRecordStatus) string {
+	if status == nil {
+		return enums.Active.String()
+	}
+	return status.String()
+}
@@ -0,0 +1,122 @@
+// Command import creates suppliers, crops or exchange rates from the rows of a CSV or XLSX file, like the
+// /imports routes of the API. It reads the configuration of the server, prints the errors of the
+// invalid rows and commits the valid ones unless -dry-run is given:
+//
+//	go run ./cmd/import -kind suppliers -dry-run suppliers.xlsx
+//	go run ./cmd/import -kind crops crops.csv
+//	go run ./cmd/import -kind exchange-rates rates.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/spreadsheet"
+	"futuagro.com/pkg/store"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("FATAL: Error loading the .env file: %v\n", err)
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	kind := flags.String("kind", "", "`kind` of the records of the file, suppliers, crops or exchange-rates")
+	dryRun := flags.Bool("dry-run", false, "only validate the rows")
+	conf, err := config.LoadFlags(flags, os.Args[1:])
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	if !enums.EnumImportKind(*kind).IsValid() || flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s -kind suppliers|crops|exchange-rates [-dry-run] file\n", filepath.Base(os.Args[0]))
+		os.Exit(2)
+	}
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logging.SetDefault(logger)
+
+	path := flags.Arg(0)
+	format := spreadsheet.FormatOf(path, "")
+	if format == "" {
+		logger.WithField("file", path).Fatal("Unsupported file, expected a .csv or .xlsx file")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		logger.WithError(err).Fatal("Error opening the file")
+	}
+	table, err := spreadsheet.Read(file, format)
+	file.Close()
+	if err != nil {
+		logger.WithError(err).Fatal("Error reading the file")
+	}
+
+	mongoClient, err := store.NewDB(conf)
+	if err != nil {
+		logger.WithError(err).Fatal("Error connecting to the database")
+	}
+	defer mongoClient.Disconnect(context.Background())
+
+	auditService := services.NewAuditService(store.NewMongoAuditRepository(conf, mongoClient))
+	eventService := services.NewEventService(store.NewMongoOutboxRepository(conf, mongoClient), services.NewEventBus(1000))
+	integrityService := services.NewIntegrityService(conf, store.NewMongoReferenceRepository(conf, mongoClient), auditService, eventService)
+	unitOfWork := services.NewUnitOfWork(store.NewMongoTransactor(mongoClient))
+	importService := services.NewImportService(
+		store.NewMongoImportJobRepository(conf, mongoClient),
+		services.NewSupplierService(store.NewMongoSupplierRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		services.NewCropService(store.NewMongoCropRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		services.NewCountryService(store.NewMongoCountryRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		services.NewCityService(store.NewMongoCityRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		services.NewItemService(store.NewMongoItemRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		services.NewVariantService(store.NewMongoVariantRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork),
+		services.NewExchangeRateService(store.NewMongoExchangeRateRepository(conf, mongoClient), auditService, unitOfWork),
+	)
+
+	ctx := services.WithPrincipal(context.Background(), &models.Principal{
+		Type: models.PrincipalSystem,
+		ID:   models.PrincipalSystem,
+		Name: "import",
+	})
+	dto := &dtos.ImportDto{
+		Kind:     enums.EnumImportKind(*kind),
+		FileName: filepath.Base(path),
+		Format:   format,
+		DryRun:   *dryRun,
+	}
+	job, err := importService.Import(ctx, dto, table, false)
+	if err != nil {
+		logger.WithError(err).Fatal("Error importing the file")
+	}
+
+	for _, rowError := range job.Errors {
+		if rowError.Column != "" {
+			fmt.Printf("%s:%d: %s: %s\n", dto.FileName, rowError.Line, rowError.Column, rowError.Message)
+		} else {
+			fmt.Printf("%s:%d: %s\n", dto.FileName, rowError.Line, rowError.Message)
+		}
+	}
+	if job.ErrorsTruncated {
+		fmt.Println("More errors were found, fix these ones and validate the file again")
+	}
+	valid := job.TotalRows - job.InvalidRows
+	if *dryRun {
+		fmt.Printf("%d rows, %d valid, %d invalid\n", job.TotalRows, valid, job.InvalidRows)
+	} else {
+		fmt.Printf("%d rows, %d imported, %d invalid, import %s is %s\n", job.TotalRows, job.CommittedRows, job.InvalidRows, job.ID.Hex(), job.Status)
+	}
+	if job.InvalidRows > 0 || (!*dryRun && job.CommittedRows < valid) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,150 @@
+// Command migrate applies the pending migrations of the database and creates the indexes the
+// repositories rely on. It reads the configuration of the server:
+//
+//	go run ./cmd/migrate list
+//	go run ./cmd/migrate up -dry-run
+//	go run ./cmd/migrate up
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/store"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: %s [configuration flags] command
+
+Commands:
+  list           list the migrations and the missing indexes
+  up [-dry-run]  apply the pending migrations, then create the missing indexes,
+                 -dry-run only prints what would be done
+
+Configuration flags:
+`
+
+func main() {
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("FATAL: Error loading the .env file: %v\n", err)
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), usage, os.Args[0])
+		flags.PrintDefaults()
+	}
+	conf, err := config.LoadFlags(flags, os.Args[1:])
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logging.SetDefault(logger)
+
+	mongoClient, err := store.NewDB(conf)
+	if err != nil {
+		logger.WithError(err).Fatal("Error connecting to the database")
+	}
+	defer mongoClient.Disconnect(context.Background())
+	migrator := store.NewMigrator(conf, mongoClient)
+	ctx := context.Background()
+
+	switch command := flags.Arg(0); command {
+	case "list":
+		err = list(ctx, migrator)
+	case "up":
+		upFlags := flag.NewFlagSet("up", flag.ExitOnError)
+		dryRun := upFlags.Bool("dry-run", false, "print the pending migrations and the missing indexes without applying them")
+		upFlags.Parse(flags.Args()[1:])
+		err = up(ctx, migrator, *dryRun)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		logger.WithError(err).Fatal("Error migrating the database")
+	}
+}
+
+func list(ctx context.Context, migrator *store.Migrator) error {
+	statuses, err := migrator.List(ctx)
+	if err != nil {
+		return err
+	}
+	plan, err := migrator.Plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tAPPLIED AT\tDESCRIPTION")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		description := status.Description
+		if status.Unknown {
+			description += " (unknown to this version)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, appliedAt, description)
+	}
+	w.Flush()
+	printIndexes("Missing indexes:", plan.Indexes)
+	return nil
+}
+
+func up(ctx context.Context, migrator *store.Migrator, dryRun bool) error {
+	if dryRun {
+		plan, err := migrator.Plan(ctx)
+		if err != nil {
+			return err
+		}
+		if plan.Empty() {
+			fmt.Println("The database is up to date")
+			return nil
+		}
+		for _, migration := range plan.Migrations {
+			fmt.Printf("Would apply the migration %d: %s\n", migration.Version, migration.Description)
+		}
+		printIndexes("Would create the indexes:", plan.Indexes)
+		return nil
+	}
+
+	applied, err := migrator.Apply(ctx)
+	if applied != nil {
+		fmt.Printf("Applied %d migrations and created %d indexes\n", len(applied.Migrations), len(applied.Indexes))
+	}
+	return err
+}
+
+func printIndexes(title string, indexes []store.Index) {
+	if len(indexes) == 0 {
+		return
+	}
+	fmt.Println(title)
+	for _, index := range indexes {
+		unique := ""
+		if index.Unique {
+			unique = " unique"
+		}
+		if index.Expires {
+			unique += " ttl"
+		}
+		fmt.Printf("  %s.%s%s\n", index.Collection, index.Name, unique)
+	}
+}
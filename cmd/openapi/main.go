@@ -0,0 +1,74 @@
+// Command openapi writes the OpenAPI document of the API and checks that it documents every route
+// mounted on the router:
+//
+//	go run ./cmd/openapi -check
+//	go run ./cmd/openapi -out docs/openapi.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/http"
+	"futuagro.com/pkg/http/openapi"
+	"futuagro.com/pkg/logging"
+)
+
+func main() {
+	check := flag.Bool("check", false, "exit with status 1 when a mounted route is not documented or a documented route is not mounted")
+	out := flag.String("out", "", "write the OpenAPI document to this file, - for the standard output")
+	flag.Parse()
+
+	conf := config.Defaults()
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+
+	// The routes are only walked, the services behind them are never called
+	server := http.NewServer(conf, logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	doc := server.OpenAPI()
+
+	if *out != "" {
+		body, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			log.Fatalf("FATAL: %v\n", err)
+		}
+		body = append(body, '\n')
+		if *out == "-" {
+			os.Stdout.Write(body)
+		} else if err := ioutil.WriteFile(*out, body, 0644); err != nil {
+			log.Fatalf("FATAL: %v\n", err)
+		}
+	}
+
+	if *check {
+		missing, stale, err := openapi.Diff(server.Router(), doc)
+		if err != nil {
+			log.Fatalf("FATAL: %v\n", err)
+		}
+		for _, route := range missing {
+			fmt.Fprintf(os.Stderr, "not documented: %s\n", route)
+		}
+		for _, route := range stale {
+			fmt.Fprintf(os.Stderr, "documented but not mounted: %s\n", route)
+		}
+		if len(missing) > 0 || len(stale) > 0 {
+			os.Exit(1)
+		}
+		fmt.Printf("The OpenAPI document covers the %d routes of the router\n", countOperations(doc))
+	}
+}
+
+func countOperations(doc *openapi.Document) int {
+	count := 0
+	for _, item := range doc.Paths {
+		count += len(item)
+	}
+	return count
+}
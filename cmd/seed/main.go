@@ -0,0 +1,213 @@
+// Command seed loads the reference geography, countries, states and cities, from CSV files keyed
+// on official codes. It reads the configuration of the server and can be run again at any time,
+// the existing records are renamed instead of being duplicated:
+//
+//	go run ./cmd/seed -dry-run
+//	go run ./cmd/seed -cities divipola.csv
+//
+// The bundled files of data/geography hold the countries (code,name), their ISO 3166-2 states
+// (country_code,code,name) and cities (state_code,code,name), each flag replaces one of them.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/store"
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("FATAL: Error loading the .env file: %v\n", err)
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	dir := flags.String("dir", filepath.Join("data", "geography"), "`directory` of the countries.csv, states.csv and cities.csv files")
+	countriesFile := flags.String("countries", "", "countries `file`, replaces the one of -dir")
+	statesFile := flags.String("states", "", "states `file`, replaces the one of -dir")
+	citiesFile := flags.String("cities", "", "cities `file`, replaces the one of -dir")
+	dryRun := flags.Bool("dry-run", false, "print what would change without writing")
+	conf, err := config.LoadFlags(flags, os.Args[1:])
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logging.SetDefault(logger)
+
+	countries, err := readGeography(
+		orDefault(*countriesFile, filepath.Join(*dir, "countries.csv")),
+		orDefault(*statesFile, filepath.Join(*dir, "states.csv")),
+		orDefault(*citiesFile, filepath.Join(*dir, "cities.csv")),
+	)
+	if err != nil {
+		logger.WithError(err).Fatal("Error reading the reference geography")
+	}
+
+	mongoClient, err := store.NewDB(conf)
+	if err != nil {
+		logger.WithError(err).Fatal("Error connecting to the database")
+	}
+	defer mongoClient.Disconnect(context.Background())
+
+	auditService := services.NewAuditService(store.NewMongoAuditRepository(conf, mongoClient))
+	eventService := services.NewEventService(store.NewMongoOutboxRepository(conf, mongoClient), services.NewEventBus(1000))
+	integrityService := services.NewIntegrityService(conf, store.NewMongoReferenceRepository(conf, mongoClient), auditService, eventService)
+	unitOfWork := services.NewUnitOfWork(store.NewMongoTransactor(mongoClient))
+	countryService := services.NewCountryService(store.NewMongoCountryRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork)
+	cityService := services.NewCityService(store.NewMongoCityRepository(conf, mongoClient), auditService, eventService, integrityService, unitOfWork)
+	geographyService := services.NewGeographyService(countryService, cityService)
+
+	ctx := services.WithPrincipal(context.Background(), &models.Principal{
+		Type: models.PrincipalSystem,
+		ID:   models.PrincipalSystem,
+		Name: "seed",
+	})
+	report, err := geographyService.Seed(ctx, countries, *dryRun)
+	if report != nil {
+		verb := "Seeded"
+		if *dryRun {
+			verb = "Would seed"
+		}
+		for _, counts := range []struct {
+			kind string
+			services.SeedCounts
+		}{{"countries", report.Countries}, {"states", report.States}, {"cities", report.Cities}} {
+			fmt.Printf("%s %s: %d created, %d updated, %d unchanged\n", verb, counts.kind, counts.Created, counts.Updated, counts.Unchanged)
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Fatal("Error seeding the reference geography")
+	}
+}
+
+func orDefault(value string, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// readGeography returns the countries of the files with their states and cities, a missing
+// states or cities file is read as an empty one
+func readGeography(countriesPath string, statesPath string, citiesPath string) ([]dtos.GeographyCountryDto, error) {
+	var countries []dtos.GeographyCountryDto
+	countryIndex := map[string]int{}
+	err := readCSV(countriesPath, false, []string{"code", "name"}, func(line int, row []string) error {
+		code := strings.ToUpper(row[0])
+		if _, ok := countryIndex[code]; ok {
+			return errors.Errorf("%s:%d: duplicate country %s", countriesPath, line, code)
+		}
+		countryIndex[code] = len(countries)
+		countries = append(countries, dtos.GeographyCountryDto{CountryCode: code, CountryName: row[1]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type statePosition struct{ country, state int }
+	stateIndex := map[string]statePosition{}
+	err = readCSV(statesPath, true, []string{"country_code", "code", "name"}, func(line int, row []string) error {
+		country, ok := countryIndex[strings.ToUpper(row[0])]
+		if !ok {
+			return errors.Errorf("%s:%d: unknown country %s", statesPath, line, row[0])
+		}
+		code := strings.ToUpper(row[1])
+		if _, ok := stateIndex[code]; ok {
+			return errors.Errorf("%s:%d: duplicate state %s", statesPath, line, code)
+		}
+		stateIndex[code] = statePosition{country, len(countries[country].States)}
+		countries[country].States = append(countries[country].States, dtos.GeographyStateDto{StateCode: code, StateName: row[2]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cityCodes := map[string]bool{}
+	err = readCSV(citiesPath, true, []string{"state_code", "code", "name"}, func(line int, row []string) error {
+		position, ok := stateIndex[strings.ToUpper(row[0])]
+		if !ok {
+			return errors.Errorf("%s:%d: unknown state %s", citiesPath, line, row[0])
+		}
+		key := strings.ToUpper(row[0]) + "/" + row[1]
+		if cityCodes[key] {
+			return errors.Errorf("%s:%d: duplicate city %s", citiesPath, line, row[1])
+		}
+		cityCodes[key] = true
+		state := &countries[position.country].States[position.state]
+		state.Cities = append(state.Cities, dtos.GeographyCityDto{CityCode: row[1], CityName: row[2]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+// readCSV calls row with the columns of every record of a CSV file in the order of columns, the
+// first record of the file names its columns and every column is required
+func readCSV(path string, optional bool, columns []string, row func(line int, values []string) error) error {
+	file, err := os.Open(path)
+	if optional && os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "Error opening a reference file")
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return errors.Wrapf(err, "Error reading the header of %s", path)
+	}
+	positions := make([]int, len(columns))
+	for i, column := range columns {
+		positions[i] = -1
+		for j, name := range header {
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")), column) {
+				positions[i] = j
+			}
+		}
+		if positions[i] < 0 {
+			return errors.Errorf("%s: missing the %s column", path, column)
+		}
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Error reading %s", path)
+		}
+		values := make([]string, len(columns))
+		for i, position := range positions {
+			if values[i] = strings.TrimSpace(record[position]); values[i] == "" {
+				return errors.Errorf("%s:%d: the %s column is empty", path, line, columns[i])
+			}
+		}
+		if err := row(line, values); err != nil {
+			return err
+		}
+	}
+}
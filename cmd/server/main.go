@@ -1,35 +1,49 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"os"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/grpc"
+	"futuagro.com/pkg/health"
 	"futuagro.com/pkg/http"
+	"futuagro.com/pkg/logging"
 	"futuagro.com/pkg/store"
 	"github.com/joho/godotenv"
 )
 
 func init() {
-	// loads values from .env into the system
-	if err := godotenv.Load(); err != nil {
-		log.Print("No .env file found")
-		log.Fatal(err)
+	// loads values from an optional .env file into the environment
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("FATAL: Error loading the .env file: %v\n", err)
 	}
 }
 
 func main() {
-	conf := config.NewDefaultConfig()
-	// Print out environment variables
-	fmt.Println(conf.Database.URI)
-	fmt.Println(conf.Database.PoolSize)
-	fmt.Println(conf.Database.Name)
-	fmt.Println(conf.Port)
+	conf, err := config.Load(os.Args[0], os.Args[1:])
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logger, err := logging.New(conf)
+	if err != nil {
+		log.Fatalf("FATAL: %v\n", err)
+	}
+	logging.SetDefault(logger)
+	logger.Debugf("Configuration:\n%s", conf)
 
 	mongoClient, err := store.NewDB(conf)
 	if err != nil {
-		log.Fatalf("FATAL: %v\n", err)
+		logger.WithError(err).Fatal("Error connecting to the database")
+	}
+
+	checkCtx, cancel := context.WithTimeout(context.Background(), conf.Database.ConnectTimeout)
+	err = store.NewMigrator(conf, mongoClient).CheckApplied(checkCtx)
+	cancel()
+	if err != nil {
+		logger.WithError(err).Fatal("Error checking the migrations of the database")
 	}
 
 	supplierRepository := store.NewMongoSupplierRepository(conf, mongoClient)
@@ -39,18 +53,59 @@ func main() {
 	variantRepository := store.NewMongoVariantRepository(conf, mongoClient)
 	cropRepository := store.NewMongoCropRepository(conf, mongoClient)
 	userRepository := store.NewMongoUserRepository(conf, mongoClient)
+	apiClientRepository := store.NewMongoAPIClientRepository(conf, mongoClient)
+	auditRepository := store.NewMongoAuditRepository(conf, mongoClient)
+	outboxRepository := store.NewMongoOutboxRepository(conf, mongoClient)
+	webhookRepository := store.NewMongoWebhookRepository(conf, mongoClient)
+	webhookDeliveryRepository := store.NewMongoWebhookDeliveryRepository(conf, mongoClient)
+	importJobRepository := store.NewMongoImportJobRepository(conf, mongoClient)
+	referenceRepository := store.NewMongoReferenceRepository(conf, mongoClient)
+	lookupRepository := store.NewMongoLookupRepository(conf, mongoClient)
+	organizationRepository := store.NewMongoOrganizationRepository(conf, mongoClient)
+	invitationRepository := store.NewMongoInvitationRepository(conf, mongoClient)
+	exchangeRateRepository := store.NewMongoExchangeRateRepository(conf, mongoClient)
+	sessionRepository := store.NewMongoSessionRepository(conf, mongoClient)
+
+	healthRegistry := health.NewRegistry(conf.Health.CheckTimeout)
+	healthRegistry.Register("config", func(ctx context.Context) error { return conf.Validate() })
+	healthRegistry.Register("mongo", store.NewPingCheck(mongoClient))
+	healthRegistry.Register("indexes", store.NewIndexCheck(conf, mongoClient))
+
+	auditService := services.NewAuditService(auditRepository)
+	eventBus := services.NewEventBus(1000)
+	eventService := services.NewEventService(outboxRepository, eventBus)
+	eventSource := services.NewEventSource(conf, outboxRepository, eventBus)
+	integrityService := services.NewIntegrityService(conf, referenceRepository, auditService, eventService)
+	unitOfWork := services.NewUnitOfWork(store.NewMongoTransactor(mongoClient))
+	supplierService := services.NewSupplierService(supplierRepository, auditService, eventService, integrityService, unitOfWork)
+	countryService := services.NewCountryService(countryRepository, auditService, eventService, integrityService, unitOfWork)
+	cityService := services.NewCityService(cityRepository, auditService, eventService, integrityService, unitOfWork)
+	itemService := services.NewItemService(itemRepository, auditService, eventService, integrityService, unitOfWork)
+	variantService := services.NewVariantService(variantRepository, auditService, eventService, integrityService, unitOfWork)
+	cropService := services.NewCropService(cropRepository, auditService, eventService, integrityService, unitOfWork)
+	userService := services.NewUserService(userRepository, auditService, integrityService, unitOfWork)
+	authService := services.NewAuthService(conf, userRepository, sessionRepository)
+	apiClientService := services.NewAPIClientService(apiClientRepository, auditService, unitOfWork)
+	webhookService := services.NewWebhookService(webhookRepository, webhookDeliveryRepository, outboxRepository, auditService, unitOfWork)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepository, auditService, unitOfWork)
+	importService := services.NewImportService(importJobRepository, supplierService, cropService, countryService, cityService, itemService, variantService, exchangeRateService)
+	lookupService := services.NewLookupService(lookupRepository)
+	organizationService := services.NewOrganizationService(organizationRepository, invitationRepository, auditService, integrityService, unitOfWork)
+	invitationService := services.NewInvitationService(conf, invitationRepository, organizationRepository, authService, auditService, unitOfWork)
+	reportService := services.NewReportService(cropService, organizationRepository)
+
+	server := http.NewServer(conf, logger, supplierService, countryService, cityService,
+		itemService, variantService, cropService, userService, authService, apiClientService, auditService, webhookService, importService, lookupService,
+		organizationService, invitationService, reportService, exchangeRateService, eventSource, healthRegistry)
+
+	// The internal services call the same services over gRPC, on the HTTP port and on
+	// server.grpcPort when it is set
+	server.ServeGRPC(grpc.NewServer(conf, logger, apiClientService, authService, organizationService, itemService, variantService, supplierService, cropService, userService, healthRegistry))
 
-	supplierService := services.NewSupplierService(supplierRepository)
-	countryService := services.NewCountryService(countryRepository)
-	cityService := services.NewCityService(cityRepository)
-	itemService := services.NewItemService(itemRepository)
-	variantService := services.NewVariantService(variantRepository)
-	cropService := services.NewCropService(cropRepository)
-	userService := services.NewUserService(userRepository)
-	authService := services.NewAuthService(userRepository)
-
-	server := http.NewServer(conf, supplierService, countryService, cityService,
-		itemService, variantService, cropService, userService, authService)
+	// Deliver the domain events written to the outbox to the webhook subscriptions, until the
+	// server shuts down
+	dispatcher := services.NewWebhookDispatcher(conf, logger, outboxRepository, webhookRepository, webhookDeliveryRepository)
+	server.RunWorker(dispatcher.Run)
 
 	server.Run()
 }
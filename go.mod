@@ -3,20 +3,30 @@ module futuagro.com
 go 1.12
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/aws/aws-lambda-go v1.12.1
 	github.com/awslabs/aws-lambda-go-api-proxy v0.4.1
 	github.com/go-chi/chi v4.0.2+incompatible
 	github.com/go-chi/cors v1.0.0
 	github.com/go-stack/stack v1.8.0 // indirect
+	github.com/golang/protobuf v1.3.2
 	github.com/golang/snappy v0.0.1 // indirect
 	github.com/google/go-cmp v0.3.0 // indirect
 	github.com/google/wire v0.3.0 // indirect
+	github.com/graph-gophers/graphql-go v1.3.0
 	github.com/joho/godotenv v1.3.0
 	github.com/pkg/errors v0.8.1
+	github.com/prometheus/client_golang v1.1.0
+	github.com/sirupsen/logrus v1.4.2
+	github.com/tealeg/xlsx v1.0.5
 	github.com/tidwall/pretty v1.0.0 // indirect
 	github.com/xdg/scram v0.0.0-20180814205039-7eeb5667e42c // indirect
 	github.com/xdg/stringprep v1.0.0 // indirect
 	go.mongodb.org/mongo-driver v1.0.4
 	golang.org/x/crypto v0.0.0-20190701094942-4def268fd1a4
-	golang.org/x/text v0.3.2 // indirect
+	golang.org/x/net v0.0.0-20190613194153-d28f0bde5980
+	golang.org/x/text v0.3.2
+	google.golang.org/genproto v0.0.0-20180817151627-c66870c02cf8
+	google.golang.org/grpc v1.23.1
+	gopkg.in/yaml.v2 v2.2.2
 )
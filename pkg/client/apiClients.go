@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllAPIClients returns every API client
+func (c *Client) FindAllAPIClients(ctx context.Context) ([]*models.APIClient, error) {
+	var apiClients []*models.APIClient
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/api-clients"}, &apiClients)
+	return apiClients, err
+}
+
+// FindAPIClientByID returns an API client
+func (c *Client) FindAPIClientByID(ctx context.Context, id string) (*models.APIClient, error) {
+	apiClient := &models.APIClient{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/api-clients/" + escape(id)}, apiClient)
+	return apiClient, err
+}
+
+// CreateAPIClient registers an API client, its key is only returned by this call and by
+// RotateAPIKey
+func (c *Client) CreateAPIClient(ctx context.Context, dto *dtos.APIClientDto) (*models.IssuedAPIKey, error) {
+	issued := &models.IssuedAPIKey{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/api-clients", body: dto}, issued)
+	return issued, err
+}
+
+// UpdateAPIClientByID updates an API client, a version other than 0 restricts the write to that
+// version of the API client
+func (c *Client) UpdateAPIClientByID(ctx context.Context, id string, dto *dtos.APIClientDto, version int64) (*models.APIClient, error) {
+	apiClient := &models.APIClient{}
+	req := &request{method: http.MethodPut, path: "/api-clients/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, apiClient)
+	return apiClient, err
+}
+
+// DeleteAPIClientByID deletes an API client, a version other than 0 restricts the delete to that
+// version of the API client
+func (c *Client) DeleteAPIClientByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/api-clients/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
+
+// RotateAPIKey issues a new key to an API client, revoking the current one, a version other than
+// 0 restricts the rotation to that version of the API client
+func (c *Client) RotateAPIKey(ctx context.Context, id string, version int64) (*models.IssuedAPIKey, error) {
+	issued := &models.IssuedAPIKey{}
+	req := &request{method: http.MethodPost, path: "/api-clients/" + escape(id) + "/rotate-key", ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, issued)
+	return issued, err
+}
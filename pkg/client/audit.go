@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAuditEntries returns a page of the audit entries matching a query, most recent first, see
+// AuditEntries to iterate over every page
+func (c *Client) FindAuditEntries(ctx context.Context, query *dtos.AuditQueryDto) ([]*models.AuditEntry, error) {
+	var entries []*models.AuditEntry
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/audit-logs", query: auditQuery(query)}, &entries)
+	return entries, err
+}
+
+// AuditEntries returns an iterator over the audit entries matching a query, most recent first. The
+// limit of the query is the size of the pages, the iteration starts at its skip.
+func (c *Client) AuditEntries(query *dtos.AuditQueryDto) *AuditEntryIterator {
+	it := &AuditEntryIterator{}
+	page := *query
+	it.pager = pager{limit: pageSize(query.Limit), skip: query.Skip}
+	it.pager.fetch = func(ctx context.Context, skip int64, limit int64) (int, error) {
+		page.Skip, page.Limit = skip, limit
+		entries, err := c.FindAuditEntries(ctx, &page)
+		it.page = entries
+		return len(entries), err
+	}
+	return it
+}
+
+// AuditEntryIterator iterates over the audit entries of a search, page by page:
+//
+//	for it.Next(ctx) { entry := it.Entry() }
+//	if err := it.Err(); err != nil { ... }
+type AuditEntryIterator struct {
+	pager
+	page  []*models.AuditEntry
+	entry *models.AuditEntry
+}
+
+// Next moves to the next entry, fetching the next page when needed, it returns false once the
+// entries are exhausted or a page failed
+func (it *AuditEntryIterator) Next(ctx context.Context) bool {
+	i := it.next(ctx)
+	if i < 0 {
+		it.entry = nil
+		return false
+	}
+	it.entry = it.page[i]
+	return true
+}
+
+// Entry returns the current entry
+func (it *AuditEntryIterator) Entry() *models.AuditEntry {
+	return it.entry
+}
+
+// Err returns the error that stopped the iteration
+func (it *AuditEntryIterator) Err() error {
+	return it.err
+}
+
+func auditQuery(query *dtos.AuditQueryDto) url.Values {
+	values := url.Values{}
+	setParam(values, "resourceType", query.ResourceType)
+	setParam(values, "resourceId", query.ResourceID)
+	setParam(values, "actorId", query.ActorID)
+	if query.From != nil {
+		values.Set("from", query.From.Format(time.RFC3339Nano))
+	}
+	if query.To != nil {
+		values.Set("to", query.To.Format(time.RFC3339Nano))
+	}
+	setPaging(values, query.Limit, query.Skip)
+	return values
+}
+
+func setParam(values url.Values, name string, value string) {
+	if value != "" {
+		values.Set(name, value)
+	}
+}
+
+func setPaging(values url.Values, limit int64, skip int64) {
+	if limit > 0 {
+		values.Set("limit", strconv.FormatInt(limit, 10))
+	}
+	if skip > 0 {
+		values.Set("skip", strconv.FormatInt(skip, 10))
+	}
+}
+
+func pageSize(limit int64) int64 {
+	if limit > 0 {
+		return limit
+	}
+	return defaultPageSize
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllItems returns the items of the catalog
+func (c *Client) FindAllItems(ctx context.Context) ([]*models.Item, error) {
+	var items []*models.Item
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/items"}, &items)
+	return items, err
+}
+
+// SearchItems returns the items having a name or a synonym in any locale with a word starting
+// with term
+func (c *Client) SearchItems(ctx context.Context, term string) ([]*models.Item, error) {
+	var items []*models.Item
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/items", query: url.Values{"q": {term}}}, &items)
+	return items, err
+}
+
+// FindItemByID returns an item
+func (c *Client) FindItemByID(ctx context.Context, id string) (*models.Item, error) {
+	item := &models.Item{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/items/" + escape(id)}, item)
+	return item, err
+}
+
+// CreateItem adds an item to the catalog
+func (c *Client) CreateItem(ctx context.Context, dto *dtos.ItemDto) (*models.Item, error) {
+	item := &models.Item{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/items", body: dto}, item)
+	return item, err
+}
+
+// UpdateItemByID updates an item, a version other than 0 restricts the write to that version of
+// the item
+func (c *Client) UpdateItemByID(ctx context.Context, id string, dto *dtos.ItemDto, version int64) (*models.Item, error) {
+	item := &models.Item{}
+	req := &request{method: http.MethodPut, path: "/items/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, item)
+	return item, err
+}
+
+// DeleteItemByID deletes an item, a version other than 0 restricts the delete to that version of
+// the item
+func (c *Client) DeleteItemByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/items/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
+
+// FindVariantsByItemID returns the variants of an item
+func (c *Client) FindVariantsByItemID(ctx context.Context, itemID string) ([]*models.Variant, error) {
+	var variants []*models.Variant
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/items/" + escape(itemID) + "/variants"}, &variants)
+	return variants, err
+}
+
+// SearchVariants returns the variants of an item having a name or a synonym in any locale with a
+// word starting with term
+func (c *Client) SearchVariants(ctx context.Context, itemID string, term string) ([]*models.Variant, error) {
+	var variants []*models.Variant
+	path := "/items/" + escape(itemID) + "/variants"
+	err := c.call(ctx, &request{method: http.MethodGet, path: path, query: url.Values{"q": {term}}}, &variants)
+	return variants, err
+}
+
+// FindVariantByID returns a variant of an item
+func (c *Client) FindVariantByID(ctx context.Context, itemID string, variantID string) (*models.Variant, error) {
+	variant := &models.Variant{}
+	path := "/items/" + escape(itemID) + "/variants/" + escape(variantID)
+	err := c.call(ctx, &request{method: http.MethodGet, path: path}, variant)
+	return variant, err
+}
+
+// CreateVariant adds a variant to an item
+func (c *Client) CreateVariant(ctx context.Context, itemID string, dto *dtos.VariantDto) (*models.Variant, error) {
+	variant := &models.Variant{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/items/" + escape(itemID) + "/variants", body: dto}, variant)
+	return variant, err
+}
+
+// UpdateVariant updates a variant of an item, a version other than 0 restricts the write to that
+// version of the variant
+func (c *Client) UpdateVariant(ctx context.Context, itemID string, variantID string, dto *dtos.VariantDto, version int64) (*models.Variant, error) {
+	variant := &models.Variant{}
+	path := "/items/" + escape(itemID) + "/variants/" + escape(variantID)
+	err := c.call(ctx, &request{method: http.MethodPut, path: path, body: dto, ifMatch: ifVersion(variantID, version)}, variant)
+	return variant, err
+}
+
+// DeleteVariant deletes a variant of an item, a version other than 0 restricts the delete to
+// that version of the variant
+func (c *Client) DeleteVariant(ctx context.Context, itemID string, variantID string, version int64) error {
+	path := "/items/" + escape(itemID) + "/variants/" + escape(variantID)
+	return c.call(ctx, &request{method: http.MethodDelete, path: path, ifMatch: ifVersion(variantID, version)}, nil)
+}
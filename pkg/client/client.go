@@ -0,0 +1,327 @@
+// Package client is a typed Go client of the Futuagro API, its methods take and return the
+// models and dtos of the domain.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	defaultBackoff    = 200 * time.Millisecond
+	maxBackoff        = 10 * time.Second
+	userAgent         = "futuagro-go-client/1.0"
+)
+
+// TokenSource supplies the API key sent with the requests, refresh is true when the API rejected
+// the previous key with 401 Unauthorized and a new one should be obtained
+type TokenSource interface {
+	Token(ctx context.Context, refresh bool) (string, error)
+}
+
+// TokenFunc adapts a function to a TokenSource
+type TokenFunc func(ctx context.Context, refresh bool) (string, error)
+
+// Token implements TokenSource
+func (f TokenFunc) Token(ctx context.Context, refresh bool) (string, error) {
+	return f(ctx, refresh)
+}
+
+// StaticToken is a TokenSource of an API key that never changes
+type StaticToken string
+
+// Token implements TokenSource
+func (t StaticToken) Token(ctx context.Context, refresh bool) (string, error) {
+	return string(t), nil
+}
+
+// Options configures a Client, only BaseURL is required
+type Options struct {
+	// BaseURL is the root of the API, e.g. https://api.futuagro.com
+	BaseURL string
+	// APIKey is the key of an API client, TokenSource takes precedence when both are set
+	APIKey      string
+	TokenSource TokenSource
+	// HTTPClient sends the requests, a client with a 30 seconds timeout by default
+	HTTPClient *http.Client
+	// MaxRetries is the number of times an idempotent request is sent again after a network error,
+	// a 429 or a 502 to 504 status, 3 by default and none when negative
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled at every retry, 200ms by default
+	Backoff time.Duration
+	// AcceptLanguage is sent as the Accept-Language header, the names of the catalog and the
+	// messages of the errors are answered in its locales, e.g. "es-AR, en;q=0.5"
+	AcceptLanguage string
+	// OrganizationID is sent as the X-Organization-ID header, the caller acts for that
+	// organization, see ForOrganization
+	OrganizationID string
+}
+
+// Client calls the Futuagro API, it is safe for concurrent use
+type Client struct {
+	baseURL    *url.URL
+	tokens     TokenSource
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	language   string
+	// organizationID is the organization the caller acts for, none when empty
+	organizationID string
+}
+
+// NewClient returns a client of the API at options.BaseURL
+func NewClient(options Options) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(options.BaseURL, "/"))
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, errors.Errorf("Invalid base URL %q", options.BaseURL)
+	}
+	c := &Client{
+		baseURL:    baseURL,
+		tokens:     options.TokenSource,
+		httpClient: options.HTTPClient,
+		maxRetries: options.MaxRetries,
+		backoff:    options.Backoff,
+		language:   options.AcceptLanguage,
+
+		organizationID: options.OrganizationID,
+	}
+	if c.tokens == nil && options.APIKey != "" {
+		c.tokens = StaticToken(options.APIKey)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if c.maxRetries == 0 {
+		c.maxRetries = defaultMaxRetries
+	} else if c.maxRetries < 0 {
+		c.maxRetries = 0
+	}
+	if c.backoff <= 0 {
+		c.backoff = defaultBackoff
+	}
+	return c, nil
+}
+
+// ForOrganization returns a copy of the client whose requests act for an organization, they send
+// its ID in the X-Organization-ID header. The reports are scoped to that organization.
+func (c *Client) ForOrganization(id string) *Client {
+	clone := *c
+	clone.organizationID = id
+	return &clone
+}
+
+// request describes a call to the API
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	// body is encoded as JSON unless it is an io.Reader, sent as is with contentType
+	body        interface{}
+	contentType string
+	// ifMatch conditions a write on the version of the document, see ifVersion
+	ifMatch string
+	accept  string
+	// anonymous requests are sent without a token
+	anonymous bool
+	// streaming requests are not bounded by the timeout of the HTTP client, their response is
+	// read for as long as the context lasts
+	streaming bool
+}
+
+// ifVersion returns the If-Match header of a write conditioned on a version of the document id, no
+// header when version is 0
+func ifVersion(id string, version int64) string {
+	if version == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`"%s-%d"`, id, version)
+}
+
+// idempotent tells whether a request can be sent again without changing its outcome, a
+// conditional write is not as the first attempt could have bumped the version
+func (r *request) idempotent() bool {
+	switch r.method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPut, http.MethodDelete:
+		return r.ifMatch == ""
+	}
+	return false
+}
+
+// call sends a request and decodes the JSON response into out when out is not nil
+func (c *Client) call(ctx context.Context, req *request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "Error decoding the response of %s %s", req.method, req.path)
+	}
+	return nil
+}
+
+// send sends a request until it succeeds or cannot be retried, the response is returned when its
+// status is below 400 and an *Error otherwise. A request rejected with 401 is sent once more with
+// a refreshed token.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	var payload []byte
+	if req.body != nil {
+		if reader, ok := req.body.(io.Reader); ok {
+			// Streamed bodies are sent once, they cannot be read again
+			return c.sendOnce(ctx, req, reader, false)
+		}
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return nil, errors.Wrapf(err, "Error encoding the body of %s %s", req.method, req.path)
+		}
+	}
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		resp, err := c.sendOnce(ctx, req, body, refreshed)
+		if apiErr, ok := err.(*Error); ok && apiErr.StatusCode == http.StatusUnauthorized && !refreshed && c.tokens != nil && !req.anonymous {
+			refreshed = true
+			continue
+		}
+		if attempt >= c.maxRetries || !req.idempotent() || !retryable(err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait := c.backoff << uint(attempt)
+		if wait > maxBackoff {
+			wait = maxBackoff
+		}
+		if apiErr, ok := err.(*Error); ok && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		} else {
+			// Jitter spreads the retries of the clients failing at the same time
+			wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) sendOnce(ctx context.Context, req *request, body io.Reader, refresh bool) (*http.Response, error) {
+	target := c.baseURL.String() + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+	httpReq, err := http.NewRequest(req.method, target, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error building %s %s", req.method, req.path)
+	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.Header.Set("User-Agent", userAgent)
+	httpReq.Header.Set("Accept", "application/json")
+	if req.accept != "" {
+		httpReq.Header.Set("Accept", req.accept)
+	}
+	if c.language != "" {
+		httpReq.Header.Set("Accept-Language", c.language)
+	}
+	if body != nil {
+		contentType := req.contentType
+		if contentType == "" {
+			contentType = "application/json; charset=utf-8"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	if req.ifMatch != "" {
+		httpReq.Header.Set("If-Match", req.ifMatch)
+	}
+	if c.organizationID != "" && !req.anonymous {
+		httpReq.Header.Set("X-Organization-ID", c.organizationID)
+	}
+	if c.tokens != nil && !req.anonymous {
+		token, err := c.tokens.Token(ctx, refresh)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting an API token")
+		}
+		if token != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	httpClient := c.httpClient
+	if req.streaming && httpClient.Timeout > 0 {
+		unbounded := *httpClient
+		unbounded.Timeout = 0
+		httpClient = &unbounded
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &networkError{errors.Wrapf(err, "Error sending %s %s", req.method, req.path)}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+// networkError is a failure to get a response, the request may or may not have been handled
+type networkError struct {
+	error
+}
+
+// retryable tells whether a failed request may succeed if sent again
+func retryable(err error) bool {
+	switch e := err.(type) {
+	case *networkError:
+		return true
+	case *Error:
+		switch e.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter reads the Retry-After header in seconds, 0 when it is absent or is a date
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// escape escapes an ID used as a segment of a path
+func escape(id string) string {
+	return url.PathEscape(id)
+}
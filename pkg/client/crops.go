@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllCrops returns every crop
+func (c *Client) FindAllCrops(ctx context.Context) ([]*models.Crop, error) {
+	var crops []*models.Crop
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/crops"}, &crops)
+	return crops, err
+}
+
+// FindCropByID returns a crop with its city, variant and supplier
+func (c *Client) FindCropByID(ctx context.Context, id string) (*models.Crop, error) {
+	crop := &models.Crop{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/crops/" + escape(id)}, crop)
+	return crop, err
+}
+
+// CreateCrop registers a crop
+func (c *Client) CreateCrop(ctx context.Context, dto *dtos.CropDto) (*models.Crop, error) {
+	crop := &models.Crop{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/crops", body: dto}, crop)
+	return crop, err
+}
+
+// UpdateCropByID updates a crop, a version other than 0 restricts the write to that version of
+// the crop
+func (c *Client) UpdateCropByID(ctx context.Context, id string, dto *dtos.CropDto, version int64) (*models.Crop, error) {
+	crop := &models.Crop{}
+	req := &request{method: http.MethodPut, path: "/crops/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, crop)
+	return crop, err
+}
+
+// DeleteCropByID deletes a crop, a version other than 0 restricts the delete to that version of
+// the crop
+func (c *Client) DeleteCropByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/crops/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
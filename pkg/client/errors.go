@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// maxErrorBody bounds the body read from an error response
+const maxErrorBody = 64 << 10
+
+// Error is an error answered by the API, decoded from its APIError body
+type Error struct {
+	StatusCode int    `json:"status"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	// Field names the attribute of the request the error is about, like the duplicate email of a
+	// conflict
+	Field string `json:"field,omitempty"`
+	// RequestID identifies the request in the logs of the API
+	RequestID string `json:"requestId,omitempty"`
+	// RetryAfter is the wait asked by a 429 or a 503 response
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.RequestID == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%d %s (request %s)", e.StatusCode, e.Message, e.RequestID)
+}
+
+// StatusOf returns the HTTP status of an error answered by the API, 0 for any other error
+func StatusOf(err error) int {
+	if apiErr, ok := err.(*Error); ok {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound tells whether err is a 404 answered by the API
+func IsNotFound(err error) bool {
+	return StatusOf(err) == http.StatusNotFound
+}
+
+// IsConflict tells whether err is a 409 answered by the API, a duplicate or a delete restricted
+// by the records referencing the deleted one
+func IsConflict(err error) bool {
+	return StatusOf(err) == http.StatusConflict
+}
+
+// IsPreconditionFailed tells whether err is a 412 answered by the API to a write conditioned on a
+// version that is no longer the current one
+func IsPreconditionFailed(err error) bool {
+	return StatusOf(err) == http.StatusPreconditionFailed
+}
+
+// decodeError returns the error of a response whose status is 400 or above, responses without an
+// APIError body, e.g. from a proxy, get their status text as message
+func decodeError(resp *http.Response) *Error {
+	apiErr := &Error{}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if json.Unmarshal(body, apiErr) != nil || apiErr.Message == "" {
+		apiErr = &Error{Code: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.RetryAfter = retryAfter(resp)
+	return apiErr
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"github.com/pkg/errors"
+)
+
+// defaultReconnectDelay is the wait before reconnecting a dropped event stream when the API did
+// not send its own
+const defaultReconnectDelay = 3 * time.Second
+
+// EventQuery selects the events of a stream, empty lists select every resource the caller can read
+type EventQuery struct {
+	ResourceTypes []string
+	ResourceIDs   []string
+	// LastEventID resumes the stream right after this event
+	LastEventID string
+}
+
+// Event is a domain event received from the stream, Data holds the resource after the change, or
+// before it for deletions
+type Event struct {
+	ID           string              `json:"id"`
+	Type         enums.EnumEventType `json:"type"`
+	ResourceType string              `json:"resourceType"`
+	ResourceID   string              `json:"resourceId"`
+	RequestID    string              `json:"requestId,omitempty"`
+	OccurredAt   time.Time           `json:"occurredAt"`
+	Data         json.RawMessage     `json:"data"`
+}
+
+// DecodeData decodes the resource of the event into v, e.g. a *models.Crop for a crop event
+func (e *Event) DecodeData(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// StreamEvents calls fn with the events of the stream until ctx is cancelled or fn returns an
+// error, which is returned. A dropped stream is reconnected, resuming after the last event
+// received so that none is missed.
+func (c *Client) StreamEvents(ctx context.Context, query *EventQuery, fn func(*Event) error) error {
+	lastEventID := query.LastEventID
+	delay := defaultReconnectDelay
+	for {
+		values := url.Values{}
+		setParam(values, "resourceType", strings.Join(query.ResourceTypes, ","))
+		setParam(values, "resourceId", strings.Join(query.ResourceIDs, ","))
+		setParam(values, "lastEventId", lastEventID)
+		req := &request{method: http.MethodGet, path: "/events", query: values, accept: "text/event-stream", streaming: true}
+		resp, err := c.send(ctx, req)
+		if _, ok := err.(*networkError); err != nil && !ok {
+			return err
+		}
+		if err == nil {
+			err = readEvents(resp.Body, func(event *Event) error {
+				lastEventID = event.ID
+				return fn(event)
+			}, &delay)
+			resp.Body.Close()
+			if stop, ok := err.(*callbackError); ok {
+				return stop.error
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// callbackError is an error returned by the function given to StreamEvents, it ends the stream
+type callbackError struct {
+	error
+}
+
+// readEvents parses a Server-Sent Events stream until it ends, the retry field updates the delay
+// before reconnecting
+func readEvents(body io.Reader, fn func(*Event) error, delay *time.Duration) error {
+	reader := bufio.NewReader(body)
+	var data strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			event := &Event{}
+			if err := json.Unmarshal([]byte(data.String()), event); err != nil {
+				return errors.Wrap(err, "Error decoding an event")
+			}
+			data.Reset()
+			if err := fn(event); err != nil {
+				return &callbackError{err}
+			}
+		case strings.HasPrefix(line, ":"):
+			// Comments are heartbeats
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil && ms > 0 {
+				*delay = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
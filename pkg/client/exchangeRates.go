@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindExchangeRates returns the exchange rates by pair and then newest first, base and quote
+// restrict them to a currency when they are not empty
+func (c *Client) FindExchangeRates(ctx context.Context, base string, quote string) ([]*models.ExchangeRate, error) {
+	var rates []*models.ExchangeRate
+	values := url.Values{}
+	if base != "" {
+		values.Set("base", base)
+	}
+	if quote != "" {
+		values.Set("quote", quote)
+	}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/exchange-rates", query: values}, &rates)
+	return rates, err
+}
+
+// FindExchangeRateByID returns an exchange rate
+func (c *Client) FindExchangeRateByID(ctx context.Context, id string) (*models.ExchangeRate, error) {
+	rate := &models.ExchangeRate{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/exchange-rates/" + escape(id)}, rate)
+	return rate, err
+}
+
+// CreateExchangeRate records an exchange rate, it applies from its effectiveAt until the next
+// rate of its pair
+func (c *Client) CreateExchangeRate(ctx context.Context, dto *dtos.ExchangeRateDto) (*models.ExchangeRate, error) {
+	rate := &models.ExchangeRate{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/exchange-rates", body: dto}, rate)
+	return rate, err
+}
+
+// DeleteExchangeRate deletes an exchange rate, the previous rate of its pair applies again
+func (c *Client) DeleteExchangeRate(ctx context.Context, id string) error {
+	return c.call(ctx, &request{method: http.MethodDelete, path: "/exchange-rates/" + escape(id)}, nil)
+}
+
+// Convert converts an amount into the currency to with the rate in effect at the given time, now
+// when it is zero, and returns the result along with the rate that was used
+func (c *Client) Convert(ctx context.Context, amount models.Money, to string, at time.Time) (*models.Conversion, error) {
+	values := url.Values{
+		"amount": {strconv.FormatInt(amount.Amount, 10)},
+		"from":   {amount.Currency},
+		"to":     {to},
+	}
+	if !at.IsZero() {
+		values.Set("at", at.Format(time.RFC3339))
+	}
+	conversion := &models.Conversion{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/exchange-rates/convert", query: values}, conversion)
+	return conversion, err
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllCountries returns every country
+func (c *Client) FindAllCountries(ctx context.Context) ([]*models.Country, error) {
+	var countries []*models.Country
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/countries"}, &countries)
+	return countries, err
+}
+
+// FindCountryByID returns a country with its states
+func (c *Client) FindCountryByID(ctx context.Context, id string) (*models.Country, error) {
+	country := &models.Country{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/countries/" + escape(id)}, country)
+	return country, err
+}
+
+// CreateCountry registers a country
+func (c *Client) CreateCountry(ctx context.Context, dto *dtos.CountryDto) (*models.Country, error) {
+	country := &models.Country{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/countries", body: dto}, country)
+	return country, err
+}
+
+// UpdateCountryByID updates a country, a version other than 0 restricts the write to that
+// version of the country
+func (c *Client) UpdateCountryByID(ctx context.Context, id string, dto *dtos.CountryDto, version int64) (*models.Country, error) {
+	country := &models.Country{}
+	req := &request{method: http.MethodPut, path: "/countries/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, country)
+	return country, err
+}
+
+// DeleteCountryByID deletes a country, a version other than 0 restricts the delete to that
+// version of the country
+func (c *Client) DeleteCountryByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/countries/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
+
+// CreateState adds a state to a country and returns the country, a version other than 0
+// restricts the write to that version of the country
+func (c *Client) CreateState(ctx context.Context, countryID string, dto *dtos.CountryStateDto, version int64) (*models.Country, error) {
+	country := &models.Country{}
+	req := &request{method: http.MethodPost, path: "/countries/" + escape(countryID) + "/country-states", body: dto, ifMatch: ifVersion(countryID, version)}
+	err := c.call(ctx, req, country)
+	return country, err
+}
+
+// UpdateState updates a state of a country and returns the country, a version other than 0
+// restricts the write to that version of the country
+func (c *Client) UpdateState(ctx context.Context, countryID string, stateID string, dto *dtos.CountryStateDto, version int64) (*models.Country, error) {
+	country := &models.Country{}
+	path := "/countries/" + escape(countryID) + "/country-states/" + escape(stateID)
+	err := c.call(ctx, &request{method: http.MethodPut, path: path, body: dto, ifMatch: ifVersion(countryID, version)}, country)
+	return country, err
+}
+
+// DeleteState removes a state from a country and returns the country, a version other than 0
+// restricts the write to that version of the country
+func (c *Client) DeleteState(ctx context.Context, countryID string, stateID string, version int64) (*models.Country, error) {
+	country := &models.Country{}
+	path := "/countries/" + escape(countryID) + "/country-states/" + escape(stateID)
+	err := c.call(ctx, &request{method: http.MethodDelete, path: path, ifMatch: ifVersion(countryID, version)}, country)
+	return country, err
+}
+
+// FindAllCitiesByState returns the cities of a country state
+func (c *Client) FindAllCitiesByState(ctx context.Context, stateID string) ([]*models.City, error) {
+	var cities []*models.City
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/country-states/" + escape(stateID) + "/cities"}, &cities)
+	return cities, err
+}
+
+// CreateCity registers a city in a country state
+func (c *Client) CreateCity(ctx context.Context, stateID string, dto *dtos.CityDto) (*models.City, error) {
+	city := &models.City{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/country-states/" + escape(stateID) + "/cities", body: dto}, city)
+	return city, err
+}
+
+// FindCityByID returns a city of a country state
+func (c *Client) FindCityByID(ctx context.Context, stateID string, cityID string) (*models.City, error) {
+	city := &models.City{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/country-states/" + escape(stateID) + "/cities/" + escape(cityID)}, city)
+	return city, err
+}
+
+// UpdateCityByID updates a city, a version other than 0 restricts the write to that version of
+// the city
+func (c *Client) UpdateCityByID(ctx context.Context, stateID string, cityID string, dto *dtos.CityDto, version int64) (*models.City, error) {
+	city := &models.City{}
+	path := "/country-states/" + escape(stateID) + "/cities/" + escape(cityID)
+	err := c.call(ctx, &request{method: http.MethodPut, path: path, body: dto, ifMatch: ifVersion(cityID, version)}, city)
+	return city, err
+}
+
+// DeleteCityByID deletes a city, a version other than 0 restricts the delete to that version of
+// the city
+func (c *Client) DeleteCityByID(ctx context.Context, stateID string, cityID string, version int64) error {
+	path := "/country-states/" + escape(stateID) + "/cities/" + escape(cityID)
+	return c.call(ctx, &request{method: http.MethodDelete, path: path, ifMatch: ifVersion(cityID, version)}, nil)
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"futuagro.com/pkg/health"
+)
+
+// Ready returns the readiness report of the API, a failing check is answered as a 503 *Error
+func (c *Client) Ready(ctx context.Context) (*health.Report, error) {
+	report := &health.Report{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/readyz", anonymous: true}, report)
+	return report, err
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindRecentImportJobs returns the most recent imports without their row errors, limit is at
+// most 100 and 0 takes the default of the API
+func (c *Client) FindRecentImportJobs(ctx context.Context, limit int64) ([]*models.ImportJob, error) {
+	var jobs []*models.ImportJob
+	values := url.Values{}
+	setPaging(values, limit, 0)
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/imports", query: values}, &jobs)
+	return jobs, err
+}
+
+// FindImportJobByID returns an import with its progress and row errors
+func (c *Client) FindImportJobByID(ctx context.Context, id string) (*models.ImportJob, error) {
+	job := &models.ImportJob{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/imports/" + escape(id)}, job)
+	return job, err
+}
+
+// ImportSuppliers uploads a CSV or XLSX file of suppliers, its format is told by the extension of
+// fileName. A dry run returns the validation report, otherwise the valid rows are committed in
+// the background and the job is to be followed with FindImportJobByID.
+func (c *Client) ImportSuppliers(ctx context.Context, fileName string, file io.Reader, dryRun bool) (*models.ImportJob, error) {
+	return c.importFile(ctx, "/imports/suppliers", fileName, file, dryRun)
+}
+
+// ImportCrops uploads a CSV or XLSX file of crops of registered suppliers, the same way as
+// ImportSuppliers
+func (c *Client) ImportCrops(ctx context.Context, fileName string, file io.Reader, dryRun bool) (*models.ImportJob, error) {
+	return c.importFile(ctx, "/imports/crops", fileName, file, dryRun)
+}
+
+// ImportExchangeRates uploads a CSV or XLSX file of exchange rates, the same way as
+// ImportSuppliers
+func (c *Client) ImportExchangeRates(ctx context.Context, fileName string, file io.Reader, dryRun bool) (*models.ImportJob, error) {
+	return c.importFile(ctx, "/imports/exchange-rates", fileName, file, dryRun)
+}
+
+func (c *Client) importFile(ctx context.Context, path string, fileName string, file io.Reader, dryRun bool) (*models.ImportJob, error) {
+	values := url.Values{"fileName": {fileName}, "dryRun": {strconv.FormatBool(dryRun)}}
+	req := &request{method: http.MethodPost, path: path, query: values, body: file, contentType: "application/octet-stream"}
+	job := &models.ImportJob{}
+	err := c.call(ctx, req, job)
+	return job, err
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindOrganizations returns the organizations the caller is a member of
+func (c *Client) FindOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	var organizations []*models.Organization
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/organizations"}, &organizations)
+	return organizations, err
+}
+
+// FindOrganizationByID returns an organization with its members
+func (c *Client) FindOrganizationByID(ctx context.Context, id string) (*models.Organization, error) {
+	organization := &models.Organization{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/organizations/" + escape(id)}, organization)
+	return organization, err
+}
+
+// CreateOrganization creates an organization, the caller is its owner
+func (c *Client) CreateOrganization(ctx context.Context, dto *dtos.OrganizationDto) (*models.Organization, error) {
+	organization := &models.Organization{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/organizations", body: dto}, organization)
+	return organization, err
+}
+
+// UpdateOrganizationByID updates an organization, a version other than 0 restricts the write to
+// that version of the organization
+func (c *Client) UpdateOrganizationByID(ctx context.Context, id string, dto *dtos.OrganizationDto, version int64) (*models.Organization, error) {
+	organization := &models.Organization{}
+	req := &request{method: http.MethodPut, path: "/organizations/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, organization)
+	return organization, err
+}
+
+// DeleteOrganizationByID deletes an organization and its invitations, a version other than 0
+// restricts the delete to that version of the organization
+func (c *Client) DeleteOrganizationByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/organizations/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
+
+// AddMember adds a user or an API client to an organization, a version other than 0 restricts the
+// write to that version of the organization
+func (c *Client) AddMember(ctx context.Context, organizationID string, dto *dtos.MembershipDto, version int64) (*models.Organization, error) {
+	organization := &models.Organization{}
+	req := &request{method: http.MethodPost, path: "/organizations/" + escape(organizationID) + "/members", body: dto, ifMatch: ifVersion(organizationID, version)}
+	err := c.call(ctx, req, organization)
+	return organization, err
+}
+
+// UpdateMember changes the role of a member, a version other than 0 restricts the write to that
+// version of the organization
+func (c *Client) UpdateMember(ctx context.Context, organizationID string, memberID string, dto *dtos.MemberRoleDto, version int64) (*models.Organization, error) {
+	organization := &models.Organization{}
+	path := "/organizations/" + escape(organizationID) + "/members/" + escape(memberID)
+	err := c.call(ctx, &request{method: http.MethodPut, path: path, body: dto, ifMatch: ifVersion(organizationID, version)}, organization)
+	return organization, err
+}
+
+// RemoveMember removes a member from an organization, a member can also leave. A version other
+// than 0 restricts the write to that version of the organization.
+func (c *Client) RemoveMember(ctx context.Context, organizationID string, memberID string, version int64) (*models.Organization, error) {
+	organization := &models.Organization{}
+	path := "/organizations/" + escape(organizationID) + "/members/" + escape(memberID)
+	err := c.call(ctx, &request{method: http.MethodDelete, path: path, ifMatch: ifVersion(organizationID, version)}, organization)
+	return organization, err
+}
+
+// FindInvitations returns the invitations to join an organization, most recent first
+func (c *Client) FindInvitations(ctx context.Context, organizationID string) ([]*models.Invitation, error) {
+	var invitations []*models.Invitation
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/organizations/" + escape(organizationID) + "/invitations"}, &invitations)
+	return invitations, err
+}
+
+// CreateInvitation invites an email to join an organization, the token of the invitation is only
+// returned by this call
+func (c *Client) CreateInvitation(ctx context.Context, organizationID string, dto *dtos.InvitationDto) (*models.IssuedInvitation, error) {
+	issued := &models.IssuedInvitation{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/organizations/" + escape(organizationID) + "/invitations", body: dto}, issued)
+	return issued, err
+}
+
+// RevokeInvitation revokes an invitation to join an organization
+func (c *Client) RevokeInvitation(ctx context.Context, organizationID string, invitationID string) error {
+	path := "/organizations/" + escape(organizationID) + "/invitations/" + escape(invitationID)
+	return c.call(ctx, &request{method: http.MethodDelete, path: path}, nil)
+}
+
+// AcceptInvitation accepts an invitation with the credentials of the invited user and returns the
+// organization it joined. It is sent without the API key.
+func (c *Client) AcceptInvitation(ctx context.Context, dto *dtos.AcceptInvitationDto) (*models.Organization, error) {
+	organization := &models.Organization{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/invitations/accept", body: dto, anonymous: true}, organization)
+	return organization, err
+}
+
+// HarvestReport counts the crops harvested within [from, to) by month and variant, the current
+// year when both are zero. The report is scoped to the organization of ForOrganization, or else
+// to the only organization the caller is a member of.
+func (c *Client) HarvestReport(ctx context.Context, from time.Time, to time.Time) ([]*models.HarvestReportRow, error) {
+	var report []*models.HarvestReportRow
+	values := url.Values{}
+	if !from.IsZero() {
+		values.Set("from", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		values.Set("to", to.Format(time.RFC3339))
+	}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/reports/harvest", query: values}, &report)
+	return report, err
+}
@@ -0,0 +1,46 @@
+package client
+
+import "context"
+
+// defaultPageSize is the number of results fetched per page by the iterators
+const defaultPageSize = 100
+
+// pager walks the pages of a search paginated with limit and skip, fetch loads the page at skip
+// and returns its size. The search ends with the first page shorter than the limit.
+type pager struct {
+	fetch    func(ctx context.Context, skip int64, limit int64) (int, error)
+	limit    int64
+	skip     int64
+	size     int
+	position int
+	last     bool
+	err      error
+}
+
+// next moves to the next result and returns its index in the current page, or -1 once the
+// results are exhausted or a page failed
+func (p *pager) next(ctx context.Context) int {
+	if p.err != nil {
+		return -1
+	}
+	if p.position+1 < p.size {
+		p.position++
+		return p.position
+	}
+	if p.last {
+		return -1
+	}
+
+	size, err := p.fetch(ctx, p.skip, p.limit)
+	if err != nil {
+		p.err = err
+		return -1
+	}
+	p.skip += int64(size)
+	p.size, p.position = size, 0
+	p.last = int64(size) < p.limit
+	if size == 0 {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllSuppliers returns every supplier
+func (c *Client) FindAllSuppliers(ctx context.Context) ([]*models.Supplier, error) {
+	var suppliers []*models.Supplier
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/suppliers"}, &suppliers)
+	return suppliers, err
+}
+
+// FindSupplierByID returns a supplier with its city and crops
+func (c *Client) FindSupplierByID(ctx context.Context, id string) (*models.Supplier, error) {
+	supplier := &models.Supplier{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/suppliers/" + escape(id)}, supplier)
+	return supplier, err
+}
+
+// CreateSupplier registers a supplier
+func (c *Client) CreateSupplier(ctx context.Context, dto *dtos.SupplierDto) (*models.Supplier, error) {
+	supplier := &models.Supplier{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/suppliers", body: dto}, supplier)
+	return supplier, err
+}
+
+// UpdateSupplierByID updates a supplier, a version other than 0 restricts the write to that
+// version of the supplier
+func (c *Client) UpdateSupplierByID(ctx context.Context, id string, dto *dtos.SupplierDto, version int64) (*models.Supplier, error) {
+	supplier := &models.Supplier{}
+	req := &request{method: http.MethodPut, path: "/suppliers/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, supplier)
+	return supplier, err
+}
+
+// DeleteSupplierByID deletes a supplier, a version other than 0 restricts the delete to that
+// version of the supplier
+func (c *Client) DeleteSupplierByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/suppliers/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
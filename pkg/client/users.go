@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllUsers returns every user
+func (c *Client) FindAllUsers(ctx context.Context) ([]*models.User, error) {
+	var users []*models.User
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/users"}, &users)
+	return users, err
+}
+
+// FindUserByID returns a user
+func (c *Client) FindUserByID(ctx context.Context, id string) (*models.User, error) {
+	user := &models.User{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/users/" + escape(id)}, user)
+	return user, err
+}
+
+// Signup registers a user
+func (c *Client) Signup(ctx context.Context, dto *dtos.UserDto) (*models.User, error) {
+	user := &models.User{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/users", body: dto}, user)
+	return user, err
+}
+
+// UpdateUserByID updates a user, a version other than 0 restricts the write to that version of
+// the user
+func (c *Client) UpdateUserByID(ctx context.Context, id string, dto *dtos.UserDto, version int64) (*models.User, error) {
+	user := &models.User{}
+	req := &request{method: http.MethodPut, path: "/users/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, user)
+	return user, err
+}
+
+// DeleteUserByID deletes a user, a version other than 0 restricts the delete to that version of
+// the user
+func (c *Client) DeleteUserByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/users/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
+
+// Login checks the email and the password of a user and opens a session, a wrong email or
+// password is a 401 *Error. It is sent without the API key. A client calls the API as the user
+// with the token of the session as its APIKey.
+func (c *Client) Login(ctx context.Context, dto *dtos.LoginDto) (*models.IssuedSession, error) {
+	session := &models.IssuedSession{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/auth/login", body: dto, anonymous: true}, session)
+	return session, err
+}
+
+// Logout closes the session whose token is the API key of the client
+func (c *Client) Logout(ctx context.Context) error {
+	return c.call(ctx, &request{method: http.MethodPost, path: "/auth/logout"}, nil)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+)
+
+// FindAllSubscriptions returns every webhook subscription
+func (c *Client) FindAllSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/webhooks/subscriptions"}, &subscriptions)
+	return subscriptions, err
+}
+
+// FindSubscriptionByID returns a webhook subscription
+func (c *Client) FindSubscriptionByID(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	subscription := &models.WebhookSubscription{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/webhooks/subscriptions/" + escape(id)}, subscription)
+	return subscription, err
+}
+
+// CreateSubscription subscribes a URL to domain events, the signing secret is only returned by
+// this call and by RotateSecret
+func (c *Client) CreateSubscription(ctx context.Context, dto *dtos.WebhookSubscriptionDto) (*models.IssuedWebhookSecret, error) {
+	issued := &models.IssuedWebhookSecret{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/webhooks/subscriptions", body: dto}, issued)
+	return issued, err
+}
+
+// UpdateSubscriptionByID updates a webhook subscription, a version other than 0 restricts the
+// write to that version of the subscription
+func (c *Client) UpdateSubscriptionByID(ctx context.Context, id string, dto *dtos.WebhookSubscriptionDto, version int64) (*models.WebhookSubscription, error) {
+	subscription := &models.WebhookSubscription{}
+	req := &request{method: http.MethodPut, path: "/webhooks/subscriptions/" + escape(id), body: dto, ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, subscription)
+	return subscription, err
+}
+
+// DeleteSubscriptionByID deletes a webhook subscription, a version other than 0 restricts the
+// delete to that version of the subscription
+func (c *Client) DeleteSubscriptionByID(ctx context.Context, id string, version int64) error {
+	req := &request{method: http.MethodDelete, path: "/webhooks/subscriptions/" + escape(id), ifMatch: ifVersion(id, version)}
+	return c.call(ctx, req, nil)
+}
+
+// RotateSecret issues a new signing secret to a webhook subscription, a version other than 0
+// restricts the rotation to that version of the subscription
+func (c *Client) RotateSecret(ctx context.Context, id string, version int64) (*models.IssuedWebhookSecret, error) {
+	issued := &models.IssuedWebhookSecret{}
+	req := &request{method: http.MethodPost, path: "/webhooks/subscriptions/" + escape(id) + "/rotate-secret", ifMatch: ifVersion(id, version)}
+	err := c.call(ctx, req, issued)
+	return issued, err
+}
+
+// FindDeliveries returns a page of the webhook deliveries matching a query, see Deliveries to
+// iterate over every page
+func (c *Client) FindDeliveries(ctx context.Context, query *dtos.WebhookDeliveryQueryDto) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/webhooks/deliveries", query: deliveryQuery(query)}, &deliveries)
+	return deliveries, err
+}
+
+// Deliveries returns an iterator over the webhook deliveries matching a query. The limit of the
+// query is the size of the pages, the iteration starts at its skip.
+func (c *Client) Deliveries(query *dtos.WebhookDeliveryQueryDto) *DeliveryIterator {
+	it := &DeliveryIterator{}
+	page := *query
+	it.pager = pager{limit: pageSize(query.Limit), skip: query.Skip}
+	it.pager.fetch = func(ctx context.Context, skip int64, limit int64) (int, error) {
+		page.Skip, page.Limit = skip, limit
+		deliveries, err := c.FindDeliveries(ctx, &page)
+		it.page = deliveries
+		return len(deliveries), err
+	}
+	return it
+}
+
+// DeliveryIterator iterates over the webhook deliveries of a search, page by page, the same way
+// as AuditEntryIterator
+type DeliveryIterator struct {
+	pager
+	page     []*models.WebhookDelivery
+	delivery *models.WebhookDelivery
+}
+
+// Next moves to the next delivery, fetching the next page when needed, it returns false once the
+// deliveries are exhausted or a page failed
+func (it *DeliveryIterator) Next(ctx context.Context) bool {
+	i := it.next(ctx)
+	if i < 0 {
+		it.delivery = nil
+		return false
+	}
+	it.delivery = it.page[i]
+	return true
+}
+
+// Delivery returns the current delivery
+func (it *DeliveryIterator) Delivery() *models.WebhookDelivery {
+	return it.delivery
+}
+
+// Err returns the error that stopped the iteration
+func (it *DeliveryIterator) Err() error {
+	return it.err
+}
+
+// FindDeliveryByID returns a webhook delivery
+func (c *Client) FindDeliveryByID(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	err := c.call(ctx, &request{method: http.MethodGet, path: "/webhooks/deliveries/" + escape(id)}, delivery)
+	return delivery, err
+}
+
+// ReplayDelivery delivers a webhook again
+func (c *Client) ReplayDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/webhooks/deliveries/" + escape(id) + "/replay"}, delivery)
+	return delivery, err
+}
+
+// ReplayEvent delivers an event again to every matching subscription
+func (c *Client) ReplayEvent(ctx context.Context, eventID string) (*models.OutboxEvent, error) {
+	event := &models.OutboxEvent{}
+	err := c.call(ctx, &request{method: http.MethodPost, path: "/webhooks/events/" + escape(eventID) + "/replay"}, event)
+	return event, err
+}
+
+func deliveryQuery(query *dtos.WebhookDeliveryQueryDto) url.Values {
+	values := url.Values{}
+	setParam(values, "subscriptionId", query.SubscriptionID)
+	setParam(values, "eventId", query.EventID)
+	setParam(values, "status", string(query.Status))
+	setPaging(values, query.Limit, query.Skip)
+	return values
+}
@@ -1,73 +1,247 @@
 package config
 
 import (
-	"log"
-	"os"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/i18n"
+	"github.com/sirupsen/logrus"
 )
 
+// Every attribute is named by its config tag, the dotted path of the tags is the key of the
+// attribute in a configuration file and the name of its command-line flag, e.g. database.uri.
+// The env tag names the environment variable setting it, an environment variable suffixed with
+// _FILE names a file holding the value instead. Attributes tagged secret are redacted when the
+// configuration is printed.
+
+// ServerConf for modeling the configuration attributes of the HTTP server
+type ServerConf struct {
+	Port string `config:"port" env:"APP_PORT"`
+	// AllowedOrigins are the origins accepted by CORS, * accepts every origin
+	AllowedOrigins []string `config:"allowedOrigins" env:"CORS_ALLOWED_ORIGINS"`
+	// RequestTimeout cancels the context of the requests that take longer
+	RequestTimeout time.Duration `config:"requestTimeout" env:"REQUEST_TIMEOUT_SECONDS"`
+	// ReadHeaderTimeout bounds the time given to clients to send the headers of a request
+	ReadHeaderTimeout time.Duration `config:"readHeaderTimeout" env:"READ_HEADER_TIMEOUT_SECONDS"`
+	// IdleTimeout closes the keep-alive connections idle for longer
+	IdleTimeout time.Duration `config:"idleTimeout" env:"IDLE_TIMEOUT_SECONDS"`
+	// ShutdownDelay is how long the readiness fails before the server stops accepting
+	// connections, so that load balancers notice it and stop routing requests to this process
+	ShutdownDelay time.Duration `config:"shutdownDelay" env:"SHUTDOWN_DELAY_SECONDS"`
+	// ShutdownTimeout bounds the time given to the requests in flight to complete
+	ShutdownTimeout time.Duration `config:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT_SECONDS"`
+	// RequireIfMatch makes every PUT, PATCH and DELETE request carry an If-Match header
+	RequireIfMatch bool `config:"requireIfMatch" env:"REQUIRE_IF_MATCH"`
+	// GRPCPort serves the gRPC API on a port of its own when set, it is served on the HTTP port
+	// with HTTP/2 either way
+	GRPCPort string `config:"grpcPort" env:"GRPC_PORT"`
+}
+
 // DatabaseConf for modeling the configuration attributes for the database connection
 type DatabaseConf struct {
-	URI      string
-	PoolSize uint16
-	Name     string
+	// URI is the MongoDB connection string, its password is redacted when printed
+	URI      string `config:"uri" env:"DB_URI" secret:"uri"`
+	PoolSize uint16 `config:"poolSize" env:"DB_POOL_SIZE"`
+	Name     string `config:"name" env:"DB_NAME"`
+	// ConnectTimeout bounds the connection to the database at startup
+	ConnectTimeout time.Duration `config:"connectTimeout" env:"DB_CONNECT_TIMEOUT_SECONDS"`
+}
+
+// AuthConf for modeling the configuration attributes of the authentication
+type AuthConf struct {
+	// AdminAPIKey is a static API key granted every scope, leave it empty to disable it
+	AdminAPIKey string `config:"adminApiKey" env:"API_ADMIN_KEY" secret:"true"`
+	// InvitationTTL is how long the invitations to join an organization can be accepted
+	InvitationTTL time.Duration `config:"invitationTtl" env:"INVITATION_TTL_SECONDS"`
+	// SessionTTL is how long the sessions opened by the login of a user last
+	SessionTTL time.Duration `config:"sessionTtl" env:"SESSION_TTL_SECONDS"`
+}
+
+// WebhookConf for modeling the configuration attributes of the webhook dispatcher
+type WebhookConf struct {
+	// PollInterval is how often the dispatcher looks for new events and due deliveries
+	PollInterval time.Duration `config:"pollInterval" env:"WEBHOOK_POLL_INTERVAL_SECONDS"`
+	// Timeout bounds each delivery request to a subscriber
+	Timeout time.Duration `config:"timeout" env:"WEBHOOK_TIMEOUT_SECONDS"`
+	// MaxAttempts is the number of attempts after which a delivery is marked as dead
+	MaxAttempts int `config:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+}
+
+// EventConf for modeling the configuration attributes of the live stream of events
+type EventConf struct {
+	// Source feeds the /events stream, "mongo" for change streams or "memory" for an in-process
+	// bus that only sees the events of this process
+	Source string `config:"source" env:"EVENT_SOURCE"`
+}
+
+// LogConf for modeling the configuration attributes of the logger
+type LogConf struct {
+	// Level is the minimum level written: trace, debug, info, warning, error, fatal or panic
+	Level string `config:"level" env:"LOG_LEVEL"`
+	// Format is json for machines or text for humans
+	Format string `config:"format" env:"LOG_FORMAT"`
+}
+
+// HealthConf for modeling the configuration attributes of the health checks
+type HealthConf struct {
+	// CheckTimeout bounds the readiness checks, a check still running is reported as failing
+	CheckTimeout time.Duration `config:"checkTimeout" env:"HEALTH_CHECK_TIMEOUT_SECONDS"`
+}
+
+// CatalogConf for modeling the configuration attributes of the catalog of items and variants
+type CatalogConf struct {
+	// DefaultLocale is the locale of the names of the items and variants, they are answered when
+	// none of their localized names serves the locales the caller asks for
+	DefaultLocale string `config:"defaultLocale" env:"CATALOG_DEFAULT_LOCALE"`
+}
+
+// IntegrityConf for modeling the delete policies of the relations between records, named after
+// the deleted record and the records referencing it. A policy is restrict, the delete is refused
+// while records reference the deleted one, cascade, they are deleted too, nullify, their reference
+// is removed, or deactivate, their record status is set to inactive.
+type IntegrityConf struct {
+	ItemVariants  string `config:"itemVariants" env:"DELETE_POLICY_ITEM_VARIANTS"`
+	VariantCrops  string `config:"variantCrops" env:"DELETE_POLICY_VARIANT_CROPS"`
+	StateCities   string `config:"stateCities" env:"DELETE_POLICY_STATE_CITIES"`
+	CityCrops     string `config:"cityCrops" env:"DELETE_POLICY_CITY_CROPS"`
+	CitySuppliers string `config:"citySuppliers" env:"DELETE_POLICY_CITY_SUPPLIERS"`
+	CityUsers     string `config:"cityUsers" env:"DELETE_POLICY_CITY_USERS"`
+	SupplierCrops string `config:"supplierCrops" env:"DELETE_POLICY_SUPPLIER_CROPS"`
+	UserCrops     string `config:"userCrops" env:"DELETE_POLICY_USER_CROPS"`
 }
 
 // Config for modeling a global object with the global app configurations
 type Config struct {
-	Database DatabaseConf
-	Port     string
+	Server    ServerConf    `config:"server"`
+	Database  DatabaseConf  `config:"database"`
+	Auth      AuthConf      `config:"auth"`
+	Webhooks  WebhookConf   `config:"webhooks"`
+	Events    EventConf     `config:"events"`
+	Log       LogConf       `config:"log"`
+	Health    HealthConf    `config:"health"`
+	Catalog   CatalogConf   `config:"catalog"`
+	Integrity IntegrityConf `config:"integrity"`
 }
 
-// NewDefaultConfig return a config object with all application environment variables loaded
-func NewDefaultConfig() *Config {
+// Defaults returns the configuration used for the attributes set by no other source
+func Defaults() *Config {
 	return &Config{
+		Server: ServerConf{
+			Port:              "3000",
+			AllowedOrigins:    []string{"*"},
+			RequestTimeout:    5 * time.Minute,
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
 		Database: DatabaseConf{
-			URI:      getEnv("DB_URI", ""),
-			PoolSize: uint16(getEnvAsUInt("DB_POOL_SIZE", 10)),
-			Name:     getEnv("DB_NAME", ""),
+			PoolSize:       10,
+			ConnectTimeout: 15 * time.Second,
+		},
+		Auth: AuthConf{
+			InvitationTTL: 7 * 24 * time.Hour,
+			SessionTTL:    12 * time.Hour,
+		},
+		Webhooks: WebhookConf{
+			PollInterval: 5 * time.Second,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  8,
+		},
+		Events: EventConf{Source: "mongo"},
+		Log: LogConf{
+			Level:  "info",
+			Format: "json",
+		},
+		Health:  HealthConf{CheckTimeout: 2 * time.Second},
+		Catalog: CatalogConf{DefaultLocale: "es"},
+		Integrity: IntegrityConf{
+			ItemVariants:  "restrict",
+			VariantCrops:  "restrict",
+			StateCities:   "deactivate",
+			CityCrops:     "restrict",
+			CitySuppliers: "restrict",
+			CityUsers:     "nullify",
+			SupplierCrops: "cascade",
+			UserCrops:     "cascade",
 		},
-		Port: getEnv("APP_PORT", "3000"),
 	}
 }
 
-// Simple helper function to read an environment or return a default value
-func getEnv(Key string, defaultVal string) string {
-	if value, exists := os.LookupEnv(Key); exists {
-		return value
-	}
-	return defaultVal
+// ValidationError lists every invalid configuration attribute
+type ValidationError struct {
+	Problems []string
 }
 
-// Simple helper function to read an environment variable into an integer or return a default value
-func getEnvAsInt(name string, defaultVal int) int {
-	valueStr := getEnv(name, "")
-	if value, err := strconv.Atoi(valueStr); err != nil {
-		log.Printf("Error reading an environment variable %s %v\n", name, err)
-	} else {
-		return value
-	}
-	return defaultVal
+func (e *ValidationError) Error() string {
+	return "Invalid configuration: " + strings.Join(e.Problems, "; ")
 }
 
-// Simple helper function to read an environment variable into an unsigned integer of 64 bytes or return a default value
-func getEnvAsUInt(name string, defaultVal uint64) uint64 {
-	valueStr := getEnv(name, "")
-	if value, err := strconv.ParseUint(valueStr, 10, 64); err != nil {
-		log.Printf("Error reading an environment variable %s %v\n", name, err)
-	} else {
-		return value
-	}
-	return defaultVal
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
 }
 
-// Helper to read an environment variable into a bool or return default value
-func getEnvAsBool(name string, defaultVal bool) bool {
-	valStr := getEnv(name, "")
-	if value, err := strconv.ParseBool(valStr); err != nil {
-		log.Printf("Error reading an environment variable %s %v\n", name, err)
-	} else {
-		return value
+// Validate reports every invalid configuration attribute at once
+func (c *Config) Validate() error {
+	problems := &ValidationError{}
+	if c.Database.URI == "" {
+		problems.add("database.uri (DB_URI) is required")
+	}
+	if c.Database.Name == "" {
+		problems.add("database.name (DB_NAME) is required")
+	}
+	if c.Database.PoolSize == 0 {
+		problems.add("database.poolSize (DB_POOL_SIZE) must be greater than 0")
+	}
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port <= 0 || port > 65535 {
+		problems.add("server.port (APP_PORT) must be a port number, got %q", c.Server.Port)
+	}
+	if c.Server.GRPCPort != "" {
+		if port, err := strconv.Atoi(c.Server.GRPCPort); err != nil || port <= 0 || port > 65535 {
+			problems.add("server.grpcPort (GRPC_PORT) must be a port number, got %q", c.Server.GRPCPort)
+		} else if c.Server.GRPCPort == c.Server.Port {
+			problems.add("server.grpcPort (GRPC_PORT) must differ from server.port (APP_PORT)")
+		}
+	}
+	if len(c.Server.AllowedOrigins) == 0 {
+		problems.add("server.allowedOrigins (CORS_ALLOWED_ORIGINS) must list at least one origin")
+	}
+	if c.Events.Source != "mongo" && c.Events.Source != "memory" {
+		problems.add("events.source (EVENT_SOURCE) must be mongo or memory, got %q", c.Events.Source)
+	}
+	if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		problems.add("log.level (LOG_LEVEL) is not a level, got %q", c.Log.Level)
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		problems.add("log.format (LOG_FORMAT) must be json or text, got %q", c.Log.Format)
+	}
+	if _, err := i18n.ParseLocale(c.Catalog.DefaultLocale); err != nil {
+		problems.add("catalog.defaultLocale (CATALOG_DEFAULT_LOCALE) must be a locale such as es or en-US, got %q", c.Catalog.DefaultLocale)
+	}
+	if c.Webhooks.MaxAttempts <= 0 {
+		problems.add("webhooks.maxAttempts (WEBHOOK_MAX_ATTEMPTS) must be greater than 0")
+	}
+	for _, field := range fields(c) {
+		if !strings.HasPrefix(field.key, "integrity.") {
+			continue
+		}
+		policy := field.value.String()
+		switch {
+		case policy != "restrict" && policy != "cascade" && policy != "nullify" && policy != "deactivate":
+			problems.add("%s (%s) must be restrict, cascade, nullify or deactivate, got %q", field.key, field.env, policy)
+		case policy == "deactivate" && strings.HasSuffix(field.key, "Crops"):
+			problems.add("%s (%s) cannot be deactivate, the crops have no record status", field.key, field.env)
+		}
+	}
+	for _, field := range fields(c) {
+		if duration, ok := field.value.Interface().(time.Duration); ok && duration <= 0 && field.key != "server.shutdownDelay" {
+			problems.add("%s (%s) must be a positive duration", field.key, field.env)
+		}
+	}
+	if len(problems.Problems) > 0 {
+		return problems
 	}
-	return defaultVal
+	return nil
 }
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// fileEnv names the environment variable giving the configuration file when no -config flag is set
+const fileEnv = "CONFIG_FILE"
+
+const redacted = "********"
+
+// field is an attribute of the configuration
+type field struct {
+	key    string
+	env    string
+	secret string
+	value  reflect.Value
+}
+
+// fields lists the attributes of the configuration in declaration order
+func fields(c *Config) []field {
+	var result []field
+	var walk func(prefix string, value reflect.Value)
+	walk = func(prefix string, value reflect.Value) {
+		for i := 0; i < value.NumField(); i++ {
+			structField := value.Type().Field(i)
+			key := prefix + structField.Tag.Get("config")
+			if structField.Type.Kind() == reflect.Struct {
+				walk(key+".", value.Field(i))
+				continue
+			}
+			result = append(result, field{
+				key:    key,
+				env:    structField.Tag.Get("env"),
+				secret: structField.Tag.Get("secret"),
+				value:  value.Field(i),
+			})
+		}
+	}
+	walk("", reflect.ValueOf(c).Elem())
+	return result
+}
+
+// Load returns the configuration built from, by increasing precedence, the defaults, the YAML or
+// TOML file given by the -config flag or the CONFIG_FILE environment variable, the environment
+// variables and the command-line flags in args. Every invalid attribute is reported at once.
+func Load(name string, args []string) (*Config, error) {
+	return LoadFlags(flag.NewFlagSet(name, flag.ContinueOnError), args)
+}
+
+// LoadFlags is Load parsing args with a flag set the caller may have defined its own flags on,
+// the arguments following the flags are left in flags.Args()
+func LoadFlags(flags *flag.FlagSet, args []string) (*Config, error) {
+	conf := Defaults()
+	attributes := fields(conf)
+	problems := &ValidationError{}
+
+	configFile := flags.String("config", os.Getenv(fileEnv), "YAML or TOML configuration `file` (env "+fileEnv+")")
+	flagValues := map[string]*string{}
+	for _, attribute := range attributes {
+		flagValues[attribute.key] = flags.String(attribute.key, "", fmt.Sprintf("sets %s (env %s)", attribute.key, attribute.env))
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, errors.Wrap(err, "Error parsing the command-line flags")
+	}
+
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, attribute := range attributes {
+			if value, ok := values[attribute.key]; ok {
+				set(problems, attribute, value, "in "+*configFile)
+				delete(values, attribute.key)
+			}
+		}
+		for key := range values {
+			problems.add("unknown attribute %s in %s", key, *configFile)
+		}
+	}
+
+	for _, attribute := range attributes {
+		if path, ok := os.LookupEnv(attribute.env + "_FILE"); ok {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				problems.add("%s_FILE: %v", attribute.env, err)
+				continue
+			}
+			set(problems, attribute, strings.TrimRight(string(content), "\r\n"), "from "+attribute.env+"_FILE")
+		} else if value, ok := os.LookupEnv(attribute.env); ok {
+			set(problems, attribute, value, "from "+attribute.env)
+		}
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		for _, attribute := range attributes {
+			if attribute.key == f.Name {
+				set(problems, attribute, *flagValues[f.Name], "from -"+f.Name)
+			}
+		}
+	})
+
+	if err := conf.Validate(); err != nil {
+		problems.Problems = append(problems.Problems, err.(*ValidationError).Problems...)
+	}
+	if len(problems.Problems) > 0 {
+		return nil, problems
+	}
+	return conf, nil
+}
+
+// readFile returns the attributes of a YAML or TOML file by dotted key
+func readFile(path string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the configuration file")
+	}
+
+	var document map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return nil, errors.Errorf("Unsupported configuration file %s, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing the configuration file %s", path)
+	}
+
+	values := map[string]string{}
+	flatten("", document, values)
+	return values, nil
+}
+
+func flatten(prefix string, value interface{}, values map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flatten(prefix+key+".", child, values)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range v {
+			flatten(prefix+fmt.Sprint(key)+".", child, values)
+		}
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		values[strings.TrimSuffix(prefix, ".")] = strings.Join(items, ",")
+	default:
+		values[strings.TrimSuffix(prefix, ".")] = fmt.Sprint(v)
+	}
+}
+
+// set parses value into the attribute, an invalid value is reported and leaves the attribute
+// unchanged, source tells where the value comes from in errors
+func set(problems *ValidationError, attribute field, value string, source string) {
+	target := attribute.value
+	var err error
+	switch target.Interface().(type) {
+	case time.Duration:
+		var duration time.Duration
+		if seconds, convErr := strconv.ParseInt(value, 10, 64); convErr == nil {
+			duration = time.Duration(seconds) * time.Second
+		} else {
+			duration, err = time.ParseDuration(value)
+		}
+		if err == nil {
+			target.SetInt(int64(duration))
+		}
+	case []string:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		target.Set(reflect.ValueOf(items))
+	case string:
+		target.SetString(value)
+	case bool:
+		var b bool
+		if b, err = strconv.ParseBool(value); err == nil {
+			target.SetBool(b)
+		}
+	case int:
+		var i int64
+		if i, err = strconv.ParseInt(value, 10, 0); err == nil {
+			target.SetInt(i)
+		}
+	case uint16:
+		var u uint64
+		if u, err = strconv.ParseUint(value, 10, 16); err == nil {
+			target.SetUint(u)
+		}
+	default:
+		err = errors.Errorf("unsupported type %s", target.Type())
+	}
+	if err != nil {
+		problems.add("%s %s: invalid value %q", attribute.key, source, redact(attribute, value))
+	}
+}
+
+// String prints every attribute of the configuration with the secrets redacted
+func (c *Config) String() string {
+	attributes := fields(c)
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].key < attributes[j].key })
+	var builder strings.Builder
+	for _, attribute := range attributes {
+		value := attribute.value.Interface()
+		if items, ok := value.([]string); ok {
+			value = strings.Join(items, ",")
+		}
+		fmt.Fprintf(&builder, "%s=%s\n", attribute.key, redact(attribute, fmt.Sprint(value)))
+	}
+	return builder.String()
+}
+
+// redact hides the value of a secret attribute, only the password of a connection string is hidden
+func redact(attribute field, value string) string {
+	switch {
+	case attribute.secret == "" || value == "":
+		return value
+	case attribute.secret == "uri":
+		if uri, err := url.Parse(value); err == nil && uri.User != nil {
+			if _, hasPassword := uri.User.Password(); hasPassword {
+				uri.User = url.UserPassword(uri.User.Username(), redacted)
+			}
+			return strings.Replace(uri.String(), url.QueryEscape(redacted), redacted, 1)
+		} else if err == nil {
+			return value
+		}
+	}
+	return redacted
+}
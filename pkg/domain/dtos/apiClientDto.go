@@ -0,0 +1,10 @@
+package dtos
+
+import "futuagro.com/pkg/domain/enums"
+
+// APIClientDto represents a DTO for an API client document
+type APIClientDto struct {
+	Name         string                  `json:"name"`
+	Scopes       []enums.EnumScope       `json:"scopes"`
+	RecordStatus *enums.EnumRecordStatus `json:"recordStatus"`
+}
@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+// AuditQueryDto represents the filters of a search in the audit trail
+type AuditQueryDto struct {
+	ResourceType string
+	ResourceID   string
+	ActorID      string
+	From         *time.Time
+	To           *time.Time
+	Limit        int64
+	Skip         int64
+}
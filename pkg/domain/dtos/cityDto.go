@@ -6,4 +6,6 @@ import "futuagro.com/pkg/domain/enums"
 type CityDto struct {
 	CityName     string                  `json:"cityName"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus"`
+	// CityCode is the official code of the city, e.g. its DIVIPOLA code in Colombia
+	CityCode string `json:"cityCode"`
 }
@@ -6,5 +6,6 @@ import "futuagro.com/pkg/domain/enums"
 type CountryDto struct {
 	CountryName  string                  `json:"countryName"`
 	CountryCode  string                  `json:"countryCode"`
+	CurrencyCode string                  `json:"currencyCode,omitempty"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus"`
 }
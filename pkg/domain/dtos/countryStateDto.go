@@ -8,4 +8,6 @@ import (
 type CountryStateDto struct {
 	StateName    string                  `json:"stateName"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus"`
+	// StateCode is the ISO 3166-2 code of the state, e.g. CO-ANT
+	StateCode string `json:"stateCode"`
 }
@@ -0,0 +1,12 @@
+package dtos
+
+import "time"
+
+// ExchangeRateDto represents a DTO for an exchange rate, the value of one unit of Base in units of
+// Quote written as a decimal such as "4012.5"
+type ExchangeRateDto struct {
+	Base        string    `json:"base"`
+	Quote       string    `json:"quote"`
+	Rate        string    `json:"rate"`
+	EffectiveAt time.Time `json:"effectiveAt"`
+}
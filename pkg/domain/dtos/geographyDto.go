@@ -0,0 +1,25 @@
+package dtos
+
+// GeographyCountryDto represents a country of the reference geography with its states, every
+// record is identified by its official code
+type GeographyCountryDto struct {
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country, e.g. CO
+	CountryCode string              `json:"countryCode"`
+	CountryName string              `json:"countryName"`
+	States      []GeographyStateDto `json:"states"`
+}
+
+// GeographyStateDto represents a state of the reference geography with its cities
+type GeographyStateDto struct {
+	// StateCode is the ISO 3166-2 code of the state, e.g. CO-ANT
+	StateCode string             `json:"stateCode"`
+	StateName string             `json:"stateName"`
+	Cities    []GeographyCityDto `json:"cities"`
+}
+
+// GeographyCityDto represents a city of the reference geography
+type GeographyCityDto struct {
+	// CityCode is the official code of the city, e.g. its DIVIPOLA code in Colombia
+	CityCode string `json:"cityCode"`
+	CityName string `json:"cityName"`
+}
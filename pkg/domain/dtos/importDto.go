@@ -0,0 +1,13 @@
+package dtos
+
+import "futuagro.com/pkg/domain/enums"
+
+// ImportDto represents a request to import the rows of a spreadsheet
+type ImportDto struct {
+	Kind     enums.EnumImportKind
+	FileName string
+	// Format is csv or xlsx
+	Format string
+	// DryRun only validates the rows and reports their errors, nothing is written
+	DryRun bool
+}
@@ -8,4 +8,6 @@ import (
 type ItemDto struct {
 	Name         string                  `json:"name" bson:"name"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	// Names replace the localized names of the item, they are kept when Names is absent
+	Names []LocalizedNameDto `json:"names" bson:"names"`
 }
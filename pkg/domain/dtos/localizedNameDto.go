@@ -0,0 +1,8 @@
+package dtos
+
+// LocalizedNameDto represents the name of an item or a variant in a locale and its synonyms
+type LocalizedNameDto struct {
+	Locale   string   `json:"locale" bson:"locale"`
+	Name     string   `json:"name" bson:"name"`
+	Synonyms []string `json:"synonyms,omitempty" bson:"synonyms,omitempty"`
+}
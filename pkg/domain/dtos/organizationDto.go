@@ -0,0 +1,39 @@
+package dtos
+
+import (
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrganizationDto represents a DTO for an organization document
+type OrganizationDto struct {
+	Name         string                     `json:"name"`
+	Kind         enums.EnumOrganizationKind `json:"kind"`
+	RecordStatus *enums.EnumRecordStatus    `json:"recordStatus"`
+}
+
+// MembershipDto represents a DTO for adding a user or an API client to an organization
+type MembershipDto struct {
+	MemberType string               `json:"memberType"`
+	MemberID   primitive.ObjectID   `json:"memberId"`
+	Role       enums.EnumMemberRole `json:"role"`
+}
+
+// MemberRoleDto represents a DTO for changing the role of a member
+type MemberRoleDto struct {
+	Role enums.EnumMemberRole `json:"role"`
+}
+
+// InvitationDto represents a DTO for inviting an email address to join an organization
+type InvitationDto struct {
+	Email string               `json:"email"`
+	Role  enums.EnumMemberRole `json:"role"`
+}
+
+// AcceptInvitationDto represents a DTO for accepting an invitation, the invitee proves it owns
+// the invited email with the credentials of its user
+type AcceptInvitationDto struct {
+	Token    string `json:"token"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+import "futuagro.com/pkg/domain/enums"
+
+// WebhookSubscriptionDto represents a DTO for a webhook subscription document
+type WebhookSubscriptionDto struct {
+	URL          string                  `json:"url"`
+	Description  string                  `json:"description"`
+	EventTypes   []enums.EnumEventType   `json:"eventTypes"`
+	RecordStatus *enums.EnumRecordStatus `json:"recordStatus"`
+}
+
+// WebhookDeliveryQueryDto represents the filters of a search in the webhook deliveries
+type WebhookDeliveryQueryDto struct {
+	SubscriptionID string
+	EventID        string
+	Status         enums.EnumDeliveryStatus
+	Limit          int64
+	Skip           int64
+}
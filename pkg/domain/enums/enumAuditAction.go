@@ -0,0 +1,17 @@
+package enums
+
+// EnumAuditAction represents the kind of mutation recorded in the audit trail
+type EnumAuditAction string
+
+const (
+	// AuditCreate records the creation of a resource
+	AuditCreate EnumAuditAction = "create"
+	// AuditUpdate records a change to an existing resource
+	AuditUpdate EnumAuditAction = "update"
+	// AuditDelete records the removal of a resource
+	AuditDelete EnumAuditAction = "delete"
+)
+
+func (a EnumAuditAction) String() string {
+	return string(a)
+}
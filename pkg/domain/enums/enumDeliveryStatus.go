@@ -0,0 +1,44 @@
+package enums
+
+// EnumDeliveryStatus represents the state of the delivery of an event to a webhook subscription
+type EnumDeliveryStatus string
+
+const (
+	// DeliveryPending is waiting for its first attempt, or for a replay
+	DeliveryPending EnumDeliveryStatus = "pending"
+	// DeliveryRetrying has failed at least once and is waiting for its next attempt
+	DeliveryRetrying EnumDeliveryStatus = "retrying"
+	// DeliverySucceeded was acknowledged by the subscriber with a 2xx response
+	DeliverySucceeded EnumDeliveryStatus = "succeeded"
+	// DeliveryDead ran out of attempts, it is only sent again when replayed
+	DeliveryDead EnumDeliveryStatus = "dead"
+)
+
+func (s EnumDeliveryStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports whether the status is one of the delivery states
+func (s EnumDeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryPending, DeliveryRetrying, DeliverySucceeded, DeliveryDead:
+		return true
+	}
+	return false
+}
+
+// EnumOutboxStatus represents the state of a domain event waiting in the outbox
+type EnumOutboxStatus string
+
+const (
+	// OutboxPending has not been fanned out to the webhook subscriptions yet
+	OutboxPending EnumOutboxStatus = "pending"
+	// OutboxDispatching has been claimed by a dispatcher that is fanning it out
+	OutboxDispatching EnumOutboxStatus = "dispatching"
+	// OutboxDispatched has a delivery for every subscription that matched it
+	OutboxDispatched EnumOutboxStatus = "dispatched"
+)
+
+func (s EnumOutboxStatus) String() string {
+	return string(s)
+}
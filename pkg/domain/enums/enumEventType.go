@@ -0,0 +1,112 @@
+package enums
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// EnumEventType represents the type of a domain event, named after a resource and what happened to it
+type EnumEventType string
+
+const (
+	// AllEvents subscribes a webhook to every event type
+	AllEvents EnumEventType = "*"
+	// SupplierCreated is emitted when a supplier is registered
+	SupplierCreated EnumEventType = "supplier.created"
+	// SupplierUpdated is emitted when the data of a supplier changes
+	SupplierUpdated EnumEventType = "supplier.updated"
+	// SupplierDeleted is emitted when a supplier is removed
+	SupplierDeleted EnumEventType = "supplier.deleted"
+	// CountryCreated is emitted when a country is created
+	CountryCreated EnumEventType = "country.created"
+	// CountryUpdated is emitted when a country or one of its states changes
+	CountryUpdated EnumEventType = "country.updated"
+	// CountryDeleted is emitted when a country is removed
+	CountryDeleted EnumEventType = "country.deleted"
+	// CityCreated is emitted when a city is created
+	CityCreated EnumEventType = "city.created"
+	// CityUpdated is emitted when the data of a city changes
+	CityUpdated EnumEventType = "city.updated"
+	// CityDeleted is emitted when a city is removed
+	CityDeleted EnumEventType = "city.deleted"
+	// ItemCreated is emitted when an item is added to the catalog
+	ItemCreated EnumEventType = "item.created"
+	// ItemUpdated is emitted when the data of an item changes
+	ItemUpdated EnumEventType = "item.updated"
+	// ItemDeleted is emitted when an item is removed from the catalog
+	ItemDeleted EnumEventType = "item.deleted"
+	// VariantCreated is emitted when a variant is added to an item
+	VariantCreated EnumEventType = "variant.created"
+	// VariantUpdated is emitted when the data of a variant changes
+	VariantUpdated EnumEventType = "variant.updated"
+	// VariantDeleted is emitted when a variant is removed from an item
+	VariantDeleted EnumEventType = "variant.deleted"
+	// CropCreated is emitted when a supplier registers a crop
+	CropCreated EnumEventType = "crop.created"
+	// CropUpdated is emitted when the data of a crop changes
+	CropUpdated EnumEventType = "crop.updated"
+	// CropHarvestDateChanged is emitted along with CropUpdated when the harvest date of a crop moves
+	CropHarvestDateChanged EnumEventType = "crop.harvest_date_changed"
+	// CropDeleted is emitted when a crop is removed
+	CropDeleted EnumEventType = "crop.deleted"
+)
+
+func (e EnumEventType) String() string {
+	return string(e)
+}
+
+var validEventTypes = map[EnumEventType]bool{
+	AllEvents:              true,
+	SupplierCreated:        true,
+	SupplierUpdated:        true,
+	SupplierDeleted:        true,
+	CountryCreated:         true,
+	CountryUpdated:         true,
+	CountryDeleted:         true,
+	CityCreated:            true,
+	CityUpdated:            true,
+	CityDeleted:            true,
+	ItemCreated:            true,
+	ItemUpdated:            true,
+	ItemDeleted:            true,
+	VariantCreated:         true,
+	VariantUpdated:         true,
+	VariantDeleted:         true,
+	CropCreated:            true,
+	CropUpdated:            true,
+	CropHarvestDateChanged: true,
+	CropDeleted:            true,
+}
+
+// IsValid reports whether the event type belongs to the event vocabulary
+func (e EnumEventType) IsValid() bool {
+	return validEventTypes[e]
+}
+
+// MarshalJSON marshals the enum as a quoted json string
+func (e *EnumEventType) MarshalJSON() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, errors.New("Invalid EventType value")
+	}
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(string(*e))
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON unmashals a quoted json string to the enum value
+func (e *EnumEventType) UnmarshalJSON(b []byte) error {
+	var j string
+	err := json.Unmarshal(b, &j)
+	if err != nil {
+		return err
+	}
+	value := EnumEventType(j)
+	if !value.IsValid() {
+		return errors.New("Invalid EventType value: " + j)
+	}
+	*e = value
+	return nil
+}
@@ -0,0 +1,44 @@
+package enums
+
+// EnumImportKind represents the kind of records a spreadsheet import creates
+type EnumImportKind string
+
+const (
+	// ImportSuppliers creates suppliers, one per row
+	ImportSuppliers EnumImportKind = "suppliers"
+	// ImportCrops creates crops, one per row, for suppliers already registered
+	ImportCrops EnumImportKind = "crops"
+	// ImportExchangeRates creates exchange rates, one per row
+	ImportExchangeRates EnumImportKind = "exchange-rates"
+)
+
+func (k EnumImportKind) String() string {
+	return string(k)
+}
+
+// IsValid reports whether the kind is one of the importable kinds of records
+func (k EnumImportKind) IsValid() bool {
+	switch k {
+	case ImportSuppliers, ImportCrops, ImportExchangeRates:
+		return true
+	}
+	return false
+}
+
+// EnumImportStatus represents the progress of an import job
+type EnumImportStatus string
+
+const (
+	// ImportValidated is the status of a dry run, nothing has been written
+	ImportValidated EnumImportStatus = "validated"
+	// ImportRunning is committing the valid rows batch after batch
+	ImportRunning EnumImportStatus = "running"
+	// ImportCompleted has committed every valid row
+	ImportCompleted EnumImportStatus = "completed"
+	// ImportFailed stopped on an error before committing every valid row
+	ImportFailed EnumImportStatus = "failed"
+)
+
+func (s EnumImportStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,60 @@
+package enums
+
+// EnumOrganizationKind represents the kind of an organization of the marketplace
+type EnumOrganizationKind string
+
+const (
+	// BuyerOrganization is a company buying crops, like a supermarket chain
+	BuyerOrganization EnumOrganizationKind = "buyer"
+	// Cooperative is an association of farmers selling their crops together
+	Cooperative EnumOrganizationKind = "cooperative"
+)
+
+func (k EnumOrganizationKind) String() string {
+	return string(k)
+}
+
+// IsValid reports whether the kind is one of the kinds of organizations
+func (k EnumOrganizationKind) IsValid() bool {
+	switch k {
+	case BuyerOrganization, Cooperative:
+		return true
+	}
+	return false
+}
+
+// EnumMemberRole represents the role of a member within an organization
+type EnumMemberRole string
+
+const (
+	// MemberOwner manages the organization, its members and its invitations, and can delete it
+	MemberOwner EnumMemberRole = "owner"
+	// MemberAdmin manages the organization, its members and its invitations
+	MemberAdmin EnumMemberRole = "admin"
+	// MemberBuyer buys on behalf of the organization
+	MemberBuyer EnumMemberRole = "buyer"
+	// MemberApprover approves the purchases of the buyers
+	MemberApprover EnumMemberRole = "approver"
+	// MemberAccountant reads the reports of the organization
+	MemberAccountant EnumMemberRole = "accountant"
+	// MemberFarmer supplies crops through a cooperative
+	MemberFarmer EnumMemberRole = "farmer"
+)
+
+func (r EnumMemberRole) String() string {
+	return string(r)
+}
+
+// IsValid reports whether the role is one of the roles of the members
+func (r EnumMemberRole) IsValid() bool {
+	switch r {
+	case MemberOwner, MemberAdmin, MemberBuyer, MemberApprover, MemberAccountant, MemberFarmer:
+		return true
+	}
+	return false
+}
+
+// CanManage reports whether the role manages the organization, its members and its invitations
+func (r EnumMemberRole) CanManage() bool {
+	return r == MemberOwner || r == MemberAdmin
+}
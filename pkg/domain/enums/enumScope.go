@@ -0,0 +1,118 @@
+package enums
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnumScope represents a permission granted to an API client, named after a resource and an action
+type EnumScope string
+
+const (
+	// AllScopes grants every permission, it is only held by the administrator key
+	AllScopes EnumScope = "*"
+	// SuppliersRead allows listing and reading suppliers
+	SuppliersRead EnumScope = "suppliers:read"
+	// SuppliersWrite allows creating, updating and deleting suppliers
+	SuppliersWrite EnumScope = "suppliers:write"
+	// CountriesRead allows listing and reading countries and their states
+	CountriesRead EnumScope = "countries:read"
+	// CountriesWrite allows creating, updating and deleting countries and their states
+	CountriesWrite EnumScope = "countries:write"
+	// CitiesRead allows listing and reading cities
+	CitiesRead EnumScope = "cities:read"
+	// CitiesWrite allows creating, updating and deleting cities
+	CitiesWrite EnumScope = "cities:write"
+	// ItemsRead allows listing and reading the catalog of items and variants
+	ItemsRead EnumScope = "items:read"
+	// ItemsWrite allows creating, updating and deleting items and variants
+	ItemsWrite EnumScope = "items:write"
+	// CropsRead allows listing and reading crops
+	CropsRead EnumScope = "crops:read"
+	// CropsWrite allows creating, updating and deleting crops
+	CropsWrite EnumScope = "crops:write"
+	// UsersRead allows listing and reading users
+	UsersRead EnumScope = "users:read"
+	// UsersWrite allows creating, updating and deleting users
+	UsersWrite EnumScope = "users:write"
+	// APIClientsAdmin allows managing API clients and their keys
+	APIClientsAdmin EnumScope = "api-clients:admin"
+	// AuditRead allows searching the audit trail
+	AuditRead EnumScope = "audit:read"
+	// WebhooksAdmin allows managing webhook subscriptions and replaying their deliveries
+	WebhooksAdmin EnumScope = "webhooks:admin"
+	// OrganizationsRead allows reading the organizations the caller is a member of
+	OrganizationsRead EnumScope = "organizations:read"
+	// OrganizationsWrite allows creating organizations and managing the ones the caller administers
+	OrganizationsWrite EnumScope = "organizations:write"
+	// ExchangeRatesRead allows listing the exchange rates and converting amounts between currencies
+	ExchangeRatesRead EnumScope = "exchange-rates:read"
+	// ExchangeRatesAdmin allows maintaining the exchange rates, by hand or by importing them
+	ExchangeRatesAdmin EnumScope = "exchange-rates:admin"
+)
+
+func (s EnumScope) String() string {
+	return string(s)
+}
+
+var validScopes = map[EnumScope]bool{
+	AllScopes:          true,
+	SuppliersRead:      true,
+	SuppliersWrite:     true,
+	CountriesRead:      true,
+	CountriesWrite:     true,
+	CitiesRead:         true,
+	CitiesWrite:        true,
+	ItemsRead:          true,
+	ItemsWrite:         true,
+	CropsRead:          true,
+	CropsWrite:         true,
+	UsersRead:          true,
+	UsersWrite:         true,
+	APIClientsAdmin:    true,
+	AuditRead:          true,
+	WebhooksAdmin:      true,
+	OrganizationsRead:  true,
+	OrganizationsWrite: true,
+	ExchangeRatesRead:  true,
+	ExchangeRatesAdmin: true,
+}
+
+// IsValid reports whether the scope belongs to the scope vocabulary
+func (s EnumScope) IsValid() bool {
+	return validScopes[s]
+}
+
+// IsRead reports whether the scope only allows reading, the other scopes allow writing
+func (s EnumScope) IsRead() bool {
+	return strings.HasSuffix(string(s), ":read")
+}
+
+// MarshalJSON marshals the enum as a quoted json string
+func (s *EnumScope) MarshalJSON() ([]byte, error) {
+	if !s.IsValid() {
+		return nil, errors.New("Invalid Scope value")
+	}
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(string(*s))
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON unmashals a quoted json string to the enum value
+func (s *EnumScope) UnmarshalJSON(b []byte) error {
+	var j string
+	err := json.Unmarshal(b, &j)
+	if err != nil {
+		return err
+	}
+	value := EnumScope(j)
+	if !value.IsValid() {
+		return errors.New("Invalid Scope value: " + j)
+	}
+	*s = value
+	return nil
+}
@@ -0,0 +1,136 @@
+// Package errs contains the typed errors of the business domain. The repositories and services
+// return them so that the transports answer the failure the caller made, any other error is a
+// failure of the application.
+package errs
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Kind classifies the errors of the domain
+type Kind int
+
+const (
+	// KindInternal is a failure of the application or of the database
+	KindInternal Kind = iota
+	// KindNotFound is a missing resource
+	KindNotFound
+	// KindInvalidID is an ID that cannot identify any resource, like a malformed ObjectID
+	KindInvalidID
+	// KindConflict is a write that clashes with the stored resources, like a duplicate key
+	KindConflict
+	// KindValidation is an invalid input
+	KindValidation
+	// KindForbidden is an operation the caller is not allowed to make
+	KindForbidden
+	// KindPreconditionFailed is a conditional write on a resource that has been modified since
+	KindPreconditionFailed
+)
+
+// Error is an error of the domain, its message can be shared with the caller
+type Error struct {
+	Kind    Kind
+	Message string
+	// Key is the message before its arguments Args are formatted in, in English, the transports
+	// translate the message from it
+	Key  string
+	Args []interface{}
+	// Field names the attribute of the input the error is about, when there is one
+	Field string
+	// Err is the underlying error, it is only logged
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + " : " + e.Err.Error()
+}
+
+// KindOf returns the kind of an error, wrapped or not, KindInternal when it is not an error of
+// the domain
+func KindOf(err error) Kind {
+	if e, ok := errors.Cause(err).(*Error); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// Is tells whether err, wrapped or not, is an error of the domain of the given kind
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
+
+// newError returns an error of the domain whose message is formatted from key and args
+func newError(kind Kind, err error, key string, args ...interface{}) *Error {
+	message := key
+	if len(args) > 0 {
+		message = fmt.Sprintf(key, args...)
+	}
+	return &Error{Kind: kind, Message: message, Key: key, Args: args, Err: err}
+}
+
+// NotFound returns the error of a missing resource, named in its message: "Supplier Not Found"
+func NotFound(resource string) error {
+	return newError(KindNotFound, nil, resource+" Not Found")
+}
+
+// InvalidID returns the error of an ID that cannot be parsed
+func InvalidID(id string, err error) error {
+	return newError(KindInvalidID, err, "Invalid ID %q", id)
+}
+
+// Conflict returns the error of a write clashing with the stored resources
+func Conflict(message string, err error) error {
+	return newError(KindConflict, err, message)
+}
+
+// Conflictf returns the error of a write clashing with the stored resources, its message is
+// formatted from key and args
+func Conflictf(err error, key string, args ...interface{}) error {
+	return newError(KindConflict, err, key, args...)
+}
+
+// Duplicate returns the conflict of a write that would give a resource the value of field of
+// another resource, like the email of another user
+func Duplicate(field string, err error) error {
+	e := newError(KindConflict, err, "%s is already in use", field)
+	e.Field = field
+	return e
+}
+
+// InvalidReference returns the error of an input whose field does not identify an active resource
+func InvalidReference(field string, resource string) error {
+	e := newError(KindValidation, nil, "%s does not reference an active %s", field, resource)
+	e.Field = field
+	return e
+}
+
+// Validation returns the error of an invalid input
+func Validation(message string) error {
+	return newError(KindValidation, nil, message)
+}
+
+// Validationf returns the error of an invalid input, its message is formatted from key and args
+func Validationf(key string, args ...interface{}) error {
+	return newError(KindValidation, nil, key, args...)
+}
+
+// Forbidden returns the error of an operation the caller is not allowed to make
+func Forbidden(message string) error {
+	return newError(KindForbidden, nil, message)
+}
+
+// Forbiddenf returns the error of an operation the caller is not allowed to make, its message is
+// formatted from key and args
+func Forbiddenf(key string, args ...interface{}) error {
+	return newError(KindForbidden, nil, key, args...)
+}
+
+// PreconditionFailed returns the error of a conditional write on a modified resource
+func PreconditionFailed(message string) error {
+	return newError(KindPreconditionFailed, nil, message)
+}
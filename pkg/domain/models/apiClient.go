@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIClient represents a machine to machine integration that authenticates with an API key
+type APIClient struct {
+	ID           primitive.ObjectID      `json:"_id" bson:"_id"`
+	Name         string                  `json:"name" bson:"name"`
+	KeyPrefix    string                  `json:"keyPrefix" bson:"keyPrefix"`
+	HashedKey    string                  `json:"-" bson:"hashedKey"`
+	Scopes       []enums.EnumScope       `json:"scopes" bson:"scopes"`
+	LastUsedAt   *time.Time              `json:"lastUsedAt,omitempty" bson:"lastUsedAt,omitempty"`
+	UsageCount   int64                   `json:"usageCount" bson:"usageCount"`
+	RecordStatus *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	CreatedAt    time.Time               `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time               `json:"updatedAt" bson:"updatedAt"`
+	Version      int64                   `json:"version" bson:"version"`
+}
+
+// IssuedAPIKey holds an API client along with its plain text key, the key is only
+// available at the moment it is issued
+type IssuedAPIKey struct {
+	Client *APIClient `json:"client"`
+	Key    string     `json:"key"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditEntry represents a mutation made to a resource, who made it and what it changed
+type AuditEntry struct {
+	ID           primitive.ObjectID    `json:"_id" bson:"_id,omitempty"`
+	Actor        AuditActor            `json:"actor" bson:"actor"`
+	Action       enums.EnumAuditAction `json:"action" bson:"action"`
+	ResourceType string                `json:"resourceType" bson:"resourceType"`
+	ResourceID   string                `json:"resourceId" bson:"resourceId"`
+	RequestID    string                `json:"requestId,omitempty" bson:"requestId,omitempty"`
+	Changes      []FieldChange         `json:"changes" bson:"changes"`
+	Timestamp    time.Time             `json:"timestamp" bson:"timestamp"`
+}
+
+// AuditActor identifies the caller that made a mutation
+type AuditActor struct {
+	Type string `json:"type" bson:"type"`
+	ID   string `json:"id,omitempty" bson:"id,omitempty"`
+	Name string `json:"name,omitempty" bson:"name,omitempty"`
+	// OrganizationID is the organization the caller was acting for, if any
+	OrganizationID string `json:"organizationId,omitempty" bson:"organizationId,omitempty"`
+}
+
+// FieldChange represents the value of a field before and after a mutation, nested fields are
+// named with a dotted path
+type FieldChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
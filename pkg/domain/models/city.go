@@ -9,6 +9,8 @@ import (
 type City struct {
 	ID             primitive.ObjectID      `json:"_id" bson:"_id"`
 	CityName       string                  `json:"cityName" bson:"cityName"`
+	CityCode       string                  `json:"cityCode,omitempty" bson:"cityCode,omitempty"`
 	CountryStateID primitive.ObjectID      `json:"countryStateId" bson:"countryStateId"`
 	RecordStatus   *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	Version        int64                   `json:"version" bson:"version"`
 }
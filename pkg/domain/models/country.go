@@ -10,13 +10,16 @@ type Country struct {
 	ID           primitive.ObjectID      `json:"_id" bson:"_id"`
 	CountryName  string                  `json:"countryName,omitempty" bson:"countryName"`
 	CountryCode  string                  `json:"countryCode,omitempty" bson:"countryCode"`
+	CurrencyCode string                  `json:"currencyCode,omitempty" bson:"currencyCode,omitempty"`
 	States       []CountryState          `json:"states,omitempty" bson:"states"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus,omitempty" bson:"recordStatus"`
+	Version      int64                   `json:"version" bson:"version"`
 }
 
 // CountryState represent the data of country state
 type CountryState struct {
 	ID           primitive.ObjectID      `json:"_id" bson:"_id"`
 	StateName    string                  `json:"stateName,omitempty" bson:"stateName"`
+	StateCode    string                  `json:"stateCode,omitempty" bson:"stateCode,omitempty"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus,omitempty" bson:"recordStatus"`
 }
@@ -17,6 +17,9 @@ type Crop struct {
 	Variant      *Variant            `json:"variant,omitempty" bson:"variant"`
 	SupplierID   *primitive.ObjectID `json:"supplierId,omitempty" bson:"supplierId"`
 	Supplier     *User               `json:"supplier,omitempty" bson:"supplier"`
-	CreatedAt    time.Time           `json:"createdAt" bson:"createdAt"`
-	UpdatedAt    time.Time           `json:"updatedAt" bson:"updatedAt"`
+	// CreatedBy is the user or the API client that created the crop, when known
+	CreatedBy *primitive.ObjectID `json:"createdBy,omitempty" bson:"createdBy,omitempty"`
+	CreatedAt time.Time           `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt" bson:"updatedAt"`
+	Version   int64               `json:"version" bson:"version"`
 }
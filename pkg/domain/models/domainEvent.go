@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DomainEvent is the envelope of a change to a resource, as delivered to webhook subscribers
+type DomainEvent struct {
+	ID           string              `json:"id"`
+	Type         enums.EnumEventType `json:"type"`
+	ResourceType string              `json:"resourceType"`
+	ResourceID   string              `json:"resourceId"`
+	RequestID    string              `json:"requestId,omitempty"`
+	OccurredAt   time.Time           `json:"occurredAt"`
+	// Data is the resource after the change, or before it for deletions
+	Data interface{} `json:"data"`
+}
+
+// HarvestDateChange is the data of a crop.harvest_date_changed event
+type HarvestDateChange struct {
+	Crop                *Crop     `json:"crop"`
+	PreviousHarvestDate time.Time `json:"previousHarvestDate"`
+}
+
+// OutboxEvent is a domain event waiting in the outbox to be fanned out to webhook subscriptions,
+// the payload holds the JSON encoded DomainEvent exactly as it is delivered and signed
+type OutboxEvent struct {
+	ID           primitive.ObjectID     `json:"_id" bson:"_id"`
+	Type         enums.EnumEventType    `json:"type" bson:"type"`
+	ResourceType string                 `json:"resourceType" bson:"resourceType"`
+	ResourceID   string                 `json:"resourceId" bson:"resourceId"`
+	Payload      string                 `json:"payload" bson:"payload"`
+	Status       enums.EnumOutboxStatus `json:"status" bson:"status"`
+	LeaseUntil   *time.Time             `json:"-" bson:"leaseUntil,omitempty"`
+	DispatchedAt *time.Time             `json:"dispatchedAt,omitempty" bson:"dispatchedAt,omitempty"`
+	CreatedAt    time.Time              `json:"createdAt" bson:"createdAt"`
+	// StreamID identifies the event on the /events stream, the resume token of the change stream
+	// it was read from or else its ID
+	StreamID string `json:"-" bson:"-"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExchangeRate is the value of one unit of the currency Base in units of the currency Quote from
+// EffectiveAt on, until the next rate of the pair. Rate is a decimal kept as written, 4012.5 for
+// USD to COP, so that conversions are exact.
+type ExchangeRate struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	Base        string             `json:"base" bson:"base"`
+	Quote       string             `json:"quote" bson:"quote"`
+	Rate        string             `json:"rate" bson:"rate"`
+	EffectiveAt time.Time          `json:"effectiveAt" bson:"effectiveAt"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// Conversion is an amount converted into another currency along with the rate that was used, a
+// rate of the opposite pair is applied inverted. An amount converted into its own currency has no
+// rate.
+type Conversion struct {
+	From Money `json:"from"`
+	To   Money `json:"to"`
+	// Rate is the exchange rate applied, as stored
+	Rate        *ExchangeRate `json:"rate,omitempty"`
+	Inverted    bool          `json:"inverted,omitempty"`
+	ConvertedAt time.Time     `json:"convertedAt"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// HarvestReportRow counts the crops of a variant harvested in a month
+type HarvestReportRow struct {
+	// Month is the month of the harvest, formatted as 2006-01
+	Month     string              `json:"month" bson:"month"`
+	VariantID *primitive.ObjectID `json:"variantId,omitempty" bson:"variantId"`
+	Variant   string              `json:"variant" bson:"variant"`
+	Crops     int64               `json:"crops" bson:"crops"`
+	Suppliers int64               `json:"suppliers" bson:"suppliers"`
+}
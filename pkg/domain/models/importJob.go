@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ImportJob represents the import of the rows of a spreadsheet and reports its progress, the
+// rows failing the validation are skipped and listed in Errors
+type ImportJob struct {
+	ID       primitive.ObjectID     `json:"_id" bson:"_id"`
+	Kind     enums.EnumImportKind   `json:"kind" bson:"kind"`
+	FileName string                 `json:"fileName" bson:"fileName"`
+	Format   string                 `json:"format" bson:"format"`
+	DryRun   bool                   `json:"dryRun" bson:"dryRun"`
+	Status   enums.EnumImportStatus `json:"status" bson:"status"`
+	// TotalRows is the number of non-blank rows of the file, InvalidRows of them failed the
+	// validation and are never committed
+	TotalRows   int `json:"totalRows" bson:"totalRows"`
+	InvalidRows int `json:"invalidRows" bson:"invalidRows"`
+	// CommittedRows is the number of records created so far
+	CommittedRows int              `json:"committedRows" bson:"committedRows"`
+	Errors        []ImportRowError `json:"errors" bson:"errors"`
+	// ErrorsTruncated tells that more rows failed than Errors lists
+	ErrorsTruncated bool       `json:"errorsTruncated,omitempty" bson:"errorsTruncated,omitempty"`
+	Failure         string     `json:"failure,omitempty" bson:"failure,omitempty"`
+	CreatedBy       AuditActor `json:"createdBy" bson:"createdBy"`
+	CreatedAt       time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt" bson:"updatedAt"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty" bson:"completedAt,omitempty"`
+}
+
+// ImportRowError explains why a row of an imported file is invalid or could not be committed
+type ImportRowError struct {
+	// Line is the number of the row in the file, the header is the line 1
+	Line    int    `json:"line" bson:"line"`
+	Column  string `json:"column,omitempty" bson:"column,omitempty"`
+	Message string `json:"message" bson:"message"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "futuagro.com/pkg/i18n"
+
+// LocalizedName is the name of an item or a variant in a locale, with the other names it goes by
+// there, like palta and aguacate
+type LocalizedName struct {
+	Locale   string   `json:"locale" bson:"locale"`
+	Name     string   `json:"name" bson:"name"`
+	Synonyms []string `json:"synonyms,omitempty" bson:"synonyms,omitempty"`
+}
+
+// localize returns the name that best serves the preferred locales and its locale, name is in
+// defaultLocale and is returned when no localized name serves them
+func localize(name string, names []LocalizedName, preferred []string, defaultLocale string) (string, string) {
+	available := make([]string, len(names), len(names)+1)
+	for i, localized := range names {
+		available[i] = localized.Locale
+	}
+	i := i18n.Match(preferred, append(available, defaultLocale))
+	if i < 0 || i == len(names) {
+		return name, defaultLocale
+	}
+	return names[i].Name, names[i].Locale
+}
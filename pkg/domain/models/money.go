@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Money is an amount in the minor units of its currency, cents for USD, so that prices are never
+// rounded by floats. Currency is an ISO 4217 code such as COP.
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount"`
+	Currency string `json:"currency" bson:"currency"`
+	// RateID and RateEffectiveAt identify the exchange rate a converted amount was computed with,
+	// they are only set on the amounts converted into the currency asked by a caller
+	RateID          *primitive.ObjectID `json:"rateId,omitempty" bson:"rateId,omitempty"`
+	RateEffectiveAt *time.Time          `json:"rateEffectiveAt,omitempty" bson:"rateEffectiveAt,omitempty"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// MemberUser identifies a membership held by a user
+	MemberUser = "user"
+	// MemberAPIClient identifies a membership held by an API client, like the procurement system
+	// of a buyer
+	MemberAPIClient = PrincipalAPIClient
+)
+
+// Organization represents a buyer company or a farmer cooperative, its members act on its behalf
+// with the role they hold in it
+type Organization struct {
+	ID           primitive.ObjectID         `json:"_id" bson:"_id"`
+	Name         string                     `json:"name" bson:"name"`
+	Kind         enums.EnumOrganizationKind `json:"kind" bson:"kind"`
+	Members      []Membership               `json:"members" bson:"members"`
+	RecordStatus *enums.EnumRecordStatus    `json:"recordStatus" bson:"recordStatus"`
+	Version      int64                      `json:"version" bson:"version"`
+	CreatedAt    time.Time                  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time                  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Membership represents a user or an API client belonging to an organization, it is stored
+// within the organization
+type Membership struct {
+	MemberType string               `json:"memberType" bson:"memberType"`
+	MemberID   primitive.ObjectID   `json:"memberId" bson:"memberId"`
+	Role       enums.EnumMemberRole `json:"role" bson:"role"`
+	JoinedAt   time.Time            `json:"joinedAt" bson:"joinedAt"`
+}
+
+// Member returns the membership of a member, or nil when it does not belong to the organization
+func (o *Organization) Member(memberType string, memberID primitive.ObjectID) *Membership {
+	for i := range o.Members {
+		if o.Members[i].MemberType == memberType && o.Members[i].MemberID == memberID {
+			return &o.Members[i]
+		}
+	}
+	return nil
+}
+
+// Invitation represents the invitation of an email address to join an organization with a role,
+// it is accepted once, before it expires, by the user registered with that email
+type Invitation struct {
+	ID             primitive.ObjectID   `json:"_id" bson:"_id"`
+	OrganizationID primitive.ObjectID   `json:"organizationId" bson:"organizationId"`
+	Email          string               `json:"email" bson:"email"`
+	Role           enums.EnumMemberRole `json:"role" bson:"role"`
+	// HashedToken is the hash of the token sent to the invitee, the token is only shown when issued
+	HashedToken string              `json:"-" bson:"hashedToken"`
+	InvitedBy   AuditActor          `json:"invitedBy" bson:"invitedBy"`
+	ExpiresAt   time.Time           `json:"expiresAt" bson:"expiresAt"`
+	AcceptedAt  *time.Time          `json:"acceptedAt,omitempty" bson:"acceptedAt,omitempty"`
+	AcceptedBy  *primitive.ObjectID `json:"acceptedBy,omitempty" bson:"acceptedBy,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt" bson:"createdAt"`
+}
+
+// IssuedInvitation holds an invitation along with its plain text token, the token is only
+// available at the moment it is issued
+type IssuedInvitation struct {
+	Invitation *Invitation `json:"invitation"`
+	Token      string      `json:"token"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "futuagro.com/pkg/domain/enums"
+
+const (
+	// PrincipalAPIClient identifies a principal authenticated with the key of an API client
+	PrincipalAPIClient = "api-client"
+	// PrincipalUser identifies a principal authenticated with the token of a session opened by
+	// the login of a user
+	PrincipalUser = "user"
+	// PrincipalAdmin identifies a principal authenticated with the administrator key
+	PrincipalAdmin = "admin"
+	// PrincipalSystem identifies the commands run by an operator, such as the seed loader
+	PrincipalSystem = "system"
+)
+
+// Principal represents the authenticated caller of a request
+type Principal struct {
+	Type   string            `json:"type"`
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Scopes []enums.EnumScope `json:"scopes"`
+	// OrganizationID is the organization the principal acts for, selected with the
+	// X-Organization-ID header, and OrganizationRole the role it holds in it
+	OrganizationID   string               `json:"organizationId,omitempty"`
+	OrganizationRole enums.EnumMemberRole `json:"organizationRole,omitempty"`
+}
+
+// HasScope reports whether the principal has been granted a scope
+func (p *Principal) HasScope(scope enums.EnumScope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == enums.AllScopes {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package models
+
+// Names of the resource types exposed by the API, used to label audit entries and domain events
+const (
+	ResourceSupplier = "supplier"
+	ResourceCountry  = "country"
+	// ResourceCountryState is a state of a country, it is stored within the country
+	ResourceCountryState = "countryState"
+	ResourceCity         = "city"
+	ResourceItem         = "item"
+	ResourceVariant      = "variant"
+	ResourceCrop         = "crop"
+	ResourceUser         = "user"
+	ResourceAPIClient    = "apiClient"
+	ResourceWebhook      = "webhookSubscription"
+	ResourceOrganization = "organization"
+	ResourceInvitation   = "invitation"
+	ResourceExchangeRate = "exchangeRate"
+)
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session represents the login of a user, it is authenticated by the hash of its token until it
+// expires or the user logs out
+type Session struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	UserID      primitive.ObjectID `json:"userId" bson:"userId"`
+	HashedToken string             `json:"-" bson:"hashedToken"`
+	ExpiresAt   time.Time          `json:"expiresAt" bson:"expiresAt"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// IssuedSession holds a user along with the plain text token of the session opened by its login,
+// the token is only available at the moment it is issued
+type IssuedSession struct {
+	User      *User     `json:"user"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -10,18 +10,21 @@ import (
 
 // Supplier represent the data of a supplier
 type Supplier struct {
-	ID             primitive.ObjectID      `json:"_id" bson:"_id"`
-	Name           string                  `json:"name" bson:"name"`
-	Surname        string                  `json:"surname" bson:"surname"`
-	DocumentType   string                  `json:"documentType" bson:"documentType"`
-	DocumentNumber string                  `json:"documentNumber" bson:"documentNumber"`
-	CityID         *primitive.ObjectID     `json:"cityId,omitempty" bson:"cityId"`
-	City           *City                   `json:"city,omitempty" bson:"city"`
-	Email          string                  `json:"email,omitempty" bson:"email"`
-	AddressLine1   string                  `json:"addressLine1,omitempty" bson:"addressLine1"`
-	PhoneNumber    string                  `json:"phoneNumber,omitempty" bson:"phoneNumber"`
-	Crops          *[]Crop                 `json:"crops,omitempty" bson:"crops"`
-	RecordStatus   *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
-	CreatedAt      time.Time               `json:"createdAt" bson:"createdAt"`
-	UpdatedAt      time.Time               `json:"updatedAt" bson:"updatedAt"`
+	ID             primitive.ObjectID  `json:"_id" bson:"_id"`
+	Name           string              `json:"name" bson:"name"`
+	Surname        string              `json:"surname" bson:"surname"`
+	DocumentType   string              `json:"documentType" bson:"documentType"`
+	DocumentNumber string              `json:"documentNumber" bson:"documentNumber"`
+	CityID         *primitive.ObjectID `json:"cityId,omitempty" bson:"cityId"`
+	City           *City               `json:"city,omitempty" bson:"city"`
+	// CountryID is the country of the city, the document of a supplier is unique within it
+	CountryID    *primitive.ObjectID     `json:"countryId,omitempty" bson:"countryId"`
+	Email        string                  `json:"email,omitempty" bson:"email"`
+	AddressLine1 string                  `json:"addressLine1,omitempty" bson:"addressLine1"`
+	PhoneNumber  string                  `json:"phoneNumber,omitempty" bson:"phoneNumber"`
+	Crops        *[]Crop                 `json:"crops,omitempty" bson:"crops"`
+	RecordStatus *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	Version      int64                   `json:"version" bson:"version"`
+	CreatedAt    time.Time               `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time               `json:"updatedAt" bson:"updatedAt"`
 }
@@ -9,6 +9,13 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// RoleUser is the role of the users signed up through the API
+	RoleUser = "user"
+	// RoleAdmin is the role of the users operating the platform
+	RoleAdmin = "admin"
+)
+
 // User represent the data of a user
 type User struct {
 	ID              primitive.ObjectID      `json:"_id" bson:"_id"`
@@ -19,13 +26,14 @@ type User struct {
 	CityID          *primitive.ObjectID     `json:"cityId,omitempty" bson:"cityId"`
 	City            *City                   `json:"city,omitempty" bson:"city"`
 	Email           string                  `json:"email,omitempty" bson:"email"`
-	HashedPassword  string                  `json:"hashedPassword,omitempty" bson:"hashedPassword"`
+	HashedPassword  string                  `json:"hashedPassword,omitempty" bson:"hashedPassword" export:"-"`
 	AddressLine1    string                  `json:"addressLine1,omitempty" bson:"addressLine1"`
 	PhoneNumber     string                  `json:"phoneNumber,omitempty" bson:"phoneNumber"`
 	IsEmailVerified bool                    `json:"isEmailVerified" bson:"IsEmailVerified"`
 	Crops           *[]Crop                 `json:"crops,omitempty" bson:"crops"`
 	Role            string                  `json:"role" bson:"role"`
 	RecordStatus    *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	Version         int64                   `json:"version" bson:"version"`
 	CreatedAt       time.Time               `json:"createdAt" bson:"createdAt"`
 	UpdatedAt       time.Time               `json:"updatedAt" bson:"updatedAt"`
 }
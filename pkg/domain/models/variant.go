@@ -11,10 +11,26 @@ import (
 type Variant struct {
 	ID           primitive.ObjectID      `json:"_id" bson:"_id"`
 	Name         string                  `json:"name" bson:"name"`
-	LName        string                  `json:"lname" bson:"lname"`
+	LName        string                  `json:"lname" bson:"lname" export:"-"`
 	ItemID       primitive.ObjectID      `json:"itemId,omitempty" bson:"itemId"`
 	CreatedAt    time.Time               `json:"createdAt" bson:"createdAt"`
 	UpdatedAt    time.Time               `json:"updatedAt" bson:"updatedAt"`
 	RecordStatus *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	Version      int64                   `json:"version" bson:"version"`
 	Item         *Item                   `json:"item,omitempty" bson:"item"`
+	// Names are the names in other locales than the default one of the catalog
+	Names []LocalizedName `json:"names,omitempty" bson:"names,omitempty" export:"-"`
+	// Terms are the folded names and synonyms matched by the searches
+	Terms []string `json:"-" bson:"terms,omitempty" export:"-"`
+	// Locale is the locale of Name once localized for a caller
+	Locale string `json:"locale,omitempty" bson:"-"`
+}
+
+// Localize sets the name of the variant to the name that best serves the preferred locales, see
+// i18n.Match, its name is in defaultLocale when it is stored
+func (variant *Variant) Localize(preferred []string, defaultLocale string) {
+	variant.Name, variant.Locale = localize(variant.Name, variant.Names, preferred, defaultLocale)
+	if variant.Item != nil {
+		variant.Item.Localize(preferred, defaultLocale)
+	}
 }
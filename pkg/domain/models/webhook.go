@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookSubscription represents a partner endpoint that receives the domain events it subscribed to
+type WebhookSubscription struct {
+	ID          primitive.ObjectID    `json:"_id" bson:"_id"`
+	URL         string                `json:"url" bson:"url"`
+	Description string                `json:"description" bson:"description"`
+	EventTypes  []enums.EnumEventType `json:"eventTypes" bson:"eventTypes"`
+	// Secret signs the deliveries, it is only shown when issued
+	Secret       string                  `json:"-" bson:"secret"`
+	RecordStatus *enums.EnumRecordStatus `json:"recordStatus" bson:"recordStatus"`
+	CreatedAt    time.Time               `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time               `json:"updatedAt" bson:"updatedAt"`
+	Version      int64                   `json:"version" bson:"version"`
+}
+
+// IssuedWebhookSecret holds a webhook subscription along with its signing secret, the secret
+// is only available at the moment it is issued
+type IssuedWebhookSecret struct {
+	Subscription *WebhookSubscription `json:"subscription"`
+	Secret       string               `json:"secret"`
+}
+
+// WebhookDelivery represents the delivery of one domain event to one webhook subscription
+type WebhookDelivery struct {
+	ID             primitive.ObjectID       `json:"_id" bson:"_id"`
+	SubscriptionID primitive.ObjectID       `json:"subscriptionId" bson:"subscriptionId"`
+	EventID        primitive.ObjectID       `json:"eventId" bson:"eventId"`
+	EventType      enums.EnumEventType      `json:"eventType" bson:"eventType"`
+	Payload        string                   `json:"payload" bson:"payload"`
+	Status         enums.EnumDeliveryStatus `json:"status" bson:"status"`
+	Attempts       int                      `json:"attempts" bson:"attempts"`
+	NextAttemptAt  time.Time                `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LastAttemptAt  *time.Time               `json:"lastAttemptAt,omitempty" bson:"lastAttemptAt,omitempty"`
+	LastStatusCode int                      `json:"lastStatusCode,omitempty" bson:"lastStatusCode,omitempty"`
+	LastError      string                   `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	CreatedAt      time.Time                `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time                `json:"updatedAt" bson:"updatedAt"`
+}
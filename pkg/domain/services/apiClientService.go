@@ -0,0 +1,250 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+)
+
+// apiKeyPrefix marks the strings issued by this service as API keys
+const apiKeyPrefix = "fa_"
+
+// ErrInvalidScopes is returned when an API client is granted a scope outside the vocabulary
+var ErrInvalidScopes = errs.Validation("Invalid API client scopes")
+
+// APIClientService implements use cases methods and domain business logic for API clients
+type APIClientService struct {
+	repository *store.MongoAPIClientRepository
+	audit      *AuditService
+	unitOfWork UnitOfWork
+}
+
+// FindAPIClientByID returns an API client by its ID
+func (s *APIClientService) FindAPIClientByID(id string) (*models.APIClient, error) {
+	apiClient, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if apiClient == nil {
+		return nil, errs.NotFound("API Client")
+	}
+	return apiClient, nil
+}
+
+// FindAllAPIClients returns a list of API clients
+func (s *APIClientService) FindAllAPIClients() ([]*models.APIClient, error) {
+	return s.repository.FindAll()
+}
+
+// CreateAPIClient registers a new API client and issues its first key, the client and its audit
+// entry are written in one unit of work
+func (s *APIClientService) CreateAPIClient(ctx context.Context, dto *dtos.APIClientDto) (*models.IssuedAPIKey, error) {
+	if err := validateScopes(dto.Scopes); err != nil {
+		return nil, err
+	}
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var apiClient *models.APIClient
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		id, err := s.repository.Insert(ctx, dto, prefix, hashAPIKey(key))
+		if err != nil {
+			return err
+		}
+		if apiClient, err = s.repository.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, enums.AuditCreate, models.ResourceAPIClient, id, nil, apiClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.IssuedAPIKey{Client: apiClient, Key: key}, nil
+}
+
+// UpdateAPIClient update the name, scopes or status of an API client
+func (s *APIClientService) UpdateAPIClient(ctx context.Context, id string, dto *dtos.APIClientDto, versions []int64) (*models.APIClient, error) {
+	if err := validateScopes(dto.Scopes); err != nil {
+		return nil, err
+	}
+	return s.updateAPIClient(ctx, id, func(ctx context.Context) (*models.APIClient, error) {
+		return s.repository.Update(ctx, id, dto, versions)
+	})
+}
+
+// RotateAPIKey issues a new key for an API client and revokes the previous one
+func (s *APIClientService) RotateAPIKey(ctx context.Context, id string, versions []int64) (*models.IssuedAPIKey, error) {
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	apiClient, err := s.updateAPIClient(ctx, id, func(ctx context.Context) (*models.APIClient, error) {
+		return s.repository.UpdateKey(ctx, id, prefix, hashAPIKey(key), versions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.IssuedAPIKey{Client: apiClient, Key: key}, nil
+}
+
+// DeleteAPIClient delete an API client by id, revoking its key
+func (s *APIClientService) DeleteAPIClient(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("API Client")
+		}
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("API Client")
+		}
+		return s.audit.Record(ctx, enums.AuditDelete, models.ResourceAPIClient, id, before, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateAPIClient runs a write of an API client and its audit entry in one unit of work, write
+// returns the client after the change or nil when it does not exist
+func (s *APIClientService) updateAPIClient(ctx context.Context, id string, write func(ctx context.Context) (*models.APIClient, error)) (*models.APIClient, error) {
+	var apiClient *models.APIClient
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("API Client")
+		}
+		if apiClient, err = write(ctx); err != nil {
+			return err
+		}
+		if apiClient == nil {
+			return errs.NotFound("API Client")
+		}
+		return s.audit.Record(ctx, enums.AuditUpdate, models.ResourceAPIClient, id, before, apiClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiClient, nil
+}
+
+// AuthenticatePrincipal returns the principal calling the API with a key, or nil when the key
+// identifies no one. adminKey is the static key granted every scope, it is ignored when empty.
+// The transports identify their callers with it.
+func (s *APIClientService) AuthenticatePrincipal(ctx context.Context, key string, adminKey string) (*models.Principal, error) {
+	if adminKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) == 1 {
+		return &models.Principal{
+			Type:   models.PrincipalAdmin,
+			ID:     models.PrincipalAdmin,
+			Name:   "Administrator",
+			Scopes: []enums.EnumScope{enums.AllScopes},
+		}, nil
+	}
+
+	apiClient, err := s.Authenticate(ctx, key)
+	if err != nil || apiClient == nil {
+		return nil, err
+	}
+	return &models.Principal{
+		Type:   models.PrincipalAPIClient,
+		ID:     apiClient.ID.Hex(),
+		Name:   apiClient.Name,
+		Scopes: apiClient.Scopes,
+	}, nil
+}
+
+// Authenticate returns the active API client that owns a key, or nil when the key is unknown,
+// revoked or belongs to an inactive client. Every successful authentication is recorded.
+func (s *APIClientService) Authenticate(ctx context.Context, key string) (*models.APIClient, error) {
+	prefix, ok := parseAPIKeyPrefix(key)
+	if !ok {
+		return nil, nil
+	}
+	apiClient, err := s.repository.FindByKeyPrefix(prefix)
+	if err != nil || apiClient == nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(apiClient.HashedKey), []byte(hashAPIKey(key))) != 1 {
+		return nil, nil
+	}
+	if apiClient.RecordStatus != nil && *apiClient.RecordStatus != enums.Active {
+		return nil, nil
+	}
+	if err := s.repository.RegisterUsage(apiClient.ID, time.Now()); err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("apiClientId", apiClient.ID.Hex()).Error("Error registering the usage of an API client")
+	}
+	return apiClient, nil
+}
+
+// NewAPIClientService creates an API client service with necessary dependencies.
+func NewAPIClientService(repository *store.MongoAPIClientRepository, auditService *AuditService, unitOfWork UnitOfWork) *APIClientService {
+	return &APIClientService{repository, auditService, unitOfWork}
+}
+
+func validateScopes(scopes []enums.EnumScope) error {
+	for _, scope := range scopes {
+		// The wildcard scope is reserved to the administrator key
+		if !scope.IsValid() || scope == enums.AllScopes {
+			return errors.Wrapf(ErrInvalidScopes, "scope %q", scope)
+		}
+	}
+	return nil
+}
+
+// generateAPIKey returns a new random key together with its public prefix, keys look like
+// fa_<prefix>.<secret> so that they can be found by prefix without storing them in plain text
+func generateAPIKey() (string, string, error) {
+	prefix := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", errors.Wrap(err, "Error generating an API key")
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", errors.Wrap(err, "Error generating an API key")
+	}
+	publicPrefix := hex.EncodeToString(prefix)
+	return apiKeyPrefix + publicPrefix + "." + base64.RawURLEncoding.EncodeToString(secret), publicPrefix, nil
+}
+
+func parseAPIKeyPrefix(key string) (string, bool) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, apiKeyPrefix), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// hashAPIKey hashes a key for storage, keys are long random strings so a fast hash is enough
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,148 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+)
+
+// auditIgnoredFields are bookkeeping fields that change on every write and are left out of diffs
+var auditIgnoredFields = map[string]bool{
+	"updatedAt": true,
+	"version":   true,
+}
+
+// auditRedactedFields are secrets whose changes are recorded without their values
+var auditRedactedFields = map[string]bool{
+	"hashedPassword": true,
+}
+
+// AuditService implements use cases methods for recording and searching the audit trail
+type AuditService struct {
+	repository *store.MongoAuditRepository
+}
+
+// Record appends a mutation of a resource to the audit trail, before is nil for creations and
+// after is nil for deletions. The actor and the request ID are taken from ctx.
+// The entry must be recorded in the unit of work of the mutation: it is written in its
+// transaction and a failure to record it fails the mutation.
+func (s *AuditService) Record(ctx context.Context, action enums.EnumAuditAction, resourceType string, resourceID string, before interface{}, after interface{}) error {
+	changes, err := diffFields(before, after)
+	if err != nil {
+		return errors.Wrapf(err, "Error computing the audit diff of %s %s", resourceType, resourceID)
+	}
+	entry := &models.AuditEntry{
+		Actor:        auditActor(ctx),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		RequestID:    RequestIDFromContext(ctx),
+		Changes:      changes,
+		Timestamp:    time.Now(),
+	}
+	if _, err := s.repository.Insert(ctx, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindAuditEntries returns the audit entries matching a query, most recent first
+func (s *AuditService) FindAuditEntries(query *dtos.AuditQueryDto) ([]*models.AuditEntry, error) {
+	return s.repository.Find(query)
+}
+
+// NewAuditService creates an audit service with necessary dependencies.
+func NewAuditService(repository *store.MongoAuditRepository) *AuditService {
+	return &AuditService{repository}
+}
+
+func auditActor(ctx context.Context) models.AuditActor {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil {
+		return models.AuditActor{Type: "anonymous"}
+	}
+	return models.AuditActor{Type: principal.Type, ID: principal.ID, Name: principal.Name, OrganizationID: principal.OrganizationID}
+}
+
+// diffFields compares the JSON representation of two versions of a resource and returns the
+// fields whose value changed, nested objects are compared field by field
+func diffFields(before interface{}, after interface{}) ([]models.FieldChange, error) {
+	beforeFields, err := flattenFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := flattenFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range beforeFields {
+		names[name] = true
+	}
+	for name := range afterFields {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		if !auditIgnoredFields[name] {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+
+	changes := []models.FieldChange{}
+	for _, name := range sorted {
+		beforeValue, afterValue := beforeFields[name], afterFields[name]
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			if auditRedactedFields[name] {
+				beforeValue, afterValue = nil, nil
+			}
+			changes = append(changes, models.FieldChange{Field: name, Before: beforeValue, After: afterValue})
+		}
+	}
+	return changes, nil
+}
+
+func flattenFields(resource interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if resource == nil {
+		return fields, nil
+	}
+	if value := reflect.ValueOf(resource); value.Kind() == reflect.Ptr && value.IsNil() {
+		return fields, nil
+	}
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, err
+	}
+	flattenInto(fields, "", document)
+	return fields, nil
+}
+
+func flattenInto(fields map[string]interface{}, prefix string, document map[string]interface{}) {
+	for key, value := range document {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInto(fields, name, nested)
+		} else {
+			fields[name] = value
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"futuagro.com/pkg/domain/models"
+)
+
+type auditedAddress struct {
+	City  string `json:"city"`
+	Line1 string `json:"line1,omitempty"`
+}
+
+type auditedUser struct {
+	Name           string          `json:"name"`
+	HashedPassword string          `json:"hashedPassword"`
+	Address        *auditedAddress `json:"address,omitempty"`
+	Tags           []string        `json:"tags"`
+	Version        int64           `json:"version"`
+}
+
+func TestDiffFields(t *testing.T) {
+	before := &auditedUser{Name: "Ana", HashedPassword: "a", Address: &auditedAddress{City: "Cali"}, Tags: []string{"a"}, Version: 1}
+	tests := []struct {
+		name     string
+		before   interface{}
+		after    interface{}
+		expected []models.FieldChange
+	}{
+		{"no change", before, &auditedUser{Name: "Ana", HashedPassword: "a", Address: &auditedAddress{City: "Cali"}, Tags: []string{"a"}, Version: 2}, []models.FieldChange{}},
+		{
+			"changed fields sorted by name",
+			before,
+			&auditedUser{Name: "Eva", HashedPassword: "a", Address: &auditedAddress{City: "Cali"}, Tags: []string{"a", "b"}, Version: 2},
+			[]models.FieldChange{
+				{Field: "name", Before: "Ana", After: "Eva"},
+				{Field: "tags", Before: []interface{}{"a"}, After: []interface{}{"a", "b"}},
+			},
+		},
+		{
+			"nested fields",
+			before,
+			&auditedUser{Name: "Ana", HashedPassword: "a", Address: &auditedAddress{City: "Pasto", Line1: "Calle 1"}, Tags: []string{"a"}},
+			[]models.FieldChange{
+				{Field: "address.city", Before: "Cali", After: "Pasto"},
+				{Field: "address.line1", Before: nil, After: "Calle 1"},
+			},
+		},
+		{
+			"redacted field",
+			before,
+			&auditedUser{Name: "Ana", HashedPassword: "b", Address: &auditedAddress{City: "Cali"}, Tags: []string{"a"}},
+			[]models.FieldChange{{Field: "hashedPassword"}},
+		},
+		{
+			"created",
+			(*auditedUser)(nil),
+			&auditedUser{Name: "Ana", Tags: []string{}},
+			[]models.FieldChange{
+				{Field: "hashedPassword", Before: nil, After: nil},
+				{Field: "name", Before: nil, After: "Ana"},
+				{Field: "tags", Before: nil, After: []interface{}{}},
+			},
+		},
+		{
+			"deleted",
+			&auditedUser{Name: "Ana", Tags: []string{}},
+			nil,
+			[]models.FieldChange{
+				{Field: "hashedPassword", Before: nil, After: nil},
+				{Field: "name", Before: "Ana", After: nil},
+				{Field: "tags", Before: []interface{}{}, After: nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := diffFields(tt.before, tt.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(changes, tt.expected) {
+				t.Errorf("got %+v, expected %+v", changes, tt.expected)
+			}
+		})
+	}
+}
@@ -2,22 +2,75 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"strings"
+	"time"
 
+	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// sessionTokenPrefix marks the strings issued by this service as session tokens, they are sent
+// like the API keys
+const sessionTokenPrefix = "fs_"
+
+// userScopes are the scopes granted to the users by role, the users operating the platform are
+// granted every scope and the others read the catalog and act through their organizations
+var userScopes = map[string][]enums.EnumScope{
+	models.RoleAdmin: {enums.AllScopes},
+	models.RoleUser: {
+		enums.SuppliersRead,
+		enums.CountriesRead,
+		enums.CitiesRead,
+		enums.ItemsRead,
+		enums.CropsRead,
+		enums.OrganizationsRead,
+		enums.OrganizationsWrite,
+		enums.ExchangeRatesRead,
+	},
+}
+
 // AuthService implements use cases methods and domain business logic for authorizing users
 type AuthService struct {
-	userRepository *store.MongoUserRepository
+	userRepository    *store.MongoUserRepository
+	sessionRepository *store.MongoSessionRepository
+	sessionTTL        time.Duration
+}
+
+// Login authenticates an user and opens a session, it returns nil when the email is unknown
+func (s *AuthService) Login(ctx context.Context, dto *dtos.LoginDto) (*models.IssuedSession, error) {
+	user, err := s.CheckCredentials(ctx, dto)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	token, err := generateSessionToken()
+	if err != nil {
+		return nil, err
+	}
+	session := &models.Session{
+		UserID:      user.ID,
+		HashedToken: hashAPIKey(token),
+		ExpiresAt:   time.Now().Add(s.sessionTTL).UTC(),
+	}
+	if _, err := s.sessionRepository.Insert(ctx, session); err != nil {
+		return nil, err
+	}
+	user.HashedPassword = ""
+	return &models.IssuedSession{User: user, Token: token, ExpiresAt: session.ExpiresAt}, nil
 }
 
-// Login authenticates an user
-func (s *AuthService) Login(dto *dtos.LoginDto) (*models.User, error) {
-	user, err := s.userRepository.FindByEmail(strings.ToLower(dto.Email))
+// CheckCredentials returns the user with the email and the password of dto, or nil when the
+// email is unknown. A wrong password is a bcrypt error.
+func (s *AuthService) CheckCredentials(ctx context.Context, dto *dtos.LoginDto) (*models.User, error) {
+	user, err := s.userRepository.FindByEmail(ctx, strings.ToLower(dto.Email))
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +84,60 @@ func (s *AuthService) Login(dto *dtos.LoginDto) (*models.User, error) {
 	return nil, nil
 }
 
+// Logout closes the session of a token, it tells whether the session was open
+func (s *AuthService) Logout(ctx context.Context, token string) (bool, error) {
+	if !IsSessionToken(token) {
+		return false, nil
+	}
+	return s.sessionRepository.DeleteByHashedToken(ctx, hashAPIKey(token))
+}
+
+// AuthenticatePrincipal returns the user calling the API with the token of a session, or nil when
+// the session does not exist, has expired or belongs to a user that no longer exists or is
+// inactive. The transports identify their callers with it.
+func (s *AuthService) AuthenticatePrincipal(ctx context.Context, token string) (*models.Principal, error) {
+	if !IsSessionToken(token) {
+		return nil, nil
+	}
+	session, err := s.sessionRepository.FindByHashedToken(ctx, hashAPIKey(token), time.Now())
+	if err != nil || session == nil {
+		return nil, err
+	}
+	user, err := s.userRepository.FindByID(ctx, session.UserID.Hex())
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if user.RecordStatus != nil && *user.RecordStatus != enums.Active {
+		return nil, nil
+	}
+	scopes, ok := userScopes[user.Role]
+	if !ok {
+		scopes = userScopes[models.RoleUser]
+	}
+	return &models.Principal{
+		Type:   models.PrincipalUser,
+		ID:     user.ID.Hex(),
+		Name:   strings.TrimSpace(user.Name + " " + user.Surname),
+		Scopes: scopes,
+	}, nil
+}
+
+// IsSessionToken tells whether a key sent by a caller is the token of a session rather than the
+// key of an API client
+func IsSessionToken(key string) bool {
+	return strings.HasPrefix(key, sessionTokenPrefix) && len(key) > len(sessionTokenPrefix)
+}
+
 // NewAuthService creates an auth service with necessary dependencies.
-func NewAuthService(userRepository *store.MongoUserRepository) *AuthService {
-	return &AuthService{userRepository}
+func NewAuthService(confPtr *config.Config, userRepository *store.MongoUserRepository, sessionRepository *store.MongoSessionRepository) *AuthService {
+	return &AuthService{userRepository, sessionRepository, confPtr.Auth.SessionTTL}
+}
+
+// generateSessionToken returns a new random token, only its hash is stored like for API keys
+func generateSessionToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "Error generating a session token")
+	}
+	return sessionTokenPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
 }
@@ -2,7 +2,11 @@
 package services
 
 import (
+	"context"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
 )
@@ -10,11 +14,34 @@ import (
 // CityService implements use cases methods and domain business logic for cities
 type CityService struct {
 	repository *store.MongoCityRepository
+	audit      *AuditService
+	events     *EventService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 // FindCityByID returns a city by its ID
 func (s *CityService) FindCityByID(id string) (*models.City, error) {
-	return s.repository.FindByID(id)
+	city, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if city == nil {
+		return nil, errs.NotFound("City")
+	}
+	return city, nil
+}
+
+// FindStateCityByID return a city of a country state, a city of another state is not found
+func (s *CityService) FindStateCityByID(stateID string, cityID string) (*models.City, error) {
+	city, err := s.FindCityByID(cityID)
+	if err != nil {
+		return nil, err
+	}
+	if city.CountryStateID.Hex() != stateID {
+		return nil, errs.NotFound("City")
+	}
+	return city, nil
 }
 
 // FindAllCities returns a list of cities
@@ -27,22 +54,113 @@ func (s *CityService) FindAllCitiesByCountryState(stateID string) ([]*models.Cit
 	return s.repository.FindCitiesByCountryState(stateID)
 }
 
-// CreateCity create a new city record
-func (s *CityService) CreateCity(stateID string, dto *dtos.CityDto) (string, error) {
-	return s.repository.Insert(stateID, dto)
+// CreateCity create a new city record, the city, its audit entry and its event are written in one
+// unit of work
+func (s *CityService) CreateCity(ctx context.Context, stateID string, dto *dtos.CityDto) (string, error) {
+	var id string
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.integrity.CheckReferences(ctx, NewHexReference("countryStateId", stateID, models.ResourceCountryState)); err != nil {
+			return err
+		}
+
+		var err error
+		if id, err = s.repository.Insert(ctx, stateID, dto); err != nil {
+			return err
+		}
+
+		after, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceCity, id, nil, after); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CityCreated, models.ResourceCity, id, after)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
 }
 
-// UpdateCityByID update a city data by its id
-func (s *CityService) UpdateCityByID(stateID string, cityID string, dto *dtos.CityDto) (*models.City, error) {
-	return s.repository.Update(stateID, cityID, dto)
+// UpdateCityByID update a city data by its id, versions optionally restricts the write to the
+// given stored versions of the document
+func (s *CityService) UpdateCityByID(ctx context.Context, stateID string, cityID string, dto *dtos.CityDto, versions []int64) (*models.City, error) {
+	var result *models.City
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, cityID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("City")
+		}
+
+		if result, err = s.repository.Update(ctx, stateID, cityID, dto, versions); err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("City")
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceCity, cityID, before, result); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CityUpdated, models.ResourceCity, cityID, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-// DeleteCityByID delete a city by id
-func (s *CityService) DeleteCityByID(stateID string, cityID string) (bool, error) {
-	return s.repository.Delete(stateID, cityID)
+// DeleteCityByID delete a city by id, versions optionally restricts the delete to the given
+// stored versions of the document
+func (s *CityService) DeleteCityByID(ctx context.Context, stateID string, cityID string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, cityID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("City")
+		}
+
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceCity, before.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.repository.Delete(ctx, stateID, cityID, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("City")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditDelete, models.ResourceCity, cityID, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CityDeleted, models.ResourceCity, cityID, before)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // NewCityService creates a country service with necessary dependencies.
-func NewCityService(cityRepository *store.MongoCityRepository) *CityService {
-	return &CityService{cityRepository}
+func NewCityService(
+	cityRepository *store.MongoCityRepository,
+	auditService *AuditService,
+	eventService *EventService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *CityService {
+	return &CityService{cityRepository, auditService, eventService, integrityService, unitOfWork}
 }
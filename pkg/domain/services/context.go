@@ -0,0 +1,36 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/models"
+)
+
+type contextKey string
+
+const principalKey contextKey = "principal"
+
+// WithPrincipal returns a copy of ctx that carries the authenticated caller of a request
+func WithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller carried by ctx, or nil for anonymous requests
+func PrincipalFromContext(ctx context.Context) *models.Principal {
+	principal, _ := ctx.Value(principalKey).(*models.Principal)
+	return principal
+}
+
+const requestIDKey contextKey = "requestID"
+
+// WithRequestID returns a copy of ctx that carries the ID of the request being served
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the ID of the request carried by ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
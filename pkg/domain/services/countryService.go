@@ -2,19 +2,35 @@
 package services
 
 import (
+	"context"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CountryService implements use cases methods and domain business logic for countries
 type CountryService struct {
 	repository *store.MongoCountryRepository
+	audit      *AuditService
+	events     *EventService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 // FindCountryByID returns a country by its ID
 func (s *CountryService) FindCountryByID(id string) (*models.Country, error) {
-	return s.repository.FindByID(id)
+	country, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if country == nil {
+		return nil, errs.NotFound("Country")
+	}
+	return country, nil
 }
 
 // FindAllCountries returns a list of countries
@@ -22,37 +38,192 @@ func (s *CountryService) FindAllCountries() ([]*models.Country, error) {
 	return s.repository.FindAll()
 }
 
-// CreateCountry create a new country record
-func (s *CountryService) CreateCountry(country *dtos.CountryDto) (string, error) {
-	return s.repository.Insert(country)
+// CreateCountry create a new country record, the country, its audit entry and its event are
+// written in one unit of work
+func (s *CountryService) CreateCountry(ctx context.Context, country *dtos.CountryDto) (string, error) {
+	if err := normalizeCurrencyCode(country); err != nil {
+		return "", err
+	}
+
+	var id string
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if id, err = s.repository.Insert(ctx, country); err != nil {
+			return err
+		}
+
+		after, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceCountry, id, nil, after); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CountryCreated, models.ResourceCountry, id, after)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UpdateCountryByID update a country data by its id, versions optionally restricts the write to
+// the given stored versions of the document
+func (s *CountryService) UpdateCountryByID(ctx context.Context, id string, country *dtos.CountryDto, versions []int64) (*models.Country, error) {
+	if err := normalizeCurrencyCode(country); err != nil {
+		return nil, err
+	}
+	return s.updateCountry(ctx, id, func(ctx context.Context, before *models.Country) (*models.Country, error) {
+		return s.repository.Update(ctx, id, country, versions)
+	})
+}
+
+// DeleteCountryByID delete a country by id, versions optionally restricts the delete to the
+// given stored versions of the document
+func (s *CountryService) DeleteCountryByID(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Country")
+		}
+
+		stateIDs := make([]primitive.ObjectID, len(before.States))
+		for i, state := range before.States {
+			stateIDs[i] = state.ID
+		}
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceCountryState, stateIDs...)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Country")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditDelete, models.ResourceCountry, id, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CountryDeleted, models.ResourceCountry, id, before)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-// UpdateCountryByID update a country data by its id
-func (s *CountryService) UpdateCountryByID(id string, country *dtos.CountryDto) (*models.Country, error) {
-	return s.repository.Update(id, country)
+// AddState add a new state to a country, versions optionally restricts the write to the given
+// stored versions of the country
+func (s *CountryService) AddState(ctx context.Context, countryID string, stateDto dtos.CountryStateDto, versions []int64) (*models.Country, error) {
+	return s.updateCountry(ctx, countryID, func(ctx context.Context, before *models.Country) (*models.Country, error) {
+		return s.repository.InsertCountryState(ctx, countryID, stateDto, versions)
+	})
 }
 
-// DeleteCountryByID delete a country by id
-func (s *CountryService) DeleteCountryByID(id string) (bool, error) {
-	return s.repository.Delete(id)
+// UpdateState update a country state data, versions optionally restricts the write to the given
+// stored versions of the country
+func (s *CountryService) UpdateState(ctx context.Context, countryID string, stateID string, stateDto dtos.CountryStateDto, versions []int64) (*models.Country, error) {
+	return s.updateCountry(ctx, countryID, func(ctx context.Context, before *models.Country) (*models.Country, error) {
+		result, err := s.repository.UpdateCountryState(ctx, countryID, stateID, stateDto, versions)
+		if err == nil && result == nil {
+			return nil, errs.NotFound("CountryState")
+		}
+		return result, err
+	})
 }
 
-// AddState add a new state to a country
-func (s *CountryService) AddState(countryID string, stateDto dtos.CountryStateDto) (*models.Country, error) {
-	return s.repository.InsertCountryState(countryID, stateDto)
+// DeleteState remove a state from a country, versions optionally restricts the write to the
+// given stored versions of the country
+func (s *CountryService) DeleteState(ctx context.Context, countryID string, stateID string, versions []int64) (*models.Country, error) {
+	return s.updateCountry(ctx, countryID, func(ctx context.Context, before *models.Country) (*models.Country, error) {
+		var stateIDs []primitive.ObjectID
+		for _, state := range before.States {
+			if state.ID.Hex() == stateID {
+				stateIDs = append(stateIDs, state.ID)
+			}
+		}
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceCountryState, stateIDs...)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.repository.DeleteCountryState(ctx, countryID, stateID, versions)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, errs.NotFound("CountryState")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
 }
 
-// UpdateState update a country state data
-func (s *CountryService) UpdateState(countryID string, stateID string, stateDto dtos.CountryStateDto) (*models.Country, error) {
-	return s.repository.UpdateCountryState(countryID, stateID, stateDto)
+// updateCountry runs a write of a country, its audit entry and its event in one unit of work,
+// write is given the country before the change and returns it after the change, nil when it no
+// longer exists
+func (s *CountryService) updateCountry(ctx context.Context, id string, write func(ctx context.Context, before *models.Country) (*models.Country, error)) (*models.Country, error) {
+	var result *models.Country
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Country")
+		}
+
+		if result, err = write(ctx, before); err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("Country")
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceCountry, id, before, result); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CountryUpdated, models.ResourceCountry, id, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-// DeleteState remove a state from a country
-func (s *CountryService) DeleteState(countryID string, stateID string) (*models.Country, error) {
-	return s.repository.DeleteCountryState(countryID, stateID)
+// normalizeCurrencyCode validates the currency of a country, written as its ISO 4217 code in upper
+// case, a country may have none
+func normalizeCurrencyCode(country *dtos.CountryDto) error {
+	if country.CurrencyCode == "" {
+		return nil
+	}
+	code, err := parseCurrency(country.CurrencyCode)
+	if err != nil {
+		return err
+	}
+	country.CurrencyCode = code
+	return nil
 }
 
 // NewCountryService creates a country service with necessary dependencies.
-func NewCountryService(countryRepository *store.MongoCountryRepository) *CountryService {
-	return &CountryService{countryRepository}
+func NewCountryService(
+	countryRepository *store.MongoCountryRepository,
+	auditService *AuditService,
+	eventService *EventService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *CountryService {
+	return &CountryService{countryRepository, auditService, eventService, integrityService, unitOfWork}
 }
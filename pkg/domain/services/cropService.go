@@ -2,19 +2,36 @@
 package services
 
 import (
+	"context"
+	"time"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CropService implements use cases methods and domain business logic for crops
 type CropService struct {
 	repository *store.MongoCropRepository
+	audit      *AuditService
+	events     *EventService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 // FindCropByID returns a crop by its ID
 func (s *CropService) FindCropByID(id string) (*models.Crop, error) {
-	return s.repository.FindByID(id)
+	crop, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if crop == nil {
+		return nil, errs.NotFound("Crop")
+	}
+	return crop, nil
 }
 
 // FindAllCrops returns a list of crops
@@ -22,46 +39,159 @@ func (s *CropService) FindAllCrops() ([]*models.Crop, error) {
 	return s.repository.FindAll()
 }
 
-// CreateCrop create a new crop record
-func (s *CropService) CreateCrop(dto *dtos.CropDto) (*models.Crop, error) {
-	result, err := s.repository.Insert(dto)
-	if err != nil {
-		return nil, err
+// EachCrop calls fn with every crop and its city, variant and supplier until fn returns an error
+func (s *CropService) EachCrop(ctx context.Context, fn func(*models.Crop) error) error {
+	return s.repository.Each(ctx, fn)
+}
+
+// CountActiveCrops returns the number of crops that have not been harvested yet
+func (s *CropService) CountActiveCrops() (int64, error) {
+	return s.repository.CountActive(time.Now())
+}
+
+// HarvestReport counts the crops whose harvest date is within [from, to), by month of harvest and
+// variant, sorted by month then variant name
+func (s *CropService) HarvestReport(ctx context.Context, from time.Time, to time.Time) ([]*models.HarvestReportRow, error) {
+	return s.repository.HarvestReport(ctx, from, to, nil)
+}
+
+// MembersHarvestReport is the HarvestReport of the crops supplied or created by the given users
+// and API clients only
+func (s *CropService) MembersHarvestReport(ctx context.Context, from time.Time, to time.Time, memberIDs []primitive.ObjectID) ([]*models.HarvestReportRow, error) {
+	if memberIDs == nil {
+		memberIDs = []primitive.ObjectID{}
 	}
+	return s.repository.HarvestReport(ctx, from, to, memberIDs)
+}
 
-	crop, err := s.repository.FindByID(result)
+// CreateCrop create a new crop record, the crop, its audit entry and its event are written in one
+// unit of work. The crop records the user or the API client creating it.
+func (s *CropService) CreateCrop(ctx context.Context, dto *dtos.CropDto) (*models.Crop, error) {
+	var crop *models.Crop
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.checkReferences(ctx, dto); err != nil {
+			return err
+		}
+
+		var createdBy *primitive.ObjectID
+		if _, memberID, ok := principalMember(PrincipalFromContext(ctx)); ok {
+			createdBy = &memberID
+		}
+		result, err := s.repository.Insert(ctx, dto, createdBy)
+		if err != nil {
+			return err
+		}
+
+		crop, err = s.repository.FindByID(ctx, result)
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceCrop, result, nil, crop); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CropCreated, models.ResourceCrop, result, crop)
+	})
 	if err != nil {
 		return nil, err
 	}
-
 	return crop, nil
 }
 
-// UpdateCropByID update a crop data by its id
-func (s *CropService) UpdateCropByID(id string, dto *dtos.CropDto) (*models.Crop, error) {
-	result, err := s.repository.Update(id, dto)
+// UpdateCropByID update a crop data by its id, versions optionally restricts the write to
+// the given stored versions of the document
+func (s *CropService) UpdateCropByID(ctx context.Context, id string, dto *dtos.CropDto, versions []int64) (*models.Crop, error) {
+	var crop *models.Crop
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Crop")
+		}
+		if err := s.checkReferences(ctx, dto); err != nil {
+			return err
+		}
+
+		result, err := s.repository.Update(ctx, id, dto, versions)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("Crop")
+		}
+
+		if crop, err = s.repository.FindByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceCrop, id, before, crop); err != nil {
+			return err
+		}
+		if err := s.events.Publish(ctx, enums.CropUpdated, models.ResourceCrop, id, crop); err != nil {
+			return err
+		}
+		if !crop.HarvestDate.Equal(before.HarvestDate) {
+			change := &models.HarvestDateChange{Crop: crop, PreviousHarvestDate: before.HarvestDate}
+			return s.events.Publish(ctx, enums.CropHarvestDateChanged, models.ResourceCrop, id, change)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return crop, nil
+}
 
-	if result == nil {
-		return nil, nil
-	}
+// DeleteCropByID delete a crop by id, versions optionally restricts the delete to the given
+// stored versions of the document
+func (s *CropService) DeleteCropByID(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Crop")
+		}
+
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Crop")
+		}
 
-	crop, err := s.repository.FindByID(id)
+		if err := s.audit.Record(ctx, enums.AuditDelete, models.ResourceCrop, id, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.CropDeleted, models.ResourceCrop, id, before)
+	})
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-
-	return crop, nil
+	return true, nil
 }
 
-// DeleteCropByID delete a crop by id
-func (s *CropService) DeleteCropByID(id string) (bool, error) {
-	return s.repository.Delete(id)
+// checkReferences verifies the city, variant and supplier of a crop are active, the supplier of a
+// crop is either a supplier or a user
+func (s *CropService) checkReferences(ctx context.Context, dto *dtos.CropDto) error {
+	return s.integrity.CheckReferences(ctx,
+		NewReference("cityId", &dto.CityID, models.ResourceCity),
+		NewReference("variantId", dto.VariantID, models.ResourceVariant),
+		NewReference("supplierId", dto.SupplierID, models.ResourceSupplier, models.ResourceUser),
+	)
 }
 
 // NewCropService creates a crop service with necessary dependencies.
-func NewCropService(repository *store.MongoCropRepository) *CropService {
-	return &CropService{repository}
+func NewCropService(
+	repository *store.MongoCropRepository,
+	auditService *AuditService,
+	eventService *EventService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *CropService {
+	return &CropService{repository, auditService, eventService, integrityService, unitOfWork}
 }
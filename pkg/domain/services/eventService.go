@@ -0,0 +1,63 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventService implements the emission of domain events, which are written to an outbox and
+// delivered to the webhook subscriptions by the WebhookDispatcher
+type EventService struct {
+	outbox *store.MongoOutboxRepository
+	bus    *EventBus
+}
+
+// Publish writes a domain event about a resource to the outbox, data is the resource after the
+// change or before it for deletions. The request ID is taken from ctx.
+// The event must be published in the unit of work of the change it describes: it is written in
+// its transaction, a failure to write it fails the change, and the in-process subscribers are
+// notified once it commits.
+func (s *EventService) Publish(ctx context.Context, eventType enums.EnumEventType, resourceType string, resourceID string, data interface{}) error {
+	id := primitive.NewObjectID()
+	now := time.Now().UTC()
+	payload, err := json.Marshal(&models.DomainEvent{
+		ID:           id.Hex(),
+		Type:         eventType,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		RequestID:    RequestIDFromContext(ctx),
+		OccurredAt:   now,
+		Data:         data,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Error encoding a %s event", eventType)
+	}
+
+	event := &models.OutboxEvent{
+		ID:           id,
+		Type:         eventType,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Payload:      string(payload),
+		Status:       enums.OutboxPending,
+		CreatedAt:    now,
+	}
+	if _, err := s.outbox.Insert(ctx, event); err != nil {
+		return err
+	}
+	AfterCommit(ctx, func() { s.bus.Publish(event) })
+	return nil
+}
+
+// NewEventService creates an event service with necessary dependencies.
+func NewEventService(outbox *store.MongoOutboxRepository, bus *EventBus) *EventService {
+	return &EventService{outbox, bus}
+}
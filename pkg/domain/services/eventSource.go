@@ -0,0 +1,336 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// EventSourceMongo streams the events from the outbox with Mongo change streams
+	EventSourceMongo = "mongo"
+	// EventSourceMemory streams the events published by this process through an in-process bus
+	EventSourceMemory = "memory"
+
+	// eventReplayLimit bounds the number of missed events sent to a client that resumes a stream
+	eventReplayLimit = 1000
+	// eventPollInterval is how often the outbox is polled when change streams are not available
+	eventPollInterval = time.Second
+	// eventPollLookback is how far before the most recent event the polls look for the events
+	// written late, the ID of an event is generated before it is written
+	eventPollLookback = 5 * time.Second
+	// sentEventsCapacity bounds the number of the IDs of the events sent that a stream remembers to
+	// skip the events seen twice
+	sentEventsCapacity = 10 * eventReplayLimit
+)
+
+// EventSource streams the domain events published from now on, or right after the event
+// lastEventID when it is not empty. lastEventID is the StreamID of an event streamed before. The
+// channel is closed when ctx is cancelled.
+type EventSource interface {
+	Subscribe(ctx context.Context, lastEventID string) (<-chan *models.OutboxEvent, error)
+}
+
+// NewEventSource returns the event source selected by the configuration
+func NewEventSource(confPtr *config.Config, outbox *store.MongoOutboxRepository, bus *EventBus) EventSource {
+	if confPtr.Events.Source == EventSourceMemory {
+		return bus
+	}
+	return &MongoEventSource{outbox}
+}
+
+// MongoEventSource streams the events of the outbox, it tails the outbox with a change stream
+// and falls back to polling on deployments without change streams. The events read from the
+// change stream are identified by their resume token, a stream resumed with one goes on exactly
+// after that event in the order of the commits. The events read by polling are identified by their
+// ID, the IDs do not tell the order the events were committed in so a stream resumed with one
+// sends again the events written a lookback before it, the clients skip the ones they have seen.
+type MongoEventSource struct {
+	outbox *store.MongoOutboxRepository
+}
+
+// Subscribe implements EventSource
+func (s *MongoEventSource) Subscribe(ctx context.Context, lastEventID string) (<-chan *models.OutboxEvent, error) {
+	var resumeToken bson.Raw
+	var after *primitive.ObjectID
+	if lastEventID != "" {
+		if id, err := primitive.ObjectIDFromHex(lastEventID); err == nil {
+			after = &id
+		} else if resumeToken, err = store.ParseResumeToken(lastEventID); err != nil {
+			return nil, errs.InvalidID(lastEventID, err)
+		}
+	}
+
+	// The change stream is opened before reading the missed events so that nothing written in
+	// between is lost, the events seen twice are skipped by remembering the IDs of the events sent
+	watcher, err := s.outbox.Watch(ctx, resumeToken)
+	if err != nil {
+		if resumeToken != nil {
+			return nil, errs.Conflict("The stream cannot be resumed after the event "+lastEventID, err)
+		}
+		logging.FromContext(ctx).WithError(err).Warn("Change streams are not available, polling the outbox")
+	}
+
+	events := make(chan *models.OutboxEvent)
+	go func() {
+		defer close(events)
+		if watcher != nil {
+			defer watcher.Close()
+		}
+		last := store.ObjectIDFromTime(time.Now())
+		if after != nil {
+			last = *after
+		}
+		sent := newSentEvents(sentEventsCapacity)
+		send := func(event *models.OutboxEvent) bool {
+			if !sent.add(event.ID) {
+				return true
+			}
+			if isAfter(event.ID, last) {
+				last = event.ID
+			}
+			if event.StreamID == "" {
+				event.StreamID = event.ID.Hex()
+			}
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if after != nil {
+			if !s.sendRecent(ctx, last, send) {
+				return
+			}
+		}
+		if watcher == nil {
+			if after == nil {
+				// The events written before the subscription are not sent
+				s.sendRecent(ctx, last, func(event *models.OutboxEvent) bool {
+					sent.add(event.ID)
+					return true
+				})
+			}
+			s.poll(ctx, &last, send)
+			return
+		}
+		for {
+			event, err := watcher.Next(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logging.FromContext(ctx).WithError(err).Error("Error streaming the outbox")
+				}
+				return
+			}
+			if !send(event) {
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// sendRecent sends the events written since a lookback before last, the ones already sent are
+// skipped by send. It reports false when the stream is over.
+func (s *MongoEventSource) sendRecent(ctx context.Context, last primitive.ObjectID, send func(*models.OutboxEvent) bool) bool {
+	after := store.ObjectIDFromTime(store.TimeFromObjectID(last).Add(-eventPollLookback))
+	for {
+		recent, err := s.outbox.FindAfter(after, eventReplayLimit)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("Error reading the recent outbox events")
+			return ctx.Err() == nil
+		}
+		for _, event := range recent {
+			if !send(event) {
+				return false
+			}
+			after = event.ID
+		}
+		if len(recent) < eventReplayLimit {
+			return true
+		}
+	}
+}
+
+func (s *MongoEventSource) poll(ctx context.Context, last *primitive.ObjectID, send func(*models.OutboxEvent) bool) {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.sendRecent(ctx, *last, send) {
+				return
+			}
+		}
+	}
+}
+
+// sentEvents remembers the IDs of the last events sent on a stream, up to a capacity
+type sentEvents struct {
+	ids      map[primitive.ObjectID]struct{}
+	order    []primitive.ObjectID
+	capacity int
+}
+
+func newSentEvents(capacity int) *sentEvents {
+	return &sentEvents{ids: map[primitive.ObjectID]struct{}{}, capacity: capacity}
+}
+
+// add remembers an ID, forgetting the oldest one beyond the capacity, and reports whether the ID
+// was not known yet
+func (e *sentEvents) add(id primitive.ObjectID) bool {
+	if _, ok := e.ids[id]; ok {
+		return false
+	}
+	e.ids[id] = struct{}{}
+	e.order = append(e.order, id)
+	if len(e.order) > e.capacity {
+		delete(e.ids, e.order[0])
+		e.order = e.order[1:]
+	}
+	return true
+}
+
+func isAfter(id primitive.ObjectID, last primitive.ObjectID) bool {
+	for i := range id {
+		if id[i] != last[i] {
+			return id[i] > last[i]
+		}
+	}
+	return false
+}
+
+// EventBus is an in-process EventSource fed by the EventService, it keeps the most recent events
+// so that clients can resume a stream. Only the events published by this process go through it,
+// in the order they were committed in, and a stream resumes right after the position of its last
+// event in that order.
+type EventBus struct {
+	mutex       sync.Mutex
+	history     []*models.OutboxEvent
+	capacity    int
+	subscribers map[chan *models.OutboxEvent]struct{}
+}
+
+// Publish sends an event to every subscriber, subscribers that are not keeping up miss it
+func (b *EventBus) Publish(event *models.OutboxEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if event.StreamID == "" {
+		event.StreamID = event.ID.Hex()
+	}
+	b.history = append(b.history, event)
+	if len(b.history) > b.capacity {
+		b.history = b.history[len(b.history)-b.capacity:]
+	}
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			logging.Default().WithField("eventId", event.ID.Hex()).Warn("Dropping an event for a slow subscriber")
+		}
+	}
+}
+
+// Subscribe implements EventSource, a stream resumed after an event that is no longer remembered
+// sends every event remembered
+func (b *EventBus) Subscribe(ctx context.Context, lastEventID string) (<-chan *models.OutboxEvent, error) {
+	if lastEventID != "" {
+		if _, err := primitive.ObjectIDFromHex(lastEventID); err != nil {
+			return nil, errs.InvalidID(lastEventID, err)
+		}
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	var missed []*models.OutboxEvent
+	if lastEventID != "" {
+		missed = b.history
+		for i := len(b.history) - 1; i >= 0; i-- {
+			if b.history[i].StreamID == lastEventID {
+				missed = b.history[i+1:]
+				break
+			}
+		}
+	}
+	events := make(chan *models.OutboxEvent, len(missed)+64)
+	for _, event := range missed {
+		events <- event
+	}
+	b.subscribers[events] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.mutex.Lock()
+		delete(b.subscribers, events)
+		close(events)
+		b.mutex.Unlock()
+	}()
+	return events, nil
+}
+
+// NewEventBus creates an in-process event bus that remembers the last capacity events.
+func NewEventBus(capacity int) *EventBus {
+	return &EventBus{capacity: capacity, subscribers: map[chan *models.OutboxEvent]struct{}{}}
+}
+
+// resourceReadScopes maps each resource type to the scope needed to read it
+var resourceReadScopes = map[string]enums.EnumScope{
+	models.ResourceSupplier: enums.SuppliersRead,
+	models.ResourceCountry:  enums.CountriesRead,
+	models.ResourceCity:     enums.CitiesRead,
+	models.ResourceItem:     enums.ItemsRead,
+	models.ResourceVariant:  enums.ItemsRead,
+	models.ResourceCrop:     enums.CropsRead,
+}
+
+// EventFilter selects the events streamed to a client
+type EventFilter struct {
+	// Principal is the caller, nil for anonymous callers who see what the public API shows
+	Principal *models.Principal
+	// ResourceTypes restricts the stream to some resource types when it is not empty
+	ResourceTypes []string
+	// ResourceIDs restricts the stream to some resources when it is not empty
+	ResourceIDs []string
+}
+
+// Allows reports whether an event goes through the filter
+func (f *EventFilter) Allows(event *models.OutboxEvent) bool {
+	scope, ok := resourceReadScopes[event.ResourceType]
+	if !ok {
+		return false
+	}
+	if f.Principal != nil && !f.Principal.HasScope(scope) {
+		return false
+	}
+	return (len(f.ResourceTypes) == 0 || contains(f.ResourceTypes, event.ResourceType)) &&
+		(len(f.ResourceIDs) == 0 || contains(f.ResourceIDs, event.ResourceID))
+}
+
+// IsKnownResourceType reports whether events are streamed for a resource type
+func IsKnownResourceType(resourceType string) bool {
+	_, ok := resourceReadScopes[resourceType]
+	return ok
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
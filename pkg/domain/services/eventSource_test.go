@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestEvent(resourceType string, resourceID string) *models.OutboxEvent {
+	return &models.OutboxEvent{ID: primitive.NewObjectID(), ResourceType: resourceType, ResourceID: resourceID}
+}
+
+// receive reads n events from a stream, failing the test when they do not come
+func receive(t *testing.T, events <-chan *models.OutboxEvent, n int) []*models.OutboxEvent {
+	t.Helper()
+	var received []*models.OutboxEvent
+	for len(received) < n {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("the stream closed after %d events, expected %d", len(received), n)
+			}
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d events, expected %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func TestEventBusSubscribe(t *testing.T) {
+	published := []*models.OutboxEvent{
+		newTestEvent(models.ResourceCrop, "1"),
+		newTestEvent(models.ResourceCrop, "2"),
+		newTestEvent(models.ResourceCrop, "3"),
+	}
+	tests := []struct {
+		name        string
+		capacity    int
+		lastEventID func() string
+		expected    []*models.OutboxEvent
+	}{
+		{"from now on", 10, func() string { return "" }, nil},
+		{"after the last event", 10, func() string { return published[2].StreamID }, nil},
+		{"after an event", 10, func() string { return published[0].StreamID }, published[1:]},
+		{"after an event no longer remembered", 2, func() string { return published[0].StreamID }, published[1:]},
+		{"after an unknown event", 10, func() string { return primitive.NewObjectID().Hex() }, published},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewEventBus(tt.capacity)
+			for _, event := range published {
+				event.StreamID = ""
+				bus.Publish(event)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := bus.Subscribe(ctx, tt.lastEventID())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			live := newTestEvent(models.ResourceCrop, "4")
+			bus.Publish(live)
+			received := receive(t, events, len(tt.expected)+1)
+			for i, event := range tt.expected {
+				if received[i] != event {
+					t.Errorf("event %d: got %s, expected %s", i, received[i].ResourceID, event.ResourceID)
+				}
+			}
+			if received[len(tt.expected)] != live {
+				t.Errorf("got %s, expected the event published after subscribing", received[len(tt.expected)].ResourceID)
+			}
+		})
+	}
+}
+
+func TestEventBusSubscribeInvalidID(t *testing.T) {
+	if _, err := NewEventBus(10).Subscribe(context.Background(), "not an id"); err == nil {
+		t.Error("expected an error for a malformed Last-Event-ID")
+	}
+}
+
+func TestEventBusClosesOnCancel(t *testing.T) {
+	bus := NewEventBus(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := bus.Subscribe(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("expected no event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the stream was not closed")
+	}
+	// Publishing after the subscriber left must not block nor panic
+	bus.Publish(newTestEvent(models.ResourceCrop, "1"))
+}
+
+func TestEventFilterAllows(t *testing.T) {
+	crop := newTestEvent(models.ResourceCrop, "1")
+	item := newTestEvent(models.ResourceItem, "2")
+	unknown := newTestEvent("invoice", "3")
+	cropReader := &models.Principal{Scopes: []enums.EnumScope{enums.CropsRead}}
+	operator := &models.Principal{Scopes: []enums.EnumScope{enums.AllScopes}}
+
+	tests := []struct {
+		name     string
+		filter   EventFilter
+		event    *models.OutboxEvent
+		expected bool
+	}{
+		{"anonymous", EventFilter{}, crop, true},
+		{"unknown resource type", EventFilter{Principal: operator}, unknown, false},
+		{"with the scope", EventFilter{Principal: cropReader}, crop, true},
+		{"without the scope", EventFilter{Principal: cropReader}, item, false},
+		{"all scopes", EventFilter{Principal: operator}, item, true},
+		{"resource type selected", EventFilter{ResourceTypes: []string{models.ResourceItem, models.ResourceCrop}}, crop, true},
+		{"resource type not selected", EventFilter{ResourceTypes: []string{models.ResourceItem}}, crop, false},
+		{"resource selected", EventFilter{ResourceIDs: []string{"1"}}, crop, true},
+		{"resource not selected", EventFilter{ResourceIDs: []string{"2"}}, crop, false},
+		{"type and resource selected", EventFilter{ResourceTypes: []string{models.ResourceCrop}, ResourceIDs: []string{"2"}}, item, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allowed := tt.filter.Allows(tt.event); allowed != tt.expected {
+				t.Errorf("got %v, expected %v", allowed, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/money"
+	"futuagro.com/pkg/store"
+)
+
+var (
+	// ErrSameCurrencies is returned when an exchange rate has the same base and quote currencies
+	ErrSameCurrencies = errs.Validation("The base and quote currencies of an exchange rate must differ")
+	// ErrMissingEffectiveAt is returned when an exchange rate does not say when it takes effect
+	ErrMissingEffectiveAt = errs.Validation("An exchange rate needs the time it takes effect")
+)
+
+// ExchangeRateService implements use cases methods and domain business logic for the exchange
+// rates and the conversions of amounts between currencies
+type ExchangeRateService struct {
+	repository *store.MongoExchangeRateRepository
+	audit      *AuditService
+	unitOfWork UnitOfWork
+}
+
+// FindRateByID returns an exchange rate by its ID
+func (s *ExchangeRateService) FindRateByID(id string) (*models.ExchangeRate, error) {
+	rate, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if rate == nil {
+		return nil, errs.NotFound("Exchange Rate")
+	}
+	return rate, nil
+}
+
+// FindRates returns the exchange rates by pair and then newest first, base and quote restrict
+// them to a currency when they are not empty
+func (s *ExchangeRateService) FindRates(base string, quote string) ([]*models.ExchangeRate, error) {
+	var err error
+	if base != "" {
+		if base, err = parseCurrency(base); err != nil {
+			return nil, err
+		}
+	}
+	if quote != "" {
+		if quote, err = parseCurrency(quote); err != nil {
+			return nil, err
+		}
+	}
+	return s.repository.Find(base, quote)
+}
+
+// CreateRate records a new exchange rate, a pair has a single rate effective from a given time,
+// the rate and its audit entry are written in one unit of work
+func (s *ExchangeRateService) CreateRate(ctx context.Context, dto *dtos.ExchangeRateDto) (*models.ExchangeRate, error) {
+	rate, err := newExchangeRate(dto)
+	if err != nil {
+		return nil, err
+	}
+	rate.CreatedAt = time.Now().UTC()
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.repository.Insert(ctx, rate); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, enums.AuditCreate, models.ResourceExchangeRate, rate.ID.Hex(), nil, rate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// DeleteRate removes an exchange rate, the previous rate of its pair takes effect again
+func (s *ExchangeRateService) DeleteRate(ctx context.Context, id string) error {
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Exchange Rate")
+		}
+		deleted, err := s.repository.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Exchange Rate")
+		}
+		return s.audit.Record(ctx, enums.AuditDelete, models.ResourceExchangeRate, id, before, nil)
+	})
+}
+
+// Convert converts an amount into the currency to with the rate in effect at the given time, now
+// when it is zero. The rate of the pair is used, or else the rate of the opposite pair inverted,
+// the most recent of both when each has one. An amount without rate is an errs.KindNotFound
+// error.
+func (s *ExchangeRateService) Convert(amount models.Money, to string, at time.Time) (*models.Conversion, error) {
+	from, err := parseCurrency(amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if to, err = parseCurrency(to); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if at.IsZero() {
+		at = now
+	}
+	conversion := &models.Conversion{
+		From:        models.Money{Amount: amount.Amount, Currency: from},
+		To:          models.Money{Amount: amount.Amount, Currency: to},
+		ConvertedAt: now,
+	}
+	if from == to {
+		return conversion, nil
+	}
+
+	direct, err := s.repository.FindEffective(from, to, at)
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := s.repository.FindEffective(to, from, at)
+	if err != nil {
+		return nil, err
+	}
+	conversion.Rate = direct
+	if inverse != nil && (direct == nil || inverse.EffectiveAt.After(direct.EffectiveAt)) {
+		conversion.Rate, conversion.Inverted = inverse, true
+	}
+	if conversion.Rate == nil {
+		return nil, errs.NotFound("Exchange Rate")
+	}
+
+	value, err := money.ParseRate(conversion.Rate.Rate)
+	if err != nil {
+		return nil, err
+	}
+	if conversion.Inverted {
+		value = new(big.Rat).Inv(value)
+	}
+	if conversion.To.Amount, err = money.Convert(amount.Amount, from, to, value); err != nil {
+		return nil, errs.Validation(err.Error())
+	}
+	conversion.To.RateID = &conversion.Rate.ID
+	conversion.To.RateEffectiveAt = &conversion.Rate.EffectiveAt
+	return conversion, nil
+}
+
+// ConvertPrices converts the prices in place into a currency with the rates in effect at the given
+// time, now when it is zero, for the reads answering them in the currency asked by the caller.
+// Each converted price carries the ID and the effective time of the rate it was converted with,
+// the prices are left as they are when currency is empty.
+func (s *ExchangeRateService) ConvertPrices(currency string, at time.Time, prices ...*models.Money) error {
+	if currency == "" {
+		return nil
+	}
+	for _, price := range prices {
+		if price == nil {
+			continue
+		}
+		conversion, err := s.Convert(*price, currency, at)
+		if err != nil {
+			return err
+		}
+		*price = conversion.To
+	}
+	return nil
+}
+
+// newExchangeRate validates an exchange rate, its currencies are written as ISO 4217 codes in upper
+// case and its time in UTC to the millisecond, as mongodb stores it
+func newExchangeRate(dto *dtos.ExchangeRateDto) (*models.ExchangeRate, error) {
+	base, err := parseCurrency(dto.Base)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := parseCurrency(dto.Quote)
+	if err != nil {
+		return nil, err
+	}
+	if base == quote {
+		return nil, ErrSameCurrencies
+	}
+	if _, err := money.ParseRate(dto.Rate); err != nil {
+		return nil, errs.Validation(err.Error())
+	}
+	if dto.EffectiveAt.IsZero() {
+		return nil, ErrMissingEffectiveAt
+	}
+	return &models.ExchangeRate{
+		Base:        base,
+		Quote:       quote,
+		Rate:        strings.TrimSpace(dto.Rate),
+		EffectiveAt: dto.EffectiveAt.UTC().Truncate(time.Millisecond),
+	}, nil
+}
+
+// parseCurrency returns the ISO 4217 code of a currency, an unknown one is an errs.KindValidation
+// error
+func parseCurrency(code string) (string, error) {
+	parsed, err := money.ParseCurrency(code)
+	if err != nil {
+		return "", errs.Validation(err.Error())
+	}
+	return parsed, nil
+}
+
+// NewExchangeRateService creates an exchange rate service with necessary dependencies.
+func NewExchangeRateService(exchangeRateRepository *store.MongoExchangeRateRepository, auditService *AuditService, unitOfWork UnitOfWork) *ExchangeRateService {
+	return &ExchangeRateService{exchangeRateRepository, auditService, unitOfWork}
+}
@@ -0,0 +1,222 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"strings"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SeedCounts counts the records of a kind by outcome of a seed
+type SeedCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+// GeographyReport tells what seeding the reference geography changed
+type GeographyReport struct {
+	Countries SeedCounts `json:"countries"`
+	States    SeedCounts `json:"states"`
+	Cities    SeedCounts `json:"cities"`
+}
+
+// GeographyService loads the reference geography, countries, states and cities, through the
+// services of each record so that the changes are audited and published like any other
+type GeographyService struct {
+	countries *CountryService
+	cities    *CityService
+}
+
+// Seed creates the missing records and renames the existing ones, a record is matched by its
+// official code, then by its name among the records without a code so that the records created
+// by hand keep their ID and the references to them. Seeding twice changes nothing, and dryRun
+// only counts what would change.
+func (s *GeographyService) Seed(ctx context.Context, countries []dtos.GeographyCountryDto, dryRun bool) (*GeographyReport, error) {
+	existingCountries, err := s.countries.FindAllCountries()
+	if err != nil {
+		return nil, err
+	}
+	existingCities, err := s.cities.FindAllCities()
+	if err != nil {
+		return nil, err
+	}
+	citiesByState := map[primitive.ObjectID][]*models.City{}
+	for _, city := range existingCities {
+		citiesByState[city.CountryStateID] = append(citiesByState[city.CountryStateID], city)
+	}
+
+	seeder := &geographySeeder{service: s, ctx: ctx, dryRun: dryRun, citiesByState: citiesByState, report: &GeographyReport{}}
+	for _, countryDto := range countries {
+		if err := seeder.seedCountry(existingCountries, countryDto); err != nil {
+			return seeder.report, err
+		}
+	}
+	return seeder.report, nil
+}
+
+type geographySeeder struct {
+	service       *GeographyService
+	ctx           context.Context
+	dryRun        bool
+	citiesByState map[primitive.ObjectID][]*models.City
+	report        *GeographyReport
+}
+
+func (g *geographySeeder) seedCountry(existing []*models.Country, dto dtos.GeographyCountryDto) error {
+	var country *models.Country
+	for _, candidate := range existing {
+		if matchesCode(candidate.CountryCode, candidate.CountryName, dto.CountryCode, dto.CountryName) {
+			country = candidate
+			break
+		}
+	}
+
+	switch {
+	case country == nil:
+		g.report.Countries.Created++
+		if g.dryRun {
+			g.countNewStates(dto.States)
+			return nil
+		}
+		id, err := g.service.countries.CreateCountry(g.ctx, &dtos.CountryDto{CountryName: dto.CountryName, CountryCode: dto.CountryCode})
+		if err != nil {
+			return errors.Wrapf(err, "Error creating the country %s", dto.CountryCode)
+		}
+		if country, err = g.service.countries.FindCountryByID(id); err != nil || country == nil {
+			return errors.Wrapf(err, "Error finding the country %s", dto.CountryCode)
+		}
+	case country.CountryName != dto.CountryName || country.CountryCode != dto.CountryCode:
+		g.report.Countries.Updated++
+		if g.dryRun {
+			break
+		}
+		update := &dtos.CountryDto{CountryName: dto.CountryName, CountryCode: dto.CountryCode, RecordStatus: country.RecordStatus}
+		updated, err := g.service.countries.UpdateCountryByID(g.ctx, country.ID.Hex(), update, []int64{country.Version})
+		if err != nil || updated == nil {
+			return errors.Wrapf(err, "Error updating the country %s", dto.CountryCode)
+		}
+		country = updated
+	default:
+		g.report.Countries.Unchanged++
+	}
+
+	for _, stateDto := range dto.States {
+		var err error
+		if country, err = g.seedState(country, stateDto); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedState returns the country holding the state, its version is bumped by every state written
+func (g *geographySeeder) seedState(country *models.Country, dto dtos.GeographyStateDto) (*models.Country, error) {
+	state := findState(country, dto)
+	update := dtos.CountryStateDto{StateName: dto.StateName, StateCode: dto.StateCode}
+
+	switch {
+	case state == nil:
+		g.report.States.Created++
+		if g.dryRun {
+			g.report.Cities.Created += len(dto.Cities)
+			return country, nil
+		}
+		updated, err := g.service.countries.AddState(g.ctx, country.ID.Hex(), update, []int64{country.Version})
+		if err != nil || updated == nil {
+			return nil, errors.Wrapf(err, "Error creating the state %s", dto.StateCode)
+		}
+		country = updated
+		if state = findState(country, dto); state == nil {
+			return nil, errors.Errorf("Error finding the state %s once created", dto.StateCode)
+		}
+	case state.StateName != dto.StateName || state.StateCode != dto.StateCode:
+		g.report.States.Updated++
+		if g.dryRun {
+			break
+		}
+		updated, err := g.service.countries.UpdateState(g.ctx, country.ID.Hex(), state.ID.Hex(), update, []int64{country.Version})
+		if err != nil || updated == nil {
+			return nil, errors.Wrapf(err, "Error updating the state %s", dto.StateCode)
+		}
+		country = updated
+	default:
+		g.report.States.Unchanged++
+	}
+
+	for _, cityDto := range dto.Cities {
+		if err := g.seedCity(state.ID, cityDto); err != nil {
+			return nil, err
+		}
+	}
+	return country, nil
+}
+
+func (g *geographySeeder) seedCity(stateID primitive.ObjectID, dto dtos.GeographyCityDto) error {
+	var city *models.City
+	for _, candidate := range g.citiesByState[stateID] {
+		if matchesCode(candidate.CityCode, candidate.CityName, dto.CityCode, dto.CityName) {
+			city = candidate
+			break
+		}
+	}
+	update := &dtos.CityDto{CityName: dto.CityName, CityCode: dto.CityCode}
+
+	switch {
+	case city == nil:
+		g.report.Cities.Created++
+		if g.dryRun {
+			return nil
+		}
+		if _, err := g.service.cities.CreateCity(g.ctx, stateID.Hex(), update); err != nil {
+			return errors.Wrapf(err, "Error creating the city %s", dto.CityCode)
+		}
+	case city.CityName != dto.CityName || city.CityCode != dto.CityCode:
+		g.report.Cities.Updated++
+		if g.dryRun {
+			return nil
+		}
+		updated, err := g.service.cities.UpdateCityByID(g.ctx, stateID.Hex(), city.ID.Hex(), update, []int64{city.Version})
+		if err != nil || updated == nil {
+			return errors.Wrapf(err, "Error updating the city %s", dto.CityCode)
+		}
+	default:
+		g.report.Cities.Unchanged++
+	}
+	return nil
+}
+
+func (g *geographySeeder) countNewStates(states []dtos.GeographyStateDto) {
+	for _, state := range states {
+		g.report.States.Created++
+		g.report.Cities.Created += len(state.Cities)
+	}
+}
+
+func findState(country *models.Country, dto dtos.GeographyStateDto) *models.CountryState {
+	for i := range country.States {
+		state := &country.States[i]
+		if matchesCode(state.StateCode, state.StateName, dto.StateCode, dto.StateName) {
+			return state
+		}
+	}
+	return nil
+}
+
+// matchesCode tells whether a stored record is the seeded one: it has the same official code,
+// or it has no code and the same name whatever its case
+func matchesCode(storedCode string, storedName string, code string, name string) bool {
+	if storedCode != "" {
+		return strings.EqualFold(storedCode, code)
+	}
+	return strings.EqualFold(strings.TrimSpace(storedName), strings.TrimSpace(name))
+}
+
+// NewGeographyService creates a geography service with necessary dependencies.
+func NewGeographyService(countryService *CountryService, cityService *CityService) *GeographyService {
+	return &GeographyService{countryService, cityService}
+}
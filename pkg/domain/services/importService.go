@@ -0,0 +1,526 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/i18n"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/money"
+	"futuagro.com/pkg/spreadsheet"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// importBatchSize is the number of rows committed between two saves of the progress of a job
+	importBatchSize = 100
+	// maxImportErrors caps the row errors stored with a job, the rows failing beyond are counted
+	maxImportErrors = 1000
+)
+
+// ImportService validates the rows of spreadsheets of suppliers, crops or exchange rates and
+// commits the valid ones through the services of each record, so that they are audited and
+// published like any other
+type ImportService struct {
+	repository *store.MongoImportJobRepository
+	suppliers  *SupplierService
+	crops      *CropService
+	countries  *CountryService
+	cities     *CityService
+	items      *ItemService
+	variants   *VariantService
+	rates      *ExchangeRateService
+}
+
+// FindImportJobByID returns an import job by its ID
+func (s *ImportService) FindImportJobByID(id string) (*models.ImportJob, error) {
+	importJob, err := s.repository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if importJob == nil {
+		return nil, errs.NotFound("Import Job")
+	}
+	return importJob, nil
+}
+
+// FindRecentImportJobs returns the most recent import jobs without their row errors
+func (s *ImportService) FindRecentImportJobs(limit int64) ([]*models.ImportJob, error) {
+	return s.repository.FindRecent(limit)
+}
+
+// Import validates every row of a table. A dry run returns the report of the validation without
+// writing anything. Otherwise the job is recorded and the valid rows are committed in batches,
+// in the background when async is true: the returned job is then running and its progress is
+// read with FindImportJobByID.
+func (s *ImportService) Import(ctx context.Context, dto *dtos.ImportDto, table *spreadsheet.Table, async bool) (*models.ImportJob, error) {
+	if !dto.Kind.IsValid() {
+		return nil, errs.Validationf("Invalid import kind %q", dto.Kind)
+	}
+	now := time.Now().UTC()
+	job := &models.ImportJob{
+		Kind:      dto.Kind,
+		FileName:  dto.FileName,
+		Format:    dto.Format,
+		DryRun:    dto.DryRun,
+		Status:    enums.ImportValidated,
+		TotalRows: len(table.Rows),
+		Errors:    []models.ImportRowError{},
+		CreatedBy: auditActor(ctx),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	refs, err := s.loadReferences()
+	if err != nil {
+		return nil, err
+	}
+	var rows []importRow
+	switch dto.Kind {
+	case enums.ImportSuppliers:
+		rows = refs.validateSuppliers(job, table)
+	case enums.ImportCrops:
+		rows = refs.validateCrops(job, table)
+	default:
+		rows = refs.validateExchangeRates(job, table)
+	}
+	if dto.DryRun {
+		job.CompletedAt = &now
+		return job, nil
+	}
+
+	job.Status = enums.ImportRunning
+	if err := s.repository.Insert(job); err != nil {
+		return nil, err
+	}
+	if !async {
+		s.commit(ctx, job, rows)
+		return job, nil
+	}
+
+	// The request that started the import is over before the rows are committed
+	detached := WithRequestID(WithPrincipal(context.Background(), PrincipalFromContext(ctx)), RequestIDFromContext(ctx))
+	detached = logging.NewContext(detached, logging.FromContext(ctx))
+	running := *job
+	running.Errors = append(make([]models.ImportRowError, 0, len(job.Errors)), job.Errors...)
+	go s.commit(detached, &running, rows)
+	return job, nil
+}
+
+// commit creates the records of the valid rows and saves the progress of the job after every batch
+func (s *ImportService) commit(ctx context.Context, job *models.ImportJob, rows []importRow) {
+	logger := logging.FromContext(ctx).WithField("importJobId", job.ID.Hex())
+	for start := 0; start < len(rows); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		for _, row := range rows[start:end] {
+			var err error
+			switch {
+			case row.supplier != nil:
+				_, err = s.suppliers.CreateSupplier(ctx, row.supplier)
+			case row.crop != nil:
+				_, err = s.crops.CreateCrop(ctx, row.crop)
+			default:
+				_, err = s.rates.CreateRate(ctx, row.exchangeRate)
+			}
+			if errs.Is(err, errs.KindConflict) || errs.Is(err, errs.KindValidation) {
+				// Registered by someone else, or a referenced record deactivated, since the rows
+				// were validated
+				rejection := errors.Cause(err).(*errs.Error)
+				addImportError(job, row.line, rejection.Field, rejection.Message)
+				continue
+			}
+			if err != nil {
+				logger.WithError(err).WithField("line", row.line).Error("Error committing an imported row")
+				addImportError(job, row.line, "", "Could not be saved, import it again")
+				continue
+			}
+			job.CommittedRows++
+		}
+		if ctx.Err() != nil {
+			job.Status = enums.ImportFailed
+			job.Failure = "The import was interrupted"
+			break
+		}
+		job.UpdatedAt = time.Now().UTC()
+		if err := s.repository.Save(job); err != nil {
+			logger.WithError(err).Error("Error saving the progress of an import job")
+		}
+	}
+
+	if job.Status == enums.ImportRunning {
+		job.Status = enums.ImportCompleted
+	}
+	now := time.Now().UTC()
+	job.UpdatedAt = now
+	job.CompletedAt = &now
+	if err := s.repository.Save(job); err != nil {
+		logger.WithError(err).Error("Error saving the outcome of an import job")
+	}
+	logger.WithField("status", job.Status).WithField("committedRows", job.CommittedRows).Info("Import job finished")
+}
+
+// importRow is a valid row ready to be committed, it holds the record of the kind of the import
+type importRow struct {
+	line         int
+	supplier     *dtos.SupplierDto
+	crop         *dtos.CropDto
+	exchangeRate *dtos.ExchangeRateDto
+}
+
+// importReferences resolves the names and codes of a spreadsheet to the IDs of the records
+type importReferences struct {
+	citiesByName map[string][]*models.City
+	citiesByCode map[string]*models.City
+	states       map[primitive.ObjectID]models.CountryState
+	variants     map[string]*models.Variant
+	suppliers    map[string][]*models.Supplier
+	rates        map[string]bool
+}
+
+func (s *ImportService) loadReferences() (*importReferences, error) {
+	refs := &importReferences{
+		citiesByName: map[string][]*models.City{},
+		citiesByCode: map[string]*models.City{},
+		states:       map[primitive.ObjectID]models.CountryState{},
+		variants:     map[string]*models.Variant{},
+		suppliers:    map[string][]*models.Supplier{},
+		rates:        map[string]bool{},
+	}
+
+	countries, err := s.countries.FindAllCountries()
+	if err != nil {
+		return nil, err
+	}
+	for _, country := range countries {
+		for _, state := range country.States {
+			refs.states[state.ID] = state
+		}
+	}
+	cities, err := s.cities.FindAllCities()
+	if err != nil {
+		return nil, err
+	}
+	for _, city := range cities {
+		refs.citiesByName[lowerKey(city.CityName)] = append(refs.citiesByName[lowerKey(city.CityName)], city)
+		if city.CityCode != "" {
+			refs.citiesByCode[lowerKey(city.CityCode)] = city
+		}
+	}
+	items, err := s.items.FindAllItems()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		variants, err := s.variants.FindVariantsByItemID(item.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		// A variant is matched by any name or synonym of its item and of its own
+		for _, variant := range variants {
+			for _, itemTerm := range catalogTerms(item.Name, item.Terms) {
+				for _, variantTerm := range catalogTerms(variant.Name, variant.Terms) {
+					refs.variants[itemTerm+"/"+variantTerm] = variant
+				}
+			}
+		}
+	}
+	suppliers, err := s.suppliers.FindAllSuppliers()
+	if err != nil {
+		return nil, err
+	}
+	for _, supplier := range suppliers {
+		refs.suppliers[lowerKey(supplier.DocumentNumber)] = append(refs.suppliers[lowerKey(supplier.DocumentNumber)], supplier)
+	}
+	rates, err := s.rates.FindRates("", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, rate := range rates {
+		refs.rates[rateKey(rate.Base, rate.Quote, rate.EffectiveAt)] = true
+	}
+	return refs, nil
+}
+
+// validateSuppliers returns the valid rows of a spreadsheet of suppliers and reports the others.
+// A supplier is identified by its document, a row whose document is already registered is invalid.
+func (refs *importReferences) validateSuppliers(job *models.ImportJob, table *spreadsheet.Table) []importRow {
+	var rows []importRow
+	seen := map[string]int{}
+	for _, row := range table.Rows {
+		v := newRowValidator(job, table, row)
+		dto := &dtos.SupplierDto{
+			Name:           v.required("name"),
+			Surname:        v.required("surname"),
+			DocumentType:   v.required("documentType"),
+			DocumentNumber: v.required("documentNumber"),
+			Email:          v.optional("email"),
+			AddressLine1:   v.optional("addressLine1", "address"),
+			PhoneNumber:    v.optional("phoneNumber", "phone"),
+		}
+		if city := refs.resolveCity(v); city != nil {
+			dto.CityID = city.ID
+		}
+		if dto.Email != "" && !strings.Contains(dto.Email, "@") {
+			v.fail("email", "Is not an email address")
+		}
+		if dto.DocumentNumber != "" {
+			key := lowerKey(dto.DocumentType) + "/" + lowerKey(dto.DocumentNumber)
+			if line, ok := seen[key]; ok {
+				v.fail("documentNumber", fmt.Sprintf("Duplicates the supplier of line %d", line))
+			}
+			seen[key] = row.Line
+			for _, existing := range refs.suppliers[lowerKey(dto.DocumentNumber)] {
+				if strings.EqualFold(existing.DocumentType, dto.DocumentType) {
+					v.fail("documentNumber", "A supplier with this document is already registered")
+				}
+			}
+		}
+		if v.valid {
+			rows = append(rows, importRow{line: row.Line, supplier: dto})
+		}
+	}
+	return rows
+}
+
+// validateCrops returns the valid rows of a spreadsheet of crops and reports the others, the
+// supplier of a crop is identified by its document and must already be registered
+func (refs *importReferences) validateCrops(job *models.ImportJob, table *spreadsheet.Table) []importRow {
+	var rows []importRow
+	for _, row := range table.Rows {
+		v := newRowValidator(job, table, row)
+		dto := &dtos.CropDto{}
+		if supplier := refs.resolveSupplier(v); supplier != nil {
+			dto.SupplierID = &supplier.ID
+		}
+		if city := refs.resolveCity(v); city != nil {
+			dto.CityID = city.ID
+		}
+		item, variantName := v.required("item"), v.required("variant")
+		if item != "" && variantName != "" {
+			if variant, ok := refs.variants[i18n.Fold(item)+"/"+i18n.Fold(variantName)]; ok {
+				dto.VariantID = &variant.ID
+			} else {
+				v.fail("variant", fmt.Sprintf("Unknown variant %s of the item %s", variantName, item))
+			}
+		}
+		dto.PlantingDate = v.date("plantingDate")
+		dto.HarvestDate = v.date("harvestDate")
+		if !dto.PlantingDate.IsZero() && !dto.HarvestDate.IsZero() && dto.HarvestDate.Before(dto.PlantingDate) {
+			v.fail("harvestDate", "Is before the planting date")
+		}
+		if v.valid {
+			rows = append(rows, importRow{line: row.Line, crop: dto})
+		}
+	}
+	return rows
+}
+
+// validateExchangeRates returns the valid rows of a spreadsheet of exchange rates and reports the
+// others. A pair has a single rate effective from a given time, a row whose rate is already
+// recorded is invalid.
+func (refs *importReferences) validateExchangeRates(job *models.ImportJob, table *spreadsheet.Table) []importRow {
+	var rows []importRow
+	seen := map[string]int{}
+	for _, row := range table.Rows {
+		v := newRowValidator(job, table, row)
+		dto := &dtos.ExchangeRateDto{
+			Base:        v.currency("base"),
+			Quote:       v.currency("quote"),
+			Rate:        v.required("rate"),
+			EffectiveAt: v.date("effectiveAt"),
+		}
+		if dto.Base != "" && dto.Base == dto.Quote {
+			v.fail("quote", "Is the base currency")
+		}
+		if dto.Rate != "" {
+			if _, err := money.ParseRate(dto.Rate); err != nil {
+				v.fail("rate", err.Error())
+			}
+		}
+		if dto.Base != "" && dto.Quote != "" && !dto.EffectiveAt.IsZero() {
+			key := rateKey(dto.Base, dto.Quote, dto.EffectiveAt)
+			if line, ok := seen[key]; ok {
+				v.fail("effectiveAt", fmt.Sprintf("Duplicates the rate of line %d", line))
+			}
+			seen[key] = row.Line
+			if refs.rates[key] {
+				v.fail("effectiveAt", "A rate of this pair is already effective from this time")
+			}
+		}
+		if v.valid {
+			rows = append(rows, importRow{line: row.Line, exchangeRate: dto})
+		}
+	}
+	return rows
+}
+
+// resolveCity returns the city of a row, given by its official code or by its name along with
+// the name or code of its state when several cities share that name
+func (refs *importReferences) resolveCity(v *rowValidator) *models.City {
+	if code := v.optional("cityCode"); code != "" {
+		city, ok := refs.citiesByCode[lowerKey(code)]
+		if !ok {
+			v.fail("cityCode", "Unknown city code "+code)
+		}
+		return city
+	}
+	name := v.required("city", "cityName")
+	if name == "" {
+		return nil
+	}
+	state := lowerKey(v.optional("state", "stateName", "stateCode"))
+	var matches []*models.City
+	for _, city := range refs.citiesByName[lowerKey(name)] {
+		cityState := refs.states[city.CountryStateID]
+		if state == "" || state == lowerKey(cityState.StateName) || state == lowerKey(cityState.StateCode) {
+			matches = append(matches, city)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		v.fail("city", "Unknown city "+name)
+		return nil
+	case 1:
+		return matches[0]
+	default:
+		v.fail("city", "Several cities are named "+name+", add a state or cityCode column")
+		return nil
+	}
+}
+
+// resolveSupplier returns the supplier of a row given by its document number, along with its
+// document type when several suppliers share that number
+func (refs *importReferences) resolveSupplier(v *rowValidator) *models.Supplier {
+	number := v.required("supplierDocumentNumber", "documentNumber")
+	if number == "" {
+		return nil
+	}
+	documentType := v.optional("supplierDocumentType", "documentType")
+	var matches []*models.Supplier
+	for _, supplier := range refs.suppliers[lowerKey(number)] {
+		if documentType == "" || strings.EqualFold(supplier.DocumentType, documentType) {
+			matches = append(matches, supplier)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		v.fail("supplierDocumentNumber", "No supplier is registered with the document "+number)
+		return nil
+	case 1:
+		return matches[0]
+	default:
+		v.fail("supplierDocumentNumber", "Several suppliers have the document "+number+", add a supplierDocumentType column")
+		return nil
+	}
+}
+
+// rowValidator reads the values of a row and reports its errors to the job
+type rowValidator struct {
+	job   *models.ImportJob
+	table *spreadsheet.Table
+	row   spreadsheet.Row
+	valid bool
+}
+
+func newRowValidator(job *models.ImportJob, table *spreadsheet.Table, row spreadsheet.Row) *rowValidator {
+	return &rowValidator{job: job, table: table, row: row, valid: true}
+}
+
+func (v *rowValidator) fail(column string, message string) {
+	if v.valid {
+		v.job.InvalidRows++
+	}
+	v.valid = false
+	addImportError(v.job, v.row.Line, column, message)
+}
+
+func (v *rowValidator) optional(columns ...string) string {
+	return v.table.Value(v.row, columns...)
+}
+
+func (v *rowValidator) required(columns ...string) string {
+	value := v.optional(columns...)
+	if value == "" {
+		v.fail(columns[0], "Is required")
+	}
+	return value
+}
+
+// date reads a required date written as YYYY-MM-DD or as an RFC 3339 timestamp
+func (v *rowValidator) date(column string) time.Time {
+	value := v.required(column)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	v.fail(column, "Is not a date, expected YYYY-MM-DD")
+	return time.Time{}
+}
+
+// currency reads a required ISO 4217 code, in upper case
+func (v *rowValidator) currency(column string) string {
+	value := v.required(column)
+	if value == "" {
+		return ""
+	}
+	code, err := money.ParseCurrency(value)
+	if err != nil {
+		v.fail(column, err.Error())
+	}
+	return code
+}
+
+func addImportError(job *models.ImportJob, line int, column string, message string) {
+	if len(job.Errors) >= maxImportErrors {
+		job.ErrorsTruncated = true
+		return
+	}
+	job.Errors = append(job.Errors, models.ImportRowError{Line: line, Column: column, Message: message})
+}
+
+func lowerKey(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// rateKey identifies the exchange rate of a pair effective from a time
+func rateKey(base string, quote string, effectiveAt time.Time) string {
+	return base + "/" + quote + "/" + effectiveAt.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+}
+
+// catalogTerms returns the search terms of an item or a variant, its folded name when it has none
+func catalogTerms(name string, terms []string) []string {
+	if len(terms) == 0 {
+		return []string{i18n.Fold(name)}
+	}
+	return terms
+}
+
+// NewImportService creates an import service with necessary dependencies.
+func NewImportService(
+	importJobRepository *store.MongoImportJobRepository,
+	supplierService *SupplierService,
+	cropService *CropService,
+	countryService *CountryService,
+	cityService *CityService,
+	itemService *ItemService,
+	variantService *VariantService,
+	exchangeRateService *ExchangeRateService,
+) *ImportService {
+	return &ImportService{importJobRepository, supplierService, cropService, countryService, cityService, itemService, variantService, exchangeRateService}
+}
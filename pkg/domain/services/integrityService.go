@@ -0,0 +1,256 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DeleteRestrict refuses to delete a record while other records reference it
+	DeleteRestrict = "restrict"
+	// DeleteCascade deletes the records referencing a deleted record, applying their own policies
+	DeleteCascade = "cascade"
+	// DeleteNullify removes the reference to a deleted record from the records holding it
+	DeleteNullify = "nullify"
+	// DeleteDeactivate sets the record status of the records referencing a deleted record to inactive
+	DeleteDeactivate = "deactivate"
+)
+
+// relation is a reference held by the field of the child records to a parent record, policy
+// tells what becomes of the children when the parent is deleted
+type relation struct {
+	parent string
+	child  string
+	field  string
+	policy string
+}
+
+// Reference is the ID of a record held by a field of an input, the ID must identify an active
+// record of one of the resource types
+type Reference struct {
+	Field     string
+	ID        *primitive.ObjectID
+	Resources []string
+}
+
+// NewReference returns the reference of field to a record of one of the resource types, a nil
+// or zero ID references nothing
+func NewReference(field string, id *primitive.ObjectID, resources ...string) Reference {
+	return Reference{Field: field, ID: id, Resources: resources}
+}
+
+// NewHexReference returns the reference of field to the record with the hex ID, an invalid ID
+// references nothing and is left to the repositories to reject
+func NewHexReference(field string, id string, resources ...string) Reference {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return NewReference(field, nil, resources...)
+	}
+	return NewReference(field, &oid, resources...)
+}
+
+// IntegrityService keeps the references between records consistent, it checks the references of
+// the records written and applies the delete policies of the relations to the records
+// referencing a deleted one
+type IntegrityService struct {
+	repository *store.MongoReferenceRepository
+	audit      *AuditService
+	events     *EventService
+	relations  []relation
+}
+
+// childEvents are the events emitted about the records a delete policy is applied to, by resource
+// type, the resource types without events are only audited
+var childEvents = map[string]struct{ updated, deleted enums.EnumEventType }{
+	models.ResourceSupplier: {enums.SupplierUpdated, enums.SupplierDeleted},
+	models.ResourceCity:     {enums.CityUpdated, enums.CityDeleted},
+	models.ResourceVariant:  {enums.VariantUpdated, enums.VariantDeleted},
+	models.ResourceCrop:     {enums.CropUpdated, enums.CropDeleted},
+}
+
+// CheckReferences returns an errs.KindValidation error about the field of the first reference
+// that does not identify an active record. It must run in the unit of work of the write holding
+// the references: the records referenced are locked until it is done, a concurrent delete of one
+// of them conflicts with it.
+func (s *IntegrityService) CheckReferences(ctx context.Context, references ...Reference) error {
+	for _, reference := range references {
+		if reference.ID == nil || reference.ID.IsZero() {
+			continue
+		}
+		active := false
+		for _, resource := range reference.Resources {
+			var err error
+			if active, err = s.repository.LockActive(ctx, resource, *reference.ID); err != nil {
+				return err
+			}
+			if active {
+				break
+			}
+		}
+		if !active {
+			return errs.InvalidReference(reference.Field, reference.Resources[0])
+		}
+	}
+	return nil
+}
+
+// DeletePlan lists what a delete does to the records referencing the deleted ones
+type DeletePlan struct {
+	steps []deleteStep
+}
+
+type deleteStep struct {
+	relation relation
+	ids      []primitive.ObjectID
+}
+
+// Effects describes what the plan does to the records referencing the deleted ones, one line per
+// relation, for operators to review a delete before applying it
+func (p *DeletePlan) Effects() []string {
+	effects := make([]string, 0, len(p.steps))
+	for _, step := range p.steps {
+		action := "delete"
+		switch step.relation.policy {
+		case DeleteNullify:
+			action = "clear the " + step.relation.field + " of"
+		case DeleteDeactivate:
+			action = "deactivate"
+		}
+		effects = append(effects, fmt.Sprintf("%s %d %s records referencing a deleted %s", action, len(step.ids), step.relation.child, step.relation.parent))
+	}
+	return effects
+}
+
+// PlanDelete returns what deleting the records of a resource type does to the records referencing
+// them, following the cascades. It returns an errs.KindConflict error when a restricted relation
+// forbids the delete, before anything is changed. The plan must be made in the unit of work of the
+// delete, for the records referencing the deleted ones not to change before it is applied.
+func (s *IntegrityService) PlanDelete(ctx context.Context, resource string, ids ...primitive.ObjectID) (*DeletePlan, error) {
+	plan := &DeletePlan{}
+	if err := s.plan(ctx, plan, resource, ids); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (s *IntegrityService) plan(ctx context.Context, plan *DeletePlan, resource string, ids []primitive.ObjectID) error {
+	for _, rel := range s.relations {
+		if rel.parent != resource || len(ids) == 0 {
+			continue
+		}
+		children, err := s.repository.FindReferencing(ctx, rel.child, rel.field, ids)
+		if err != nil {
+			return err
+		}
+		if len(children) == 0 {
+			continue
+		}
+		if rel.policy == DeleteRestrict {
+			return errs.Conflictf(nil, "The %s is referenced by %d %s records, delete them first", resource, len(children), rel.child)
+		}
+		plan.steps = append(plan.steps, deleteStep{relation: rel, ids: children})
+		if rel.policy == DeleteCascade {
+			if err := s.plan(ctx, plan, rel.child, children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyDelete applies a plan once the records it was planned for are deleted, the records
+// referenced by the cascaded ones are handled first. Every record changed is audited and has its
+// event published in the unit of work of ctx, which must be the one of the delete.
+func (s *IntegrityService) ApplyDelete(ctx context.Context, plan *DeletePlan) error {
+	logger := logging.FromContext(ctx)
+	for i := len(plan.steps) - 1; i >= 0; i-- {
+		step := plan.steps[i]
+		before, err := s.repository.Find(ctx, step.relation.child, step.ids)
+		if err != nil {
+			return err
+		}
+		var count int64
+		switch step.relation.policy {
+		case DeleteCascade:
+			count, err = s.repository.Delete(ctx, step.relation.child, step.ids)
+		case DeleteNullify:
+			count, err = s.repository.Nullify(ctx, step.relation.child, step.relation.field, step.ids)
+		case DeleteDeactivate:
+			count, err = s.repository.Deactivate(ctx, step.relation.child, step.ids)
+		}
+		if err != nil {
+			return err
+		}
+		after := map[primitive.ObjectID]interface{}{}
+		if step.relation.policy != DeleteCascade {
+			if after, err = s.repository.Find(ctx, step.relation.child, step.ids); err != nil {
+				return err
+			}
+		}
+		for _, id := range step.ids {
+			// A record deleted by a later step of the plan is already gone
+			if record, ok := before[id]; ok {
+				if err := s.recordChange(ctx, step.relation, id, record, after[id]); err != nil {
+					return err
+				}
+			}
+		}
+		logger.WithField("parent", step.relation.parent).WithField("child", step.relation.child).
+			WithField("policy", step.relation.policy).WithField("count", count).Info("Applied a delete policy")
+	}
+	return nil
+}
+
+// recordChange audits the change a delete policy made to a record and publishes its event, after
+// is nil when the record was deleted
+func (s *IntegrityService) recordChange(ctx context.Context, rel relation, id primitive.ObjectID, before interface{}, after interface{}) error {
+	action := enums.AuditUpdate
+	if after == nil {
+		action = enums.AuditDelete
+	}
+	if err := s.audit.Record(ctx, action, rel.child, id.Hex(), before, after); err != nil {
+		return err
+	}
+	events, ok := childEvents[rel.child]
+	if !ok {
+		return nil
+	}
+	if after == nil {
+		return s.events.Publish(ctx, events.deleted, rel.child, id.Hex(), before)
+	}
+	return s.events.Publish(ctx, events.updated, rel.child, id.Hex(), after)
+}
+
+// NewIntegrityService creates an integrity service with the delete policies of the configuration
+func NewIntegrityService(
+	confPtr *config.Config,
+	repository *store.MongoReferenceRepository,
+	auditService *AuditService,
+	eventService *EventService,
+) *IntegrityService {
+	policies := confPtr.Integrity
+	return &IntegrityService{
+		repository: repository,
+		audit:      auditService,
+		events:     eventService,
+		relations: []relation{
+			{parent: models.ResourceItem, child: models.ResourceVariant, field: "itemId", policy: policies.ItemVariants},
+			{parent: models.ResourceVariant, child: models.ResourceCrop, field: "variantId", policy: policies.VariantCrops},
+			{parent: models.ResourceCountryState, child: models.ResourceCity, field: "countryStateId", policy: policies.StateCities},
+			{parent: models.ResourceCity, child: models.ResourceCrop, field: "cityId", policy: policies.CityCrops},
+			{parent: models.ResourceCity, child: models.ResourceSupplier, field: "cityId", policy: policies.CitySuppliers},
+			{parent: models.ResourceCity, child: models.ResourceUser, field: "cityId", policy: policies.CityUsers},
+			{parent: models.ResourceSupplier, child: models.ResourceCrop, field: "supplierId", policy: policies.SupplierCrops},
+			{parent: models.ResourceUser, child: models.ResourceCrop, field: "supplierId", policy: policies.UserCrops},
+		},
+	}
+}
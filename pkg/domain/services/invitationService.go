@@ -0,0 +1,210 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// invitationTokenPrefix marks the strings issued by this service as invitation tokens
+const invitationTokenPrefix = "inv_"
+
+var (
+	// ErrInvalidInvitationEmail is returned when an invitation is not addressed to an email
+	ErrInvalidInvitationEmail = errs.Validation("Invalid invitation email")
+	// ErrWrongCredentials is returned when the invitee does not prove it owns the invited email
+	ErrWrongCredentials = errors.New("Wrong user or password")
+)
+
+// InvitationService implements use cases methods and domain business logic for the invitations
+// to join an organization. The tokens are returned to the inviter, who sends them to the invitee.
+type InvitationService struct {
+	repository    *store.MongoInvitationRepository
+	organizations *store.MongoOrganizationRepository
+	auth          *AuthService
+	audit         *AuditService
+	unitOfWork    UnitOfWork
+	ttl           time.Duration
+}
+
+// FindInvitations returns the invitations to join an organization the caller manages, most
+// recent first
+func (s *InvitationService) FindInvitations(ctx context.Context, organizationID string) ([]*models.Invitation, error) {
+	organization, err := findManagedOrganization(ctx, s.organizations, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.FindByOrganization(ctx, organization.ID)
+}
+
+// CreateInvitation invites an email address to join an organization with a role and issues the
+// token that accepts the invitation, it expires after the TTL of the configuration
+func (s *InvitationService) CreateInvitation(ctx context.Context, organizationID string, dto *dtos.InvitationDto) (*models.IssuedInvitation, error) {
+	email := strings.ToLower(strings.TrimSpace(dto.Email))
+	if !strings.Contains(email, "@") {
+		return nil, ErrInvalidInvitationEmail
+	}
+	organization, role, err := findMemberOrganization(ctx, s.organizations, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeRoleChange(role, dto.Role); err != nil {
+		return nil, err
+	}
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &models.Invitation{
+		OrganizationID: organization.ID,
+		Email:          email,
+		Role:           dto.Role,
+		HashedToken:    hashAPIKey(token),
+		InvitedBy:      auditActor(ctx),
+		ExpiresAt:      time.Now().Add(s.ttl),
+	}
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		id, err := s.repository.Insert(ctx, invitation)
+		if err != nil {
+			return err
+		}
+		if invitation, err = s.repository.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, enums.AuditCreate, models.ResourceInvitation, id, nil, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.IssuedInvitation{Invitation: invitation, Token: token}, nil
+}
+
+// RevokeInvitation deletes an invitation to join an organization, its token stops working
+func (s *InvitationService) RevokeInvitation(ctx context.Context, organizationID string, id string) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		organization, err := findManagedOrganization(ctx, s.organizations, organizationID)
+		if err != nil {
+			return err
+		}
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil || before.OrganizationID != organization.ID {
+			return errs.NotFound("Invitation")
+		}
+		deleted, err := s.repository.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Invitation")
+		}
+		return s.audit.Record(ctx, enums.AuditDelete, models.ResourceInvitation, id, before, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AcceptInvitation makes the user registered with the invited email a member of the organization,
+// the user proves it is the invitee with its credentials. An invitation is accepted once, before
+// it expires, a user who is already a member keeps its role.
+func (s *InvitationService) AcceptInvitation(ctx context.Context, dto *dtos.AcceptInvitationDto) (*models.Organization, error) {
+	invitation, err := s.repository.FindByHashedToken(ctx, hashAPIKey(dto.Token))
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, errs.NotFound("Invitation")
+	}
+	user, err := s.auth.CheckCredentials(ctx, &dtos.LoginDto{Email: dto.Email, Password: dto.Password})
+	if err != nil {
+		if errors.Cause(err) == bcrypt.ErrMismatchedHashAndPassword || errors.Cause(err) == bcrypt.ErrHashTooShort {
+			return nil, ErrWrongCredentials
+		}
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrWrongCredentials
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errs.Forbidden("The invitation was sent to another email")
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, errs.Conflict("The invitation has already been accepted", nil)
+	}
+	now := time.Now()
+	if !invitation.ExpiresAt.After(now) {
+		return nil, errs.Conflict("The invitation has expired", nil)
+	}
+
+	organizationID := invitation.OrganizationID.Hex()
+	var before, organization *models.Organization
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		accepted, err := s.repository.MarkAccepted(ctx, invitation.ID, user.ID, now)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return errs.Conflict("The invitation has already been accepted", nil)
+		}
+		if before, err = s.organizations.FindByID(ctx, organizationID); err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Organization")
+		}
+		membership := &models.Membership{MemberType: models.MemberUser, MemberID: user.ID, Role: invitation.Role, JoinedAt: now}
+		if organization, err = s.organizations.AddMember(ctx, organizationID, membership, nil); err != nil {
+			return err
+		}
+		if organization == nil {
+			// The user was already a member
+			return nil
+		}
+		return s.audit.Record(ctx, enums.AuditUpdate, models.ResourceOrganization, organizationID, before, organization)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return before, nil
+	}
+	return organization, nil
+}
+
+// NewInvitationService creates an invitation service with necessary dependencies.
+func NewInvitationService(
+	confPtr *config.Config,
+	repository *store.MongoInvitationRepository,
+	organizations *store.MongoOrganizationRepository,
+	authService *AuthService,
+	auditService *AuditService,
+	unitOfWork UnitOfWork,
+) *InvitationService {
+	return &InvitationService{repository, organizations, authService, auditService, unitOfWork, confPtr.Auth.InvitationTTL}
+}
+
+// generateInvitationToken returns a new random token, only its hash is stored like for API keys
+func generateInvitationToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "Error generating an invitation token")
+	}
+	return invitationTokenPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
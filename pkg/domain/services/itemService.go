@@ -2,19 +2,36 @@
 package services
 
 import (
+	"context"
+	"strings"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/i18n"
 	"futuagro.com/pkg/store"
 )
 
 // ItemService implements use cases methods and domain business logic for items
 type ItemService struct {
 	repository *store.MongoItemRepository
+	audit      *AuditService
+	events     *EventService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 // FindItemByID returns an Item by its ID
 func (s *ItemService) FindItemByID(id string) (*models.Item, error) {
-	return s.repository.FindByID(id)
+	item, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, errs.NotFound("Item")
+	}
+	return item, nil
 }
 
 // FindAllItems returns a list of items
@@ -22,22 +39,174 @@ func (s *ItemService) FindAllItems() ([]*models.Item, error) {
 	return s.repository.FindAll()
 }
 
-// CreateItem create a new Item record
-func (s *ItemService) CreateItem(dto *dtos.ItemDto) (string, error) {
-	return s.repository.Insert(dto)
+// SearchItems returns the items having a name or a synonym in any locale with a word starting with
+// term, whatever its case and accents
+func (s *ItemService) SearchItems(term string) ([]*models.Item, error) {
+	return s.repository.FindByTerm(term)
+}
+
+// EachItem calls fn with every item and its variants until fn returns an error
+func (s *ItemService) EachItem(ctx context.Context, fn func(*models.Item) error) error {
+	return s.repository.Each(ctx, fn)
 }
 
-// UpdateItemByID update an item data by its id
-func (s *ItemService) UpdateItemByID(id string, itemDto *dtos.ItemDto) (*models.Item, error) {
-	return s.repository.Update(id, itemDto)
+// CreateItem create a new Item record, the item, its audit entry and its event are written in one
+// unit of work
+func (s *ItemService) CreateItem(ctx context.Context, dto *dtos.ItemDto) (string, error) {
+	names, err := normalizeLocalizedNames(dto.Names)
+	if err != nil {
+		return "", err
+	}
+	dto.Names = names
+
+	var id string
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if id, err = s.repository.Insert(ctx, dto); err != nil {
+			return err
+		}
+
+		after, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceItem, id, nil, after); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.ItemCreated, models.ResourceItem, id, after)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UpdateItemByID update an item data by its id, versions optionally restricts the write to
+// the given stored versions of the document
+func (s *ItemService) UpdateItemByID(ctx context.Context, id string, itemDto *dtos.ItemDto, versions []int64) (*models.Item, error) {
+	var result *models.Item
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Item")
+		}
+		if itemDto.Names == nil {
+			itemDto.Names = localizedNameDtos(before.Names)
+		}
+		if itemDto.Names, err = normalizeLocalizedNames(itemDto.Names); err != nil {
+			return err
+		}
+
+		if result, err = s.repository.Update(ctx, id, itemDto, versions); err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("Item")
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceItem, id, before, result); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.ItemUpdated, models.ResourceItem, id, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-// DeleteItemByID delete an item by id
-func (s *ItemService) DeleteItemByID(id string) (bool, error) {
-	return s.repository.Delete(id)
+// DeleteItemByID delete an item by id, versions optionally restricts the delete to the given
+// stored versions of the document
+func (s *ItemService) DeleteItemByID(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Item")
+		}
+
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceItem, before.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Item")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditDelete, models.ResourceItem, id, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.ItemDeleted, models.ResourceItem, id, before)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // NewItemService creates an Item service with necessary dependencies.
-func NewItemService(repository *store.MongoItemRepository) *ItemService {
-	return &ItemService{repository}
+func NewItemService(
+	repository *store.MongoItemRepository,
+	auditService *AuditService,
+	eventService *EventService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *ItemService {
+	return &ItemService{repository, auditService, eventService, integrityService, unitOfWork}
+}
+
+// normalizeLocalizedNames validates the localized names of an item or a variant, their locales are
+// canonicalized and their synonyms are trimmed and listed once
+func normalizeLocalizedNames(names []dtos.LocalizedNameDto) ([]dtos.LocalizedNameDto, error) {
+	normalized := make([]dtos.LocalizedNameDto, 0, len(names))
+	locales := map[string]bool{}
+	for _, localized := range names {
+		locale, err := i18n.ParseLocale(localized.Locale)
+		if err != nil {
+			return nil, errs.Validationf("Invalid locale %q of a localized name", localized.Locale)
+		}
+		if locales[locale] {
+			return nil, errs.Validationf("Several localized names have the locale %s", locale)
+		}
+		locales[locale] = true
+		name := strings.TrimSpace(localized.Name)
+		if name == "" {
+			return nil, errs.Validationf("The localized name %s has no name", locale)
+		}
+
+		synonyms := []string{}
+		seen := map[string]bool{i18n.Fold(name): true}
+		for _, synonym := range localized.Synonyms {
+			synonym = strings.TrimSpace(synonym)
+			if term := i18n.Fold(synonym); term != "" && !seen[term] {
+				seen[term] = true
+				synonyms = append(synonyms, synonym)
+			}
+		}
+		normalized = append(normalized, dtos.LocalizedNameDto{Locale: locale, Name: name, Synonyms: synonyms})
+	}
+	return normalized, nil
+}
+
+// localizedNameDtos returns the stored localized names of an item or a variant as written by a dto
+func localizedNameDtos(names []models.LocalizedName) []dtos.LocalizedNameDto {
+	dtoNames := make([]dtos.LocalizedNameDto, len(names))
+	for i, localized := range names {
+		dtoNames[i] = dtos.LocalizedNameDto{Locale: localized.Locale, Name: localized.Name, Synonyms: localized.Synonyms}
+	}
+	return dtoNames
 }
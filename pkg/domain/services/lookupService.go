@@ -0,0 +1,37 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+
+	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LookupService reads the records of any resource type by pages or by batches of IDs, for the
+// transports resolving the relations of many records at once such as the GraphQL API
+type LookupService struct {
+	repository *store.MongoLookupRepository
+}
+
+// Page decodes into results at most limit records of a resource type following the after ID,
+// results is a pointer to a slice of pointers to the model of the resource type
+func (s *LookupService) Page(ctx context.Context, resource string, after *primitive.ObjectID, limit int64, results interface{}) error {
+	return s.repository.Page(ctx, resource, after, limit, results)
+}
+
+// FindIn decodes into results the records of a resource type whose field holds one of the IDs,
+// results is a pointer to a slice of pointers to the model of the resource type
+func (s *LookupService) FindIn(ctx context.Context, resource string, field string, ids []primitive.ObjectID, results interface{}) error {
+	return s.repository.FindIn(ctx, resource, field, ids, results)
+}
+
+// Count returns the number of records of a resource type
+func (s *LookupService) Count(ctx context.Context, resource string) (int64, error) {
+	return s.repository.Count(ctx, resource)
+}
+
+// NewLookupService creates a lookup service with necessary dependencies.
+func NewLookupService(repository *store.MongoLookupRepository) *LookupService {
+	return &LookupService{repository}
+}
@@ -0,0 +1,409 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrInvalidOrganization is returned when an organization has no name or an unknown kind
+	ErrInvalidOrganization = errs.Validation("Invalid organization, it needs a name and a kind among buyer and cooperative")
+	// ErrInvalidMemberRole is returned when a member is given a role outside the roles of the members
+	ErrInvalidMemberRole = errs.Validation("Invalid member role")
+	// ErrInvalidMemberType is returned when a member is neither a user nor an API client
+	ErrInvalidMemberType = errs.Validation("Invalid member type, it must be user or api-client")
+	// ErrLastOwner is returned when a change would leave an organization without an owner
+	ErrLastOwner = errs.Conflict("An organization keeps at least one owner", nil)
+)
+
+// OrganizationService implements use cases methods and domain business logic for organizations
+// and their members
+type OrganizationService struct {
+	repository  *store.MongoOrganizationRepository
+	invitations *store.MongoInvitationRepository
+	audit       *AuditService
+	integrity   *IntegrityService
+	unitOfWork  UnitOfWork
+}
+
+// FindOrganizations returns the organizations the caller is a member of, the operators of the
+// platform get every organization
+func (s *OrganizationService) FindOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	principal := PrincipalFromContext(ctx)
+	if isOperator(principal) {
+		return s.repository.FindAll(ctx)
+	}
+	memberType, memberID, ok := principalMember(principal)
+	if !ok {
+		return []*models.Organization{}, nil
+	}
+	return s.repository.FindByMember(ctx, memberType, memberID)
+}
+
+// FindOrganizationByID returns an organization the caller is a member of by its ID
+func (s *OrganizationService) FindOrganizationByID(ctx context.Context, id string) (*models.Organization, error) {
+	organization, _, err := s.findAuthorized(ctx, id)
+	return organization, err
+}
+
+// CreateOrganization create a new organization, an API client creating it becomes its owner
+func (s *OrganizationService) CreateOrganization(ctx context.Context, dto *dtos.OrganizationDto) (*models.Organization, error) {
+	if err := validateOrganization(dto); err != nil {
+		return nil, err
+	}
+	return s.inUnitOfWork(ctx, func(ctx context.Context) (*models.Organization, error) {
+		var owner *models.Membership
+		if memberType, memberID, ok := principalMember(PrincipalFromContext(ctx)); ok {
+			owner = &models.Membership{MemberType: memberType, MemberID: memberID, Role: enums.MemberOwner, JoinedAt: time.Now()}
+		}
+		id, err := s.repository.Insert(ctx, dto, owner)
+		if err != nil {
+			return nil, err
+		}
+		organization, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceOrganization, id, nil, organization); err != nil {
+			return nil, err
+		}
+		return organization, nil
+	})
+}
+
+// UpdateOrganization update the name, kind or status of an organization, versions optionally
+// restricts the write to the given stored versions of the document
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, id string, dto *dtos.OrganizationDto, versions []int64) (*models.Organization, error) {
+	if err := validateOrganization(dto); err != nil {
+		return nil, err
+	}
+	return s.inUnitOfWork(ctx, func(ctx context.Context) (*models.Organization, error) {
+		before, err := findManagedOrganization(ctx, s.repository, id)
+		if err != nil {
+			return nil, err
+		}
+		organization, err := s.repository.Update(ctx, id, dto, versions)
+		if err != nil {
+			return nil, err
+		}
+		if organization == nil {
+			return nil, errs.NotFound("Organization")
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceOrganization, id, before, organization); err != nil {
+			return nil, err
+		}
+		return organization, nil
+	})
+}
+
+// DeleteOrganization delete an organization by id along with its invitations, only its owners
+// can delete it
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, role, err := s.findAuthorized(ctx, id)
+		if err != nil {
+			return err
+		}
+		if role != enums.MemberOwner {
+			return errs.Forbidden("Only the owners can delete an organization")
+		}
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Organization")
+		}
+		if _, err := s.invitations.DeleteByOrganization(ctx, before.ID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, enums.AuditDelete, models.ResourceOrganization, id, before, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddMember adds an existing user or API client to an organization, only an owner can add another
+// owner
+func (s *OrganizationService) AddMember(ctx context.Context, id string, dto *dtos.MembershipDto, versions []int64) (*models.Organization, error) {
+	if dto.MemberType != models.MemberUser && dto.MemberType != models.MemberAPIClient {
+		return nil, ErrInvalidMemberType
+	}
+	return s.inUnitOfWork(ctx, func(ctx context.Context) (*models.Organization, error) {
+		before, role, err := s.findAuthorized(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorizeRoleChange(role, dto.Role); err != nil {
+			return nil, err
+		}
+		resource := models.ResourceUser
+		if dto.MemberType == models.MemberAPIClient {
+			resource = models.ResourceAPIClient
+		}
+		if err := s.integrity.CheckReferences(ctx, NewReference("memberId", &dto.MemberID, resource)); err != nil {
+			return nil, err
+		}
+
+		membership := &models.Membership{MemberType: dto.MemberType, MemberID: dto.MemberID, Role: dto.Role, JoinedAt: time.Now()}
+		organization, err := s.repository.AddMember(ctx, id, membership, versions)
+		if err != nil {
+			return nil, err
+		}
+		if organization == nil {
+			return nil, errs.Conflict("The member already belongs to the organization", nil)
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceOrganization, id, before, organization); err != nil {
+			return nil, err
+		}
+		return organization, nil
+	})
+}
+
+// UpdateMember changes the role of a member of an organization, only an owner can grant or revoke
+// the owner role and the last owner keeps it
+func (s *OrganizationService) UpdateMember(ctx context.Context, id string, memberID string, dto *dtos.MemberRoleDto, versions []int64) (*models.Organization, error) {
+	return s.inUnitOfWork(ctx, func(ctx context.Context) (*models.Organization, error) {
+		before, role, err := s.findAuthorized(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		member, err := findMember(before, memberID)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorizeRoleChange(role, dto.Role); err != nil {
+			return nil, err
+		}
+		if member.Role == enums.MemberOwner && dto.Role != enums.MemberOwner {
+			if role != enums.MemberOwner {
+				return nil, errs.Forbidden("Only the owners can revoke the owner role")
+			}
+			if countOwners(before) == 1 {
+				return nil, ErrLastOwner
+			}
+		}
+
+		organization, err := s.repository.UpdateMember(ctx, id, member.MemberID, dto.Role, versions)
+		if err != nil {
+			return nil, err
+		}
+		if organization == nil {
+			return nil, errs.NotFound("Member")
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceOrganization, id, before, organization); err != nil {
+			return nil, err
+		}
+		return organization, nil
+	})
+}
+
+// RemoveMember removes a member from an organization, the members can leave by themselves and the
+// last owner cannot leave
+func (s *OrganizationService) RemoveMember(ctx context.Context, id string, memberID string, versions []int64) (*models.Organization, error) {
+	return s.inUnitOfWork(ctx, func(ctx context.Context) (*models.Organization, error) {
+		before, role, err := s.findAuthorized(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		member, err := findMember(before, memberID)
+		if err != nil {
+			return nil, err
+		}
+		memberType, principalID, _ := principalMember(PrincipalFromContext(ctx))
+		leaving := member.MemberType == memberType && member.MemberID == principalID
+		if !leaving && !role.CanManage() {
+			return nil, errs.Forbidden("Only the owners and the admins can remove the members of an organization")
+		}
+		if member.Role == enums.MemberOwner {
+			if !leaving && role != enums.MemberOwner {
+				return nil, errs.Forbidden("Only the owners can remove an owner")
+			}
+			if countOwners(before) == 1 {
+				return nil, ErrLastOwner
+			}
+		}
+
+		organization, err := s.repository.RemoveMember(ctx, id, member.MemberID, versions)
+		if err != nil {
+			return nil, err
+		}
+		if organization == nil {
+			return nil, errs.NotFound("Member")
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceOrganization, id, before, organization); err != nil {
+			return nil, err
+		}
+		return organization, nil
+	})
+}
+
+// inUnitOfWork runs a write of an organization and its audit entry in one unit of work, write
+// returns the organization after the change
+func (s *OrganizationService) inUnitOfWork(ctx context.Context, write func(ctx context.Context) (*models.Organization, error)) (*models.Organization, error) {
+	var organization *models.Organization
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		organization, err = write(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// ActivateOrganization returns a copy of principal acting for an organization with the role it
+// holds in it, the operators of the platform act as owners of every organization
+func (s *OrganizationService) ActivateOrganization(ctx context.Context, principal *models.Principal, id string) (*models.Principal, error) {
+	organization, role, err := s.findAuthorized(WithPrincipal(ctx, principal), id)
+	if err != nil {
+		if errs.Is(err, errs.KindNotFound) || errs.Is(err, errs.KindInvalidID) {
+			return nil, errs.Forbiddenf("not a member of the organization %s", id)
+		}
+		return nil, err
+	}
+	if organization.RecordStatus != nil && *organization.RecordStatus != enums.Active {
+		return nil, errs.Forbiddenf("the organization %s is inactive", id)
+	}
+	active := *principal
+	active.OrganizationID = organization.ID.Hex()
+	active.OrganizationRole = role
+	return &active, nil
+}
+
+// NewOrganizationService creates an organization service with necessary dependencies.
+func NewOrganizationService(
+	repository *store.MongoOrganizationRepository,
+	invitations *store.MongoInvitationRepository,
+	auditService *AuditService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *OrganizationService {
+	return &OrganizationService{repository, invitations, auditService, integrityService, unitOfWork}
+}
+
+// findAuthorized returns an organization along with the role the caller holds in it, the
+// organization is not found for the callers that are not members
+func (s *OrganizationService) findAuthorized(ctx context.Context, id string) (*models.Organization, enums.EnumMemberRole, error) {
+	return findMemberOrganization(ctx, s.repository, id)
+}
+
+// findMemberOrganization returns an organization along with the role the caller of ctx holds in
+// it, it is not found for the callers that are not members
+func findMemberOrganization(ctx context.Context, repository *store.MongoOrganizationRepository, id string) (*models.Organization, enums.EnumMemberRole, error) {
+	organization, err := repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if organization == nil {
+		return nil, "", errs.NotFound("Organization")
+	}
+	principal := PrincipalFromContext(ctx)
+	if isOperator(principal) {
+		return organization, enums.MemberOwner, nil
+	}
+	memberType, memberID, ok := principalMember(principal)
+	if !ok {
+		return nil, "", errs.NotFound("Organization")
+	}
+	member := organization.Member(memberType, memberID)
+	if member == nil {
+		return nil, "", errs.NotFound("Organization")
+	}
+	return organization, member.Role, nil
+}
+
+// findManagedOrganization returns an organization the caller of ctx is an owner or an admin of
+func findManagedOrganization(ctx context.Context, repository *store.MongoOrganizationRepository, id string) (*models.Organization, error) {
+	organization, role, err := findMemberOrganization(ctx, repository, id)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanManage() {
+		return nil, errs.Forbidden("Only the owners and the admins can manage an organization")
+	}
+	return organization, nil
+}
+
+// isOperator tells whether a principal operates the platform, operators manage every organization
+func isOperator(principal *models.Principal) bool {
+	return principal != nil && (principal.Type == models.PrincipalSystem || principal.HasScope(enums.AllScopes))
+}
+
+// principalMember returns the member type and ID of a principal that can be a member of an
+// organization
+func principalMember(principal *models.Principal) (string, primitive.ObjectID, bool) {
+	if principal == nil {
+		return "", primitive.NilObjectID, false
+	}
+	var memberType string
+	switch principal.Type {
+	case models.PrincipalAPIClient:
+		memberType = models.MemberAPIClient
+	case models.PrincipalUser:
+		memberType = models.MemberUser
+	default:
+		return "", primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(principal.ID)
+	if err != nil {
+		return "", primitive.NilObjectID, false
+	}
+	return memberType, id, true
+}
+
+// authorizeRoleChange checks that a member holding role can give another member the role granted
+func authorizeRoleChange(role enums.EnumMemberRole, granted enums.EnumMemberRole) error {
+	if !granted.IsValid() {
+		return ErrInvalidMemberRole
+	}
+	if !role.CanManage() {
+		return errs.Forbidden("Only the owners and the admins can manage the members of an organization")
+	}
+	if granted == enums.MemberOwner && role != enums.MemberOwner {
+		return errs.Forbidden("Only the owners can grant the owner role")
+	}
+	return nil
+}
+
+func findMember(organization *models.Organization, memberID string) (*models.Membership, error) {
+	id, err := primitive.ObjectIDFromHex(memberID)
+	if err != nil {
+		return nil, errs.InvalidID(memberID, err)
+	}
+	for i := range organization.Members {
+		if organization.Members[i].MemberID == id {
+			return &organization.Members[i], nil
+		}
+	}
+	return nil, errs.NotFound("Member")
+}
+
+func countOwners(organization *models.Organization) int {
+	owners := 0
+	for _, member := range organization.Members {
+		if member.Role == enums.MemberOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+func validateOrganization(dto *dtos.OrganizationDto) error {
+	if strings.TrimSpace(dto.Name) == "" || !dto.Kind.IsValid() {
+		return ErrInvalidOrganization
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrReportOrganization is returned when a report is asked by a caller that is not an operator
+// without telling the organization it is scoped to
+var ErrReportOrganization = errs.Forbidden("The reports are scoped to an organization, select one with X-Organization-ID")
+
+// ReportService implements use cases methods for the reports, scoped to the organization the
+// caller acts for
+type ReportService struct {
+	crops         *CropService
+	organizations *store.MongoOrganizationRepository
+}
+
+// HarvestReport counts the crops harvested within [from, to) by month and variant. Only the crops
+// supplied by the users or created by the users and API clients that are members of the
+// organization of the report are counted, the operators acting for no organization count every
+// crop.
+func (s *ReportService) HarvestReport(ctx context.Context, from time.Time, to time.Time) ([]*models.HarvestReportRow, error) {
+	organization, err := s.reportOrganization(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return s.crops.HarvestReport(ctx, from, to)
+	}
+	memberIDs := make([]primitive.ObjectID, len(organization.Members))
+	for i, member := range organization.Members {
+		memberIDs[i] = member.MemberID
+	}
+	return s.crops.MembersHarvestReport(ctx, from, to, memberIDs)
+}
+
+// reportOrganization returns the organization a report is scoped to, the one the caller acts for
+// or else the only one it is a member of. It returns nil for the operators acting for no
+// organization and ErrReportOrganization for the other callers that do not tell it.
+func (s *ReportService) reportOrganization(ctx context.Context) (*models.Organization, error) {
+	principal := PrincipalFromContext(ctx)
+	if principal != nil && principal.OrganizationID != "" {
+		organization, _, err := findMemberOrganization(ctx, s.organizations, principal.OrganizationID)
+		return organization, err
+	}
+	if isOperator(principal) {
+		return nil, nil
+	}
+	if memberType, memberID, ok := principalMember(principal); ok {
+		organizations, err := s.organizations.FindByMember(ctx, memberType, memberID)
+		if err != nil {
+			return nil, err
+		}
+		if len(organizations) == 1 {
+			return organizations[0], nil
+		}
+	}
+	return nil, ErrReportOrganization
+}
+
+// NewReportService creates a report service with necessary dependencies.
+func NewReportService(cropService *CropService, organizations *store.MongoOrganizationRepository) *ReportService {
+	return &ReportService{cropService, organizations}
+}
@@ -2,7 +2,11 @@
 package services
 
 import (
+	"context"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
 )
@@ -10,16 +14,34 @@ import (
 // SupplierService implements use cases methods and domain business logic for suppliers
 type SupplierService struct {
 	repository *store.MongoSupplierRepository
+	audit      *AuditService
+	events     *EventService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 // FindSupplierByID returns a supplier by its ID
 func (s *SupplierService) FindSupplierByID(id string) (*models.Supplier, error) {
-	return s.repository.FindByID(id)
+	supplier, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil {
+		return nil, errs.NotFound("Supplier")
+	}
+	return supplier, nil
 }
 
 // PopulateSupplierByID return a supplier with the crops property populated with the variant data
 func (s *SupplierService) PopulateSupplierByID(id string) (*models.Supplier, error) {
-	return s.repository.PopulateSupplierByID(id)
+	supplier, err := s.repository.PopulateSupplierByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil {
+		return nil, errs.NotFound("Supplier")
+	}
+	return supplier, nil
 }
 
 // FindAllSuppliers returns a list of suppliers
@@ -27,46 +49,161 @@ func (s *SupplierService) FindAllSuppliers() ([]*models.Supplier, error) {
 	return s.repository.FindAll()
 }
 
-// CreateSupplier create a new supplier record
-func (s *SupplierService) CreateSupplier(dto *dtos.SupplierDto) (*models.Supplier, error) {
-	result, err := s.repository.Insert(dto)
-	if err != nil {
-		return nil, err
-	}
+// EachSupplier calls fn with every supplier and its city until fn returns an error
+func (s *SupplierService) EachSupplier(ctx context.Context, fn func(*models.Supplier) error) error {
+	return s.repository.Each(ctx, fn)
+}
+
+// CountActiveSuppliers returns the number of active suppliers
+func (s *SupplierService) CountActiveSuppliers() (int64, error) {
+	return s.repository.CountActive()
+}
+
+// CreateSupplier create a new supplier record, the supplier, its audit entry and its event are
+// written in one unit of work
+func (s *SupplierService) CreateSupplier(ctx context.Context, dto *dtos.SupplierDto) (*models.Supplier, error) {
+	var supplier *models.Supplier
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.integrity.CheckReferences(ctx, NewReference("cityId", &dto.CityID, models.ResourceCity)); err != nil {
+			return err
+		}
+
+		result, err := s.repository.Insert(ctx, dto)
+		if err != nil {
+			return err
+		}
+
+		if supplier, err = s.repository.FindByID(ctx, result); err != nil {
+			return err
+		}
 
-	supplier, err := s.repository.FindByID(result)
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceSupplier, result, nil, supplier); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.SupplierCreated, models.ResourceSupplier, result, supplier)
+	})
 	if err != nil {
 		return nil, err
 	}
-
 	return supplier, nil
 }
 
-// UpdateSupplierByID update a supplier data by its id
-func (s *SupplierService) UpdateSupplierByID(id string, dto *dtos.SupplierDto) (*models.Supplier, error) {
-	result, err := s.repository.Update(id, dto)
+// UpdateSupplierByID update a supplier data by its id, versions optionally restricts the write to
+// the given stored versions of the document
+func (s *SupplierService) UpdateSupplierByID(ctx context.Context, id string, dto *dtos.SupplierDto, versions []int64) (*models.Supplier, error) {
+	var supplier *models.Supplier
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Supplier")
+		}
+		if err := s.integrity.CheckReferences(ctx, NewReference("cityId", &dto.CityID, models.ResourceCity)); err != nil {
+			return err
+		}
+
+		result, err := s.repository.Update(ctx, id, dto, versions)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("Supplier")
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceSupplier, id, before, result); err != nil {
+			return err
+		}
+		if err := s.events.Publish(ctx, enums.SupplierUpdated, models.ResourceSupplier, id, result); err != nil {
+			return err
+		}
+
+		supplier, err = s.repository.PopulateSupplierByID(ctx, id)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return supplier, nil
+}
 
-	if result == nil {
-		return nil, nil
-	}
+// SetSupplierStatus set the record status of a supplier by its id, an inactive supplier is kept
+// but cannot be referenced by new crops. versions optionally restricts the write to the given
+// stored versions of the document
+func (s *SupplierService) SetSupplierStatus(ctx context.Context, id string, status enums.EnumRecordStatus, versions []int64) (*models.Supplier, error) {
+	var supplier *models.Supplier
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Supplier")
+		}
 
-	supplier, err := s.repository.PopulateSupplierByID(id)
+		if supplier, err = s.repository.SetRecordStatus(ctx, id, status, versions); err != nil {
+			return err
+		}
+		if supplier == nil {
+			return errs.NotFound("Supplier")
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceSupplier, id, before, supplier); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.SupplierUpdated, models.ResourceSupplier, id, supplier)
+	})
 	if err != nil {
 		return nil, err
 	}
-
 	return supplier, nil
 }
 
-// DeleteSupplier delete a suplier by id
-func (s *SupplierService) DeleteSupplier(id string) (bool, error) {
-	return s.repository.Delete(id)
+// DeleteSupplier delete a suplier by id, versions optionally restricts the delete to the given
+// stored versions of the document
+func (s *SupplierService) DeleteSupplier(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Supplier")
+		}
+
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceSupplier, before.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Supplier")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditDelete, models.ResourceSupplier, id, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.SupplierDeleted, models.ResourceSupplier, id, before)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // NewSupplierService creates a supplier service with necessary dependencies.
-func NewSupplierService(supplierRepository *store.MongoSupplierRepository) *SupplierService {
-	return &SupplierService{supplierRepository}
+func NewSupplierService(
+	supplierRepository *store.MongoSupplierRepository,
+	auditService *AuditService,
+	eventService *EventService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *SupplierService {
+	return &SupplierService{supplierRepository, auditService, eventService, integrityService, unitOfWork}
 }
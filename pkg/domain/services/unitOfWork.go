@@ -0,0 +1,77 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+
+	"futuagro.com/pkg/store"
+)
+
+// UnitOfWork runs the writes of a composite operation as a whole, the repositories must be called
+// with the context given to fn. Do returns the error of fn, in which case none of its writes is
+// kept when the deployment supports transactions.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NewUnitOfWork returns a unit of work running the functions in Mongo transactions, or a no-op
+// one without a transactor
+func NewUnitOfWork(transactor *store.MongoTransactor) UnitOfWork {
+	if transactor == nil {
+		return NoopUnitOfWork{}
+	}
+	return &MongoUnitOfWork{transactor}
+}
+
+const afterCommitKey contextKey = "afterCommit"
+
+// afterCommit holds the functions to run once the unit of work they were registered in is done
+type afterCommit struct {
+	fns []func()
+}
+
+// AfterCommit runs fn once the unit of work of ctx has committed, or right away outside a unit of
+// work. It defers the effects that cannot be rolled back, e.g. notifying the in-process
+// subscribers of an event that could still be aborted.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey).(*afterCommit); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn()
+}
+
+// MongoUnitOfWork runs the functions in Mongo transactions, retried on transient errors. The
+// functions run without a transaction on standalone servers.
+type MongoUnitOfWork struct {
+	transactor *store.MongoTransactor
+}
+
+// Do implements UnitOfWork, a unit of work started within another one joins it
+func (u *MongoUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(afterCommitKey).(*afterCommit); ok {
+		return fn(ctx)
+	}
+
+	var hooks *afterCommit
+	err := u.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		// A retried run registers its functions again
+		hooks = &afterCommit{}
+		return fn(context.WithValue(ctx, afterCommitKey, hooks))
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks.fns {
+		hook()
+	}
+	return nil
+}
+
+// NoopUnitOfWork runs the functions as they are, for the in-memory setups without a database
+type NoopUnitOfWork struct{}
+
+// Do implements UnitOfWork
+func (NoopUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -2,24 +2,51 @@
 package services
 
 import (
+	"context"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
 )
 
+// minPasswordLength and maxPasswordLength bound the passwords accepted at login
+const (
+	minPasswordLength = 6
+	maxPasswordLength = 16
+)
+
 // UserService implements use cases methods and domain business logic for users
 type UserService struct {
 	repository *store.MongoUserRepository
+	audit      *AuditService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 // FindUserByID returns an user by its ID
 func (s *UserService) FindUserByID(id string) (*models.User, error) {
-	return s.repository.FindByID(id)
+	user, err := s.repository.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errs.NotFound("User")
+	}
+	return user, nil
 }
 
 // PopulateUserByID return an user with the crops property populated with the variant data
 func (s *UserService) PopulateUserByID(id string) (*models.User, error) {
-	return s.repository.PopulateUserByID(id)
+	user, err := s.repository.PopulateUserByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errs.NotFound("User")
+	}
+	return user, nil
 }
 
 // FindAllUsers returns a list of users
@@ -27,46 +54,184 @@ func (s *UserService) FindAllUsers() ([]*models.User, error) {
 	return s.repository.FindAll()
 }
 
-// Signup create a new user record
-func (s *UserService) Signup(dto *dtos.UserDto) (*models.User, error) {
-	result, err := s.repository.Insert(dto)
+// EachUser calls fn with every user and its crops until fn returns an error
+func (s *UserService) EachUser(ctx context.Context, fn func(*models.User) error) error {
+	return s.repository.Each(ctx, fn)
+}
+
+// Signup create a new user record, the user and its audit entry are written in one unit of work
+func (s *UserService) Signup(ctx context.Context, dto *dtos.UserDto) (*models.User, error) {
+	var user *models.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.integrity.CheckReferences(ctx, NewReference("cityId", dto.CityID, models.ResourceCity)); err != nil {
+			return err
+		}
+
+		result, err := s.repository.Insert(ctx, dto)
+		if err != nil {
+			return err
+		}
+
+		if user, err = s.repository.FindByID(ctx, result); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, enums.AuditCreate, models.ResourceUser, result, nil, user)
+	})
 	if err != nil {
 		return nil, err
 	}
+	return user, nil
+}
 
-	user, err := s.repository.FindByID(result)
+// UpdateUserByID update an user data by its id, versions optionally restricts the write to
+// the given stored versions of the document
+func (s *UserService) UpdateUserByID(ctx context.Context, id string, dto *dtos.UserDto, versions []int64) (*models.User, error) {
+	var user *models.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("User")
+		}
+		if err := s.integrity.CheckReferences(ctx, NewReference("cityId", dto.CityID, models.ResourceCity)); err != nil {
+			return err
+		}
+
+		result, err := s.repository.Update(ctx, id, dto, versions)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("User")
+		}
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceUser, id, before, result); err != nil {
+			return err
+		}
+
+		user, err = s.repository.PopulateUserByID(ctx, id)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-
 	return user, nil
 }
 
-// UpdateUserByID update an user data by its id
-func (s *UserService) UpdateUserByID(id string, dto *dtos.UserDto) (*models.User, error) {
-	result, err := s.repository.Update(id, dto)
+// CreateAdmin create a new user record with the admin role, the user is created and promoted in
+// one unit of work
+func (s *UserService) CreateAdmin(ctx context.Context, dto *dtos.UserDto) (*models.User, error) {
+	if err := checkPassword(dto.Password); err != nil {
+		return nil, err
+	}
+	var admin *models.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		user, err := s.Signup(ctx, dto)
+		if err != nil {
+			return err
+		}
+		admin, err = s.SetUserRole(ctx, user.ID.Hex(), models.RoleAdmin)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return admin, nil
+}
 
-	if result == nil {
-		return nil, nil
+// SetUserRole set the role of an user by its id
+func (s *UserService) SetUserRole(ctx context.Context, id string, role string) (*models.User, error) {
+	if role != models.RoleUser && role != models.RoleAdmin {
+		return nil, errs.Validationf("The role must be %s or %s", models.RoleUser, models.RoleAdmin)
 	}
+	return s.updateUser(ctx, id, func(ctx context.Context) (*models.User, error) {
+		return s.repository.SetRole(ctx, id, role)
+	})
+}
 
-	user, err := s.repository.PopulateUserByID(id)
-	if err != nil {
+// ResetPassword replace the password of an user by its id
+func (s *UserService) ResetPassword(ctx context.Context, id string, password string) (*models.User, error) {
+	if err := checkPassword(password); err != nil {
 		return nil, err
 	}
+	return s.updateUser(ctx, id, func(ctx context.Context) (*models.User, error) {
+		return s.repository.SetPassword(ctx, id, password)
+	})
+}
+
+// DeleteUser delete an user by id, versions optionally restricts the delete to the given
+// stored versions of the document
+func (s *UserService) DeleteUser(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("User")
+		}
+
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceUser, before.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.repository.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("User")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, enums.AuditDelete, models.ResourceUser, id, before, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
+// updateUser runs a write of an user and its audit entry in one unit of work, write returns the
+// user after the change or nil when it does not exist
+func (s *UserService) updateUser(ctx context.Context, id string, write func(ctx context.Context) (*models.User, error)) (*models.User, error) {
+	var user *models.User
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("User")
+		}
+		if user, err = write(ctx); err != nil {
+			return err
+		}
+		if user == nil {
+			return errs.NotFound("User")
+		}
+		return s.audit.Record(ctx, enums.AuditUpdate, models.ResourceUser, id, before, user)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
-// DeleteUser delete an user by id
-func (s *UserService) DeleteUser(id string) (bool, error) {
-	return s.repository.Delete(id)
+// checkPassword rejects the passwords that could not be used to login
+func checkPassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return errs.Validationf("The password must have from %d to %d characters", minPasswordLength, maxPasswordLength)
+	}
+	return nil
 }
 
 // NewUserService creates an user service with necessary dependencies.
-func NewUserService(repository *store.MongoUserRepository) *UserService {
-	return &UserService{repository}
+func NewUserService(repository *store.MongoUserRepository, auditService *AuditService, integrityService *IntegrityService, unitOfWork UnitOfWork) *UserService {
+	return &UserService{repository, auditService, integrityService, unitOfWork}
 }
@@ -2,7 +2,11 @@
 package services
 
 import (
+	"context"
+
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
 	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/store"
 )
@@ -10,16 +14,34 @@ import (
 // VariantService implements use cases methods and domain business logic for variants
 type VariantService struct {
 	repository *store.MongoVariantRepository
+	audit      *AuditService
+	events     *EventService
+	integrity  *IntegrityService
+	unitOfWork UnitOfWork
 }
 
 //FindVariantByID return a variant by its ID
 func (s *VariantService) FindVariantByID(ID string) (*models.Variant, error) {
-	return s.repository.FindVariantByID(ID)
+	variant, err := s.repository.FindVariantByID(context.Background(), ID)
+	if err != nil {
+		return nil, err
+	}
+	if variant == nil {
+		return nil, errs.NotFound("Variant")
+	}
+	return variant, nil
 }
 
 // FindOneVariantByItemID returns a variant by its ID and item ID
 func (s *VariantService) FindOneVariantByItemID(itemID string, variantID string) (*models.Variant, error) {
-	return s.repository.FindOneVariantByItemID(itemID, variantID)
+	variant, err := s.repository.FindOneVariantByItemID(context.Background(), itemID, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if variant == nil {
+		return nil, errs.NotFound("Variant")
+	}
+	return variant, nil
 }
 
 // FindVariantsByItemID returns a list of variants that belongs to an item
@@ -27,22 +49,131 @@ func (s *VariantService) FindVariantsByItemID(itemID string) ([]*models.Variant,
 	return s.repository.FindVariantsByItemID(itemID)
 }
 
-// CreateVariant create a new Variant record
-func (s *VariantService) CreateVariant(itemID string, dto *dtos.VariantDto) (string, error) {
-	return s.repository.Insert(itemID, dto)
+// SearchVariants returns the variants of an item having a name or a synonym in any locale with a
+// word starting with term, whatever its case and accents
+func (s *VariantService) SearchVariants(itemID string, term string) ([]*models.Variant, error) {
+	return s.repository.FindVariantsByTerm(itemID, term)
+}
+
+// CreateVariant create a new Variant record, the variant, its audit entry and its event are
+// written in one unit of work
+func (s *VariantService) CreateVariant(ctx context.Context, itemID string, dto *dtos.VariantDto) (string, error) {
+	names, err := normalizeLocalizedNames(dto.Names)
+	if err != nil {
+		return "", err
+	}
+	dto.Names = names
+
+	var id string
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.integrity.CheckReferences(ctx, NewHexReference("itemId", itemID, models.ResourceItem)); err != nil {
+			return err
+		}
+
+		var err error
+		if id, err = s.repository.Insert(ctx, itemID, dto); err != nil {
+			return err
+		}
+
+		after, err := s.repository.FindVariantByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditCreate, models.ResourceVariant, id, nil, after); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.VariantCreated, models.ResourceVariant, id, after)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
 }
 
-// UpdateVariant update a variant data
-func (s *VariantService) UpdateVariant(itemID string, variantID string, itemDto *dtos.VariantDto) (*models.Variant, error) {
-	return s.repository.Update(itemID, variantID, itemDto)
+// UpdateVariant update a variant data, versions optionally restricts the write to the given
+// stored versions of the document
+func (s *VariantService) UpdateVariant(ctx context.Context, itemID string, variantID string, itemDto *dtos.VariantDto, versions []int64) (*models.Variant, error) {
+	var result *models.Variant
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindOneVariantByItemID(ctx, itemID, variantID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Variant")
+		}
+		if itemDto.Names == nil {
+			itemDto.Names = localizedNameDtos(before.Names)
+		}
+		if itemDto.Names, err = normalizeLocalizedNames(itemDto.Names); err != nil {
+			return err
+		}
+
+		if result, err = s.repository.Update(ctx, itemID, variantID, itemDto, versions); err != nil {
+			return err
+		}
+		if result == nil {
+			return errs.NotFound("Variant")
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditUpdate, models.ResourceVariant, variantID, before, result); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.VariantUpdated, models.ResourceVariant, variantID, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-// DeleteVariant delete a variant by id
-func (s *VariantService) DeleteVariant(itemID string, variantID string) (bool, error) {
-	return s.repository.Delete(itemID, variantID)
+// DeleteVariant delete a variant by id, versions optionally restricts the delete to the given
+// stored versions of the document
+func (s *VariantService) DeleteVariant(ctx context.Context, itemID string, variantID string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.repository.FindOneVariantByItemID(ctx, itemID, variantID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Variant")
+		}
+
+		plan, err := s.integrity.PlanDelete(ctx, models.ResourceVariant, before.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.repository.Delete(ctx, itemID, variantID, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Variant")
+		}
+		if err := s.integrity.ApplyDelete(ctx, plan); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, enums.AuditDelete, models.ResourceVariant, variantID, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, enums.VariantDeleted, models.ResourceVariant, variantID, before)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // NewVariantService creates a variant service with necessary dependencies.
-func NewVariantService(repository *store.MongoVariantRepository) *VariantService {
-	return &VariantService{repository}
+func NewVariantService(
+	repository *store.MongoVariantRepository,
+	auditService *AuditService,
+	eventService *EventService,
+	integrityService *IntegrityService,
+	unitOfWork UnitOfWork,
+) *VariantService {
+	return &VariantService{repository, auditService, eventService, integrityService, unitOfWork}
 }
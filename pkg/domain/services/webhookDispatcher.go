@@ -0,0 +1,232 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookBaseBackoff is the delay before the second attempt, it doubles on every failure
+	webhookBaseBackoff = 30 * time.Second
+	// webhookMaxBackoff caps the delay between two attempts
+	webhookMaxBackoff = 6 * time.Hour
+	// webhookBatchSize bounds the work done on every poll so that a backlog does not starve shutdown
+	webhookBatchSize = 100
+)
+
+// Headers sent along every webhook delivery, the signature is the hex encoded HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the subscription secret
+const (
+	WebhookEventHeader     = "X-Futuagro-Event"
+	WebhookDeliveryHeader  = "X-Futuagro-Delivery"
+	WebhookTimestampHeader = "X-Futuagro-Timestamp"
+	WebhookSignatureHeader = "X-Futuagro-Signature"
+)
+
+// WebhookDispatcher fans the events of the outbox out to the webhook subscriptions and delivers
+// them, retrying failed deliveries with exponential backoff until they are marked as dead
+type WebhookDispatcher struct {
+	outbox        *store.MongoOutboxRepository
+	subscriptions *store.MongoWebhookRepository
+	deliveries    *store.MongoWebhookDeliveryRepository
+	client        *http.Client
+	logger        *logrus.Entry
+	pollInterval  time.Duration
+	maxAttempts   int
+}
+
+// Run dispatches events until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+	for {
+		d.dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) {
+	for i := 0; i < webhookBatchSize && ctx.Err() == nil; i++ {
+		found, err := d.fanOutNext()
+		if err != nil {
+			d.logger.WithError(err).Error("Error fanning out an outbox event")
+			break
+		}
+		if !found {
+			break
+		}
+	}
+	for i := 0; i < webhookBatchSize && ctx.Err() == nil; i++ {
+		found, err := d.deliverNext(ctx)
+		if err != nil {
+			d.logger.WithError(err).Error("Error delivering a webhook")
+			break
+		}
+		if !found {
+			break
+		}
+	}
+}
+
+// fanOutNext creates a delivery of the oldest pending event for each subscription to its type
+func (d *WebhookDispatcher) fanOutNext() (bool, error) {
+	now := time.Now()
+	event, err := d.outbox.ClaimPending(now, now.Add(d.lease()))
+	if err != nil || event == nil {
+		return false, err
+	}
+
+	subscriptions, err := d.subscriptions.FindActiveByEventType(event.Type)
+	if err != nil {
+		return true, err
+	}
+	for _, subscription := range subscriptions {
+		if err := d.deliveries.Enqueue(subscription.ID, event, now); err != nil {
+			return true, err
+		}
+	}
+	return true, d.outbox.MarkDispatched(event.ID, time.Now())
+}
+
+// deliverNext attempts the delivery that has been due the longest
+func (d *WebhookDispatcher) deliverNext(ctx context.Context) (bool, error) {
+	now := time.Now()
+	delivery, err := d.deliveries.ClaimDue(now, now.Add(d.lease()))
+	if err != nil || delivery == nil {
+		return false, err
+	}
+
+	subscription, err := d.subscriptions.FindByID(ctx, delivery.SubscriptionID.Hex())
+	if err != nil {
+		return true, err
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = 0
+	delivery.LastError = ""
+	switch {
+	case subscription == nil:
+		delivery.Status = enums.DeliveryDead
+		delivery.LastError = "The subscription has been deleted"
+	case subscription.RecordStatus != nil && *subscription.RecordStatus != enums.Active:
+		delivery.Status = enums.DeliveryDead
+		delivery.LastError = "The subscription is inactive"
+	default:
+		delivery.LastStatusCode, err = d.send(ctx, subscription, delivery)
+		if err == nil {
+			delivery.Status = enums.DeliverySucceeded
+		} else {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= d.maxAttempts {
+				delivery.Status = enums.DeliveryDead
+			} else {
+				delivery.Status = enums.DeliveryRetrying
+				delivery.NextAttemptAt = time.Now().Add(webhookBackoff(delivery.Attempts))
+			}
+		}
+	}
+	d.logger.WithFields(logrus.Fields{
+		"deliveryId":     delivery.ID.Hex(),
+		"subscriptionId": delivery.SubscriptionID.Hex(),
+		"eventType":      delivery.EventType,
+		"attempts":       delivery.Attempts,
+		"status":         delivery.Status,
+		"statusCode":     delivery.LastStatusCode,
+	}).Info("Webhook delivery attempted")
+	return true, d.deliveries.RecordAttempt(delivery)
+}
+
+// send posts a signed delivery to the subscriber, any response other than a 2xx is a failure
+func (d *WebhookDispatcher) send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request, err := http.NewRequest(http.MethodPost, subscription.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "Error building the webhook request")
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	request.Header.Set("User-Agent", "Futuagro-Webhooks/1.0")
+	request.Header.Set(WebhookEventHeader, delivery.EventType.String())
+	request.Header.Set(WebhookDeliveryHeader, delivery.ID.Hex())
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error sending the webhook request")
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, errors.Errorf("The subscriber responded %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+	return response.StatusCode, nil
+}
+
+// lease is how long a claimed event or delivery is hidden from other dispatchers
+func (d *WebhookDispatcher) lease() time.Duration {
+	return d.client.Timeout + time.Minute
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>", subscribers
+// recompute it with their secret to authenticate a delivery
+func SignWebhookPayload(secret string, timestamp string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff returns the delay before the attempt following the given number of attempts
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
+
+// NewWebhookDispatcher creates a webhook dispatcher with necessary dependencies.
+func NewWebhookDispatcher(
+	confPtr *config.Config,
+	logger *logrus.Logger,
+	outbox *store.MongoOutboxRepository,
+	subscriptions *store.MongoWebhookRepository,
+	deliveries *store.MongoWebhookDeliveryRepository,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		outbox:        outbox,
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        &http.Client{Timeout: confPtr.Webhooks.Timeout},
+		logger:        logger.WithField("component", "webhookDispatcher"),
+		pollInterval:  confPtr.Webhooks.PollInterval,
+		maxAttempts:   confPtr.Webhooks.MaxAttempts,
+	}
+}
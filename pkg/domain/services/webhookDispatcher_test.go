@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		payload   string
+		expected  string
+	}{
+		{"payload", "whsec_test", "1700000000", `{"type":"crop.created"}`, "616ec6512209b44a3aa35ecb4b1aca296595950298dd7f241f1a389644ee47e1"},
+		{"empty", "", "0", "", "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if signature := SignWebhookPayload(tt.secret, tt.timestamp, tt.payload); signature != tt.expected {
+				t.Errorf("got %s, expected %s", signature, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSignWebhookPayloadBindsTimestamp fails when a signature can be replayed with another
+// timestamp or moved to another payload
+func TestSignWebhookPayloadBindsTimestamp(t *testing.T) {
+	signature := SignWebhookPayload("whsec_test", "1700000000", "{}")
+	if SignWebhookPayload("whsec_test", "1700000001", "{}") == signature {
+		t.Error("the signature does not depend on the timestamp")
+	}
+	if SignWebhookPayload("whsec_test", "1700000000", "[]") == signature {
+		t.Error("the signature does not depend on the payload")
+	}
+	if SignWebhookPayload("whsec_other", "1700000000", "{}") == signature {
+		t.Error("the signature does not depend on the secret")
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{8, 64 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if backoff := webhookBackoff(tt.attempts); backoff != tt.expected {
+			t.Errorf("webhookBackoff(%d): got %s, expected %s", tt.attempts, backoff, tt.expected)
+		}
+	}
+}
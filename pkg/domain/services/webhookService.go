@@ -0,0 +1,239 @@
+// Package services contains the interfaces for all use cases in the business domain.
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/store"
+	"github.com/pkg/errors"
+)
+
+// webhookSecretPrefix marks the strings issued by this service as webhook signing secrets
+const webhookSecretPrefix = "whsec_"
+
+var (
+	// ErrInvalidWebhookURL is returned when a webhook subscription does not target an absolute http(s) URL
+	ErrInvalidWebhookURL = errs.Validation("Invalid webhook URL")
+	// ErrInvalidEventTypes is returned when a webhook subscription lists no event type or an unknown one
+	ErrInvalidEventTypes = errs.Validation("Invalid webhook event types")
+)
+
+// WebhookService implements use cases methods and domain business logic for webhook
+// subscriptions and their deliveries
+type WebhookService struct {
+	subscriptions *store.MongoWebhookRepository
+	deliveries    *store.MongoWebhookDeliveryRepository
+	outbox        *store.MongoOutboxRepository
+	audit         *AuditService
+	unitOfWork    UnitOfWork
+}
+
+// FindSubscriptionByID returns a webhook subscription by its ID
+func (s *WebhookService) FindSubscriptionByID(id string) (*models.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.FindByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, errs.NotFound("Webhook Subscription")
+	}
+	return subscription, nil
+}
+
+// FindAllSubscriptions returns a list of webhook subscriptions
+func (s *WebhookService) FindAllSubscriptions() ([]*models.WebhookSubscription, error) {
+	return s.subscriptions.FindAll()
+}
+
+// CreateSubscription registers a new webhook subscription and issues its signing secret, the
+// subscription and its audit entry are written in one unit of work
+func (s *WebhookService) CreateSubscription(ctx context.Context, dto *dtos.WebhookSubscriptionDto) (*models.IssuedWebhookSecret, error) {
+	if err := validateSubscription(dto); err != nil {
+		return nil, err
+	}
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription *models.WebhookSubscription
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		id, err := s.subscriptions.Insert(ctx, dto, secret)
+		if err != nil {
+			return err
+		}
+		if subscription, err = s.subscriptions.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, enums.AuditCreate, models.ResourceWebhook, id, nil, subscription)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.IssuedWebhookSecret{Subscription: subscription, Secret: secret}, nil
+}
+
+// UpdateSubscription update the target, event types or status of a webhook subscription
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id string, dto *dtos.WebhookSubscriptionDto, versions []int64) (*models.WebhookSubscription, error) {
+	if err := validateSubscription(dto); err != nil {
+		return nil, err
+	}
+	return s.updateSubscription(ctx, id, func(ctx context.Context) (*models.WebhookSubscription, error) {
+		return s.subscriptions.Update(ctx, id, dto, versions)
+	})
+}
+
+// RotateSecret issues a new signing secret for a webhook subscription, deliveries are signed
+// with it from their next attempt on
+func (s *WebhookService) RotateSecret(ctx context.Context, id string, versions []int64) (*models.IssuedWebhookSecret, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.updateSubscription(ctx, id, func(ctx context.Context) (*models.WebhookSubscription, error) {
+		return s.subscriptions.UpdateSecret(ctx, id, secret, versions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.IssuedWebhookSecret{Subscription: subscription, Secret: secret}, nil
+}
+
+// DeleteSubscription delete a webhook subscription by id, its pending deliveries are dropped
+// by the dispatcher
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string, versions []int64) (bool, error) {
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.subscriptions.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Webhook Subscription")
+		}
+		deleted, err := s.subscriptions.Delete(ctx, id, versions)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errs.NotFound("Webhook Subscription")
+		}
+		return s.audit.Record(ctx, enums.AuditDelete, models.ResourceWebhook, id, before, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateSubscription runs a write of a webhook subscription and its audit entry in one unit of
+// work, write returns the subscription after the change or nil when it does not exist
+func (s *WebhookService) updateSubscription(ctx context.Context, id string, write func(ctx context.Context) (*models.WebhookSubscription, error)) (*models.WebhookSubscription, error) {
+	var subscription *models.WebhookSubscription
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		before, err := s.subscriptions.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errs.NotFound("Webhook Subscription")
+		}
+		if subscription, err = write(ctx); err != nil {
+			return err
+		}
+		if subscription == nil {
+			return errs.NotFound("Webhook Subscription")
+		}
+		return s.audit.Record(ctx, enums.AuditUpdate, models.ResourceWebhook, id, before, subscription)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// FindDeliveryByID returns a webhook delivery by its ID
+func (s *WebhookService) FindDeliveryByID(id string) (*models.WebhookDelivery, error) {
+	delivery, err := s.deliveries.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, errs.NotFound("Webhook Delivery")
+	}
+	return delivery, nil
+}
+
+// FindDeliveries returns the webhook deliveries matching a query, most recent first
+func (s *WebhookService) FindDeliveries(query *dtos.WebhookDeliveryQueryDto) ([]*models.WebhookDelivery, error) {
+	return s.deliveries.Find(query)
+}
+
+// ReplayDelivery sends a delivery again, whatever its current status, with a fresh set of attempts
+func (s *WebhookService) ReplayDelivery(id string) (*models.WebhookDelivery, error) {
+	delivery, err := s.deliveries.Replay(id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, errs.NotFound("Webhook Delivery")
+	}
+	return delivery, nil
+}
+
+// ReplayEvent sends an event again to every subscription, the ones that already received it
+// get their delivery replayed and the ones created since then get a new delivery
+func (s *WebhookService) ReplayEvent(eventID string) (*models.OutboxEvent, error) {
+	event, err := s.outbox.Requeue(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, errs.NotFound("Event")
+	}
+	if _, err := s.deliveries.ReplayEvent(event.ID); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// NewWebhookService creates a webhook service with necessary dependencies.
+func NewWebhookService(
+	subscriptions *store.MongoWebhookRepository,
+	deliveries *store.MongoWebhookDeliveryRepository,
+	outbox *store.MongoOutboxRepository,
+	auditService *AuditService,
+	unitOfWork UnitOfWork,
+) *WebhookService {
+	return &WebhookService{subscriptions, deliveries, outbox, auditService, unitOfWork}
+}
+
+func validateSubscription(dto *dtos.WebhookSubscriptionDto) error {
+	target, err := url.Parse(dto.URL)
+	if err != nil || !target.IsAbs() || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.Wrapf(ErrInvalidWebhookURL, "url %q", dto.URL)
+	}
+	if len(dto.EventTypes) == 0 {
+		return errors.Wrap(ErrInvalidEventTypes, "no event type")
+	}
+	for _, eventType := range dto.EventTypes {
+		if !eventType.IsValid() {
+			return errors.Wrapf(ErrInvalidEventTypes, "event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "Error generating a webhook secret")
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	futuagrov1 "futuagro.com/pkg/grpc/proto/futuagro/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// catalogServer serves the items of the catalog and their variants
+type catalogServer struct {
+	items    *services.ItemService
+	variants *services.VariantService
+	// requireVersion refuses the writes sent without a version, like server.requireIfMatch does
+	// for the REST API
+	requireVersion bool
+}
+
+func (s *catalogServer) GetItem(ctx context.Context, req *futuagrov1.GetItemRequest) (*futuagrov1.Item, error) {
+	item, err := s.items.FindItemByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return itemProto(item), nil
+}
+
+func (s *catalogServer) ListItems(req *futuagrov1.ListItemsRequest, stream futuagrov1.CatalogService_ListItemsServer) error {
+	return s.items.EachItem(stream.Context(), func(item *models.Item) error {
+		return stream.Send(itemProto(item))
+	})
+}
+
+func (s *catalogServer) CreateItem(ctx context.Context, req *futuagrov1.CreateItemRequest) (*futuagrov1.Item, error) {
+	id, err := s.items.CreateItem(ctx, itemDto(req.GetItem()))
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.items.FindItemByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return itemProto(item), nil
+}
+
+func (s *catalogServer) UpdateItem(ctx context.Context, req *futuagrov1.UpdateItemRequest) (*futuagrov1.Item, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.items.UpdateItemByID(ctx, req.GetId(), itemDto(req.GetItem()), versions)
+	if err != nil {
+		return nil, err
+	}
+	return itemProto(item), nil
+}
+
+func (s *catalogServer) DeleteItem(ctx context.Context, req *futuagrov1.DeleteItemRequest) (*empty.Empty, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.items.DeleteItemByID(ctx, req.GetId(), versions); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *catalogServer) GetVariant(ctx context.Context, req *futuagrov1.GetVariantRequest) (*futuagrov1.Variant, error) {
+	variant, err := s.variants.FindOneVariantByItemID(req.GetItemId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return variantProto(variant), nil
+}
+
+func (s *catalogServer) ListVariants(req *futuagrov1.ListVariantsRequest, stream futuagrov1.CatalogService_ListVariantsServer) error {
+	// The variants of an item are few, they are read at once
+	variants, err := s.variants.FindVariantsByItemID(req.GetItemId())
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if err := stream.Send(variantProto(variant)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *catalogServer) CreateVariant(ctx context.Context, req *futuagrov1.CreateVariantRequest) (*futuagrov1.Variant, error) {
+	id, err := s.variants.CreateVariant(ctx, req.GetItemId(), variantDto(req.GetVariant()))
+	if err != nil {
+		return nil, err
+	}
+	variant, err := s.variants.FindVariantByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return variantProto(variant), nil
+}
+
+func (s *catalogServer) UpdateVariant(ctx context.Context, req *futuagrov1.UpdateVariantRequest) (*futuagrov1.Variant, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := s.variants.UpdateVariant(ctx, req.GetItemId(), req.GetId(), variantDto(req.GetVariant()), versions)
+	if err != nil {
+		return nil, err
+	}
+	return variantProto(variant), nil
+}
+
+func (s *catalogServer) DeleteVariant(ctx context.Context, req *futuagrov1.DeleteVariantRequest) (*empty.Empty, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.variants.DeleteVariant(ctx, req.GetItemId(), req.GetId(), versions); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func itemProto(item *models.Item) *futuagrov1.Item {
+	return &futuagrov1.Item{
+		Id:         item.ID.Hex(),
+		Name:       item.Name,
+		Status:     statusProto(item.RecordStatus),
+		Version:    item.Version,
+		CreateTime: timestampProto(item.CreatedAt),
+		UpdateTime: timestampProto(item.UpdatedAt),
+	}
+}
+
+func itemDto(input *futuagrov1.ItemInput) *dtos.ItemDto {
+	return &dtos.ItemDto{Name: input.GetName(), RecordStatus: inputStatus(input.GetStatus())}
+}
+
+func variantProto(variant *models.Variant) *futuagrov1.Variant {
+	return &futuagrov1.Variant{
+		Id:         variant.ID.Hex(),
+		ItemId:     variant.ItemID.Hex(),
+		Name:       variant.Name,
+		Status:     statusProto(variant.RecordStatus),
+		Version:    variant.Version,
+		CreateTime: timestampProto(variant.CreatedAt),
+		UpdateTime: timestampProto(variant.UpdatedAt),
+	}
+}
+
+func variantDto(input *futuagrov1.VariantInput) *dtos.VariantDto {
+	return &dtos.VariantDto{Name: input.GetName(), RecordStatus: inputStatus(input.GetStatus())}
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	futuagrov1 "futuagro.com/pkg/grpc/proto/futuagro/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusProto returns the status of a record, the records without a status are active
+func statusProto(recordStatus *enums.EnumRecordStatus) futuagrov1.RecordStatus {
+	if recordStatus != nil && *recordStatus == enums.Inactive {
+		return futuagrov1.RecordStatus_RECORD_STATUS_INACTIVE
+	}
+	return futuagrov1.RecordStatus_RECORD_STATUS_ACTIVE
+}
+
+// inputStatus returns the status of a DTO, nil when the input leaves it unspecified
+func inputStatus(status futuagrov1.RecordStatus) *enums.EnumRecordStatus {
+	var value enums.EnumRecordStatus
+	switch status {
+	case futuagrov1.RecordStatus_RECORD_STATUS_ACTIVE:
+		value = enums.Active
+	case futuagrov1.RecordStatus_RECORD_STATUS_INACTIVE:
+		value = enums.Inactive
+	default:
+		return nil
+	}
+	return &value
+}
+
+// timestampProto returns the timestamp of a time, nil for the zero time
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// inputTime returns the time of a timestamp of an input, the zero time when it is missing
+func inputTime(field string, ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}, errs.Validationf("%s is not a valid timestamp", field)
+	}
+	return t, nil
+}
+
+// hexProto returns the hex form of an optional ID, empty when it is nil
+func hexProto(id *primitive.ObjectID) string {
+	if id == nil {
+		return ""
+	}
+	return id.Hex()
+}
+
+// inputID parses an ID of an input
+func inputID(id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, errs.InvalidID(id, err)
+	}
+	return oid, nil
+}
+
+// inputOptionalID parses an optional ID of an input, nil when it is empty
+func inputOptionalID(id string) (*primitive.ObjectID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	oid, err := inputID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &oid, nil
+}
+
+// requestVersions turns the version of a request into the versions the write is conditioned on,
+// like the If-Match header of the REST API. The versions start at 1, 0 makes the write
+// unconditional unless required, then the write fails like the REST API answers 428.
+func requestVersions(version int64, required bool) ([]int64, error) {
+	if version == 0 {
+		if required {
+			return nil, status.Error(codes.FailedPrecondition, "Precondition Required : send the version.")
+		}
+		return nil, nil
+	}
+	return []int64{version}, nil
+}
@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	futuagrov1 "futuagro.com/pkg/grpc/proto/futuagro/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// cropServer serves the crops
+type cropServer struct {
+	crops *services.CropService
+	// requireVersion refuses the writes sent without a version, like server.requireIfMatch does
+	// for the REST API
+	requireVersion bool
+}
+
+func (s *cropServer) GetCrop(ctx context.Context, req *futuagrov1.GetCropRequest) (*futuagrov1.Crop, error) {
+	crop, err := s.crops.FindCropByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return cropProto(crop), nil
+}
+
+func (s *cropServer) ListCrops(req *futuagrov1.ListCropsRequest, stream futuagrov1.CropService_ListCropsServer) error {
+	return s.crops.EachCrop(stream.Context(), func(crop *models.Crop) error {
+		return stream.Send(cropProto(crop))
+	})
+}
+
+func (s *cropServer) CreateCrop(ctx context.Context, req *futuagrov1.CreateCropRequest) (*futuagrov1.Crop, error) {
+	dto, err := cropDto(req.GetCrop())
+	if err != nil {
+		return nil, err
+	}
+	crop, err := s.crops.CreateCrop(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return cropProto(crop), nil
+}
+
+func (s *cropServer) UpdateCrop(ctx context.Context, req *futuagrov1.UpdateCropRequest) (*futuagrov1.Crop, error) {
+	dto, err := cropDto(req.GetCrop())
+	if err != nil {
+		return nil, err
+	}
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	crop, err := s.crops.UpdateCropByID(ctx, req.GetId(), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return cropProto(crop), nil
+}
+
+func (s *cropServer) DeleteCrop(ctx context.Context, req *futuagrov1.DeleteCropRequest) (*empty.Empty, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.crops.DeleteCropByID(ctx, req.GetId(), versions); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func cropProto(crop *models.Crop) *futuagrov1.Crop {
+	return &futuagrov1.Crop{
+		Id:           crop.ID.Hex(),
+		CityId:       hexProto(crop.CityID),
+		VariantId:    hexProto(crop.VariantID),
+		SupplierId:   hexProto(crop.SupplierID),
+		PlantingTime: timestampProto(crop.PlantingDate),
+		HarvestTime:  timestampProto(crop.HarvestDate),
+		Version:      crop.Version,
+		CreateTime:   timestampProto(crop.CreatedAt),
+		UpdateTime:   timestampProto(crop.UpdatedAt),
+	}
+}
+
+func cropDto(input *futuagrov1.CropInput) (*dtos.CropDto, error) {
+	cityID, err := inputID(input.GetCityId())
+	if err != nil {
+		return nil, err
+	}
+	variantID, err := inputOptionalID(input.GetVariantId())
+	if err != nil {
+		return nil, err
+	}
+	supplierID, err := inputOptionalID(input.GetSupplierId())
+	if err != nil {
+		return nil, err
+	}
+	plantingDate, err := inputTime("planting_time", input.GetPlantingTime())
+	if err != nil {
+		return nil, err
+	}
+	harvestDate, err := inputTime("harvest_time", input.GetHarvestTime())
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.CropDto{CityID: cityID, PlantingDate: plantingDate, HarvestDate: harvestDate, VariantID: variantID, SupplierID: supplierID}, nil
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/i18n"
+	"futuagro.com/pkg/logging"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError answers an error the way newDomainError answers it on the REST API: the errors of
+// the domain are given the matching code and the other errors are logged and answered as
+// internal errors. The field of an invalid input is named in a BadRequest detail. The messages
+// are logged in English and answered in the language of the locales of the call.
+func statusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	locales := callLocales(ctx)
+	if st, ok := status.FromError(err); ok {
+		return status.Error(st.Code(), i18n.TranslateMessage(locales, st.Message()))
+	}
+
+	cause, ok := errors.Cause(err).(*errs.Error)
+	if !ok || cause.Kind == errs.KindInternal {
+		logging.FromContext(ctx).WithField("cause", err.Error()).Error("Internal Server Error")
+		return status.Error(codes.Internal, i18n.TranslateMessage(locales, "Internal Server Error"))
+	}
+
+	// The context wrapping a validation error names the invalid input, the context wrapping the
+	// other kinds and the underlying errors are only logged. A message is translated from its key
+	// unless it has such a context.
+	message := cause.Message
+	translated := i18n.Translate(locales, cause.Key, cause.Args...)
+	if cause.Kind == errs.KindValidation && err.Error() != cause.Error() {
+		message = err.Error()
+	}
+	if message != cause.Message || cause.Key == "" {
+		translated = i18n.TranslateMessage(locales, message)
+	}
+	code := codes.Internal
+	switch cause.Kind {
+	case errs.KindNotFound:
+		code = codes.NotFound
+	case errs.KindInvalidID, errs.KindValidation:
+		code = codes.InvalidArgument
+	case errs.KindConflict:
+		// A duplicate value names its field, the other conflicts are records still referenced
+		code = codes.FailedPrecondition
+		if cause.Field != "" {
+			code = codes.AlreadyExists
+		}
+	case errs.KindForbidden:
+		code = codes.PermissionDenied
+	case errs.KindPreconditionFailed:
+		code = codes.Aborted
+	}
+	logger := logging.FromContext(ctx).WithField("code", code.String())
+	if cause.Err != nil {
+		logger = logger.WithField("cause", err.Error())
+	}
+	logger.Info(message)
+
+	st := status.New(code, translated)
+	if cause.Field != "" {
+		violation := &errdetails.BadRequest_FieldViolation{Field: cause.Field, Description: translated}
+		if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{violation}}); err == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// callLocales returns the locales a call asks for, most preferred first, from the lang metadata
+// or else from the accept-language metadata
+func callLocales(ctx context.Context) []string {
+	return i18n.Preferred(metadataValue(ctx, "lang"), metadataValue(ctx, "accept-language"))
+}
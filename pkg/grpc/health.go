@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthWatchInterval is how often Watch runs the readiness checks
+const healthWatchInterval = 5 * time.Second
+
+// healthServer answers the gRPC health checks with the readiness checks of the registry, every
+// service is serving when the application is ready
+type healthServer struct {
+	registry *health.Registry
+	services map[string]bool
+}
+
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() != "" && !s.services[req.GetService()] {
+		return nil, status.Error(codes.NotFound, "Unknown service "+req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+// Watch sends the status of a service when it changes, an unknown service is reported as
+// SERVICE_UNKNOWN
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if req.GetService() == "" || s.services[req.GetService()] {
+			current = s.status(stream.Context())
+		}
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+func (s *healthServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if s.registry.Check(ctx).Status != health.StatusOK {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
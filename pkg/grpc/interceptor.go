@@ -0,0 +1,230 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodScopes are the scopes an API client needs to call a method, they are the scopes of the
+// matching REST routes. The methods missing from the table, like the health checks and the
+// reflection, are served to every caller.
+var methodScopes = map[string][]enums.EnumScope{
+	"/futuagro.v1.CatalogService/GetItem":       {enums.ItemsRead},
+	"/futuagro.v1.CatalogService/ListItems":     {enums.ItemsRead},
+	"/futuagro.v1.CatalogService/CreateItem":    {enums.ItemsWrite},
+	"/futuagro.v1.CatalogService/UpdateItem":    {enums.ItemsWrite},
+	"/futuagro.v1.CatalogService/DeleteItem":    {enums.ItemsWrite},
+	"/futuagro.v1.CatalogService/GetVariant":    {enums.ItemsRead},
+	"/futuagro.v1.CatalogService/ListVariants":  {enums.ItemsRead},
+	"/futuagro.v1.CatalogService/CreateVariant": {enums.ItemsWrite},
+	"/futuagro.v1.CatalogService/UpdateVariant": {enums.ItemsWrite},
+	"/futuagro.v1.CatalogService/DeleteVariant": {enums.ItemsWrite},
+
+	"/futuagro.v1.SupplierService/GetSupplier":    {enums.SuppliersRead},
+	"/futuagro.v1.SupplierService/ListSuppliers":  {enums.SuppliersRead},
+	"/futuagro.v1.SupplierService/CreateSupplier": {enums.SuppliersWrite},
+	"/futuagro.v1.SupplierService/UpdateSupplier": {enums.SuppliersWrite},
+	"/futuagro.v1.SupplierService/DeleteSupplier": {enums.SuppliersWrite},
+
+	"/futuagro.v1.CropService/GetCrop":    {enums.CropsRead},
+	"/futuagro.v1.CropService/ListCrops":  {enums.CropsRead},
+	"/futuagro.v1.CropService/CreateCrop": {enums.CropsWrite},
+	"/futuagro.v1.CropService/UpdateCrop": {enums.CropsWrite},
+	"/futuagro.v1.CropService/DeleteCrop": {enums.CropsWrite},
+
+	"/futuagro.v1.UserService/GetUser":    {enums.UsersRead},
+	"/futuagro.v1.UserService/ListUsers":  {enums.UsersRead},
+	"/futuagro.v1.UserService/CreateUser": {enums.UsersWrite},
+	"/futuagro.v1.UserService/UpdateUser": {enums.UsersWrite},
+	"/futuagro.v1.UserService/DeleteUser": {enums.UsersWrite},
+}
+
+// signupMethods are the writes served to anonymous callers, the people sign up by themselves
+var signupMethods = map[string]bool{
+	"/futuagro.v1.UserService/CreateUser": true,
+}
+
+// authenticatedMethods are the methods refused to anonymous callers, the ones needing a scope other
+// than a read scope like the writes of the REST API guarded by RequireScopes, except the signup
+var authenticatedMethods = writeMethods(methodScopes, signupMethods)
+
+// writeMethods returns the methods of scopes needing a scope other than a read scope, except the
+// excluded ones
+func writeMethods(scopes map[string][]enums.EnumScope, excluded map[string]bool) map[string]bool {
+	methods := map[string]bool{}
+	for method, methodScopes := range scopes {
+		for _, scope := range methodScopes {
+			if !scope.IsRead() && !excluded[method] {
+				methods[method] = true
+			}
+		}
+	}
+	return methods
+}
+
+// interceptor plays for every call the part of the middlewares of the REST API: it tags the call
+// with a request ID and a log entry, identifies the caller from its key and the organization
+// it acts for, checks the scopes of the method, turns the errors of the domain into statuses,
+// recovers from panics and writes an access log line
+type interceptor struct {
+	logger        *logrus.Logger
+	apiClients    *services.APIClientService
+	sessions      *services.AuthService
+	organizations *services.OrganizationService
+	adminKey      string
+}
+
+func (i *interceptor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, requestID := i.begin(ctx, info.FullMethod)
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestID))
+	start := time.Now()
+	defer func() {
+		err = i.finish(ctx, start, recover(), err)
+	}()
+
+	if ctx, err = i.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptor) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx, requestID := i.begin(ss.Context(), info.FullMethod)
+	ss.SetHeader(metadata.Pairs("x-request-id", requestID))
+	start := time.Now()
+	defer func() {
+		err = i.finish(ctx, start, recover(), err)
+	}()
+
+	if ctx, err = i.authorize(ctx, info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// begin tags the context of a call with its request ID, the one sent in the x-request-id metadata
+// or a new one, and with its log entry
+func (i *interceptor) begin(ctx context.Context, method string) (context.Context, string) {
+	requestID := metadataValue(ctx, "x-request-id")
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	ctx = services.WithRequestID(ctx, requestID)
+	entry := i.logger.WithFields(logrus.Fields{
+		"requestId": requestID,
+		"method":    method,
+		"userAgent": metadataValue(ctx, "user-agent"),
+	})
+	return logging.NewContext(ctx, entry), requestID
+}
+
+// finish turns the outcome of a call into its status and writes the access log line
+func (i *interceptor) finish(ctx context.Context, start time.Time, recovered interface{}, err error) error {
+	if recovered != nil {
+		logging.FromContext(ctx).
+			WithField("stack", string(debug.Stack())).
+			Error(fmt.Sprintf("Panic serving the call: %v", recovered))
+		err = status.Error(codes.Internal, "Internal Server Error")
+	}
+	err = statusError(ctx, err)
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"code":       status.Code(err).String(),
+		"durationMs": float64(time.Since(start).Nanoseconds()) / 1e6,
+	}).Info("Call served")
+	return err
+}
+
+// authorize identifies the API client from the key sent in the x-api-key metadata or as a bearer
+// token, or the user from the token of its session, and applies the rules of the REST API: anonymous callers are served except for the
+// authenticated methods, the API clients need every scope of the method and act for the
+// organization sent in the x-organization-id metadata, if any
+func (i *interceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	key := metadataValue(ctx, "x-api-key")
+	if authorization := metadataValue(ctx, "authorization"); key == "" && len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		key = strings.TrimSpace(authorization[7:])
+	}
+	organizationID := metadataValue(ctx, "x-organization-id")
+	if key == "" {
+		if organizationID != "" {
+			return ctx, status.Error(codes.Unauthenticated, "Authentication required. Send an API key or a session token to act for an organization.")
+		}
+		if authenticatedMethods[method] {
+			return ctx, status.Error(codes.Unauthenticated, "Authentication required. Send an API key.")
+		}
+		return ctx, nil
+	}
+
+	var principal *models.Principal
+	var err error
+	if services.IsSessionToken(key) {
+		principal, err = i.sessions.AuthenticatePrincipal(ctx, key)
+	} else {
+		principal, err = i.apiClients.AuthenticatePrincipal(ctx, key, i.adminKey)
+	}
+	if err != nil {
+		return ctx, err
+	}
+	if principal == nil {
+		return ctx, status.Error(codes.Unauthenticated, "Authentication failed. Invalid API key.")
+	}
+	if organizationID != "" {
+		if principal, err = i.organizations.ActivateOrganization(ctx, principal, organizationID); err != nil {
+			return ctx, err
+		}
+		grpc.SetHeader(ctx, metadata.Pairs("x-organization-id", principal.OrganizationID))
+		logging.AddFields(ctx, logrus.Fields{"organizationId": principal.OrganizationID})
+	}
+	logging.AddFields(ctx, logrus.Fields{"principalType": principal.Type, "principalId": principal.ID})
+
+	for _, scope := range methodScopes[method] {
+		if !principal.HasScope(scope) {
+			return ctx, status.Error(codes.PermissionDenied, "Forbidden : missing scope "+scope.String()+".")
+		}
+	}
+	return services.WithPrincipal(ctx, principal), nil
+}
+
+// serverStream replaces the context of a stream with the one tagged by the interceptor
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
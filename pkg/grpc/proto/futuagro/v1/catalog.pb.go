@@ -0,0 +1,1283 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: futuagro/v1/catalog.proto
+
+package futuagrov1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Item is a product or service of the catalog
+type Item struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status               RecordStatus         `protobuf:"varint,3,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	Version              int64                `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Item) Reset()         { *m = Item{} }
+func (m *Item) String() string { return proto.CompactTextString(m) }
+func (*Item) ProtoMessage()    {}
+func (*Item) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{0}
+}
+
+func (m *Item) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Item.Unmarshal(m, b)
+}
+func (m *Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Item.Marshal(b, m, deterministic)
+}
+func (m *Item) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Item.Merge(m, src)
+}
+func (m *Item) XXX_Size() int {
+	return xxx_messageInfo_Item.Size(m)
+}
+func (m *Item) XXX_DiscardUnknown() {
+	xxx_messageInfo_Item.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Item proto.InternalMessageInfo
+
+func (m *Item) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Item) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Item) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+func (m *Item) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Item) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Item) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+// Variant is a variant of an item
+type Variant struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId               string               `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name                 string               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Status               RecordStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	Version              int64                `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Variant) Reset()         { *m = Variant{} }
+func (m *Variant) String() string { return proto.CompactTextString(m) }
+func (*Variant) ProtoMessage()    {}
+func (*Variant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{1}
+}
+
+func (m *Variant) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Variant.Unmarshal(m, b)
+}
+func (m *Variant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Variant.Marshal(b, m, deterministic)
+}
+func (m *Variant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Variant.Merge(m, src)
+}
+func (m *Variant) XXX_Size() int {
+	return xxx_messageInfo_Variant.Size(m)
+}
+func (m *Variant) XXX_DiscardUnknown() {
+	xxx_messageInfo_Variant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Variant proto.InternalMessageInfo
+
+func (m *Variant) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Variant) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+func (m *Variant) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Variant) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+func (m *Variant) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Variant) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Variant) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+// ItemInput holds the attributes of an item that are written
+type ItemInput struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               RecordStatus `protobuf:"varint,2,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ItemInput) Reset()         { *m = ItemInput{} }
+func (m *ItemInput) String() string { return proto.CompactTextString(m) }
+func (*ItemInput) ProtoMessage()    {}
+func (*ItemInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{2}
+}
+
+func (m *ItemInput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ItemInput.Unmarshal(m, b)
+}
+func (m *ItemInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ItemInput.Marshal(b, m, deterministic)
+}
+func (m *ItemInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ItemInput.Merge(m, src)
+}
+func (m *ItemInput) XXX_Size() int {
+	return xxx_messageInfo_ItemInput.Size(m)
+}
+func (m *ItemInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_ItemInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ItemInput proto.InternalMessageInfo
+
+func (m *ItemInput) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ItemInput) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+// VariantInput holds the attributes of a variant that are written
+type VariantInput struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               RecordStatus `protobuf:"varint,2,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *VariantInput) Reset()         { *m = VariantInput{} }
+func (m *VariantInput) String() string { return proto.CompactTextString(m) }
+func (*VariantInput) ProtoMessage()    {}
+func (*VariantInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{3}
+}
+
+func (m *VariantInput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VariantInput.Unmarshal(m, b)
+}
+func (m *VariantInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VariantInput.Marshal(b, m, deterministic)
+}
+func (m *VariantInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VariantInput.Merge(m, src)
+}
+func (m *VariantInput) XXX_Size() int {
+	return xxx_messageInfo_VariantInput.Size(m)
+}
+func (m *VariantInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_VariantInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VariantInput proto.InternalMessageInfo
+
+func (m *VariantInput) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VariantInput) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+type GetItemRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetItemRequest) Reset()         { *m = GetItemRequest{} }
+func (m *GetItemRequest) String() string { return proto.CompactTextString(m) }
+func (*GetItemRequest) ProtoMessage()    {}
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{4}
+}
+
+func (m *GetItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetItemRequest.Unmarshal(m, b)
+}
+func (m *GetItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetItemRequest.Marshal(b, m, deterministic)
+}
+func (m *GetItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetItemRequest.Merge(m, src)
+}
+func (m *GetItemRequest) XXX_Size() int {
+	return xxx_messageInfo_GetItemRequest.Size(m)
+}
+func (m *GetItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetItemRequest proto.InternalMessageInfo
+
+func (m *GetItemRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListItemsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListItemsRequest) Reset()         { *m = ListItemsRequest{} }
+func (m *ListItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListItemsRequest) ProtoMessage()    {}
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{5}
+}
+
+func (m *ListItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListItemsRequest.Unmarshal(m, b)
+}
+func (m *ListItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListItemsRequest.Merge(m, src)
+}
+func (m *ListItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListItemsRequest.Size(m)
+}
+func (m *ListItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListItemsRequest proto.InternalMessageInfo
+
+type CreateItemRequest struct {
+	Item                 *ItemInput `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateItemRequest) Reset()         { *m = CreateItemRequest{} }
+func (m *CreateItemRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateItemRequest) ProtoMessage()    {}
+func (*CreateItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{6}
+}
+
+func (m *CreateItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateItemRequest.Unmarshal(m, b)
+}
+func (m *CreateItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateItemRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateItemRequest.Merge(m, src)
+}
+func (m *CreateItemRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateItemRequest.Size(m)
+}
+func (m *CreateItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateItemRequest proto.InternalMessageInfo
+
+func (m *CreateItemRequest) GetItem() *ItemInput {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+// UpdateItemRequest only applies when the item is at version, like the If-Match header of the
+// REST API, unless version is 0
+type UpdateItemRequest struct {
+	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Item                 *ItemInput `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	Version              int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateItemRequest) Reset()         { *m = UpdateItemRequest{} }
+func (m *UpdateItemRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateItemRequest) ProtoMessage()    {}
+func (*UpdateItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{7}
+}
+
+func (m *UpdateItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateItemRequest.Unmarshal(m, b)
+}
+func (m *UpdateItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateItemRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateItemRequest.Merge(m, src)
+}
+func (m *UpdateItemRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateItemRequest.Size(m)
+}
+func (m *UpdateItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateItemRequest proto.InternalMessageInfo
+
+func (m *UpdateItemRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateItemRequest) GetItem() *ItemInput {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (m *UpdateItemRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DeleteItemRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteItemRequest) Reset()         { *m = DeleteItemRequest{} }
+func (m *DeleteItemRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteItemRequest) ProtoMessage()    {}
+func (*DeleteItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{8}
+}
+
+func (m *DeleteItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteItemRequest.Unmarshal(m, b)
+}
+func (m *DeleteItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteItemRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteItemRequest.Merge(m, src)
+}
+func (m *DeleteItemRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteItemRequest.Size(m)
+}
+func (m *DeleteItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteItemRequest proto.InternalMessageInfo
+
+func (m *DeleteItemRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteItemRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type GetVariantRequest struct {
+	ItemId               string   `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetVariantRequest) Reset()         { *m = GetVariantRequest{} }
+func (m *GetVariantRequest) String() string { return proto.CompactTextString(m) }
+func (*GetVariantRequest) ProtoMessage()    {}
+func (*GetVariantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{9}
+}
+
+func (m *GetVariantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVariantRequest.Unmarshal(m, b)
+}
+func (m *GetVariantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVariantRequest.Marshal(b, m, deterministic)
+}
+func (m *GetVariantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVariantRequest.Merge(m, src)
+}
+func (m *GetVariantRequest) XXX_Size() int {
+	return xxx_messageInfo_GetVariantRequest.Size(m)
+}
+func (m *GetVariantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVariantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVariantRequest proto.InternalMessageInfo
+
+func (m *GetVariantRequest) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+func (m *GetVariantRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListVariantsRequest struct {
+	ItemId               string   `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVariantsRequest) Reset()         { *m = ListVariantsRequest{} }
+func (m *ListVariantsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVariantsRequest) ProtoMessage()    {}
+func (*ListVariantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{10}
+}
+
+func (m *ListVariantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVariantsRequest.Unmarshal(m, b)
+}
+func (m *ListVariantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVariantsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListVariantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVariantsRequest.Merge(m, src)
+}
+func (m *ListVariantsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVariantsRequest.Size(m)
+}
+func (m *ListVariantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVariantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVariantsRequest proto.InternalMessageInfo
+
+func (m *ListVariantsRequest) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+type CreateVariantRequest struct {
+	ItemId               string        `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Variant              *VariantInput `protobuf:"bytes,2,opt,name=variant,proto3" json:"variant,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateVariantRequest) Reset()         { *m = CreateVariantRequest{} }
+func (m *CreateVariantRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateVariantRequest) ProtoMessage()    {}
+func (*CreateVariantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{11}
+}
+
+func (m *CreateVariantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateVariantRequest.Unmarshal(m, b)
+}
+func (m *CreateVariantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateVariantRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateVariantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateVariantRequest.Merge(m, src)
+}
+func (m *CreateVariantRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateVariantRequest.Size(m)
+}
+func (m *CreateVariantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateVariantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateVariantRequest proto.InternalMessageInfo
+
+func (m *CreateVariantRequest) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+func (m *CreateVariantRequest) GetVariant() *VariantInput {
+	if m != nil {
+		return m.Variant
+	}
+	return nil
+}
+
+type UpdateVariantRequest struct {
+	ItemId               string        `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Id                   string        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Variant              *VariantInput `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`
+	Version              int64         `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *UpdateVariantRequest) Reset()         { *m = UpdateVariantRequest{} }
+func (m *UpdateVariantRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateVariantRequest) ProtoMessage()    {}
+func (*UpdateVariantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{12}
+}
+
+func (m *UpdateVariantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateVariantRequest.Unmarshal(m, b)
+}
+func (m *UpdateVariantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateVariantRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateVariantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateVariantRequest.Merge(m, src)
+}
+func (m *UpdateVariantRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateVariantRequest.Size(m)
+}
+func (m *UpdateVariantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateVariantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateVariantRequest proto.InternalMessageInfo
+
+func (m *UpdateVariantRequest) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+func (m *UpdateVariantRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateVariantRequest) GetVariant() *VariantInput {
+	if m != nil {
+		return m.Variant
+	}
+	return nil
+}
+
+func (m *UpdateVariantRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DeleteVariantRequest struct {
+	ItemId               string   `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteVariantRequest) Reset()         { *m = DeleteVariantRequest{} }
+func (m *DeleteVariantRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteVariantRequest) ProtoMessage()    {}
+func (*DeleteVariantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce1215aa57315cdf, []int{13}
+}
+
+func (m *DeleteVariantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteVariantRequest.Unmarshal(m, b)
+}
+func (m *DeleteVariantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteVariantRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteVariantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVariantRequest.Merge(m, src)
+}
+func (m *DeleteVariantRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteVariantRequest.Size(m)
+}
+func (m *DeleteVariantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVariantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteVariantRequest proto.InternalMessageInfo
+
+func (m *DeleteVariantRequest) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+func (m *DeleteVariantRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteVariantRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Item)(nil), "futuagro.v1.Item")
+	proto.RegisterType((*Variant)(nil), "futuagro.v1.Variant")
+	proto.RegisterType((*ItemInput)(nil), "futuagro.v1.ItemInput")
+	proto.RegisterType((*VariantInput)(nil), "futuagro.v1.VariantInput")
+	proto.RegisterType((*GetItemRequest)(nil), "futuagro.v1.GetItemRequest")
+	proto.RegisterType((*ListItemsRequest)(nil), "futuagro.v1.ListItemsRequest")
+	proto.RegisterType((*CreateItemRequest)(nil), "futuagro.v1.CreateItemRequest")
+	proto.RegisterType((*UpdateItemRequest)(nil), "futuagro.v1.UpdateItemRequest")
+	proto.RegisterType((*DeleteItemRequest)(nil), "futuagro.v1.DeleteItemRequest")
+	proto.RegisterType((*GetVariantRequest)(nil), "futuagro.v1.GetVariantRequest")
+	proto.RegisterType((*ListVariantsRequest)(nil), "futuagro.v1.ListVariantsRequest")
+	proto.RegisterType((*CreateVariantRequest)(nil), "futuagro.v1.CreateVariantRequest")
+	proto.RegisterType((*UpdateVariantRequest)(nil), "futuagro.v1.UpdateVariantRequest")
+	proto.RegisterType((*DeleteVariantRequest)(nil), "futuagro.v1.DeleteVariantRequest")
+}
+
+func init() { proto.RegisterFile("futuagro/v1/catalog.proto", fileDescriptor_ce1215aa57315cdf) }
+
+var fileDescriptor_ce1215aa57315cdf = []byte{
+	// 662 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x6f, 0x93, 0x50,
+	0x14, 0x0f, 0xb4, 0x2b, 0xd9, 0xe9, 0xda, 0xd8, 0x6b, 0x33, 0x19, 0x8b, 0x8a, 0x3c, 0x35, 0x3e,
+	0xc0, 0xda, 0xf9, 0x62, 0xa6, 0x69, 0xb2, 0xcd, 0x6c, 0x35, 0x3e, 0x31, 0x67, 0xa2, 0x2f, 0x0b,
+	0x83, 0x3b, 0x42, 0x2c, 0x05, 0xe1, 0x42, 0xe2, 0x87, 0xd0, 0x0f, 0xe5, 0x37, 0xf2, 0x1b, 0x18,
+	0xb8, 0x40, 0xb9, 0x40, 0xff, 0x58, 0x7d, 0x03, 0xce, 0xf9, 0xfd, 0xce, 0x39, 0xbf, 0x7b, 0x7e,
+	0x00, 0x1c, 0x3d, 0x44, 0x24, 0x32, 0xec, 0xc0, 0xd3, 0xe2, 0xb1, 0x66, 0x1a, 0xc4, 0x98, 0x7b,
+	0xb6, 0xea, 0x07, 0x1e, 0xf1, 0x50, 0x37, 0x0f, 0xa9, 0xf1, 0x58, 0x3a, 0xb6, 0x3d, 0xcf, 0x9e,
+	0x63, 0x2d, 0x0d, 0xdd, 0x47, 0x0f, 0x1a, 0x76, 0x7d, 0xf2, 0x9d, 0x66, 0x4a, 0xcf, 0xab, 0x41,
+	0xe2, 0xb8, 0x38, 0x24, 0x86, 0xeb, 0x67, 0x09, 0x22, 0x53, 0xc5, 0x73, 0x5d, 0x6f, 0x41, 0x23,
+	0xca, 0x6f, 0x0e, 0xda, 0x33, 0x82, 0x5d, 0xd4, 0x07, 0xde, 0xb1, 0x44, 0x4e, 0xe6, 0x46, 0xfb,
+	0x3a, 0xef, 0x58, 0x08, 0x41, 0x7b, 0x61, 0xb8, 0x58, 0xe4, 0xd3, 0x27, 0xe9, 0x35, 0x1a, 0x43,
+	0x27, 0x24, 0x06, 0x89, 0x42, 0xb1, 0x25, 0x73, 0xa3, 0xfe, 0xe4, 0x48, 0x2d, 0xb5, 0xa8, 0xea,
+	0xd8, 0xf4, 0x02, 0xeb, 0x26, 0x4d, 0xd0, 0xb3, 0x44, 0x24, 0x82, 0x10, 0xe3, 0x20, 0x74, 0xbc,
+	0x85, 0xd8, 0x96, 0xb9, 0x51, 0x4b, 0xcf, 0x6f, 0xd1, 0x19, 0x74, 0xcd, 0x00, 0x1b, 0x04, 0xdf,
+	0x25, 0xdd, 0x8a, 0x7b, 0x32, 0x37, 0xea, 0x4e, 0x24, 0x95, 0x8e, 0xa2, 0xe6, 0xa3, 0xa8, 0x1f,
+	0xf3, 0x51, 0x74, 0xa0, 0xe9, 0xc9, 0x83, 0x04, 0x1c, 0xf9, 0x56, 0x01, 0xee, 0x6c, 0x06, 0xd3,
+	0xf4, 0xe4, 0x81, 0xf2, 0x83, 0x07, 0xe1, 0x93, 0x11, 0x38, 0xc6, 0x82, 0xd4, 0xc6, 0x7e, 0x02,
+	0x82, 0x43, 0xb0, 0x7b, 0xe7, 0x58, 0xd9, 0xe4, 0x9d, 0xe4, 0x76, 0xb6, 0xd4, 0xa3, 0xd5, 0xa8,
+	0x47, 0x7b, 0x07, 0x3d, 0xf6, 0xd6, 0xea, 0xd1, 0xf9, 0x17, 0x3d, 0x84, 0xbf, 0xd2, 0x43, 0x87,
+	0xfd, 0x64, 0x05, 0x66, 0x0b, 0x3f, 0x22, 0xc5, 0x9c, 0x5c, 0xe3, 0x9c, 0xfc, 0x96, 0x73, 0x2a,
+	0xb7, 0x70, 0x90, 0x49, 0xfc, 0x5f, 0x69, 0x65, 0xe8, 0x5f, 0x61, 0x92, 0x74, 0xab, 0xe3, 0x6f,
+	0x11, 0x0e, 0x6b, 0x07, 0xa8, 0x20, 0x78, 0xf4, 0xc1, 0x09, 0xd3, 0x94, 0x30, 0xcb, 0x51, 0xa6,
+	0x30, 0xb8, 0x48, 0xb5, 0x2a, 0x03, 0x5f, 0x42, 0x3b, 0x39, 0xda, 0x14, 0xda, 0x9d, 0x1c, 0x32,
+	0xb5, 0x0b, 0x39, 0xf4, 0x34, 0x47, 0x71, 0x60, 0x70, 0x9b, 0xea, 0xb5, 0xa6, 0x72, 0x41, 0xc8,
+	0x6f, 0x26, 0x2c, 0xaf, 0x41, 0x8b, 0x59, 0x03, 0xe5, 0x2d, 0x0c, 0x2e, 0xf1, 0x1c, 0xaf, 0x2f,
+	0x55, 0x82, 0xf3, 0x2c, 0xfc, 0x0d, 0x0c, 0xae, 0x30, 0xc9, 0xa4, 0xcf, 0xe1, 0xa5, 0xa5, 0xe6,
+	0x98, 0xa5, 0xa6, 0xbc, 0x7c, 0x21, 0x9e, 0x0a, 0x8f, 0x13, 0xf1, 0x32, 0x78, 0xb8, 0x09, 0xaf,
+	0x58, 0x30, 0xa4, 0xc2, 0x6e, 0x5b, 0xf0, 0x14, 0x84, 0x98, 0xa6, 0x66, 0x32, 0xb1, 0x67, 0x5e,
+	0x5e, 0x19, 0x3d, 0xcf, 0x54, 0x7e, 0x72, 0x30, 0xa4, 0xf2, 0xef, 0x38, 0x57, 0xb9, 0x6c, 0x6b,
+	0xdb, 0xb2, 0xab, 0x5f, 0x5d, 0xca, 0x67, 0x18, 0xd2, 0x33, 0xda, 0xb5, 0x9f, 0x95, 0xc7, 0x3f,
+	0xf9, 0xb5, 0x07, 0xfd, 0x0b, 0xfa, 0x19, 0xb8, 0xc1, 0x41, 0xec, 0x98, 0x18, 0xbd, 0x06, 0x21,
+	0xdb, 0x79, 0x74, 0xcc, 0xb4, 0xcd, 0x3a, 0x41, 0x1a, 0xd4, 0x36, 0x0e, 0x4d, 0x61, 0xbf, 0x30,
+	0x03, 0x7a, 0xca, 0xc4, 0xab, 0x26, 0x69, 0x80, 0x9f, 0x70, 0x68, 0x0a, 0xb0, 0x74, 0x0e, 0x7a,
+	0xc6, 0xa4, 0xd4, 0x2c, 0xd5, 0xdc, 0x01, 0x2c, 0x9d, 0x53, 0x21, 0xa8, 0x59, 0xaa, 0x89, 0xe0,
+	0x12, 0x60, 0xe9, 0x87, 0x0a, 0x41, 0xcd, 0x28, 0xd2, 0x61, 0xed, 0x95, 0xf7, 0x2e, 0xf9, 0x4e,
+	0xa2, 0x73, 0x80, 0xa5, 0x2d, 0x2a, 0x2c, 0x35, 0xbf, 0x48, 0xc3, 0xa6, 0xed, 0x40, 0xd7, 0x70,
+	0x50, 0x36, 0x07, 0x92, 0x6b, 0x7a, 0x56, 0x7c, 0xd3, 0xcc, 0x73, 0xc2, 0xa1, 0x6b, 0xe8, 0x31,
+	0xb6, 0x41, 0x2f, 0x1a, 0x84, 0xdd, 0xb2, 0xa7, 0x1e, 0xe3, 0x8c, 0x0a, 0x53, 0x93, 0x6b, 0x56,
+	0x30, 0xbd, 0x87, 0x1e, 0xb3, 0xd3, 0x15, 0xa6, 0xa6, 0x7d, 0x5f, 0xa5, 0xf6, 0xf9, 0xab, 0x2f,
+	0x93, 0x02, 0x6b, 0x7a, 0xae, 0xe6, 0x7f, 0xb5, 0x35, 0x3b, 0xf0, 0x4d, 0xfa, 0x83, 0xa2, 0x95,
+	0x7e, 0x46, 0xce, 0xf2, 0xeb, 0x78, 0x7c, 0xdf, 0x49, 0xa3, 0xa7, 0x7f, 0x06, 0x00, 0xaa, 0x65,
+	0x3f, 0x32, 0x13, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CatalogServiceClient interface {
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error)
+	// ListItems streams every item of the catalog
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (CatalogService_ListItemsClient, error)
+	CreateItem(ctx context.Context, in *CreateItemRequest, opts ...grpc.CallOption) (*Item, error)
+	UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*Item, error)
+	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetVariant(ctx context.Context, in *GetVariantRequest, opts ...grpc.CallOption) (*Variant, error)
+	// ListVariants streams the variants of an item
+	ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (CatalogService_ListVariantsClient, error)
+	CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*Variant, error)
+	UpdateVariant(ctx context.Context, in *UpdateVariantRequest, opts ...grpc.CallOption) (*Variant, error)
+	DeleteVariant(ctx context.Context, in *DeleteVariantRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type catalogServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCatalogServiceClient(cc *grpc.ClientConn) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error) {
+	out := new(Item)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/GetItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (CatalogService_ListItemsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CatalogService_serviceDesc.Streams[0], "/futuagro.v1.CatalogService/ListItems", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogServiceListItemsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CatalogService_ListItemsClient interface {
+	Recv() (*Item, error)
+	grpc.ClientStream
+}
+
+type catalogServiceListItemsClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogServiceListItemsClient) Recv() (*Item, error) {
+	m := new(Item)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogServiceClient) CreateItem(ctx context.Context, in *CreateItemRequest, opts ...grpc.CallOption) (*Item, error) {
+	out := new(Item)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/CreateItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*Item, error) {
+	out := new(Item)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/UpdateItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/DeleteItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetVariant(ctx context.Context, in *GetVariantRequest, opts ...grpc.CallOption) (*Variant, error) {
+	out := new(Variant)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/GetVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListVariants(ctx context.Context, in *ListVariantsRequest, opts ...grpc.CallOption) (CatalogService_ListVariantsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CatalogService_serviceDesc.Streams[1], "/futuagro.v1.CatalogService/ListVariants", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogServiceListVariantsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CatalogService_ListVariantsClient interface {
+	Recv() (*Variant, error)
+	grpc.ClientStream
+}
+
+type catalogServiceListVariantsClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogServiceListVariantsClient) Recv() (*Variant, error) {
+	m := new(Variant)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogServiceClient) CreateVariant(ctx context.Context, in *CreateVariantRequest, opts ...grpc.CallOption) (*Variant, error) {
+	out := new(Variant)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/CreateVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateVariant(ctx context.Context, in *UpdateVariantRequest, opts ...grpc.CallOption) (*Variant, error) {
+	out := new(Variant)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/UpdateVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteVariant(ctx context.Context, in *DeleteVariantRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CatalogService/DeleteVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogServiceServer is the server API for CatalogService service.
+type CatalogServiceServer interface {
+	GetItem(context.Context, *GetItemRequest) (*Item, error)
+	// ListItems streams every item of the catalog
+	ListItems(*ListItemsRequest, CatalogService_ListItemsServer) error
+	CreateItem(context.Context, *CreateItemRequest) (*Item, error)
+	UpdateItem(context.Context, *UpdateItemRequest) (*Item, error)
+	DeleteItem(context.Context, *DeleteItemRequest) (*empty.Empty, error)
+	GetVariant(context.Context, *GetVariantRequest) (*Variant, error)
+	// ListVariants streams the variants of an item
+	ListVariants(*ListVariantsRequest, CatalogService_ListVariantsServer) error
+	CreateVariant(context.Context, *CreateVariantRequest) (*Variant, error)
+	UpdateVariant(context.Context, *UpdateVariantRequest) (*Variant, error)
+	DeleteVariant(context.Context, *DeleteVariantRequest) (*empty.Empty, error)
+}
+
+// UnimplementedCatalogServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCatalogServiceServer struct {
+}
+
+func (*UnimplementedCatalogServiceServer) GetItem(ctx context.Context, req *GetItemRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (*UnimplementedCatalogServiceServer) ListItems(req *ListItemsRequest, srv CatalogService_ListItemsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (*UnimplementedCatalogServiceServer) CreateItem(ctx context.Context, req *CreateItemRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateItem not implemented")
+}
+func (*UnimplementedCatalogServiceServer) UpdateItem(ctx context.Context, req *UpdateItemRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItem not implemented")
+}
+func (*UnimplementedCatalogServiceServer) DeleteItem(ctx context.Context, req *DeleteItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteItem not implemented")
+}
+func (*UnimplementedCatalogServiceServer) GetVariant(ctx context.Context, req *GetVariantRequest) (*Variant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariant not implemented")
+}
+func (*UnimplementedCatalogServiceServer) ListVariants(req *ListVariantsRequest, srv CatalogService_ListVariantsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListVariants not implemented")
+}
+func (*UnimplementedCatalogServiceServer) CreateVariant(ctx context.Context, req *CreateVariantRequest) (*Variant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVariant not implemented")
+}
+func (*UnimplementedCatalogServiceServer) UpdateVariant(ctx context.Context, req *UpdateVariantRequest) (*Variant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVariant not implemented")
+}
+func (*UnimplementedCatalogServiceServer) DeleteVariant(ctx context.Context, req *DeleteVariantRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVariant not implemented")
+}
+
+func RegisterCatalogServiceServer(s *grpc.Server, srv CatalogServiceServer) {
+	s.RegisterService(&_CatalogService_serviceDesc, srv)
+}
+
+func _CatalogService_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/GetItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).ListItems(m, &catalogServiceListItemsServer{stream})
+}
+
+type CatalogService_ListItemsServer interface {
+	Send(*Item) error
+	grpc.ServerStream
+}
+
+type catalogServiceListItemsServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogServiceListItemsServer) Send(m *Item) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CatalogService_CreateItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/CreateItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateItem(ctx, req.(*CreateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/UpdateItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateItem(ctx, req.(*UpdateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/DeleteItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteItem(ctx, req.(*DeleteItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/GetVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetVariant(ctx, req.(*GetVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListVariants_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVariantsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).ListVariants(m, &catalogServiceListVariantsServer{stream})
+}
+
+type CatalogService_ListVariantsServer interface {
+	Send(*Variant) error
+	grpc.ServerStream
+}
+
+type catalogServiceListVariantsServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogServiceListVariantsServer) Send(m *Variant) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CatalogService_CreateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/CreateVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateVariant(ctx, req.(*CreateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/UpdateVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateVariant(ctx, req.(*UpdateVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CatalogService/DeleteVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteVariant(ctx, req.(*DeleteVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CatalogService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "futuagro.v1.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetItem",
+			Handler:    _CatalogService_GetItem_Handler,
+		},
+		{
+			MethodName: "CreateItem",
+			Handler:    _CatalogService_CreateItem_Handler,
+		},
+		{
+			MethodName: "UpdateItem",
+			Handler:    _CatalogService_UpdateItem_Handler,
+		},
+		{
+			MethodName: "DeleteItem",
+			Handler:    _CatalogService_DeleteItem_Handler,
+		},
+		{
+			MethodName: "GetVariant",
+			Handler:    _CatalogService_GetVariant_Handler,
+		},
+		{
+			MethodName: "CreateVariant",
+			Handler:    _CatalogService_CreateVariant_Handler,
+		},
+		{
+			MethodName: "UpdateVariant",
+			Handler:    _CatalogService_UpdateVariant_Handler,
+		},
+		{
+			MethodName: "DeleteVariant",
+			Handler:    _CatalogService_DeleteVariant_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListItems",
+			Handler:       _CatalogService_ListItems_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListVariants",
+			Handler:       _CatalogService_ListVariants_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "futuagro/v1/catalog.proto",
+}
@@ -0,0 +1,71 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: futuagro/v1/common.proto
+
+package futuagrov1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// RecordStatus is the status of a record, the inactive records are soft deleted. A record
+// written without a status is active.
+type RecordStatus int32
+
+const (
+	RecordStatus_RECORD_STATUS_UNSPECIFIED RecordStatus = 0
+	RecordStatus_RECORD_STATUS_ACTIVE      RecordStatus = 1
+	RecordStatus_RECORD_STATUS_INACTIVE    RecordStatus = 2
+)
+
+var RecordStatus_name = map[int32]string{
+	0: "RECORD_STATUS_UNSPECIFIED",
+	1: "RECORD_STATUS_ACTIVE",
+	2: "RECORD_STATUS_INACTIVE",
+}
+
+var RecordStatus_value = map[string]int32{
+	"RECORD_STATUS_UNSPECIFIED": 0,
+	"RECORD_STATUS_ACTIVE":      1,
+	"RECORD_STATUS_INACTIVE":    2,
+}
+
+func (x RecordStatus) String() string {
+	return proto.EnumName(RecordStatus_name, int32(x))
+}
+
+func (RecordStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fb65823d2bc770ef, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("futuagro.v1.RecordStatus", RecordStatus_name, RecordStatus_value)
+}
+
+func init() { proto.RegisterFile("futuagro/v1/common.proto", fileDescriptor_fb65823d2bc770ef) }
+
+var fileDescriptor_fb65823d2bc770ef = []byte{
+	// 160 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x48, 0x2b, 0x2d, 0x29,
+	0x4d, 0x4c, 0x2f, 0xca, 0xd7, 0x2f, 0x33, 0xd4, 0x4f, 0xce, 0xcf, 0xcd, 0xcd, 0xcf, 0xd3, 0x2b,
+	0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x86, 0xc9, 0xe8, 0x95, 0x19, 0x6a, 0x25, 0x73, 0xf1, 0x04,
+	0xa5, 0x26, 0xe7, 0x17, 0xa5, 0x04, 0x97, 0x24, 0x96, 0x94, 0x16, 0x0b, 0xc9, 0x72, 0x49, 0x06,
+	0xb9, 0x3a, 0xfb, 0x07, 0xb9, 0xc4, 0x07, 0x87, 0x38, 0x86, 0x84, 0x06, 0xc7, 0x87, 0xfa, 0x05,
+	0x07, 0xb8, 0x3a, 0x7b, 0xba, 0x79, 0xba, 0xba, 0x08, 0x30, 0x08, 0x49, 0x70, 0x89, 0xa0, 0x4a,
+	0x3b, 0x3a, 0x87, 0x78, 0x86, 0xb9, 0x0a, 0x30, 0x0a, 0x49, 0x71, 0x89, 0xa1, 0xca, 0x78, 0xfa,
+	0x41, 0xe5, 0x98, 0x9c, 0x4c, 0xa2, 0x8c, 0xe0, 0x76, 0x26, 0xe7, 0xe7, 0xea, 0x17, 0x64, 0xa7,
+	0xeb, 0xa7, 0x17, 0x15, 0x24, 0xeb, 0x83, 0x1d, 0xa4, 0x8f, 0xe4, 0x52, 0x6b, 0x18, 0xbb, 0xcc,
+	0x30, 0x89, 0x0d, 0x2c, 0x6b, 0x0c, 0x18, 0x00, 0x84, 0xe5, 0xda, 0x84, 0xca, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,719 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: futuagro/v1/crops.proto
+
+package futuagrov1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Crop is a crop of a variant grown by a supplier or a user in a city
+type Crop struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CityId               string               `protobuf:"bytes,2,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	VariantId            string               `protobuf:"bytes,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	SupplierId           string               `protobuf:"bytes,4,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	PlantingTime         *timestamp.Timestamp `protobuf:"bytes,5,opt,name=planting_time,json=plantingTime,proto3" json:"planting_time,omitempty"`
+	HarvestTime          *timestamp.Timestamp `protobuf:"bytes,6,opt,name=harvest_time,json=harvestTime,proto3" json:"harvest_time,omitempty"`
+	Version              int64                `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,9,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Crop) Reset()         { *m = Crop{} }
+func (m *Crop) String() string { return proto.CompactTextString(m) }
+func (*Crop) ProtoMessage()    {}
+func (*Crop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{0}
+}
+
+func (m *Crop) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Crop.Unmarshal(m, b)
+}
+func (m *Crop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Crop.Marshal(b, m, deterministic)
+}
+func (m *Crop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Crop.Merge(m, src)
+}
+func (m *Crop) XXX_Size() int {
+	return xxx_messageInfo_Crop.Size(m)
+}
+func (m *Crop) XXX_DiscardUnknown() {
+	xxx_messageInfo_Crop.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Crop proto.InternalMessageInfo
+
+func (m *Crop) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Crop) GetCityId() string {
+	if m != nil {
+		return m.CityId
+	}
+	return ""
+}
+
+func (m *Crop) GetVariantId() string {
+	if m != nil {
+		return m.VariantId
+	}
+	return ""
+}
+
+func (m *Crop) GetSupplierId() string {
+	if m != nil {
+		return m.SupplierId
+	}
+	return ""
+}
+
+func (m *Crop) GetPlantingTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PlantingTime
+	}
+	return nil
+}
+
+func (m *Crop) GetHarvestTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.HarvestTime
+	}
+	return nil
+}
+
+func (m *Crop) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Crop) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Crop) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+// CropInput holds the attributes of a crop that are written
+type CropInput struct {
+	CityId               string               `protobuf:"bytes,1,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	VariantId            string               `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	SupplierId           string               `protobuf:"bytes,3,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	PlantingTime         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=planting_time,json=plantingTime,proto3" json:"planting_time,omitempty"`
+	HarvestTime          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=harvest_time,json=harvestTime,proto3" json:"harvest_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CropInput) Reset()         { *m = CropInput{} }
+func (m *CropInput) String() string { return proto.CompactTextString(m) }
+func (*CropInput) ProtoMessage()    {}
+func (*CropInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{1}
+}
+
+func (m *CropInput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CropInput.Unmarshal(m, b)
+}
+func (m *CropInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CropInput.Marshal(b, m, deterministic)
+}
+func (m *CropInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CropInput.Merge(m, src)
+}
+func (m *CropInput) XXX_Size() int {
+	return xxx_messageInfo_CropInput.Size(m)
+}
+func (m *CropInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_CropInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CropInput proto.InternalMessageInfo
+
+func (m *CropInput) GetCityId() string {
+	if m != nil {
+		return m.CityId
+	}
+	return ""
+}
+
+func (m *CropInput) GetVariantId() string {
+	if m != nil {
+		return m.VariantId
+	}
+	return ""
+}
+
+func (m *CropInput) GetSupplierId() string {
+	if m != nil {
+		return m.SupplierId
+	}
+	return ""
+}
+
+func (m *CropInput) GetPlantingTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PlantingTime
+	}
+	return nil
+}
+
+func (m *CropInput) GetHarvestTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.HarvestTime
+	}
+	return nil
+}
+
+type GetCropRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCropRequest) Reset()         { *m = GetCropRequest{} }
+func (m *GetCropRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCropRequest) ProtoMessage()    {}
+func (*GetCropRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{2}
+}
+
+func (m *GetCropRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCropRequest.Unmarshal(m, b)
+}
+func (m *GetCropRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCropRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCropRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCropRequest.Merge(m, src)
+}
+func (m *GetCropRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCropRequest.Size(m)
+}
+func (m *GetCropRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCropRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCropRequest proto.InternalMessageInfo
+
+func (m *GetCropRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListCropsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCropsRequest) Reset()         { *m = ListCropsRequest{} }
+func (m *ListCropsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCropsRequest) ProtoMessage()    {}
+func (*ListCropsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{3}
+}
+
+func (m *ListCropsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCropsRequest.Unmarshal(m, b)
+}
+func (m *ListCropsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCropsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCropsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCropsRequest.Merge(m, src)
+}
+func (m *ListCropsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCropsRequest.Size(m)
+}
+func (m *ListCropsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCropsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCropsRequest proto.InternalMessageInfo
+
+type CreateCropRequest struct {
+	Crop                 *CropInput `protobuf:"bytes,1,opt,name=crop,proto3" json:"crop,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateCropRequest) Reset()         { *m = CreateCropRequest{} }
+func (m *CreateCropRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateCropRequest) ProtoMessage()    {}
+func (*CreateCropRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{4}
+}
+
+func (m *CreateCropRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCropRequest.Unmarshal(m, b)
+}
+func (m *CreateCropRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCropRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateCropRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCropRequest.Merge(m, src)
+}
+func (m *CreateCropRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateCropRequest.Size(m)
+}
+func (m *CreateCropRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCropRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCropRequest proto.InternalMessageInfo
+
+func (m *CreateCropRequest) GetCrop() *CropInput {
+	if m != nil {
+		return m.Crop
+	}
+	return nil
+}
+
+// UpdateCropRequest only applies when the crop is at version, like the If-Match header of the
+// REST API, unless version is 0
+type UpdateCropRequest struct {
+	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Crop                 *CropInput `protobuf:"bytes,2,opt,name=crop,proto3" json:"crop,omitempty"`
+	Version              int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateCropRequest) Reset()         { *m = UpdateCropRequest{} }
+func (m *UpdateCropRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateCropRequest) ProtoMessage()    {}
+func (*UpdateCropRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{5}
+}
+
+func (m *UpdateCropRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCropRequest.Unmarshal(m, b)
+}
+func (m *UpdateCropRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCropRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateCropRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCropRequest.Merge(m, src)
+}
+func (m *UpdateCropRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateCropRequest.Size(m)
+}
+func (m *UpdateCropRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCropRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCropRequest proto.InternalMessageInfo
+
+func (m *UpdateCropRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateCropRequest) GetCrop() *CropInput {
+	if m != nil {
+		return m.Crop
+	}
+	return nil
+}
+
+func (m *UpdateCropRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DeleteCropRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCropRequest) Reset()         { *m = DeleteCropRequest{} }
+func (m *DeleteCropRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCropRequest) ProtoMessage()    {}
+func (*DeleteCropRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4dec166c37ac0d3, []int{6}
+}
+
+func (m *DeleteCropRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCropRequest.Unmarshal(m, b)
+}
+func (m *DeleteCropRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCropRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteCropRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCropRequest.Merge(m, src)
+}
+func (m *DeleteCropRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCropRequest.Size(m)
+}
+func (m *DeleteCropRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCropRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCropRequest proto.InternalMessageInfo
+
+func (m *DeleteCropRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteCropRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Crop)(nil), "futuagro.v1.Crop")
+	proto.RegisterType((*CropInput)(nil), "futuagro.v1.CropInput")
+	proto.RegisterType((*GetCropRequest)(nil), "futuagro.v1.GetCropRequest")
+	proto.RegisterType((*ListCropsRequest)(nil), "futuagro.v1.ListCropsRequest")
+	proto.RegisterType((*CreateCropRequest)(nil), "futuagro.v1.CreateCropRequest")
+	proto.RegisterType((*UpdateCropRequest)(nil), "futuagro.v1.UpdateCropRequest")
+	proto.RegisterType((*DeleteCropRequest)(nil), "futuagro.v1.DeleteCropRequest")
+}
+
+func init() { proto.RegisterFile("futuagro/v1/crops.proto", fileDescriptor_b4dec166c37ac0d3) }
+
+var fileDescriptor_b4dec166c37ac0d3 = []byte{
+	// 514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xc1, 0x6e, 0x9b, 0x40,
+	0x10, 0x86, 0x05, 0x38, 0x76, 0x19, 0xd2, 0xa8, 0xde, 0x43, 0x82, 0x88, 0xd2, 0x58, 0x9c, 0xac,
+	0x1e, 0xa0, 0x76, 0x7b, 0xa9, 0xac, 0xc8, 0x52, 0x93, 0xaa, 0xb2, 0xd4, 0x13, 0x6d, 0x2f, 0xbd,
+	0x44, 0x04, 0x36, 0x74, 0x55, 0x9b, 0xdd, 0x2e, 0x0b, 0x52, 0xce, 0x7d, 0xc2, 0xbe, 0x43, 0x1f,
+	0xa4, 0xda, 0xc5, 0x60, 0xc0, 0x96, 0x51, 0xd5, 0x9b, 0x99, 0xff, 0xff, 0x87, 0xf1, 0xb7, 0xc3,
+	0xc2, 0xc5, 0x63, 0x2e, 0xf2, 0x30, 0xe1, 0xd4, 0x2f, 0x66, 0x7e, 0xc4, 0x29, 0xcb, 0x3c, 0xc6,
+	0xa9, 0xa0, 0xc8, 0xaa, 0x04, 0xaf, 0x98, 0x39, 0x97, 0x09, 0xa5, 0xc9, 0x1a, 0xfb, 0x4a, 0x7a,
+	0xc8, 0x1f, 0x7d, 0xbc, 0x61, 0xe2, 0xa9, 0x74, 0x3a, 0xd7, 0x5d, 0x51, 0x90, 0x0d, 0xce, 0x44,
+	0xb8, 0x61, 0xa5, 0xc1, 0xfd, 0x65, 0xc0, 0xe0, 0x96, 0x53, 0x86, 0xce, 0x40, 0x27, 0xb1, 0xad,
+	0x4d, 0xb4, 0xa9, 0x19, 0xe8, 0x24, 0x46, 0x17, 0x30, 0x8a, 0x88, 0x78, 0xba, 0x27, 0xb1, 0xad,
+	0xab, 0xe2, 0x50, 0x3e, 0xae, 0x62, 0x74, 0x05, 0x50, 0x84, 0x9c, 0x84, 0xa9, 0x90, 0x9a, 0xa1,
+	0x34, 0x73, 0x5b, 0x59, 0xc5, 0xe8, 0x1a, 0xac, 0x2c, 0x67, 0x6c, 0x4d, 0x30, 0x97, 0xfa, 0x40,
+	0xe9, 0x50, 0x95, 0x56, 0x31, 0x5a, 0xc2, 0x73, 0xb6, 0x0e, 0x53, 0x41, 0xd2, 0xe4, 0x5e, 0x4e,
+	0x63, 0x9f, 0x4c, 0xb4, 0xa9, 0x35, 0x77, 0xbc, 0x72, 0x54, 0xaf, 0x1a, 0xd5, 0xfb, 0x52, 0x8d,
+	0x1a, 0x9c, 0x56, 0x01, 0x59, 0x42, 0x37, 0x70, 0xfa, 0x3d, 0xe4, 0x05, 0xce, 0x44, 0x99, 0x1f,
+	0xf6, 0xe6, 0xad, 0xad, 0x5f, 0xc5, 0x6d, 0x18, 0x15, 0x98, 0x67, 0x84, 0xa6, 0xf6, 0x68, 0xa2,
+	0x4d, 0x8d, 0xa0, 0x7a, 0x44, 0x0b, 0xb0, 0x22, 0x8e, 0x43, 0x81, 0xcb, 0xbe, 0xcf, 0x7a, 0xfb,
+	0x42, 0x69, 0x57, 0x6d, 0x17, 0x60, 0xe5, 0x2c, 0xae, 0xc3, 0x66, 0x7f, 0xb8, 0xb4, 0xcb, 0x82,
+	0xfb, 0x47, 0x03, 0x53, 0x9e, 0xc2, 0x2a, 0x65, 0xb9, 0x68, 0xa2, 0xd7, 0x8e, 0xa0, 0xd7, 0x7b,
+	0xd0, 0x1b, 0xfd, 0xe8, 0x07, 0xff, 0x89, 0xfe, 0xe4, 0x9f, 0xd0, 0xbb, 0x13, 0x38, 0xfb, 0x88,
+	0x85, 0xfc, 0xa3, 0x01, 0xfe, 0x99, 0xe3, 0x4c, 0x74, 0xb7, 0xce, 0x45, 0xf0, 0xe2, 0x13, 0xc9,
+	0x94, 0x25, 0xdb, 0x7a, 0xdc, 0x25, 0x8c, 0x6f, 0x15, 0xe7, 0x66, 0xf0, 0x15, 0x0c, 0xe4, 0x17,
+	0xa1, 0xa2, 0xd6, 0xfc, 0xdc, 0x6b, 0x7c, 0x11, 0x5e, 0x4d, 0x32, 0x50, 0x1e, 0x97, 0xc0, 0xf8,
+	0xab, 0x62, 0x7d, 0xe4, 0xcd, 0x75, 0x43, 0xbd, 0xbf, 0x61, 0x73, 0x85, 0x8c, 0xd6, 0x0a, 0xb9,
+	0x37, 0x30, 0xbe, 0xc3, 0x6b, 0x7c, 0xfc, 0x55, 0x8d, 0xb8, 0xde, 0x8a, 0xcf, 0x7f, 0xeb, 0x60,
+	0xc9, 0xe4, 0x67, 0xcc, 0x0b, 0x12, 0x61, 0xf4, 0x0e, 0x46, 0x5b, 0x60, 0xe8, 0xb2, 0x35, 0x51,
+	0x1b, 0xa3, 0x33, 0xde, 0x1b, 0x17, 0x2d, 0xc1, 0xac, 0x49, 0xa2, 0xab, 0x96, 0xde, 0x25, 0x7c,
+	0x20, 0xfe, 0x5a, 0x43, 0x4b, 0x80, 0x1d, 0x76, 0xf4, 0xb2, 0x63, 0xe9, 0x9c, 0xc7, 0xe1, 0x09,
+	0x60, 0x87, 0xbd, 0xd3, 0x60, 0xef, 0x3c, 0x0e, 0x35, 0xb8, 0x03, 0xd8, 0xc1, 0xec, 0x34, 0xd8,
+	0xa3, 0xec, 0x9c, 0xef, 0x6d, 0xe1, 0x07, 0x79, 0x11, 0xbe, 0x7f, 0xfb, 0x6d, 0x5e, 0x07, 0x23,
+	0xba, 0xf1, 0xd9, 0x8f, 0xc4, 0x4f, 0x38, 0x8b, 0xca, 0x3b, 0xd1, 0x6f, 0xdc, 0xb1, 0x8b, 0xea,
+	0x77, 0x31, 0x7b, 0x18, 0x2a, 0xf5, 0xcd, 0xdf, 0x01, 0x00, 0x8f, 0x7d, 0xaf, 0x4c, 0x84, 0x05,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CropServiceClient is the client API for CropService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CropServiceClient interface {
+	GetCrop(ctx context.Context, in *GetCropRequest, opts ...grpc.CallOption) (*Crop, error)
+	// ListCrops streams every crop
+	ListCrops(ctx context.Context, in *ListCropsRequest, opts ...grpc.CallOption) (CropService_ListCropsClient, error)
+	CreateCrop(ctx context.Context, in *CreateCropRequest, opts ...grpc.CallOption) (*Crop, error)
+	UpdateCrop(ctx context.Context, in *UpdateCropRequest, opts ...grpc.CallOption) (*Crop, error)
+	DeleteCrop(ctx context.Context, in *DeleteCropRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type cropServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCropServiceClient(cc *grpc.ClientConn) CropServiceClient {
+	return &cropServiceClient{cc}
+}
+
+func (c *cropServiceClient) GetCrop(ctx context.Context, in *GetCropRequest, opts ...grpc.CallOption) (*Crop, error) {
+	out := new(Crop)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CropService/GetCrop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cropServiceClient) ListCrops(ctx context.Context, in *ListCropsRequest, opts ...grpc.CallOption) (CropService_ListCropsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CropService_serviceDesc.Streams[0], "/futuagro.v1.CropService/ListCrops", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cropServiceListCropsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CropService_ListCropsClient interface {
+	Recv() (*Crop, error)
+	grpc.ClientStream
+}
+
+type cropServiceListCropsClient struct {
+	grpc.ClientStream
+}
+
+func (x *cropServiceListCropsClient) Recv() (*Crop, error) {
+	m := new(Crop)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cropServiceClient) CreateCrop(ctx context.Context, in *CreateCropRequest, opts ...grpc.CallOption) (*Crop, error) {
+	out := new(Crop)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CropService/CreateCrop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cropServiceClient) UpdateCrop(ctx context.Context, in *UpdateCropRequest, opts ...grpc.CallOption) (*Crop, error) {
+	out := new(Crop)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CropService/UpdateCrop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cropServiceClient) DeleteCrop(ctx context.Context, in *DeleteCropRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.CropService/DeleteCrop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CropServiceServer is the server API for CropService service.
+type CropServiceServer interface {
+	GetCrop(context.Context, *GetCropRequest) (*Crop, error)
+	// ListCrops streams every crop
+	ListCrops(*ListCropsRequest, CropService_ListCropsServer) error
+	CreateCrop(context.Context, *CreateCropRequest) (*Crop, error)
+	UpdateCrop(context.Context, *UpdateCropRequest) (*Crop, error)
+	DeleteCrop(context.Context, *DeleteCropRequest) (*empty.Empty, error)
+}
+
+// UnimplementedCropServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCropServiceServer struct {
+}
+
+func (*UnimplementedCropServiceServer) GetCrop(ctx context.Context, req *GetCropRequest) (*Crop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCrop not implemented")
+}
+func (*UnimplementedCropServiceServer) ListCrops(req *ListCropsRequest, srv CropService_ListCropsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListCrops not implemented")
+}
+func (*UnimplementedCropServiceServer) CreateCrop(ctx context.Context, req *CreateCropRequest) (*Crop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCrop not implemented")
+}
+func (*UnimplementedCropServiceServer) UpdateCrop(ctx context.Context, req *UpdateCropRequest) (*Crop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCrop not implemented")
+}
+func (*UnimplementedCropServiceServer) DeleteCrop(ctx context.Context, req *DeleteCropRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCrop not implemented")
+}
+
+func RegisterCropServiceServer(s *grpc.Server, srv CropServiceServer) {
+	s.RegisterService(&_CropService_serviceDesc, srv)
+}
+
+func _CropService_GetCrop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCropRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CropServiceServer).GetCrop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CropService/GetCrop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CropServiceServer).GetCrop(ctx, req.(*GetCropRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CropService_ListCrops_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCropsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CropServiceServer).ListCrops(m, &cropServiceListCropsServer{stream})
+}
+
+type CropService_ListCropsServer interface {
+	Send(*Crop) error
+	grpc.ServerStream
+}
+
+type cropServiceListCropsServer struct {
+	grpc.ServerStream
+}
+
+func (x *cropServiceListCropsServer) Send(m *Crop) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CropService_CreateCrop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCropRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CropServiceServer).CreateCrop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CropService/CreateCrop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CropServiceServer).CreateCrop(ctx, req.(*CreateCropRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CropService_UpdateCrop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCropRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CropServiceServer).UpdateCrop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CropService/UpdateCrop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CropServiceServer).UpdateCrop(ctx, req.(*UpdateCropRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CropService_DeleteCrop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCropRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CropServiceServer).DeleteCrop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.CropService/DeleteCrop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CropServiceServer).DeleteCrop(ctx, req.(*DeleteCropRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CropService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "futuagro.v1.CropService",
+	HandlerType: (*CropServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCrop",
+			Handler:    _CropService_GetCrop_Handler,
+		},
+		{
+			MethodName: "CreateCrop",
+			Handler:    _CropService_CreateCrop_Handler,
+		},
+		{
+			MethodName: "UpdateCrop",
+			Handler:    _CropService_UpdateCrop_Handler,
+		},
+		{
+			MethodName: "DeleteCrop",
+			Handler:    _CropService_DeleteCrop_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListCrops",
+			Handler:       _CropService_ListCrops_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "futuagro/v1/crops.proto",
+}
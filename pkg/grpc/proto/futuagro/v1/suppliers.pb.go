@@ -0,0 +1,798 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: futuagro/v1/suppliers.proto
+
+package futuagrov1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Supplier is a farmer selling its crops
+type Supplier struct {
+	Id             string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Surname        string `protobuf:"bytes,3,opt,name=surname,proto3" json:"surname,omitempty"`
+	DocumentType   string `protobuf:"bytes,4,opt,name=document_type,json=documentType,proto3" json:"document_type,omitempty"`
+	DocumentNumber string `protobuf:"bytes,5,opt,name=document_number,json=documentNumber,proto3" json:"document_number,omitempty"`
+	CityId         string `protobuf:"bytes,6,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	// country_id is the country of the city, the document of a supplier is unique within it
+	CountryId            string               `protobuf:"bytes,7,opt,name=country_id,json=countryId,proto3" json:"country_id,omitempty"`
+	Email                string               `protobuf:"bytes,8,opt,name=email,proto3" json:"email,omitempty"`
+	AddressLine1         string               `protobuf:"bytes,9,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	PhoneNumber          string               `protobuf:"bytes,10,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Status               RecordStatus         `protobuf:"varint,11,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	Version              int64                `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,13,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,14,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Supplier) Reset()         { *m = Supplier{} }
+func (m *Supplier) String() string { return proto.CompactTextString(m) }
+func (*Supplier) ProtoMessage()    {}
+func (*Supplier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{0}
+}
+
+func (m *Supplier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Supplier.Unmarshal(m, b)
+}
+func (m *Supplier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Supplier.Marshal(b, m, deterministic)
+}
+func (m *Supplier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Supplier.Merge(m, src)
+}
+func (m *Supplier) XXX_Size() int {
+	return xxx_messageInfo_Supplier.Size(m)
+}
+func (m *Supplier) XXX_DiscardUnknown() {
+	xxx_messageInfo_Supplier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Supplier proto.InternalMessageInfo
+
+func (m *Supplier) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Supplier) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Supplier) GetSurname() string {
+	if m != nil {
+		return m.Surname
+	}
+	return ""
+}
+
+func (m *Supplier) GetDocumentType() string {
+	if m != nil {
+		return m.DocumentType
+	}
+	return ""
+}
+
+func (m *Supplier) GetDocumentNumber() string {
+	if m != nil {
+		return m.DocumentNumber
+	}
+	return ""
+}
+
+func (m *Supplier) GetCityId() string {
+	if m != nil {
+		return m.CityId
+	}
+	return ""
+}
+
+func (m *Supplier) GetCountryId() string {
+	if m != nil {
+		return m.CountryId
+	}
+	return ""
+}
+
+func (m *Supplier) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Supplier) GetAddressLine1() string {
+	if m != nil {
+		return m.AddressLine1
+	}
+	return ""
+}
+
+func (m *Supplier) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *Supplier) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+func (m *Supplier) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Supplier) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Supplier) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+// SupplierInput holds the attributes of a supplier that are written
+type SupplierInput struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Surname              string       `protobuf:"bytes,2,opt,name=surname,proto3" json:"surname,omitempty"`
+	DocumentType         string       `protobuf:"bytes,3,opt,name=document_type,json=documentType,proto3" json:"document_type,omitempty"`
+	DocumentNumber       string       `protobuf:"bytes,4,opt,name=document_number,json=documentNumber,proto3" json:"document_number,omitempty"`
+	CityId               string       `protobuf:"bytes,5,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Email                string       `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	AddressLine1         string       `protobuf:"bytes,7,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	PhoneNumber          string       `protobuf:"bytes,8,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Status               RecordStatus `protobuf:"varint,9,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SupplierInput) Reset()         { *m = SupplierInput{} }
+func (m *SupplierInput) String() string { return proto.CompactTextString(m) }
+func (*SupplierInput) ProtoMessage()    {}
+func (*SupplierInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{1}
+}
+
+func (m *SupplierInput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SupplierInput.Unmarshal(m, b)
+}
+func (m *SupplierInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SupplierInput.Marshal(b, m, deterministic)
+}
+func (m *SupplierInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SupplierInput.Merge(m, src)
+}
+func (m *SupplierInput) XXX_Size() int {
+	return xxx_messageInfo_SupplierInput.Size(m)
+}
+func (m *SupplierInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_SupplierInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SupplierInput proto.InternalMessageInfo
+
+func (m *SupplierInput) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetSurname() string {
+	if m != nil {
+		return m.Surname
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetDocumentType() string {
+	if m != nil {
+		return m.DocumentType
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetDocumentNumber() string {
+	if m != nil {
+		return m.DocumentNumber
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetCityId() string {
+	if m != nil {
+		return m.CityId
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetAddressLine1() string {
+	if m != nil {
+		return m.AddressLine1
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *SupplierInput) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+type GetSupplierRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSupplierRequest) Reset()         { *m = GetSupplierRequest{} }
+func (m *GetSupplierRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSupplierRequest) ProtoMessage()    {}
+func (*GetSupplierRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{2}
+}
+
+func (m *GetSupplierRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSupplierRequest.Unmarshal(m, b)
+}
+func (m *GetSupplierRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSupplierRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSupplierRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSupplierRequest.Merge(m, src)
+}
+func (m *GetSupplierRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSupplierRequest.Size(m)
+}
+func (m *GetSupplierRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSupplierRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSupplierRequest proto.InternalMessageInfo
+
+func (m *GetSupplierRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListSuppliersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSuppliersRequest) Reset()         { *m = ListSuppliersRequest{} }
+func (m *ListSuppliersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSuppliersRequest) ProtoMessage()    {}
+func (*ListSuppliersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{3}
+}
+
+func (m *ListSuppliersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSuppliersRequest.Unmarshal(m, b)
+}
+func (m *ListSuppliersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSuppliersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSuppliersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSuppliersRequest.Merge(m, src)
+}
+func (m *ListSuppliersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSuppliersRequest.Size(m)
+}
+func (m *ListSuppliersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSuppliersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSuppliersRequest proto.InternalMessageInfo
+
+type CreateSupplierRequest struct {
+	Supplier             *SupplierInput `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateSupplierRequest) Reset()         { *m = CreateSupplierRequest{} }
+func (m *CreateSupplierRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateSupplierRequest) ProtoMessage()    {}
+func (*CreateSupplierRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{4}
+}
+
+func (m *CreateSupplierRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSupplierRequest.Unmarshal(m, b)
+}
+func (m *CreateSupplierRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSupplierRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateSupplierRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSupplierRequest.Merge(m, src)
+}
+func (m *CreateSupplierRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateSupplierRequest.Size(m)
+}
+func (m *CreateSupplierRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSupplierRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSupplierRequest proto.InternalMessageInfo
+
+func (m *CreateSupplierRequest) GetSupplier() *SupplierInput {
+	if m != nil {
+		return m.Supplier
+	}
+	return nil
+}
+
+// UpdateSupplierRequest only applies when the supplier is at version, like the If-Match header
+// of the REST API, unless version is 0
+type UpdateSupplierRequest struct {
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Supplier             *SupplierInput `protobuf:"bytes,2,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	Version              int64          `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *UpdateSupplierRequest) Reset()         { *m = UpdateSupplierRequest{} }
+func (m *UpdateSupplierRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateSupplierRequest) ProtoMessage()    {}
+func (*UpdateSupplierRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{5}
+}
+
+func (m *UpdateSupplierRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateSupplierRequest.Unmarshal(m, b)
+}
+func (m *UpdateSupplierRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateSupplierRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateSupplierRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateSupplierRequest.Merge(m, src)
+}
+func (m *UpdateSupplierRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateSupplierRequest.Size(m)
+}
+func (m *UpdateSupplierRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateSupplierRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateSupplierRequest proto.InternalMessageInfo
+
+func (m *UpdateSupplierRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateSupplierRequest) GetSupplier() *SupplierInput {
+	if m != nil {
+		return m.Supplier
+	}
+	return nil
+}
+
+func (m *UpdateSupplierRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DeleteSupplierRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSupplierRequest) Reset()         { *m = DeleteSupplierRequest{} }
+func (m *DeleteSupplierRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSupplierRequest) ProtoMessage()    {}
+func (*DeleteSupplierRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_efa9c37d8f649d14, []int{6}
+}
+
+func (m *DeleteSupplierRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSupplierRequest.Unmarshal(m, b)
+}
+func (m *DeleteSupplierRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSupplierRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteSupplierRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSupplierRequest.Merge(m, src)
+}
+func (m *DeleteSupplierRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteSupplierRequest.Size(m)
+}
+func (m *DeleteSupplierRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSupplierRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSupplierRequest proto.InternalMessageInfo
+
+func (m *DeleteSupplierRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteSupplierRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Supplier)(nil), "futuagro.v1.Supplier")
+	proto.RegisterType((*SupplierInput)(nil), "futuagro.v1.SupplierInput")
+	proto.RegisterType((*GetSupplierRequest)(nil), "futuagro.v1.GetSupplierRequest")
+	proto.RegisterType((*ListSuppliersRequest)(nil), "futuagro.v1.ListSuppliersRequest")
+	proto.RegisterType((*CreateSupplierRequest)(nil), "futuagro.v1.CreateSupplierRequest")
+	proto.RegisterType((*UpdateSupplierRequest)(nil), "futuagro.v1.UpdateSupplierRequest")
+	proto.RegisterType((*DeleteSupplierRequest)(nil), "futuagro.v1.DeleteSupplierRequest")
+}
+
+func init() { proto.RegisterFile("futuagro/v1/suppliers.proto", fileDescriptor_efa9c37d8f649d14) }
+
+var fileDescriptor_efa9c37d8f649d14 = []byte{
+	// 622 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xcd, 0x6e, 0x9b, 0x40,
+	0x14, 0x85, 0x05, 0x4e, 0xfc, 0x73, 0x1d, 0x13, 0x69, 0x14, 0xa7, 0x53, 0x47, 0x55, 0x1c, 0x5a,
+	0xa9, 0x5e, 0x41, 0xed, 0x56, 0xdd, 0x64, 0xd5, 0x9f, 0xa8, 0x8a, 0x12, 0xb5, 0x12, 0x49, 0x37,
+	0xdd, 0x58, 0x04, 0x6e, 0x5c, 0x54, 0xc3, 0x50, 0x18, 0x2c, 0xf9, 0x21, 0xfa, 0x38, 0x7d, 0xb2,
+	0xbe, 0x40, 0xc5, 0xc0, 0x20, 0xc6, 0x21, 0xb1, 0x77, 0xcc, 0x39, 0x67, 0xee, 0xbd, 0x9e, 0x0f,
+	0x3c, 0x70, 0x72, 0x9f, 0xf1, 0xcc, 0x5d, 0x24, 0xcc, 0x5e, 0x4d, 0xed, 0x34, 0x8b, 0xe3, 0x65,
+	0x80, 0x49, 0x6a, 0xc5, 0x09, 0xe3, 0x8c, 0xf4, 0xa5, 0x69, 0xad, 0xa6, 0xa3, 0x93, 0x05, 0x63,
+	0x8b, 0x25, 0xda, 0xc2, 0xba, 0xcb, 0xee, 0x6d, 0x0c, 0x63, 0xbe, 0x2e, 0x92, 0xa3, 0xd3, 0x4d,
+	0x93, 0x07, 0x21, 0xa6, 0xdc, 0x0d, 0xe3, 0x32, 0x40, 0xeb, 0x7d, 0x3c, 0x16, 0x86, 0x2c, 0x2a,
+	0x1c, 0xf3, 0x5f, 0x0b, 0xba, 0x37, 0x65, 0x63, 0x62, 0x80, 0x1e, 0xf8, 0x54, 0x1b, 0x6b, 0x93,
+	0x9e, 0xa3, 0x07, 0x3e, 0x21, 0xb0, 0x17, 0xb9, 0x21, 0x52, 0x5d, 0x28, 0xe2, 0x99, 0x50, 0xe8,
+	0xa4, 0x59, 0x22, 0xe4, 0x96, 0x90, 0xe5, 0x92, 0xbc, 0x84, 0x81, 0xcf, 0xbc, 0x2c, 0xc4, 0x88,
+	0xcf, 0xf9, 0x3a, 0x46, 0xba, 0x27, 0xfc, 0x03, 0x29, 0xde, 0xae, 0x63, 0x24, 0xaf, 0xe1, 0xb0,
+	0x0a, 0x45, 0x59, 0x78, 0x87, 0x09, 0xdd, 0x17, 0x31, 0x43, 0xca, 0x5f, 0x85, 0x4a, 0x9e, 0x41,
+	0xc7, 0x0b, 0xf8, 0x7a, 0x1e, 0xf8, 0xb4, 0x2d, 0x02, 0xed, 0x7c, 0x79, 0xe9, 0x93, 0x17, 0x00,
+	0x1e, 0xcb, 0x22, 0x9e, 0x08, 0xaf, 0x23, 0xbc, 0x5e, 0xa9, 0x5c, 0xfa, 0xe4, 0x08, 0xf6, 0x31,
+	0x74, 0x83, 0x25, 0xed, 0x0a, 0xa7, 0x58, 0xe4, 0xb3, 0xb9, 0xbe, 0x9f, 0x60, 0x9a, 0xce, 0x97,
+	0x41, 0x84, 0x53, 0xda, 0x2b, 0x66, 0x2b, 0xc5, 0xeb, 0x5c, 0x23, 0x67, 0x70, 0x10, 0xff, 0x64,
+	0x11, 0xca, 0xc1, 0x40, 0x64, 0xfa, 0x42, 0x2b, 0xa7, 0x9a, 0x42, 0x3b, 0xe5, 0x2e, 0xcf, 0x52,
+	0xda, 0x1f, 0x6b, 0x13, 0x63, 0xf6, 0xdc, 0xaa, 0x41, 0xb2, 0x1c, 0xf4, 0x58, 0xe2, 0xdf, 0x88,
+	0x80, 0x53, 0x06, 0xf3, 0x03, 0x5b, 0x61, 0x92, 0x06, 0x2c, 0xa2, 0x07, 0x63, 0x6d, 0xd2, 0x72,
+	0xe4, 0x92, 0x9c, 0x43, 0xdf, 0x4b, 0xd0, 0xe5, 0x38, 0xcf, 0x79, 0xd1, 0xc1, 0x58, 0x9b, 0xf4,
+	0x67, 0x23, 0xab, 0x80, 0x69, 0x49, 0x98, 0xd6, 0xad, 0x84, 0xe9, 0x40, 0x11, 0xcf, 0x85, 0x7c,
+	0x73, 0x16, 0xfb, 0xd5, 0x66, 0x63, 0xfb, 0xe6, 0x22, 0x9e, 0x0b, 0xe6, 0x5f, 0x1d, 0x06, 0x92,
+	0xfa, 0x65, 0x14, 0x67, 0xbc, 0x42, 0xad, 0x35, 0xa3, 0xd6, 0xb7, 0xa0, 0x6e, 0xed, 0x86, 0x7a,
+	0x6f, 0x1b, 0xea, 0x7d, 0x05, 0x75, 0xc5, 0xb2, 0xfd, 0x24, 0xcb, 0xce, 0x0e, 0x2c, 0xbb, 0x4f,
+	0xb1, 0xec, 0xed, 0xc8, 0xd2, 0x7c, 0x05, 0xe4, 0x0b, 0x72, 0x79, 0x72, 0x0e, 0xfe, 0xce, 0x30,
+	0xe5, 0x9b, 0x9f, 0x8d, 0x79, 0x0c, 0x47, 0xd7, 0x41, 0x5a, 0xc5, 0xd2, 0x32, 0x67, 0x7e, 0x83,
+	0xe1, 0x27, 0x01, 0x70, 0xb3, 0xc0, 0x7b, 0xe8, 0xca, 0x8f, 0x9f, 0x6a, 0x25, 0xc8, 0xfa, 0x2c,
+	0x0a, 0x2a, 0xa7, 0xca, 0x9a, 0x6b, 0x18, 0x7e, 0x17, 0x50, 0xb7, 0x4c, 0xa4, 0x34, 0xd0, 0x77,
+	0x6f, 0x50, 0x7f, 0x77, 0x5b, 0xca, 0xbb, 0x6b, 0x7e, 0x80, 0xe1, 0x67, 0x5c, 0xe2, 0xf6, 0xd6,
+	0xb5, 0x12, 0xba, 0x52, 0x62, 0xf6, 0xa7, 0x05, 0x87, 0x72, 0xf7, 0x0d, 0x26, 0xab, 0xc0, 0x43,
+	0x72, 0x01, 0xfd, 0xda, 0x01, 0x93, 0x53, 0x65, 0xca, 0x87, 0x47, 0x3f, 0x1a, 0x36, 0xfe, 0x0c,
+	0x72, 0x05, 0x03, 0x85, 0x00, 0x39, 0x53, 0x72, 0x4d, 0x74, 0x1e, 0x29, 0xf5, 0x46, 0x23, 0x57,
+	0x60, 0xa8, 0xd8, 0x88, 0xa9, 0x44, 0x1b, 0x99, 0x3e, 0x3e, 0x99, 0xa1, 0x22, 0xdb, 0x28, 0xd6,
+	0xc8, 0xf3, 0xb1, 0x62, 0xd7, 0x60, 0xa8, 0x10, 0x36, 0x8a, 0x35, 0x12, 0x1a, 0x1d, 0x3f, 0xf8,
+	0x93, 0xb8, 0xc8, 0xef, 0x92, 0x8f, 0xef, 0x7e, 0xcc, 0xaa, 0xcd, 0x1e, 0x0b, 0xed, 0xf8, 0xd7,
+	0xc2, 0x5e, 0x24, 0xb1, 0x57, 0x5c, 0x2b, 0x76, 0xed, 0x0a, 0x39, 0x97, 0xcf, 0xab, 0xe9, 0x5d,
+	0x5b, 0xb8, 0x6f, 0xff, 0x0f, 0x00, 0x50, 0xff, 0xb0, 0xaa, 0xcb, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SupplierServiceClient is the client API for SupplierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SupplierServiceClient interface {
+	GetSupplier(ctx context.Context, in *GetSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	// ListSuppliers streams every supplier
+	ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (SupplierService_ListSuppliersClient, error)
+	CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	UpdateSupplier(ctx context.Context, in *UpdateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	DeleteSupplier(ctx context.Context, in *DeleteSupplierRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type supplierServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSupplierServiceClient(cc *grpc.ClientConn) SupplierServiceClient {
+	return &supplierServiceClient{cc}
+}
+
+func (c *supplierServiceClient) GetSupplier(ctx context.Context, in *GetSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.SupplierService/GetSupplier", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (SupplierService_ListSuppliersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SupplierService_serviceDesc.Streams[0], "/futuagro.v1.SupplierService/ListSuppliers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &supplierServiceListSuppliersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SupplierService_ListSuppliersClient interface {
+	Recv() (*Supplier, error)
+	grpc.ClientStream
+}
+
+type supplierServiceListSuppliersClient struct {
+	grpc.ClientStream
+}
+
+func (x *supplierServiceListSuppliersClient) Recv() (*Supplier, error) {
+	m := new(Supplier)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *supplierServiceClient) CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.SupplierService/CreateSupplier", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) UpdateSupplier(ctx context.Context, in *UpdateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.SupplierService/UpdateSupplier", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) DeleteSupplier(ctx context.Context, in *DeleteSupplierRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.SupplierService/DeleteSupplier", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SupplierServiceServer is the server API for SupplierService service.
+type SupplierServiceServer interface {
+	GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error)
+	// ListSuppliers streams every supplier
+	ListSuppliers(*ListSuppliersRequest, SupplierService_ListSuppliersServer) error
+	CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error)
+	UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error)
+	DeleteSupplier(context.Context, *DeleteSupplierRequest) (*empty.Empty, error)
+}
+
+// UnimplementedSupplierServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSupplierServiceServer struct {
+}
+
+func (*UnimplementedSupplierServiceServer) GetSupplier(ctx context.Context, req *GetSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplier not implemented")
+}
+func (*UnimplementedSupplierServiceServer) ListSuppliers(req *ListSuppliersRequest, srv SupplierService_ListSuppliersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSuppliers not implemented")
+}
+func (*UnimplementedSupplierServiceServer) CreateSupplier(ctx context.Context, req *CreateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplier not implemented")
+}
+func (*UnimplementedSupplierServiceServer) UpdateSupplier(ctx context.Context, req *UpdateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSupplier not implemented")
+}
+func (*UnimplementedSupplierServiceServer) DeleteSupplier(ctx context.Context, req *DeleteSupplierRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSupplier not implemented")
+}
+
+func RegisterSupplierServiceServer(s *grpc.Server, srv SupplierServiceServer) {
+	s.RegisterService(&_SupplierService_serviceDesc, srv)
+}
+
+func _SupplierService_GetSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).GetSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.SupplierService/GetSupplier",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).GetSupplier(ctx, req.(*GetSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_ListSuppliers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSuppliersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SupplierServiceServer).ListSuppliers(m, &supplierServiceListSuppliersServer{stream})
+}
+
+type SupplierService_ListSuppliersServer interface {
+	Send(*Supplier) error
+	grpc.ServerStream
+}
+
+type supplierServiceListSuppliersServer struct {
+	grpc.ServerStream
+}
+
+func (x *supplierServiceListSuppliersServer) Send(m *Supplier) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SupplierService_CreateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).CreateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.SupplierService/CreateSupplier",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).CreateSupplier(ctx, req.(*CreateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_UpdateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).UpdateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.SupplierService/UpdateSupplier",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).UpdateSupplier(ctx, req.(*UpdateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_DeleteSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).DeleteSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.SupplierService/DeleteSupplier",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).DeleteSupplier(ctx, req.(*DeleteSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SupplierService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "futuagro.v1.SupplierService",
+	HandlerType: (*SupplierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSupplier",
+			Handler:    _SupplierService_GetSupplier_Handler,
+		},
+		{
+			MethodName: "CreateSupplier",
+			Handler:    _SupplierService_CreateSupplier_Handler,
+		},
+		{
+			MethodName: "UpdateSupplier",
+			Handler:    _SupplierService_UpdateSupplier_Handler,
+		},
+		{
+			MethodName: "DeleteSupplier",
+			Handler:    _SupplierService_DeleteSupplier_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSuppliers",
+			Handler:       _SupplierService_ListSuppliers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "futuagro/v1/suppliers.proto",
+}
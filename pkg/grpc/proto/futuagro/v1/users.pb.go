@@ -0,0 +1,817 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: futuagro/v1/users.proto
+
+package futuagrov1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// User is a user of the platform, its password is never returned
+type User struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Surname              string               `protobuf:"bytes,3,opt,name=surname,proto3" json:"surname,omitempty"`
+	DocumentType         string               `protobuf:"bytes,4,opt,name=document_type,json=documentType,proto3" json:"document_type,omitempty"`
+	DocumentNumber       string               `protobuf:"bytes,5,opt,name=document_number,json=documentNumber,proto3" json:"document_number,omitempty"`
+	CityId               string               `protobuf:"bytes,6,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Email                string               `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	AddressLine1         string               `protobuf:"bytes,8,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	PhoneNumber          string               `protobuf:"bytes,9,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Role                 string               `protobuf:"bytes,10,opt,name=role,proto3" json:"role,omitempty"`
+	EmailVerified        bool                 `protobuf:"varint,11,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	Status               RecordStatus         `protobuf:"varint,12,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	Version              int64                `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,14,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,15,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *User) Reset()         { *m = User{} }
+func (m *User) String() string { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()    {}
+func (*User) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{0}
+}
+
+func (m *User) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_User.Unmarshal(m, b)
+}
+func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_User.Marshal(b, m, deterministic)
+}
+func (m *User) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User.Merge(m, src)
+}
+func (m *User) XXX_Size() int {
+	return xxx_messageInfo_User.Size(m)
+}
+func (m *User) XXX_DiscardUnknown() {
+	xxx_messageInfo_User.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User proto.InternalMessageInfo
+
+func (m *User) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *User) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *User) GetSurname() string {
+	if m != nil {
+		return m.Surname
+	}
+	return ""
+}
+
+func (m *User) GetDocumentType() string {
+	if m != nil {
+		return m.DocumentType
+	}
+	return ""
+}
+
+func (m *User) GetDocumentNumber() string {
+	if m != nil {
+		return m.DocumentNumber
+	}
+	return ""
+}
+
+func (m *User) GetCityId() string {
+	if m != nil {
+		return m.CityId
+	}
+	return ""
+}
+
+func (m *User) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *User) GetAddressLine1() string {
+	if m != nil {
+		return m.AddressLine1
+	}
+	return ""
+}
+
+func (m *User) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *User) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *User) GetEmailVerified() bool {
+	if m != nil {
+		return m.EmailVerified
+	}
+	return false
+}
+
+func (m *User) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+func (m *User) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *User) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *User) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+// UserInput holds the attributes of a user that are written
+type UserInput struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Surname              string       `protobuf:"bytes,2,opt,name=surname,proto3" json:"surname,omitempty"`
+	DocumentType         string       `protobuf:"bytes,3,opt,name=document_type,json=documentType,proto3" json:"document_type,omitempty"`
+	DocumentNumber       string       `protobuf:"bytes,4,opt,name=document_number,json=documentNumber,proto3" json:"document_number,omitempty"`
+	CityId               string       `protobuf:"bytes,5,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Email                string       `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	Password             string       `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`
+	AddressLine1         string       `protobuf:"bytes,8,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	PhoneNumber          string       `protobuf:"bytes,9,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Status               RecordStatus `protobuf:"varint,10,opt,name=status,proto3,enum=futuagro.v1.RecordStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UserInput) Reset()         { *m = UserInput{} }
+func (m *UserInput) String() string { return proto.CompactTextString(m) }
+func (*UserInput) ProtoMessage()    {}
+func (*UserInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{1}
+}
+
+func (m *UserInput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserInput.Unmarshal(m, b)
+}
+func (m *UserInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserInput.Marshal(b, m, deterministic)
+}
+func (m *UserInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserInput.Merge(m, src)
+}
+func (m *UserInput) XXX_Size() int {
+	return xxx_messageInfo_UserInput.Size(m)
+}
+func (m *UserInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserInput proto.InternalMessageInfo
+
+func (m *UserInput) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UserInput) GetSurname() string {
+	if m != nil {
+		return m.Surname
+	}
+	return ""
+}
+
+func (m *UserInput) GetDocumentType() string {
+	if m != nil {
+		return m.DocumentType
+	}
+	return ""
+}
+
+func (m *UserInput) GetDocumentNumber() string {
+	if m != nil {
+		return m.DocumentNumber
+	}
+	return ""
+}
+
+func (m *UserInput) GetCityId() string {
+	if m != nil {
+		return m.CityId
+	}
+	return ""
+}
+
+func (m *UserInput) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *UserInput) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *UserInput) GetAddressLine1() string {
+	if m != nil {
+		return m.AddressLine1
+	}
+	return ""
+}
+
+func (m *UserInput) GetPhoneNumber() string {
+	if m != nil {
+		return m.PhoneNumber
+	}
+	return ""
+}
+
+func (m *UserInput) GetStatus() RecordStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RecordStatus_RECORD_STATUS_UNSPECIFIED
+}
+
+type GetUserRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUserRequest) Reset()         { *m = GetUserRequest{} }
+func (m *GetUserRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUserRequest) ProtoMessage()    {}
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{2}
+}
+
+func (m *GetUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUserRequest.Unmarshal(m, b)
+}
+func (m *GetUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUserRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserRequest.Merge(m, src)
+}
+func (m *GetUserRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUserRequest.Size(m)
+}
+func (m *GetUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUserRequest proto.InternalMessageInfo
+
+func (m *GetUserRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUsersRequest) Reset()         { *m = ListUsersRequest{} }
+func (m *ListUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUsersRequest) ProtoMessage()    {}
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{3}
+}
+
+func (m *ListUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersRequest.Unmarshal(m, b)
+}
+func (m *ListUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersRequest.Merge(m, src)
+}
+func (m *ListUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUsersRequest.Size(m)
+}
+func (m *ListUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersRequest proto.InternalMessageInfo
+
+type CreateUserRequest struct {
+	User                 *UserInput `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateUserRequest) Reset()         { *m = CreateUserRequest{} }
+func (m *CreateUserRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateUserRequest) ProtoMessage()    {}
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{4}
+}
+
+func (m *CreateUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUserRequest.Unmarshal(m, b)
+}
+func (m *CreateUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUserRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUserRequest.Merge(m, src)
+}
+func (m *CreateUserRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateUserRequest.Size(m)
+}
+func (m *CreateUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUserRequest proto.InternalMessageInfo
+
+func (m *CreateUserRequest) GetUser() *UserInput {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+// UpdateUserRequest only applies when the user is at version, like the If-Match header of the
+// REST API, unless version is 0
+type UpdateUserRequest struct {
+	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	User                 *UserInput `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Version              int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateUserRequest) Reset()         { *m = UpdateUserRequest{} }
+func (m *UpdateUserRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserRequest) ProtoMessage()    {}
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{5}
+}
+
+func (m *UpdateUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateUserRequest.Unmarshal(m, b)
+}
+func (m *UpdateUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateUserRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserRequest.Merge(m, src)
+}
+func (m *UpdateUserRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateUserRequest.Size(m)
+}
+func (m *UpdateUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserRequest proto.InternalMessageInfo
+
+func (m *UpdateUserRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateUserRequest) GetUser() *UserInput {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *UpdateUserRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DeleteUserRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteUserRequest) Reset()         { *m = DeleteUserRequest{} }
+func (m *DeleteUserRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteUserRequest) ProtoMessage()    {}
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6566187da9211a47, []int{6}
+}
+
+func (m *DeleteUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteUserRequest.Unmarshal(m, b)
+}
+func (m *DeleteUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteUserRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteUserRequest.Merge(m, src)
+}
+func (m *DeleteUserRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteUserRequest.Size(m)
+}
+func (m *DeleteUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteUserRequest proto.InternalMessageInfo
+
+func (m *DeleteUserRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteUserRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*User)(nil), "futuagro.v1.User")
+	proto.RegisterType((*UserInput)(nil), "futuagro.v1.UserInput")
+	proto.RegisterType((*GetUserRequest)(nil), "futuagro.v1.GetUserRequest")
+	proto.RegisterType((*ListUsersRequest)(nil), "futuagro.v1.ListUsersRequest")
+	proto.RegisterType((*CreateUserRequest)(nil), "futuagro.v1.CreateUserRequest")
+	proto.RegisterType((*UpdateUserRequest)(nil), "futuagro.v1.UpdateUserRequest")
+	proto.RegisterType((*DeleteUserRequest)(nil), "futuagro.v1.DeleteUserRequest")
+}
+
+func init() { proto.RegisterFile("futuagro/v1/users.proto", fileDescriptor_6566187da9211a47) }
+
+var fileDescriptor_6566187da9211a47 = []byte{
+	// 647 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0x9d, 0x34, 0x69, 0xc6, 0x6d, 0x4a, 0x56, 0xa8, 0x5d, 0x52, 0x01, 0xc1, 0x08, 0x11,
+	0x71, 0xb0, 0x49, 0xe0, 0x82, 0x2a, 0x54, 0x09, 0x8a, 0x50, 0xa5, 0x8a, 0x83, 0xdb, 0x72, 0xe0,
+	0x12, 0xb9, 0xf6, 0x34, 0x58, 0xc4, 0x5e, 0xb3, 0xbb, 0x0e, 0xca, 0x6f, 0xe0, 0xb7, 0x71, 0xe0,
+	0x1f, 0x21, 0xaf, 0x3f, 0x62, 0x27, 0x51, 0xd3, 0x03, 0xb7, 0xdd, 0x37, 0x6f, 0x76, 0xd6, 0xef,
+	0x3d, 0x6b, 0xe1, 0xe8, 0x36, 0x91, 0x89, 0x3b, 0xe5, 0xcc, 0x9e, 0x8f, 0xec, 0x44, 0x20, 0x17,
+	0x56, 0xcc, 0x99, 0x64, 0xc4, 0x28, 0x0a, 0xd6, 0x7c, 0xd4, 0x3f, 0x9e, 0x32, 0x36, 0x9d, 0xa1,
+	0xad, 0x4a, 0x37, 0xc9, 0xad, 0x8d, 0x61, 0x2c, 0x17, 0x19, 0xb3, 0xff, 0x74, 0xb5, 0x28, 0x83,
+	0x10, 0x85, 0x74, 0xc3, 0x38, 0x27, 0xd0, 0xea, 0x0c, 0x8f, 0x85, 0x21, 0x8b, 0xb2, 0x8a, 0xf9,
+	0xbb, 0x09, 0xcd, 0x6b, 0x81, 0x9c, 0x74, 0x41, 0x0f, 0x7c, 0xaa, 0x0d, 0xb4, 0x61, 0xc7, 0xd1,
+	0x03, 0x9f, 0x10, 0x68, 0x46, 0x6e, 0x88, 0x54, 0x57, 0x88, 0x5a, 0x13, 0x0a, 0x6d, 0x91, 0x70,
+	0x05, 0x37, 0x14, 0x5c, 0x6c, 0xc9, 0x73, 0xd8, 0xf7, 0x99, 0x97, 0x84, 0x18, 0xc9, 0x89, 0x5c,
+	0xc4, 0x48, 0x9b, 0xaa, 0xbe, 0x57, 0x80, 0x57, 0x8b, 0x18, 0xc9, 0x4b, 0x38, 0x28, 0x49, 0x51,
+	0x12, 0xde, 0x20, 0xa7, 0x3b, 0x8a, 0xd6, 0x2d, 0xe0, 0x2f, 0x0a, 0x25, 0x47, 0xd0, 0xf6, 0x02,
+	0xb9, 0x98, 0x04, 0x3e, 0x6d, 0x29, 0x42, 0x2b, 0xdd, 0x9e, 0xfb, 0xe4, 0x21, 0xec, 0x60, 0xe8,
+	0x06, 0x33, 0xda, 0x56, 0x70, 0xb6, 0x49, 0x87, 0xbb, 0xbe, 0xcf, 0x51, 0x88, 0xc9, 0x2c, 0x88,
+	0x70, 0x44, 0x77, 0xb3, 0xe1, 0x39, 0x78, 0x91, 0x62, 0xe4, 0x19, 0xec, 0xc5, 0xdf, 0x59, 0x84,
+	0xc5, 0xe4, 0x8e, 0xe2, 0x18, 0x0a, 0xcb, 0xc7, 0x12, 0x68, 0x72, 0x36, 0x43, 0x0a, 0xd9, 0x27,
+	0xa7, 0x6b, 0xf2, 0x02, 0xba, 0x6a, 0xc8, 0x64, 0x8e, 0x3c, 0xb8, 0x0d, 0xd0, 0xa7, 0xc6, 0x40,
+	0x1b, 0xee, 0x3a, 0xfb, 0x0a, 0xfd, 0x9a, 0x83, 0x64, 0x04, 0x2d, 0x21, 0x5d, 0x99, 0x08, 0xba,
+	0x37, 0xd0, 0x86, 0xdd, 0xf1, 0x23, 0xab, 0x62, 0x9e, 0xe5, 0xa0, 0xc7, 0xb8, 0x7f, 0xa9, 0x08,
+	0x4e, 0x4e, 0x4c, 0xc5, 0x9c, 0x23, 0x17, 0x01, 0x8b, 0xe8, 0xfe, 0x40, 0x1b, 0x36, 0x9c, 0x62,
+	0x4b, 0x4e, 0xc0, 0xf0, 0x38, 0xba, 0x12, 0x27, 0xa9, 0x8f, 0xb4, 0x3b, 0xd0, 0x86, 0xc6, 0xb8,
+	0x6f, 0x65, 0x26, 0x5b, 0x85, 0xc9, 0xd6, 0x55, 0x61, 0xb2, 0x03, 0x19, 0x3d, 0x05, 0xd2, 0xe6,
+	0x24, 0xf6, 0xcb, 0xe6, 0x83, 0xed, 0xcd, 0x19, 0x3d, 0x05, 0xcc, 0x3f, 0x3a, 0x74, 0xd2, 0x34,
+	0x9c, 0x47, 0x71, 0x22, 0xcb, 0x08, 0x68, 0x9b, 0x23, 0xa0, 0x6f, 0x89, 0x40, 0xe3, 0x7e, 0x11,
+	0x68, 0x6e, 0x8b, 0xc0, 0xce, 0xe6, 0x08, 0xb4, 0xaa, 0x11, 0xe8, 0xc3, 0x6e, 0xec, 0x0a, 0xf1,
+	0x8b, 0x71, 0x3f, 0xcf, 0x46, 0xb9, 0xff, 0x6f, 0xf1, 0x58, 0x7a, 0x0c, 0xf7, 0xf4, 0xd8, 0x1c,
+	0x40, 0xf7, 0x33, 0xca, 0x54, 0x51, 0x07, 0x7f, 0x26, 0x28, 0xe4, 0xea, 0x6f, 0x66, 0x12, 0x78,
+	0x70, 0x11, 0x08, 0x45, 0x11, 0x39, 0xc7, 0x3c, 0x85, 0xde, 0x47, 0x65, 0x68, 0xb5, 0xf1, 0x15,
+	0x34, 0x13, 0x81, 0x5c, 0xb5, 0x1a, 0xe3, 0xc3, 0xda, 0xec, 0xd2, 0x32, 0x47, 0x71, 0xcc, 0x00,
+	0x7a, 0xd7, 0xca, 0xd4, 0x3b, 0x26, 0x97, 0x07, 0xea, 0xdb, 0x0f, 0xac, 0x66, 0xb5, 0x51, 0xcb,
+	0xaa, 0xf9, 0x1e, 0x7a, 0x67, 0x38, 0xc3, 0xbb, 0x47, 0x55, 0xda, 0xf5, 0x5a, 0xfb, 0xf8, 0xaf,
+	0x0e, 0x46, 0xda, 0x79, 0x89, 0x7c, 0x1e, 0x78, 0x48, 0xde, 0x41, 0x3b, 0x17, 0x8c, 0x1c, 0xd7,
+	0x6e, 0x54, 0x97, 0xb1, 0xdf, 0x5b, 0xbb, 0x2e, 0x39, 0x85, 0x4e, 0xa9, 0x24, 0x79, 0x5c, 0xab,
+	0xaf, 0x2a, 0xbc, 0xa1, 0xfd, 0xb5, 0x46, 0x4e, 0x01, 0x96, 0xb2, 0x93, 0x27, 0x35, 0xca, 0x9a,
+	0x1f, 0x9b, 0x6f, 0x00, 0x4b, 0xd9, 0x57, 0x0e, 0x58, 0xf3, 0x63, 0xd3, 0x01, 0x67, 0x00, 0x4b,
+	0x31, 0x57, 0x0e, 0x58, 0x53, 0xb9, 0x7f, 0xb8, 0xf6, 0x53, 0x7f, 0x4a, 0xdf, 0x84, 0x0f, 0x6f,
+	0xbf, 0x8d, 0xcb, 0x46, 0x8f, 0x85, 0x76, 0xfc, 0x63, 0x6a, 0x4f, 0x79, 0xec, 0x65, 0xcf, 0x83,
+	0x5d, 0x79, 0x0a, 0x4e, 0x8a, 0xf5, 0x7c, 0x74, 0xd3, 0x52, 0xd5, 0x37, 0xff, 0x06, 0x00, 0x72,
+	0x70, 0xaf, 0x10, 0x8f, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UserServiceClient interface {
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// ListUsers streams every user
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (UserService_ListUsersClient, error)
+	// CreateUser signs up a user
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type userServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserServiceClient(cc *grpc.ClientConn) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.UserService/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (UserService_ListUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserService_serviceDesc.Streams[0], "/futuagro.v1.UserService/ListUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceListUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ListUsersClient interface {
+	Recv() (*User, error)
+	grpc.ClientStream
+}
+
+type userServiceListUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceListUsersClient) Recv() (*User, error) {
+	m := new(User)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.UserService/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.UserService/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/futuagro.v1.UserService/DeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+type UserServiceServer interface {
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// ListUsers streams every user
+	ListUsers(*ListUsersRequest, UserService_ListUsersServer) error
+	// CreateUser signs up a user
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
+	DeleteUser(context.Context, *DeleteUserRequest) (*empty.Empty, error)
+}
+
+// UnimplementedUserServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (*UnimplementedUserServiceServer) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (*UnimplementedUserServiceServer) ListUsers(req *ListUsersRequest, srv UserService_ListUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (*UnimplementedUserServiceServer) CreateUser(ctx context.Context, req *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (*UnimplementedUserServiceServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (*UnimplementedUserServiceServer) DeleteUser(ctx context.Context, req *DeleteUserRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+
+func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
+	s.RegisterService(&_UserService_serviceDesc, srv)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.UserService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ListUsers(m, &userServiceListUsersServer{stream})
+}
+
+type UserService_ListUsersServer interface {
+	Send(*User) error
+	grpc.ServerStream
+}
+
+type userServiceListUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceListUsersServer) Send(m *User) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.UserService/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.UserService/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/futuagro.v1.UserService/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UserService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "futuagro.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UserService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UserService_DeleteUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUsers",
+			Handler:       _UserService_ListUsers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "futuagro/v1/users.proto",
+}
@@ -0,0 +1,60 @@
+// Package grpc serves the catalog, the suppliers, the crops and the users over gRPC for the
+// internal services, with the services, the API keys and the scopes of the REST API. The
+// protobuf definitions are in the proto directory.
+package grpc
+
+//go:generate protoc -I proto --go_out=plugins=grpc,paths=source_relative:proto proto/futuagro/v1/common.proto proto/futuagro/v1/catalog.proto proto/futuagro/v1/suppliers.proto proto/futuagro/v1/crops.proto proto/futuagro/v1/users.proto
+
+import (
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/services"
+	futuagrov1 "futuagro.com/pkg/grpc/proto/futuagro/v1"
+	"futuagro.com/pkg/health"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer returns a gRPC server with the services of the API, the health service answering
+// with the readiness checks and the reflection service
+func NewServer(
+	confPtr *config.Config,
+	logger *logrus.Logger,
+	apiClientServ *services.APIClientService,
+	authServ *services.AuthService,
+	organizationServ *services.OrganizationService,
+	itemServ *services.ItemService,
+	variantServ *services.VariantService,
+	supplierServ *services.SupplierService,
+	cropServ *services.CropService,
+	userServ *services.UserService,
+	healthRegistry *health.Registry,
+) *grpc.Server {
+	interceptor := &interceptor{
+		logger:        logger,
+		apiClients:    apiClientServ,
+		sessions:      authServ,
+		organizations: organizationServ,
+		adminKey:      confPtr.Auth.AdminAPIKey,
+	}
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.unary),
+		grpc.StreamInterceptor(interceptor.stream),
+	)
+
+	requireVersion := confPtr.Server.RequireIfMatch
+	futuagrov1.RegisterCatalogServiceServer(server, &catalogServer{items: itemServ, variants: variantServ, requireVersion: requireVersion})
+	futuagrov1.RegisterSupplierServiceServer(server, &supplierServer{suppliers: supplierServ, requireVersion: requireVersion})
+	futuagrov1.RegisterCropServiceServer(server, &cropServer{crops: cropServ, requireVersion: requireVersion})
+	futuagrov1.RegisterUserServiceServer(server, &userServer{users: userServ, requireVersion: requireVersion})
+
+	healthServer := &healthServer{registry: healthRegistry, services: map[string]bool{}}
+	for name := range server.GetServiceInfo() {
+		healthServer.services[name] = true
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server
+}
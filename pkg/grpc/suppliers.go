@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	futuagrov1 "futuagro.com/pkg/grpc/proto/futuagro/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// supplierServer serves the suppliers
+type supplierServer struct {
+	suppliers *services.SupplierService
+	// requireVersion refuses the writes sent without a version, like server.requireIfMatch does
+	// for the REST API
+	requireVersion bool
+}
+
+func (s *supplierServer) GetSupplier(ctx context.Context, req *futuagrov1.GetSupplierRequest) (*futuagrov1.Supplier, error) {
+	supplier, err := s.suppliers.FindSupplierByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return supplierProto(supplier), nil
+}
+
+func (s *supplierServer) ListSuppliers(req *futuagrov1.ListSuppliersRequest, stream futuagrov1.SupplierService_ListSuppliersServer) error {
+	return s.suppliers.EachSupplier(stream.Context(), func(supplier *models.Supplier) error {
+		return stream.Send(supplierProto(supplier))
+	})
+}
+
+func (s *supplierServer) CreateSupplier(ctx context.Context, req *futuagrov1.CreateSupplierRequest) (*futuagrov1.Supplier, error) {
+	dto, err := supplierDto(req.GetSupplier())
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := s.suppliers.CreateSupplier(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return supplierProto(supplier), nil
+}
+
+func (s *supplierServer) UpdateSupplier(ctx context.Context, req *futuagrov1.UpdateSupplierRequest) (*futuagrov1.Supplier, error) {
+	dto, err := supplierDto(req.GetSupplier())
+	if err != nil {
+		return nil, err
+	}
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := s.suppliers.UpdateSupplierByID(ctx, req.GetId(), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return supplierProto(supplier), nil
+}
+
+func (s *supplierServer) DeleteSupplier(ctx context.Context, req *futuagrov1.DeleteSupplierRequest) (*empty.Empty, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.suppliers.DeleteSupplier(ctx, req.GetId(), versions); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func supplierProto(supplier *models.Supplier) *futuagrov1.Supplier {
+	return &futuagrov1.Supplier{
+		Id:             supplier.ID.Hex(),
+		Name:           supplier.Name,
+		Surname:        supplier.Surname,
+		DocumentType:   supplier.DocumentType,
+		DocumentNumber: supplier.DocumentNumber,
+		CityId:         hexProto(supplier.CityID),
+		CountryId:      hexProto(supplier.CountryID),
+		Email:          supplier.Email,
+		AddressLine1:   supplier.AddressLine1,
+		PhoneNumber:    supplier.PhoneNumber,
+		Status:         statusProto(supplier.RecordStatus),
+		Version:        supplier.Version,
+		CreateTime:     timestampProto(supplier.CreatedAt),
+		UpdateTime:     timestampProto(supplier.UpdatedAt),
+	}
+}
+
+func supplierDto(input *futuagrov1.SupplierInput) (*dtos.SupplierDto, error) {
+	cityID, err := inputID(input.GetCityId())
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.SupplierDto{
+		Name:           input.GetName(),
+		Surname:        input.GetSurname(),
+		DocumentType:   input.GetDocumentType(),
+		DocumentNumber: input.GetDocumentNumber(),
+		CityID:         cityID,
+		Email:          input.GetEmail(),
+		AddressLine1:   input.GetAddressLine1(),
+		PhoneNumber:    input.GetPhoneNumber(),
+		RecordStatus:   inputStatus(input.GetStatus()),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	futuagrov1 "futuagro.com/pkg/grpc/proto/futuagro/v1"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// userServer serves the users
+type userServer struct {
+	users *services.UserService
+	// requireVersion refuses the writes sent without a version, like server.requireIfMatch does
+	// for the REST API
+	requireVersion bool
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *futuagrov1.GetUserRequest) (*futuagrov1.User, error) {
+	user, err := s.users.FindUserByID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return userProto(user), nil
+}
+
+func (s *userServer) ListUsers(req *futuagrov1.ListUsersRequest, stream futuagrov1.UserService_ListUsersServer) error {
+	return s.users.EachUser(stream.Context(), func(user *models.User) error {
+		return stream.Send(userProto(user))
+	})
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *futuagrov1.CreateUserRequest) (*futuagrov1.User, error) {
+	dto, err := userDto(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.Signup(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return userProto(user), nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *futuagrov1.UpdateUserRequest) (*futuagrov1.User, error) {
+	dto, err := userDto(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.UpdateUserByID(ctx, req.GetId(), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return userProto(user), nil
+}
+
+func (s *userServer) DeleteUser(ctx context.Context, req *futuagrov1.DeleteUserRequest) (*empty.Empty, error) {
+	versions, err := requestVersions(req.GetVersion(), s.requireVersion)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.users.DeleteUser(ctx, req.GetId(), versions); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func userProto(user *models.User) *futuagrov1.User {
+	return &futuagrov1.User{
+		Id:             user.ID.Hex(),
+		Name:           user.Name,
+		Surname:        user.Surname,
+		DocumentType:   user.DocumentType,
+		DocumentNumber: user.DocumentNumber,
+		CityId:         hexProto(user.CityID),
+		Email:          user.Email,
+		AddressLine1:   user.AddressLine1,
+		PhoneNumber:    user.PhoneNumber,
+		Role:           user.Role,
+		EmailVerified:  user.IsEmailVerified,
+		Status:         statusProto(user.RecordStatus),
+		Version:        user.Version,
+		CreateTime:     timestampProto(user.CreatedAt),
+		UpdateTime:     timestampProto(user.UpdatedAt),
+	}
+}
+
+func userDto(input *futuagrov1.UserInput) (*dtos.UserDto, error) {
+	cityID, err := inputOptionalID(input.GetCityId())
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.UserDto{
+		Name:           input.GetName(),
+		Surname:        input.GetSurname(),
+		DocumentType:   input.GetDocumentType(),
+		DocumentNumber: input.GetDocumentNumber(),
+		CityID:         cityID,
+		Email:          input.GetEmail(),
+		Password:       input.GetPassword(),
+		AddressLine1:   input.GetAddressLine1(),
+		PhoneNumber:    input.GetPhoneNumber(),
+		RecordStatus:   inputStatus(input.GetStatus()),
+	}, nil
+}
@@ -0,0 +1,129 @@
+// Package health keeps the registry of the checks that tell whether the application is ready to
+// serve requests.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatusOK reports a passing check or a ready application
+	StatusOK = "ok"
+	// StatusFailing reports a failing check or an application that must not receive traffic
+	StatusFailing = "failing"
+)
+
+// ErrShuttingDown is reported by the readiness once the application started shutting down
+var ErrShuttingDown = errors.New("The application is shutting down")
+
+// CheckFunc verifies a dependency, it must return before ctx is done
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check
+type CheckResult struct {
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// Report is the outcome of every check of the registry
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Registry holds the readiness checks, dependencies register their own checks on it
+type Registry struct {
+	timeout      time.Duration
+	mutex        sync.RWMutex
+	checks       map[string]CheckFunc
+	shuttingDown bool
+}
+
+// NewRegistry returns an empty registry, timeout bounds every check
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout, checks: map[string]CheckFunc{}}
+}
+
+// Register adds a check to the registry, replacing a check registered with the same name
+func (r *Registry) Register(name string, check CheckFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.checks[name] = check
+}
+
+// SetShuttingDown makes the readiness fail so that load balancers stop routing requests to this
+// process while the ones in flight complete
+func (r *Registry) SetShuttingDown() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.shuttingDown = true
+}
+
+// Check runs every check concurrently and reports the application as ready when all of them pass
+func (r *Registry) Check(ctx context.Context) *Report {
+	r.mutex.RLock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	checks := r.checks
+	shuttingDown := r.shuttingDown
+	r.mutex.RUnlock()
+	sort.Strings(names)
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, check CheckFunc) {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}(i, checks[name])
+	}
+	wg.Wait()
+
+	report := &Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(names)+1)}
+	for i, name := range names {
+		report.Checks[name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+	if shuttingDown {
+		report.Status = StatusFailing
+		report.Checks["shutdown"] = CheckResult{Status: StatusFailing, Error: ErrShuttingDown.Error()}
+	}
+	return report
+}
+
+// run executes a check, a check that does not return before the deadline is reported as failing
+func run(ctx context.Context, check CheckFunc) CheckResult {
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errors.Wrap(ctx.Err(), "The check did not complete in time")
+	}
+
+	result := CheckResult{Status: StatusOK, DurationMs: float64(time.Since(start).Nanoseconds()) / 1e6}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+const jsonContentType = "application/json"
+
+// errorSchemaName is the component describing the body of every error response
+const errorSchemaName = "APIError"
+
+var pathParamPattern = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// Route documents an operation served by the router
+type Route struct {
+	Method string
+	// Path is the chi pattern of the route as mounted on the root router
+	Path        string
+	OperationID string
+	Tag         string
+	Summary     string
+	Description string
+	// Scopes lists the scopes an API client needs, Authenticated rejects anonymous callers
+	Scopes        []string
+	Authenticated bool
+	Query         []Parameter
+	// Request is a value of the type of the JSON payload, nil when the operation has no payload
+	Request interface{}
+	// Status is the status of a successful response, 200 when zero
+	Status int
+	// Response is a value of the type of the successful JSON response, nil when it has no body
+	Response interface{}
+	// ContentType of the successful response when it is not JSON
+	ContentType string
+	// Downloads lists the other media types the successful response can be downloaded as, they are
+	// documented as binary files
+	Downloads []string
+	// Conditional documents the ETag of the response and the If-None-Match header on reads or the
+	// If-Match header on writes
+	Conditional bool
+	// Errors lists the statuses of the error responses besides 400 for payloads, 401 and 403
+	// for scopes, 404 for path parameters, 412 and 428 for conditional writes and 500
+	Errors []int
+}
+
+// Spec collects the routes and the types of the API to build its OpenAPI document
+type Spec struct {
+	info            Info
+	tags            []Tag
+	routes          []Route
+	securitySchemes map[string]SecurityScheme
+	schemas         *schemas
+}
+
+// New returns an empty spec, errorBody is a value of the type answered on errors
+func New(info Info, errorBody interface{}) *Spec {
+	spec := &Spec{info: info, securitySchemes: map[string]SecurityScheme{}, schemas: newSchemas()}
+	spec.schemas.components[errorSchemaName] = spec.schemas.object(reflect.TypeOf(errorBody).Elem())
+	return spec
+}
+
+// Enum documents the values of a string type, value is a value of the type
+func (s *Spec) Enum(value interface{}, values ...string) {
+	s.schemas.enums[reflect.TypeOf(value)] = values
+}
+
+// Tag describes a group of operations
+func (s *Spec) Tag(name string, description string) {
+	s.tags = append(s.tags, Tag{Name: name, Description: description})
+}
+
+// SecurityScheme declares a way for callers to authenticate, every scheme is accepted
+func (s *Spec) SecurityScheme(name string, scheme SecurityScheme) {
+	s.securitySchemes[name] = scheme
+}
+
+// Add registers routes in the spec
+func (s *Spec) Add(routes ...Route) {
+	s.routes = append(s.routes, routes...)
+}
+
+// Document builds the OpenAPI document of the registered routes
+func (s *Spec) Document() *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    s.info,
+		Tags:    s.tags,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas:         s.schemas.components,
+			SecuritySchemes: s.securitySchemes,
+		},
+	}
+	for _, route := range s.routes {
+		item, ok := doc.Paths[route.Path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = s.operation(route)
+	}
+	return doc
+}
+
+func (s *Spec) operation(route Route) *Operation {
+	op := &Operation{
+		Summary:     route.Summary,
+		Description: route.Description,
+		OperationID: route.OperationID,
+		Responses:   map[string]*Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	op.Parameters = append(op.Parameters, route.Query...)
+
+	errorStatuses := append([]int{http.StatusInternalServerError}, route.Errors...)
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: s.schemas.of(route.Request)}},
+		}
+		errorStatuses = append(errorStatuses, http.StatusBadRequest)
+	}
+	if pathParamPattern.MatchString(route.Path) {
+		// A malformed ID in the path is rejected before the resource is looked up
+		errorStatuses = append(errorStatuses, http.StatusBadRequest, http.StatusNotFound)
+	}
+	if len(route.Scopes) > 0 || route.Authenticated {
+		op.Security = s.securityRequirements()
+		op.RequiredScopes = route.Scopes
+		errorStatuses = append(errorStatuses, http.StatusForbidden)
+		if route.Authenticated {
+			errorStatuses = append(errorStatuses, http.StatusUnauthorized)
+		} else {
+			// Anonymous callers are let through, the empty requirement makes security optional
+			op.Security = append(op.Security, SecurityRequirement{})
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := &Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = jsonContentType
+		}
+		response.Content = map[string]MediaType{contentType: {Schema: s.schemas.of(route.Response)}}
+		for _, download := range route.Downloads {
+			response.Content[download] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+
+	if route.Conditional {
+		response.Headers = map[string]Header{"ETag": {Description: "Version of the returned representation", Schema: &Schema{Type: "string"}}}
+		if route.Method == http.MethodGet {
+			op.Parameters = append(op.Parameters, Parameter{Name: "If-None-Match", In: "header", Description: "Answers 304 when the representation still has one of these entity tags", Schema: &Schema{Type: "string"}})
+			op.Responses[strconv.Itoa(http.StatusNotModified)] = &Response{Description: http.StatusText(http.StatusNotModified)}
+		} else {
+			op.Parameters = append(op.Parameters, Parameter{Name: "If-Match", In: "header", Description: "Entity tags the stored document must still match, required when the server enforces conditional writes", Schema: &Schema{Type: "string"}})
+			errorStatuses = append(errorStatuses, http.StatusPreconditionFailed, http.StatusPreconditionRequired)
+		}
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	errorRef := &Schema{Ref: "#/components/schemas/" + errorSchemaName}
+	for _, errorStatus := range errorStatuses {
+		op.Responses[strconv.Itoa(errorStatus)] = &Response{
+			Description: http.StatusText(errorStatus),
+			Content:     map[string]MediaType{jsonContentType: {Schema: errorRef}},
+		}
+	}
+	return op
+}
+
+func (s *Spec) securityRequirements() []SecurityRequirement {
+	names := make([]string, 0, len(s.securitySchemes))
+	for name := range s.securitySchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	requirements := make([]SecurityRequirement, 0, len(names))
+	for _, name := range names {
+		requirements = append(requirements, SecurityRequirement{name: []string{}})
+	}
+	return requirements
+}
+
+// Diff compares the routes mounted on router with the operations of doc. It returns as
+// "METHOD /path" the mounted routes missing from the document and the documented operations
+// that are not mounted.
+func Diff(router chi.Routes, doc *Document) (missing []string, stale []string, err error) {
+	mounted := map[string]bool{}
+	walk := func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		mounted[method+" "+normalizePattern(route)] = true
+		return nil
+	}
+	if err := chi.Walk(router, walk); err != nil {
+		return nil, nil, errors.Wrap(err, "Error walking the routes of the router")
+	}
+
+	documented := map[string]bool{}
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	for route := range mounted {
+		if !documented[route] {
+			missing = append(missing, route)
+		}
+	}
+	for route := range documented {
+		if !mounted[route] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	return missing, stale, nil
+}
+
+// normalizePattern turns the pattern reported by chi.Walk for a route of a mounted router, like
+// /suppliers/*/{supplierID}/*/, into the pattern clients request, like /suppliers/{supplierID}
+func normalizePattern(pattern string) string {
+	pattern = strings.Replace(pattern, "/*/", "/", -1)
+	pattern = strings.TrimSuffix(pattern, "/*")
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	return pattern
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+)
+
+// APIClientHandler return a handler for the Rest API used by administrators to manage API clients
+type APIClientHandler struct {
+	Service *services.APIClientService
+}
+
+// NewRouter export a router configured with the API client routes
+func (h *APIClientHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Use(RequireScopes(enums.APIClientsAdmin))
+
+	r.Method(http.MethodGet, "/", rootHandler(h.findAllAPIClients))
+	r.Method(http.MethodPost, "/", rootHandler(h.createAPIClient))
+
+	// Subroutes:
+	r.Route("/{apiClientID}", func(r chi.Router) {
+		r.Method(http.MethodGet, "/", rootHandler(h.findAPIClientByID))
+		r.Method(http.MethodPut, "/", rootHandler(h.updateAPIClientByID))
+		r.Method(http.MethodDelete, "/", rootHandler(h.deleteAPIClientByID))
+		r.Method(http.MethodPost, "/rotate-key", rootHandler(h.rotateAPIKey))
+	})
+
+	return r
+}
+
+func (h *APIClientHandler) findAllAPIClients(w http.ResponseWriter, r *http.Request) error {
+	results, err := h.Service.FindAllAPIClients()
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *APIClientHandler) createAPIClient(w http.ResponseWriter, r *http.Request) error {
+	var payload dtos.APIClientDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	issued, err := h.Service.CreateAPIClient(r.Context(), &payload)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", entityETag(issued.Client.ID, issued.Client.Version))
+	return writeJSON(w, http.StatusCreated, issued)
+}
+
+func (h *APIClientHandler) findAPIClientByID(w http.ResponseWriter, r *http.Request) error {
+	apiClientID := chi.URLParam(r, "apiClientID")
+	apiClient, err := h.Service.FindAPIClientByID(apiClientID)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(apiClient.ID, apiClient.Version), apiClient)
+}
+
+func (h *APIClientHandler) updateAPIClientByID(w http.ResponseWriter, r *http.Request) error {
+	apiClientID := chi.URLParam(r, "apiClientID")
+	versions, err := ifMatchVersions(r, apiClientID)
+	if err != nil {
+		return err
+	}
+	var payload dtos.APIClientDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	apiClient, err := h.Service.UpdateAPIClient(r.Context(), apiClientID, &payload, versions)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(apiClient.ID, apiClient.Version), apiClient)
+}
+
+func (h *APIClientHandler) rotateAPIKey(w http.ResponseWriter, r *http.Request) error {
+	apiClientID := chi.URLParam(r, "apiClientID")
+	versions, err := ifMatchVersions(r, apiClientID)
+	if err != nil {
+		return err
+	}
+
+	issued, err := h.Service.RotateAPIKey(r.Context(), apiClientID, versions)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", entityETag(issued.Client.ID, issued.Client.Version))
+	return writeJSON(w, http.StatusOK, issued)
+}
+
+func (h *APIClientHandler) deleteAPIClientByID(w http.ResponseWriter, r *http.Request) error {
+	apiClientID := chi.URLParam(r, "apiClientID")
+	versions, err := ifMatchVersions(r, apiClientID)
+	if err != nil {
+		return err
+	}
+	if _, err := h.Service.DeleteAPIClient(r.Context(), apiClientID, versions); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AuditHandler return a handler for the Rest API used by administrators to search the audit trail
+type AuditHandler struct {
+	Service *services.AuditService
+}
+
+// NewRouter export a router configured with the audit log routes
+func (h *AuditHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Use(RequireScopes(enums.AuditRead))
+
+	r.Method(http.MethodGet, "/", rootHandler(h.findAuditEntries))
+
+	return r
+}
+
+// findAuditEntries searches the audit trail, the query string accepts resourceType, resourceId,
+// actorId, from and to (RFC 3339 timestamps), limit and skip
+func (h *AuditHandler) findAuditEntries(w http.ResponseWriter, r *http.Request) error {
+	query, err := parseAuditQuery(r)
+	if err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+
+	results, err := h.Service.FindAuditEntries(query)
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func parseAuditQuery(r *http.Request) (*dtos.AuditQueryDto, error) {
+	values := r.URL.Query()
+	query := &dtos.AuditQueryDto{
+		ResourceType: values.Get("resourceType"),
+		ResourceID:   values.Get("resourceId"),
+		ActorID:      values.Get("actorId"),
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(values, "from"); err != nil {
+		return nil, err
+	}
+	if query.To, err = parseTimeParam(values, "to"); err != nil {
+		return nil, err
+	}
+	if query.Limit, query.Skip, err = parsePaging(values, defaultAuditLimit, maxAuditLimit); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
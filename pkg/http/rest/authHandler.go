@@ -21,6 +21,7 @@ func (h *AuthHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
 	r.Method(http.MethodPost, "/login", rootHandler(h.login))
+	r.With(RequireScopes()).Method(http.MethodPost, "/logout", rootHandler(h.logout))
 
 	return r
 }
@@ -31,24 +32,27 @@ func (h *AuthHandler) login(w http.ResponseWriter, r *http.Request) error {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	user, err := h.Service.Login(&payload)
+	session, err := h.Service.Login(r.Context(), &payload)
 
 	if err != nil {
 		if errors.Cause(err) == bcrypt.ErrMismatchedHashAndPassword || errors.Cause(err) == bcrypt.ErrHashTooShort {
 			return NewUnauthorizedError(err, "Authentication failed. Wrong user or password.")
 		}
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if user == nil {
+	if session == nil {
 		return NewAPIError(nil, http.StatusUnauthorized, http.StatusUnauthorized, "Authentication failed. Wrong user or password.")
 	}
 
-	user.HashedPassword = ""
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(user); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	return writeJSON(w, http.StatusOK, session)
+}
+
+func (h *AuthHandler) logout(w http.ResponseWriter, r *http.Request) error {
+	if _, err := h.Service.Logout(r.Context(), requestAPIKey(r)); err != nil {
+		return err
 	}
+
+	w.WriteHeader(http.StatusNoContent)
 	return nil
 }
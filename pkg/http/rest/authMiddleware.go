@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"net/http"
+	"strings"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Authenticator is a middleware that identifies the API client calling the API from the key sent
+// in the X-API-Key header or as a bearer token, or the user from the token of the session opened
+// by its login, requests without a key go on as anonymous. The principal acts for the
+// organization named by the X-Organization-ID header, if any.
+type Authenticator struct {
+	Service       *services.APIClientService
+	Sessions      *services.AuthService
+	Organizations *services.OrganizationService
+	// AdminKey is a static key granted every scope, used to bootstrap the first API clients
+	AdminKey string
+}
+
+// Handler returns the middleware handler
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) error {
+		key := requestAPIKey(r)
+		organizationID := r.Header.Get("X-Organization-ID")
+		if key == "" {
+			if organizationID != "" {
+				return NewUnauthorizedError(nil, "Authentication required. Send an API key or a session token to act for an organization.")
+			}
+			next.ServeHTTP(w, r)
+			return nil
+		}
+
+		var principal *models.Principal
+		var err error
+		if services.IsSessionToken(key) {
+			principal, err = a.Sessions.AuthenticatePrincipal(r.Context(), key)
+		} else {
+			principal, err = a.Service.AuthenticatePrincipal(r.Context(), key, a.AdminKey)
+		}
+		if err != nil {
+			return err
+		}
+		if principal == nil {
+			return NewUnauthorizedError(nil, "Authentication failed. Invalid API key.")
+		}
+
+		if organizationID != "" {
+			if principal, err = a.Organizations.ActivateOrganization(r.Context(), principal, organizationID); err != nil {
+				return err
+			}
+			w.Header().Set("X-Organization-ID", principal.OrganizationID)
+			logging.AddFields(r.Context(), logrus.Fields{"organizationId": principal.OrganizationID})
+		}
+
+		w.Header().Set("X-OAuth-Scopes", joinScopes(principal.Scopes))
+		logging.AddFields(r.Context(), logrus.Fields{"principalType": principal.Type, "principalId": principal.ID})
+		next.ServeHTTP(w, r.WithContext(services.WithPrincipal(r.Context(), principal)))
+		return nil
+	}
+	return rootHandler(fn)
+}
+
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		return strings.TrimSpace(authorization[7:])
+	}
+	return ""
+}
+
+// RequireScopes is a middleware that only lets through principals holding every one of the scopes,
+// anonymous requests are rejected with 401
+func RequireScopes(scopes ...enums.EnumScope) func(http.Handler) http.Handler {
+	return scopeGuard(scopes, false)
+}
+
+// RestrictScopes is a middleware that only lets through principals holding every one of the scopes,
+// anonymous requests are still served so that the public reads keep working without a key, the
+// writes are guarded by RequireScopes
+func RestrictScopes(scopes ...enums.EnumScope) func(http.Handler) http.Handler {
+	return scopeGuard(scopes, true)
+}
+
+func scopeGuard(scopes []enums.EnumScope, allowAnonymous bool) func(http.Handler) http.Handler {
+	accepted := joinScopes(scopes)
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) error {
+			w.Header().Set("X-Accepted-OAuth-Scopes", accepted)
+
+			principal := services.PrincipalFromContext(r.Context())
+			if principal == nil {
+				if !allowAnonymous {
+					return NewUnauthorizedError(nil, "Authentication required. Send an API key.")
+				}
+				next.ServeHTTP(w, r)
+				return nil
+			}
+			for _, scope := range scopes {
+				if !principal.HasScope(scope) {
+					return NewForbiddenError(nil, "Forbidden : missing scope "+scope.String()+".")
+				}
+			}
+			next.ServeHTTP(w, r)
+			return nil
+		}
+		return rootHandler(fn)
+	}
+}
+
+func joinScopes(scopes []enums.EnumScope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = scope.String()
+	}
+	return strings.Join(names, ", ")
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
@@ -19,10 +20,11 @@ func (h *CityHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
 	r.Route("/{stateID}/cities", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findAllCitiesByState))
-		r.Method(http.MethodPost, "/", rootHandler(h.createCity))
-		r.Method(http.MethodPut, "/{cityID}", rootHandler(h.updateCityByID))
-		r.Method(http.MethodDelete, "/{cityID}", rootHandler(h.deleteCityByID))
+		r.With(RestrictScopes(enums.CitiesRead)).Method(http.MethodGet, "/", rootHandler(h.findAllCitiesByState))
+		r.With(RequireScopes(enums.CitiesWrite)).Method(http.MethodPost, "/", rootHandler(h.createCity))
+		r.With(RestrictScopes(enums.CitiesRead)).Method(http.MethodGet, "/{cityID}", rootHandler(h.findCityByID))
+		r.With(RequireScopes(enums.CitiesWrite)).Method(http.MethodPut, "/{cityID}", rootHandler(h.updateCityByID))
+		r.With(RequireScopes(enums.CitiesWrite)).Method(http.MethodDelete, "/{cityID}", rootHandler(h.deleteCityByID))
 	})
 
 	return r
@@ -32,16 +34,10 @@ func (h *CityHandler) findAllCitiesByState(w http.ResponseWriter, r *http.Reques
 	stateID := chi.URLParam(r, "stateID")
 	results, err := h.Service.FindAllCitiesByCountryState(stateID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(results); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	return nil
+	return respondWithCollection(w, r, results)
 }
 
 func (h *CityHandler) createCity(w http.ResponseWriter, r *http.Request) error {
@@ -51,77 +47,59 @@ func (h *CityHandler) createCity(w http.ResponseWriter, r *http.Request) error {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	result, err := h.Service.CreateCity(stateID, &payload)
+	result, err := h.Service.CreateCity(r.Context(), stateID, &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
 	city, err := h.Service.FindCityByID(result)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(city); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(city.ID, city.Version), city)
 }
 
 func (h *CityHandler) findCityByID(w http.ResponseWriter, r *http.Request) error {
-	ID := chi.URLParam(r, "id")
-	city, err := h.Service.FindCityByID(ID)
+	stateID := chi.URLParam(r, "stateID")
+	cityID := chi.URLParam(r, "cityID")
+	city, err := h.Service.FindStateCityByID(stateID, cityID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if city == nil {
-		return NewNotFoundError(nil, "City Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(city); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(city.ID, city.Version), city)
 }
 
 func (h *CityHandler) updateCityByID(w http.ResponseWriter, r *http.Request) error {
 	stateID := chi.URLParam(r, "stateID")
 	cityID := chi.URLParam(r, "cityID")
+	versions, err := ifMatchVersions(r, cityID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.CityDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	city, err := h.Service.UpdateCityByID(stateID, cityID, &payload)
+	city, err := h.Service.UpdateCityByID(r.Context(), stateID, cityID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if city == nil {
-		return NewNotFoundError(nil, "City Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(city); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(city.ID, city.Version), city)
 }
 
 func (h *CityHandler) deleteCityByID(w http.ResponseWriter, r *http.Request) error {
 	stateID := chi.URLParam(r, "stateID")
 	cityID := chi.URLParam(r, "cityID")
-	result, err := h.Service.DeleteCityByID(stateID, cityID)
+	versions, err := ifMatchVersions(r, cityID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "Country Not Found")
+	if _, err := h.Service.DeleteCityByID(r.Context(), stateID, cityID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
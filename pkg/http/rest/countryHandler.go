@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
@@ -18,20 +19,20 @@ type CountryHandler struct {
 func (h *CountryHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
-	r.Method(http.MethodGet, "/", rootHandler(h.findAllCountries))
-	r.Method(http.MethodPost, "/", rootHandler(h.createCountry))
+	r.With(RestrictScopes(enums.CountriesRead)).Method(http.MethodGet, "/", rootHandler(h.findAllCountries))
+	r.With(RequireScopes(enums.CountriesWrite)).Method(http.MethodPost, "/", rootHandler(h.createCountry))
 
 	// Subroutes:
 	r.Route("/{countryID}", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findCountryByID))
-		r.Method(http.MethodPut, "/", rootHandler(h.updateCountryByID))
-		r.Method(http.MethodDelete, "/", rootHandler(h.deleteCountryByID))
+		r.With(RestrictScopes(enums.CountriesRead)).Method(http.MethodGet, "/", rootHandler(h.findCountryByID))
+		r.With(RequireScopes(enums.CountriesWrite)).Method(http.MethodPut, "/", rootHandler(h.updateCountryByID))
+		r.With(RequireScopes(enums.CountriesWrite)).Method(http.MethodDelete, "/", rootHandler(h.deleteCountryByID))
 	})
 
 	r.Route("/{countryID}/country-states", func(r chi.Router) {
-		r.Method(http.MethodPost, "/", rootHandler(h.createState))
-		r.Method(http.MethodPut, "/{stateID}", rootHandler(h.updateState))
-		r.Method(http.MethodDelete, "/{stateID}", rootHandler(h.deleteState))
+		r.With(RequireScopes(enums.CountriesWrite)).Method(http.MethodPost, "/", rootHandler(h.createState))
+		r.With(RequireScopes(enums.CountriesWrite)).Method(http.MethodPut, "/{stateID}", rootHandler(h.updateState))
+		r.With(RequireScopes(enums.CountriesWrite)).Method(http.MethodDelete, "/{stateID}", rootHandler(h.deleteState))
 	})
 
 	return r
@@ -40,16 +41,10 @@ func (h *CountryHandler) NewRouter() chi.Router {
 func (h *CountryHandler) findAllCountries(w http.ResponseWriter, r *http.Request) error {
 	results, err := h.Service.FindAllCountries()
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(results); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	return nil
+	return respondWithCollection(w, r, results)
 }
 
 func (h *CountryHandler) createCountry(w http.ResponseWriter, r *http.Request) error {
@@ -58,75 +53,56 @@ func (h *CountryHandler) createCountry(w http.ResponseWriter, r *http.Request) e
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	result, err := h.Service.CreateCountry(&payload)
+	result, err := h.Service.CreateCountry(r.Context(), &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
 	country, err := h.Service.FindCountryByID(result)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(country); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(country.ID, country.Version), country)
 }
 
 func (h *CountryHandler) findCountryByID(w http.ResponseWriter, r *http.Request) error {
 	ID := chi.URLParam(r, "countryID")
 	country, err := h.Service.FindCountryByID(ID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if country == nil {
-		return NewNotFoundError(nil, "Country Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(country); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(country.ID, country.Version), country)
 }
 
 func (h *CountryHandler) updateCountryByID(w http.ResponseWriter, r *http.Request) error {
 	ID := chi.URLParam(r, "countryID")
+	versions, err := ifMatchVersions(r, ID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.CountryDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	country, err := h.Service.UpdateCountryByID(ID, &payload)
+	country, err := h.Service.UpdateCountryByID(r.Context(), ID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if country == nil {
-		return NewNotFoundError(nil, "Country Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(country); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(country.ID, country.Version), country)
 }
 
 func (h *CountryHandler) deleteCountryByID(w http.ResponseWriter, r *http.Request) error {
 	ID := chi.URLParam(r, "countryID")
-	result, err := h.Service.DeleteCountryByID(ID)
+	versions, err := ifMatchVersions(r, ID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "Country Not Found")
+	if _, err := h.Service.DeleteCountryByID(r.Context(), ID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -137,69 +113,54 @@ func (h *CountryHandler) deleteCountryByID(w http.ResponseWriter, r *http.Reques
 
 func (h *CountryHandler) createState(w http.ResponseWriter, r *http.Request) error {
 	countryID := chi.URLParam(r, "countryID")
+	versions, err := ifMatchVersions(r, countryID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.CountryStateDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	country, err := h.Service.AddState(countryID, payload)
+	country, err := h.Service.AddState(r.Context(), countryID, payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if country == nil {
-		return NewNotFoundError(nil, "Country Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(country); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(country.ID, country.Version), country)
 }
 
 func (h *CountryHandler) updateState(w http.ResponseWriter, r *http.Request) error {
 	countryID := chi.URLParam(r, "countryID")
 	stateID := chi.URLParam(r, "stateID")
+	versions, err := ifMatchVersions(r, countryID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.CountryStateDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	country, err := h.Service.UpdateState(countryID, stateID, payload)
+	country, err := h.Service.UpdateState(r.Context(), countryID, stateID, payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if country == nil {
-		return NewNotFoundError(nil, "CountryState Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(country); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(country.ID, country.Version), country)
 }
 
 func (h *CountryHandler) deleteState(w http.ResponseWriter, r *http.Request) error {
 	countryID := chi.URLParam(r, "countryID")
 	stateID := chi.URLParam(r, "stateID")
-	country, err := h.Service.DeleteState(countryID, stateID)
+	versions, err := ifMatchVersions(r, countryID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-
-	if country == nil {
-		return NewNotFoundError(nil, "CountryState Not Found")
+	country, err := h.Service.DeleteState(r.Context(), countryID, stateID, versions)
+	if err != nil {
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(country); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(country.ID, country.Version), country)
 }
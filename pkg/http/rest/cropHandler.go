@@ -3,8 +3,11 @@ package rest
 import (
 	"encoding/json"
 	"net/http"
+	"reflect"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
@@ -18,32 +21,37 @@ type CropHandler struct {
 func (h *CropHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
-	r.Method(http.MethodGet, "/", rootHandler(h.findAllCrops))
-	r.Method(http.MethodPost, "/", rootHandler(h.createCrop))
+	r.With(RestrictScopes(enums.CropsRead)).Method(http.MethodGet, "/", rootHandler(h.findAllCrops))
+	r.With(RequireScopes(enums.CropsWrite)).Method(http.MethodPost, "/", rootHandler(h.createCrop))
 
 	// Subroutes:
 	r.Route("/{cropID}", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findCropByID))
-		r.Method(http.MethodPut, "/", rootHandler(h.updateCropByID))
-		r.Method(http.MethodDelete, "/", rootHandler(h.deleteCropByID))
+		r.With(RestrictScopes(enums.CropsRead)).Method(http.MethodGet, "/", rootHandler(h.findCropByID))
+		r.With(RequireScopes(enums.CropsWrite)).Method(http.MethodPut, "/", rootHandler(h.updateCropByID))
+		r.With(RequireScopes(enums.CropsWrite)).Method(http.MethodDelete, "/", rootHandler(h.deleteCropByID))
 	})
 
 	return r
 }
 
 func (h *CropHandler) findAllCrops(w http.ResponseWriter, r *http.Request) error {
-	results, err := h.Service.FindAllCrops()
+	// The exports are streamed from the database instead of being read at once
+	format, err := exportFormat(r)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+	if format != formatJSON {
+		return respondWithExport(w, r, format, reflect.TypeOf(models.Crop{}), func(write func(interface{}) error) error {
+			return h.Service.EachCrop(r.Context(), func(crop *models.Crop) error { return write(crop) })
+		})
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(results); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	results, err := h.Service.FindAllCrops()
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return respondWithCollection(w, r, results)
 }
 
 func (h *CropHandler) createCrop(w http.ResponseWriter, r *http.Request) error {
@@ -52,70 +60,51 @@ func (h *CropHandler) createCrop(w http.ResponseWriter, r *http.Request) error {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	crop, err := h.Service.CreateCrop(&payload)
+	crop, err := h.Service.CreateCrop(r.Context(), &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(crop); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(crop.ID, crop.Version), crop)
 }
 
 func (h *CropHandler) findCropByID(w http.ResponseWriter, r *http.Request) error {
 	cropID := chi.URLParam(r, "cropID")
 	crop, err := h.Service.FindCropByID(cropID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if crop == nil {
-		return NewNotFoundError(nil, "Crop Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(crop); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(crop.ID, crop.Version), crop)
 }
 
 func (h *CropHandler) updateCropByID(w http.ResponseWriter, r *http.Request) error {
 	cropID := chi.URLParam(r, "cropID")
+	versions, err := ifMatchVersions(r, cropID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.CropDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	crop, err := h.Service.UpdateCropByID(cropID, &payload)
+	crop, err := h.Service.UpdateCropByID(r.Context(), cropID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if crop == nil {
-		return NewNotFoundError(nil, "Crop Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(crop); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(crop.ID, crop.Version), crop)
 }
 
 func (h *CropHandler) deleteCropByID(w http.ResponseWriter, r *http.Request) error {
 	cropID := chi.URLParam(r, "cropID")
-	result, err := h.Service.DeleteCropByID(cropID)
+	versions, err := ifMatchVersions(r, cropID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "Crop Not Found")
+	if _, err := h.Service.DeleteCropByID(r.Context(), cropID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"futuagro.com/pkg/domain/errs"
 	"github.com/pkg/errors"
 )
 
@@ -13,6 +14,17 @@ type APIError struct {
 	Status  int    `json:"status"`
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// Field names the attribute of the request the error is about, like the duplicate email of a
+	// conflict
+	Field string `json:"field,omitempty"`
+	// RequestID identifies the request in the logs, it is filled in when the error is answered
+	RequestID string `json:"requestId,omitempty"`
+
+	// prefix, key and args are the parts of the message of an error of the domain, it is
+	// translated from them
+	prefix string
+	key    string
+	args   []interface{}
 }
 
 func (e *APIError) Error() string {
@@ -72,3 +84,79 @@ func NewUnauthorizedError(err error, message string) error {
 		Message: message,
 	}
 }
+
+// NewPreconditionFailedError create an error instance for an http error 412
+func NewPreconditionFailedError(err error, message string) error {
+	return &APIError{
+		Cause:   err,
+		Status:  http.StatusPreconditionFailed,
+		Code:    http.StatusPreconditionFailed,
+		Message: message,
+	}
+}
+
+// NewPreconditionRequiredError create an error instance for an http error 428
+func NewPreconditionRequiredError(err error, message string) error {
+	return &APIError{
+		Cause:   err,
+		Status:  http.StatusPreconditionRequired,
+		Code:    http.StatusPreconditionRequired,
+		Message: message,
+	}
+}
+
+// NewForbiddenError create an error instance for an http error 403
+func NewForbiddenError(err error, message string) error {
+	return &APIError{
+		Cause:   err,
+		Status:  http.StatusForbidden,
+		Code:    http.StatusForbidden,
+		Message: message,
+	}
+}
+
+// newDomainError maps an error returned by a service into the API error sent to the client. The
+// typed errors of the domain get their status and message, any other error is answered with a 500
+// and only logged.
+func newDomainError(err error) *APIError {
+	cause, ok := errors.Cause(err).(*errs.Error)
+	if !ok || cause.Kind == errs.KindInternal {
+		return &APIError{
+			Cause:   err,
+			Status:  http.StatusInternalServerError,
+			Code:    http.StatusInternalServerError,
+			Message: http.StatusText(http.StatusInternalServerError),
+		}
+	}
+
+	// The context wrapping a validation error names the invalid input, the context wrapping the
+	// other kinds and the underlying errors are only logged. A message is translated from its key
+	// unless it has such a context.
+	message, key := cause.Message, cause.Key
+	if cause.Kind == errs.KindValidation && err.Error() != cause.Error() {
+		message, key = err.Error(), ""
+	}
+	status, prefix := http.StatusInternalServerError, ""
+	switch cause.Kind {
+	case errs.KindNotFound:
+		status = http.StatusNotFound
+	case errs.KindInvalidID, errs.KindValidation:
+		status, prefix = http.StatusBadRequest, "Bad request : "
+	case errs.KindConflict:
+		status, prefix = http.StatusConflict, "Conflict : "
+	case errs.KindForbidden:
+		status, prefix = http.StatusForbidden, "Forbidden : "
+	case errs.KindPreconditionFailed:
+		status, prefix = http.StatusPreconditionFailed, "Precondition Failed : "
+	}
+	return &APIError{
+		Cause:   err,
+		Status:  status,
+		Code:    status,
+		Message: prefix + message,
+		Field:   cause.Field,
+		prefix:  prefix,
+		key:     key,
+		args:    cause.Args,
+	}
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"futuagro.com/pkg/domain/errs"
+	"github.com/pkg/errors"
+)
+
+func TestNewDomainError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+		field   string
+	}{
+		{"not found", errs.NotFound("Supplier"), http.StatusNotFound, "Supplier Not Found", ""},
+		{"invalid ID", errs.InvalidID("x", nil), http.StatusBadRequest, `Bad request : Invalid ID "x"`, ""},
+		{"validation", errs.Validation("The name is required"), http.StatusBadRequest, "Bad request : The name is required", ""},
+		{"wrapped validation", errors.Wrap(errs.Validation("The name is required"), "row 3"), http.StatusBadRequest, "Bad request : row 3: The name is required", ""},
+		{"duplicate", errs.Duplicate("email", nil), http.StatusConflict, "Conflict : email is already in use", "email"},
+		{"wrapped conflict", errors.Wrap(errs.Conflict("The city has crops", nil), "Error deleting"), http.StatusConflict, "Conflict : The city has crops", ""},
+		{"forbidden", errs.Forbidden("Not a member"), http.StatusForbidden, "Forbidden : Not a member", ""},
+		{"precondition failed", errs.PreconditionFailed("Modified"), http.StatusPreconditionFailed, "Precondition Failed : Modified", ""},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, "Internal Server Error", ""},
+		{"internal kind", &errs.Error{Kind: errs.KindInternal, Message: "secret"}, http.StatusInternalServerError, "Internal Server Error", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := newDomainError(tt.err)
+			if apiErr.Status != tt.status || apiErr.Code != tt.status {
+				t.Errorf("got status %d code %d, expected %d", apiErr.Status, apiErr.Code, tt.status)
+			}
+			if apiErr.Message != tt.message {
+				t.Errorf("got %q, expected %q", apiErr.Message, tt.message)
+			}
+			if apiErr.Field != tt.field {
+				t.Errorf("got field %q, expected %q", apiErr.Field, tt.field)
+			}
+		})
+	}
+}
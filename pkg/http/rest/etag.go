@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// entityETag builds the strong entity tag of a versioned document, it changes every time the
+// document is written
+func entityETag(id primitive.ObjectID, version int64) string {
+	return fmt.Sprintf(`"%s-%d"`, id.Hex(), version)
+}
+
+// collectionETag builds a weak entity tag for a list response from its encoded body
+func collectionETag(body []byte) string {
+	sum := sha1.Sum(body)
+	return `W/"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// splitETags returns the entity tags listed in a If-Match or If-None-Match header
+func splitETags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// notModified reports whether the If-None-Match header of the request matches etag, using the
+// weak comparison required for GET requests
+func notModified(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range splitETags(header) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersions returns the document versions a write is conditioned on by the If-Match header.
+// It returns nil when the header is absent or is "*", and a 412 error when none of the listed
+// entity tags belong to the document id.
+func ifMatchVersions(r *http.Request, id string) ([]int64, error) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil, nil
+	}
+	versions := []int64{}
+	for _, tag := range splitETags(header) {
+		if tag == "*" {
+			return nil, nil
+		}
+		if strings.HasPrefix(tag, "W/") {
+			// Weak entity tags never match in a If-Match comparison
+			continue
+		}
+		parts := strings.SplitN(strings.Trim(tag, `"`), "-", 2)
+		if len(parts) != 2 || parts[0] != id {
+			continue
+		}
+		version, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return nil, NewPreconditionFailedError(nil, "Precondition Failed : If-Match does not match the resource.")
+	}
+	return versions, nil
+}
+
+// respondWithEntity writes a versioned document as JSON along with its ETag, or a 304 status
+// when the client already holds the current version
+func respondWithEntity(w http.ResponseWriter, r *http.Request, etag string, entity interface{}) error {
+	w.Header().Set("ETag", etag)
+	if r.Method == http.MethodGet && notModified(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	// The entity is encoded before writing the status, so that a failure is still answered as an
+	// error by the root handler
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(entity); err != nil {
+		w.Header().Del("ETag")
+		return errors.Wrap(err, "Error encoding a response")
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+	return nil
+}
+
+// respondWithCollection writes a list as JSON along with an ETag derived from its content, or a 304
+// status when the client already holds the same representation. A list requested as CSV, XLSX or
+// NDJSON is exported instead, without an ETag.
+func respondWithCollection(w http.ResponseWriter, r *http.Request, results interface{}) error {
+	format, err := exportFormat(r)
+	if err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+	if format != formatJSON {
+		return exportCollection(w, r, format, results)
+	}
+	w.Header().Add("Vary", "Accept")
+
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(results); err != nil {
+		return errors.Wrap(err, "Error encoding a collection")
+	}
+
+	etag := collectionETag(body.Bytes())
+	w.Header().Set("ETag", etag)
+	if notModified(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+	return nil
+}
+
+
+// RequireIfMatch is a middleware that rejects unconditional writes with 428 Precondition Required,
+// forcing clients to send the ETag of the version they are modifying
+func RequireIfMatch(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) error {
+		switch r.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if r.Header.Get("If-Match") == "" {
+				return NewPreconditionRequiredError(nil, "Precondition Required : send an If-Match header.")
+			}
+		}
+		next.ServeHTTP(w, r)
+		return nil
+	}
+	return rootHandler(fn)
+}
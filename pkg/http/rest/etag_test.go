@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEntityETag(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex("5d8b7a2e9f1c4a3b2c1d0e0f")
+	if etag := entityETag(id, 3); etag != `"5d8b7a2e9f1c4a3b2c1d0e0f-3"` {
+		t.Errorf("got %s", etag)
+	}
+	if entityETag(id, 3) == entityETag(id, 4) {
+		t.Error("the entity tag does not change with the version")
+	}
+}
+
+func TestIfMatchVersions(t *testing.T) {
+	id := "5d8b7a2e9f1c4a3b2c1d0e0f"
+	tests := []struct {
+		name     string
+		header   string
+		expected []int64
+		failed   bool
+	}{
+		{"no header", "", nil, false},
+		{"any version", "*", nil, false},
+		{"any version among tags", `"other-1", *`, nil, false},
+		{"one version", `"5d8b7a2e9f1c4a3b2c1d0e0f-3"`, []int64{3}, false},
+		{"several versions", `"5d8b7a2e9f1c4a3b2c1d0e0f-3", "5d8b7a2e9f1c4a3b2c1d0e0f-4"`, []int64{3, 4}, false},
+		{"tags of other documents skipped", `"5d8b7a2e9f1c4a3b2c1d0e00-9", "5d8b7a2e9f1c4a3b2c1d0e0f-3"`, []int64{3}, false},
+		{"weak tag", `W/"5d8b7a2e9f1c4a3b2c1d0e0f-3"`, nil, true},
+		{"another document", `"5d8b7a2e9f1c4a3b2c1d0e00-3"`, nil, true},
+		{"not a version", `"5d8b7a2e9f1c4a3b2c1d0e0f-x"`, nil, true},
+		{"not an entity tag", `"5d8b7a2e9f1c4a3b2c1d0e0f"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			versions, err := ifMatchVersions(r, id)
+			if tt.failed {
+				apiErr, ok := err.(*APIError)
+				if !ok || apiErr.Status != http.StatusPreconditionFailed {
+					t.Fatalf("got %v, expected a 412 error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(versions, tt.expected) {
+				t.Errorf("got %v, expected %v", versions, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		header   string
+		etag     string
+		expected bool
+	}{
+		{"", `"a-1"`, false},
+		{`"a-1"`, `"a-1"`, true},
+		{`W/"a-1"`, `"a-1"`, true},
+		{`"a-2", "a-1"`, `"a-1"`, true},
+		{"*", `"a-1"`, true},
+		{`"a-2"`, `"a-1"`, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("If-None-Match", tt.header)
+		if modified := notModified(r, tt.etag); modified != tt.expected {
+			t.Errorf("If-None-Match %s: got %v, expected %v", tt.header, modified, tt.expected)
+		}
+	}
+}
+
+func TestRespondWithEntity(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := respondWithEntity(w, r, `"a-1"`, map[string]string{"name": "Maracuyá"}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"a-1"` || w.Body.String() != "{\"name\":\"Maracuyá\"}\n" {
+		t.Errorf("got %d %s %q", w.Code, w.Header().Get("ETag"), w.Body.String())
+	}
+
+	// An entity that cannot be encoded leaves the response untouched for the error
+	w = httptest.NewRecorder()
+	if err := respondWithEntity(w, r, `"a-1"`, map[string]interface{}{"f": func() {}}); err == nil {
+		t.Fatal("expected an encoding error")
+	}
+	if w.Body.Len() != 0 || w.Header().Get("ETag") != "" {
+		t.Errorf("the response was written: %q %s", w.Body.String(), w.Header().Get("ETag"))
+	}
+
+	w = httptest.NewRecorder()
+	r.Header.Set("If-None-Match", `"a-1"`)
+	if err := respondWithEntity(w, r, `"a-1"`, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("got %d %q, expected a 304", w.Code, w.Body.String())
+	}
+}
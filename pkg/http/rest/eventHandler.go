@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+// eventHeartbeatInterval is how often a comment is sent on an idle stream to keep proxies from
+// closing it
+const eventHeartbeatInterval = 15 * time.Second
+
+// EventHandler return a handler for the Server-Sent Events stream of the changes to the resources
+type EventHandler struct {
+	Source services.EventSource
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Close ends the streams being served, http.Server.Shutdown does not wait for them to end by
+// themselves
+func (h *EventHandler) Close() {
+	close(h.closedChan())
+}
+
+func (h *EventHandler) closedChan() chan struct{} {
+	h.closeOnce.Do(func() {
+		h.closed = make(chan struct{})
+	})
+	return h.closed
+}
+
+// NewRouter export a router configured with the event stream routes
+func (h *EventHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+
+	r.Method(http.MethodGet, "/", rootHandler(h.streamEvents))
+
+	return r
+}
+
+// streamEvents streams the create, update and delete events of the resources the caller can read.
+// The query string accepts resourceType and resourceId, both repeatable or comma separated, and
+// the stream resumes after the event given in the Last-Event-ID header or lastEventId parameter.
+func (h *EventHandler) streamEvents(w http.ResponseWriter, r *http.Request) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("Streaming unsupported")
+	}
+
+	filter := &services.EventFilter{
+		Principal:     services.PrincipalFromContext(r.Context()),
+		ResourceTypes: queryList(r, "resourceType"),
+		ResourceIDs:   queryList(r, "resourceId"),
+	}
+	for _, resourceType := range filter.ResourceTypes {
+		if !services.IsKnownResourceType(resourceType) {
+			return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+errInvalidParam("resourceType").Error())
+		}
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+	events, err := h.Source.Subscribe(r.Context(), lastEventID)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	closed := h.closedChan()
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-closed:
+			return nil
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !filter.Allows(event) {
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.StreamID, event.Type, event.Payload)
+			flusher.Flush()
+		}
+	}
+}
+
+// queryList returns the values of a repeatable query parameter, each value may also hold a
+// comma separated list
+func queryList(r *http.Request, name string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[name] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
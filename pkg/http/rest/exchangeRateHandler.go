@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+)
+
+// ExchangeRateHandler return a handler for the Rest API of the exchange rates and of the
+// conversions of amounts between currencies
+type ExchangeRateHandler struct {
+	Service *services.ExchangeRateService
+}
+
+// NewRouter export a router configured with the exchange rate routes
+func (h *ExchangeRateHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(RestrictScopes(enums.ExchangeRatesRead)).Method(http.MethodGet, "/", rootHandler(h.findRates))
+	r.With(RequireScopes(enums.ExchangeRatesAdmin)).Method(http.MethodPost, "/", rootHandler(h.createRate))
+	r.With(RestrictScopes(enums.ExchangeRatesRead)).Method(http.MethodGet, "/convert", rootHandler(h.convert))
+	r.With(RestrictScopes(enums.ExchangeRatesRead)).Method(http.MethodGet, "/{rateID}", rootHandler(h.findRateByID))
+	r.With(RequireScopes(enums.ExchangeRatesAdmin)).Method(http.MethodDelete, "/{rateID}", rootHandler(h.deleteRate))
+
+	return r
+}
+
+// findRates lists the rates, of a single currency with the base or quote query parameters
+func (h *ExchangeRateHandler) findRates(w http.ResponseWriter, r *http.Request) error {
+	values := r.URL.Query()
+	results, err := h.Service.FindRates(values.Get("base"), values.Get("quote"))
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *ExchangeRateHandler) createRate(w http.ResponseWriter, r *http.Request) error {
+	var payload dtos.ExchangeRateDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	rate, err := h.Service.CreateRate(r.Context(), &payload)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", "/exchange-rates/"+rate.ID.Hex())
+	return writeJSON(w, http.StatusCreated, rate)
+}
+
+func (h *ExchangeRateHandler) findRateByID(w http.ResponseWriter, r *http.Request) error {
+	rate, err := h.Service.FindRateByID(chi.URLParam(r, "rateID"))
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, rate)
+}
+
+func (h *ExchangeRateHandler) deleteRate(w http.ResponseWriter, r *http.Request) error {
+	if err := h.Service.DeleteRate(r.Context(), chi.URLParam(r, "rateID")); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// convert converts the amount query parameter, in the minor units of the currency from, into the
+// currency of the currency query parameter, or of to, with the rate in effect at the time of the
+// at query parameter, now by default
+func (h *ExchangeRateHandler) convert(w http.ResponseWriter, r *http.Request) error {
+	values := r.URL.Query()
+	amount, err := strconv.ParseInt(values.Get("amount"), 10, 64)
+	if err != nil {
+		err = errInvalidParam("amount")
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+	var at time.Time
+	if t, err := parseTimeParam(values, "at"); err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	} else if t != nil {
+		at = *t
+	}
+
+	currency := values.Get("currency")
+	if currency == "" {
+		currency = values.Get("to")
+	}
+
+	conversion, err := h.Service.Convert(models.Money{Amount: amount, Currency: values.Get("from")}, currency, at)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, conversion)
+}
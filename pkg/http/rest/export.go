@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/spreadsheet"
+	"github.com/pkg/errors"
+)
+
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// exportMediaTypes are the media types of the formats a collection can be downloaded in
+var exportMediaTypes = map[string]string{
+	formatJSON:       "application/json",
+	formatNDJSON:     "application/x-ndjson",
+	spreadsheet.CSV:  "text/csv",
+	spreadsheet.XLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportFormat returns the format a collection is requested in, from the format query parameter or
+// else from the first media type of the Accept header that is a known format. It defaults to json.
+func exportFormat(r *http.Request) (string, error) {
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" {
+		if _, ok := exportMediaTypes[format]; !ok {
+			return "", errInvalidParam("format")
+		}
+		return format, nil
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		for format, exportType := range exportMediaTypes {
+			if mediaType == exportType {
+				return format, nil
+			}
+		}
+	}
+	return formatJSON, nil
+}
+
+// respondWithExport streams a collection in one of the export formats as a download named after
+// the last segment of the path. each calls its argument with every element of the collection,
+// whose type is itemType.
+func respondWithExport(w http.ResponseWriter, r *http.Request, format string, itemType reflect.Type, each func(write func(interface{}) error) error) error {
+	// Once the first row is sent the status can no longer be changed, a failure then truncates
+	// the download and is only logged
+	started := false
+	start := func() {
+		if !started {
+			started = true
+			name := path.Base(strings.TrimSuffix(r.URL.Path, "/"))
+			w.Header().Set("Content-Type", exportMediaTypes[format])
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + "." + format}))
+			w.Header().Add("Vary", "Accept")
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+
+	var err error
+	if format == formatNDJSON {
+		encoder := json.NewEncoder(w)
+		err = each(func(item interface{}) error {
+			start()
+			return encoder.Encode(item)
+		})
+	} else {
+		// The file is opened with the first row, a collection that cannot be read is still answered
+		// with an error status
+		flattener := spreadsheet.NewFlattener(itemType)
+		var writer spreadsheet.Writer
+		open := func() error {
+			if writer != nil {
+				return nil
+			}
+			start()
+			var err error
+			writer, err = spreadsheet.NewWriter(w, format, flattener.Columns())
+			return err
+		}
+		err = each(func(item interface{}) error {
+			if err := open(); err != nil {
+				return err
+			}
+			return writer.Write(flattener.Row(item))
+		})
+		if err == nil {
+			err = open()
+		}
+		if writer != nil {
+			if closeErr := writer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+
+	if err != nil && !started {
+		return err
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).WithError(err).Error("Error exporting a collection, the download is truncated")
+		return nil
+	}
+	start()
+	return nil
+}
+
+// exportCollection exports a collection already read, results is a slice
+func exportCollection(w http.ResponseWriter, r *http.Request, format string, results interface{}) error {
+	list := reflect.ValueOf(results)
+	if list.Kind() != reflect.Slice {
+		return errors.Errorf("Cannot export a %s", list.Type())
+	}
+	return respondWithExport(w, r, format, list.Type().Elem(), func(write func(interface{}) error) error {
+		for i := 0; i < list.Len(); i++ {
+			if err := write(list.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
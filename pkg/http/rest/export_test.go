@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"futuagro.com/pkg/spreadsheet"
+)
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		accept   string
+		expected string
+		invalid  bool
+	}{
+		{"default", "", "", formatJSON, false},
+		{"query", "?format=csv", "", spreadsheet.CSV, false},
+		{"query in upper case", "?format=XLSX", "", spreadsheet.XLSX, false},
+		{"query over Accept", "?format=ndjson", "text/csv", formatNDJSON, false},
+		{"unknown query", "?format=pdf", "", "", true},
+		{"Accept", "", "text/csv", spreadsheet.CSV, false},
+		{"Accept with parameters", "", "application/x-ndjson; charset=utf-8", formatNDJSON, false},
+		{"first known type of Accept", "", "text/html, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet;q=0.9, text/csv", spreadsheet.XLSX, false},
+		{"unknown Accept", "", "text/html, */*", formatJSON, false},
+		{"malformed Accept", "", ";;;", formatJSON, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/crops"+tt.query, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			format, err := exportFormat(r)
+			if tt.invalid {
+				if err == nil {
+					t.Errorf("got %s, expected an error", format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.expected {
+				t.Errorf("got %s, expected %s", format, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/errs"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/go-chi/chi"
+	graphql "github.com/graph-gophers/graphql-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// graphMaxBody bounds the size of a GraphQL request
+	graphMaxBody = 1 << 20
+	// graphMaxDepth bounds the nesting of the selections of a query
+	graphMaxDepth = 10
+	// graphDefaultPage and graphMaxPage are the default and the largest first argument of a
+	// connection
+	graphDefaultPage = 20
+	graphMaxPage     = 100
+)
+
+// GraphQLHandler return a handler for the GraphQL API over the records of the marketplace. The
+// queries read the records through the lookup service, the mutations go through the same services
+// as the REST routes.
+type GraphQLHandler struct {
+	Lookup    *services.LookupService
+	Countries *services.CountryService
+	Cities    *services.CityService
+	Items     *services.ItemService
+	Variants  *services.VariantService
+	Suppliers *services.SupplierService
+	Users     *services.UserService
+	Crops     *services.CropService
+	// RequireVersion rejects the writes sent without the version of the record with 428
+	// Precondition Required, like RequireIfMatch on the REST routes
+	RequireVersion bool
+
+	schema *graphql.Schema
+}
+
+// NewRouter export a router configured with the GraphQL route, queries can be sent with GET and
+// mutations only with POST
+func (h *GraphQLHandler) NewRouter() chi.Router {
+	h.schema = graphql.MustParseSchema(graphSchema, &graphRoot{}, graphql.MaxDepth(graphMaxDepth))
+
+	r := chi.NewRouter()
+
+	r.Method(http.MethodGet, "/", rootHandler(h.serve))
+	r.Method(http.MethodPost, "/", rootHandler(h.serve))
+
+	return r
+}
+
+// GraphQLRequest is the payload of a GraphQL request, a GET request sends its fields as query
+// parameters with the variables as JSON
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (h *GraphQLHandler) serve(w http.ResponseWriter, r *http.Request) error {
+	var query GraphQLRequest
+	if r.Method == http.MethodGet {
+		values := r.URL.Query()
+		query.Query = values.Get("query")
+		query.OperationName = values.Get("operationName")
+		if variables := values.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &query.Variables); err != nil {
+				return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid variables.")
+			}
+		}
+	} else if err := json.NewDecoder(io.LimitReader(r.Body, graphMaxBody)).Decode(&query); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+	if strings.TrimSpace(query.Query) == "" {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : missing query.")
+	}
+
+	request := newGraphRequest(h)
+	request.readOnly = r.Method == http.MethodGet
+	ctx := context.WithValue(r.Context(), graphRequestKey, request)
+	response := h.schema.Exec(ctx, query.Query, query.OperationName, query.Variables)
+	locales := requestLocales(w, r)
+	for _, queryError := range response.Errors {
+		if queryError.ResolverError == nil {
+			continue
+		}
+		// The errors of the resolvers are answered like the errors of the REST routes
+		apiError, ok := queryError.ResolverError.(*APIError)
+		if !ok {
+			apiError = newDomainError(queryError.ResolverError)
+		}
+		apiError.RequestID = services.RequestIDFromContext(r.Context())
+		metrics.APIErrors.WithLabelValues(strconv.Itoa(apiError.Code)).Inc()
+		logger := logging.FromContext(r.Context()).WithField("status", apiError.Status).WithField("path", queryError.Path)
+		if apiError.Cause != nil {
+			logger = logger.WithField("cause", apiError.Cause.Error())
+		}
+		if apiError.Status >= http.StatusInternalServerError {
+			logger.Error(apiError.Message)
+		} else {
+			logger.Info(apiError.Message)
+		}
+		// The message is logged in English and answered in the language of the caller
+		localizeError(locales, apiError)
+		queryError.Message = apiError.Message
+		queryError.Extensions = map[string]interface{}{"status": apiError.Status, "code": apiError.Code, "requestId": apiError.RequestID}
+		if apiError.Field != "" {
+			queryError.Extensions["field"] = apiError.Field
+		}
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+	return nil
+}
+
+type graphContextKey string
+
+const graphRequestKey graphContextKey = "graphRequest"
+
+// graphRequest holds the loaders of a GraphQL request, the nodes of the response share them
+type graphRequest struct {
+	h *GraphQLHandler
+	// readOnly requests are sent with GET, they cannot run mutations
+	readOnly bool
+
+	countries        *graphLoader
+	countriesByState *graphLoader
+	cities           *graphLoader
+	citiesByState    *graphLoader
+	items            *graphLoader
+	variants         *graphLoader
+	variantsByItem   *graphLoader
+	suppliers        *graphLoader
+	users            *graphLoader
+	crops            *graphLoader
+	cropsBySupplier  *graphLoader
+	cropsByVariant   *graphLoader
+}
+
+func newGraphRequest(h *GraphQLHandler) *graphRequest {
+	lookup := h.Lookup
+	req := &graphRequest{h: h}
+	req.countries = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var countries []*models.Country
+		err := lookup.FindIn(ctx, models.ResourceCountry, "_id", ids, &countries)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, country := range countries {
+			req.primeCountry(country)
+			values[country.ID] = country
+		}
+		return values, err
+	})
+	req.countriesByState = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var countries []*models.Country
+		err := lookup.FindIn(ctx, models.ResourceCountry, "states._id", ids, &countries)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, country := range countries {
+			req.primeCountry(country)
+			for _, state := range country.States {
+				values[state.ID] = country
+			}
+		}
+		return values, err
+	})
+	req.cities = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var cities []*models.City
+		err := lookup.FindIn(ctx, models.ResourceCity, "_id", ids, &cities)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, city := range cities {
+			req.primeCity(city)
+			values[city.ID] = city
+		}
+		return values, err
+	})
+	req.citiesByState = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var cities []*models.City
+		err := lookup.FindIn(ctx, models.ResourceCity, "countryStateId", ids, &cities)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, city := range cities {
+			req.primeCity(city)
+			list, _ := values[city.CountryStateID].([]*models.City)
+			values[city.CountryStateID] = append(list, city)
+		}
+		return values, err
+	})
+	req.items = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var items []*models.Item
+		err := lookup.FindIn(ctx, models.ResourceItem, "_id", ids, &items)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, item := range items {
+			req.primeItem(item)
+			values[item.ID] = item
+		}
+		return values, err
+	})
+	req.variants = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var variants []*models.Variant
+		err := lookup.FindIn(ctx, models.ResourceVariant, "_id", ids, &variants)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, variant := range variants {
+			req.primeVariant(variant)
+			values[variant.ID] = variant
+		}
+		return values, err
+	})
+	req.variantsByItem = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var variants []*models.Variant
+		err := lookup.FindIn(ctx, models.ResourceVariant, "itemId", ids, &variants)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, variant := range variants {
+			req.primeVariant(variant)
+			list, _ := values[variant.ItemID].([]*models.Variant)
+			values[variant.ItemID] = append(list, variant)
+		}
+		return values, err
+	})
+	req.suppliers = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var suppliers []*models.Supplier
+		err := lookup.FindIn(ctx, models.ResourceSupplier, "_id", ids, &suppliers)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, supplier := range suppliers {
+			req.primeSupplier(supplier)
+			values[supplier.ID] = supplier
+		}
+		return values, err
+	})
+	req.users = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var users []*models.User
+		err := lookup.FindIn(ctx, models.ResourceUser, "_id", ids, &users)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, user := range users {
+			req.primeUser(user)
+			values[user.ID] = user
+		}
+		return values, err
+	})
+	req.crops = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var crops []*models.Crop
+		err := lookup.FindIn(ctx, models.ResourceCrop, "_id", ids, &crops)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, crop := range crops {
+			req.primeCrop(crop)
+			values[crop.ID] = crop
+		}
+		return values, err
+	})
+	req.cropsBySupplier = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var crops []*models.Crop
+		err := lookup.FindIn(ctx, models.ResourceCrop, "supplierId", ids, &crops)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, crop := range crops {
+			req.primeCrop(crop)
+			list, _ := values[*crop.SupplierID].([]*models.Crop)
+			values[*crop.SupplierID] = append(list, crop)
+		}
+		return values, err
+	})
+	req.cropsByVariant = newGraphLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+		var crops []*models.Crop
+		err := lookup.FindIn(ctx, models.ResourceCrop, "variantId", ids, &crops)
+		values := map[primitive.ObjectID]interface{}{}
+		for _, crop := range crops {
+			req.primeCrop(crop)
+			list, _ := values[*crop.VariantID].([]*models.Crop)
+			values[*crop.VariantID] = append(list, crop)
+		}
+		return values, err
+	})
+	return req
+}
+
+// The prime methods register the relations of a record with the loaders, so that the relations
+// of the records read together are read together
+
+func (req *graphRequest) primeCountry(country *models.Country) {
+	for i := range country.States {
+		req.citiesByState.prime(&country.States[i].ID)
+	}
+}
+
+func (req *graphRequest) primeCity(city *models.City) {
+	req.countriesByState.prime(&city.CountryStateID)
+}
+
+func (req *graphRequest) primeItem(item *models.Item) {
+	req.variantsByItem.prime(&item.ID)
+}
+
+func (req *graphRequest) primeVariant(variant *models.Variant) {
+	req.items.prime(&variant.ItemID)
+	req.cropsByVariant.prime(&variant.ID)
+}
+
+func (req *graphRequest) primeSupplier(supplier *models.Supplier) {
+	req.cities.prime(supplier.CityID)
+	req.cropsBySupplier.prime(&supplier.ID)
+}
+
+func (req *graphRequest) primeUser(user *models.User) {
+	req.cities.prime(user.CityID)
+	req.cropsBySupplier.prime(&user.ID)
+}
+
+// primeCrop registers the supplier ID with both the suppliers and the users, the grower of a crop
+// is one or the other
+func (req *graphRequest) primeCrop(crop *models.Crop) {
+	req.cities.prime(crop.CityID)
+	req.variants.prime(crop.VariantID)
+	req.suppliers.prime(crop.SupplierID)
+	req.users.prime(crop.SupplierID)
+}
+
+func graphRequestFrom(ctx context.Context) *graphRequest {
+	return ctx.Value(graphRequestKey).(*graphRequest)
+}
+
+// authorizeGraphWrite applies the rule of RequireScopes to a mutation: it must be sent with POST
+// by an API client holding every one of the scopes, anonymous callers are rejected
+func authorizeGraphWrite(ctx context.Context, scopes ...enums.EnumScope) error {
+	if graphRequestFrom(ctx).readOnly {
+		return NewAPIError(nil, http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, "Method Not Allowed : send mutations with POST.")
+	}
+	if services.PrincipalFromContext(ctx) == nil {
+		return NewUnauthorizedError(nil, "Authentication required. Send an API key.")
+	}
+	return authorizeGraph(ctx, scopes...)
+}
+
+// authorizeGraphSignup applies the rule of the signup route to a mutation: it must be sent with
+// POST, anonymous callers are served and the API clients need every one of the scopes
+func authorizeGraphSignup(ctx context.Context, scopes ...enums.EnumScope) error {
+	if graphRequestFrom(ctx).readOnly {
+		return NewAPIError(nil, http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, "Method Not Allowed : send mutations with POST.")
+	}
+	return authorizeGraph(ctx, scopes...)
+}
+
+// authorizeGraph applies the rule of RestrictScopes to a field: anonymous callers are served and
+// the API clients need every one of the scopes
+func authorizeGraph(ctx context.Context, scopes ...enums.EnumScope) error {
+	principal := services.PrincipalFromContext(ctx)
+	if principal == nil {
+		return nil
+	}
+	for _, scope := range scopes {
+		if !principal.HasScope(scope) {
+			return NewForbiddenError(nil, "Forbidden : missing scope "+scope.String()+".")
+		}
+	}
+	return nil
+}
+
+// graphID parses an ID argument
+func graphID(id graphql.ID) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(string(id))
+	if err != nil {
+		return oid, errs.InvalidID(string(id), err)
+	}
+	return oid, nil
+}
+
+// graphOptionalID parses an optional ID argument, nil when it is absent
+func graphOptionalID(id *graphql.ID) (*primitive.ObjectID, error) {
+	if id == nil {
+		return nil, nil
+	}
+	oid, err := graphID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &oid, nil
+}
+
+// graphVersions turns the version argument of a write into the versions it is conditioned on,
+// like the If-Match header of the REST routes. A write without version is rejected when the
+// handler requires one, like RequireIfMatch does.
+func graphVersions(ctx context.Context, version *int32) ([]int64, error) {
+	if version == nil {
+		if graphRequestFrom(ctx).h.RequireVersion {
+			return nil, NewPreconditionRequiredError(nil, "Precondition Required : send the version argument.")
+		}
+		return nil, nil
+	}
+	return []int64{int64(*version)}, nil
+}
+
+// graphPageArgs are the arguments of a connection
+type graphPageArgs struct {
+	First *int32
+	After *string
+}
+
+// graphConnection holds the page of a connection, its cursors are the IDs of the nodes
+type graphConnection struct {
+	resource    string
+	cursors     []string
+	hasNextPage bool
+}
+
+// graphPage decodes into results the page of the records of a resource type asked by args, see
+// LookupService.Page
+func graphPage(ctx context.Context, resource string, args graphPageArgs, results interface{}) (*graphConnection, error) {
+	first := int64(graphDefaultPage)
+	if args.First != nil {
+		if *args.First < 0 || *args.First > graphMaxPage {
+			return nil, errs.Validation("first must be between 0 and 100")
+		}
+		first = int64(*args.First)
+	}
+	var after *primitive.ObjectID
+	if args.After != nil {
+		decoded, err := base64.RawURLEncoding.DecodeString(*args.After)
+		if err != nil {
+			return nil, errs.Validationf("invalid cursor %s", *args.After)
+		}
+		id, err := primitive.ObjectIDFromHex(string(decoded))
+		if err != nil {
+			return nil, errs.Validationf("invalid cursor %s", *args.After)
+		}
+		after = &id
+	}
+
+	connection := &graphConnection{resource: resource}
+	if first == 0 {
+		return connection, nil
+	}
+	// One more record tells whether there is a next page
+	if err := graphRequestFrom(ctx).h.Lookup.Page(ctx, resource, after, first+1, results); err != nil {
+		return nil, err
+	}
+	records := reflect.ValueOf(results).Elem()
+	if int64(records.Len()) > first {
+		connection.hasNextPage = true
+		records.Set(records.Slice(0, int(first)))
+	}
+	for i := 0; i < records.Len(); i++ {
+		id := records.Index(i).Elem().FieldByName("ID").Interface().(primitive.ObjectID)
+		connection.cursors = append(connection.cursors, base64.RawURLEncoding.EncodeToString([]byte(id.Hex())))
+	}
+	return connection, nil
+}
+
+// graphPageInfo resolves PageInfo
+type graphPageInfo struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (p *graphPageInfo) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+func (p *graphPageInfo) EndCursor() *string {
+	return p.endCursor
+}
+
+func (c *graphConnection) PageInfo() *graphPageInfo {
+	info := &graphPageInfo{hasNextPage: c.hasNextPage}
+	if len(c.cursors) > 0 {
+		info.endCursor = &c.cursors[len(c.cursors)-1]
+	}
+	return info
+}
+
+// TotalCount counts the records of the resource type, only when the field is selected
+func (c *graphConnection) TotalCount(ctx context.Context) (int32, error) {
+	count, err := graphRequestFrom(ctx).h.Lookup.Count(ctx, c.resource)
+	return int32(count), err
+}
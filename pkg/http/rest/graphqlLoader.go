@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// graphLoader batches the lookups of the records related to the nodes of a GraphQL response. The
+// nodes register the IDs of their relations as they are resolved, the first load reads the records
+// of every registered ID with one query and the next loads are served from the loaded records. A
+// list of N nodes thus costs one query per relation instead of N.
+type graphLoader struct {
+	fetch func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error)
+
+	mu       sync.Mutex
+	pending  map[primitive.ObjectID]bool
+	inflight map[primitive.ObjectID]*graphBatch
+	loaded   map[primitive.ObjectID]interface{}
+}
+
+// graphBatch is a fetch in progress, done is closed once its records are loaded
+type graphBatch struct {
+	done chan struct{}
+	err  error
+}
+
+func newGraphLoader(fetch func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error)) *graphLoader {
+	return &graphLoader{
+		fetch:    fetch,
+		pending:  map[primitive.ObjectID]bool{},
+		inflight: map[primitive.ObjectID]*graphBatch{},
+		loaded:   map[primitive.ObjectID]interface{}{},
+	}
+}
+
+// prime registers IDs to read with the next batch, the nil and zero IDs are skipped
+func (l *graphLoader) prime(ids ...*primitive.ObjectID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range ids {
+		if id == nil || id.IsZero() {
+			continue
+		}
+		if _, ok := l.loaded[*id]; ok {
+			continue
+		}
+		if _, ok := l.inflight[*id]; !ok {
+			l.pending[*id] = true
+		}
+	}
+}
+
+// load returns what the fetch found for an ID, nil when it found nothing. A load joins the batch
+// fetching its ID or fetches the ID with every registered one, the lock is not held while
+// fetching so that the fetch can register the relations of the records it read.
+func (l *graphLoader) load(ctx context.Context, id primitive.ObjectID) (interface{}, error) {
+	l.mu.Lock()
+	if value, ok := l.loaded[id]; ok {
+		l.mu.Unlock()
+		return value, nil
+	}
+	batch, ok := l.inflight[id]
+	if !ok {
+		batch = &graphBatch{done: make(chan struct{})}
+		l.pending[id] = true
+		ids := make([]primitive.ObjectID, 0, len(l.pending))
+		for pending := range l.pending {
+			ids = append(ids, pending)
+			l.inflight[pending] = batch
+		}
+		l.pending = map[primitive.ObjectID]bool{}
+		l.mu.Unlock()
+
+		values, err := l.fetch(ctx, ids)
+
+		l.mu.Lock()
+		for _, fetched := range ids {
+			delete(l.inflight, fetched)
+			if err == nil {
+				l.loaded[fetched] = values[fetched]
+			}
+		}
+		batch.err = err
+		close(batch.done)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.loaded[id], nil
+}
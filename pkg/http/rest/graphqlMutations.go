@@ -0,0 +1,578 @@
+package rest
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// The mutations go through the services of the REST routes, with the same scopes, validations,
+// audit entries and events
+
+type countryInput struct {
+	Name     string
+	Code     string
+	Currency *string
+	Status   *string
+}
+
+type stateInput struct {
+	Name   string
+	Code   *string
+	Status *string
+}
+
+type cityInput struct {
+	Name   string
+	Code   *string
+	Status *string
+}
+
+type nameInput struct {
+	Name   string
+	Status *string
+}
+
+type supplierInput struct {
+	Name           string
+	Surname        *string
+	DocumentType   *string
+	DocumentNumber *string
+	CityID         graphql.ID
+	Email          *string
+	AddressLine1   *string
+	PhoneNumber    *string
+	Status         *string
+}
+
+type userInput struct {
+	Name           string
+	Surname        *string
+	DocumentType   *string
+	DocumentNumber *string
+	CityID         *graphql.ID
+	Email          string
+	Password       *string
+	AddressLine1   *string
+	PhoneNumber    *string
+	Status         *string
+}
+
+type cropInput struct {
+	CityID       graphql.ID
+	PlantingDate graphql.Time
+	HarvestDate  graphql.Time
+	VariantID    *graphql.ID
+	SupplierID   *graphql.ID
+}
+
+// graphDeleteArgs are the arguments of the deletions of the top level records
+type graphDeleteArgs struct {
+	ID      graphql.ID
+	Version *int32
+}
+
+// graphInputStatus turns the status of an input into the status of a DTO
+func graphInputStatus(status *string) *enums.EnumRecordStatus {
+	if status == nil {
+		return nil
+	}
+	value := enums.EnumRecordStatus(*status)
+	return &value
+}
+
+// graphString dereferences an optional string of an input
+func graphString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func (in countryInput) dto() *dtos.CountryDto {
+	return &dtos.CountryDto{CountryName: in.Name, CountryCode: in.Code, CurrencyCode: graphString(in.Currency), RecordStatus: graphInputStatus(in.Status)}
+}
+
+func (in stateInput) dto() dtos.CountryStateDto {
+	return dtos.CountryStateDto{StateName: in.Name, StateCode: graphString(in.Code), RecordStatus: graphInputStatus(in.Status)}
+}
+
+func (in cityInput) dto() *dtos.CityDto {
+	return &dtos.CityDto{CityName: in.Name, CityCode: graphString(in.Code), RecordStatus: graphInputStatus(in.Status)}
+}
+
+func (in supplierInput) dto() (*dtos.SupplierDto, error) {
+	cityID, err := graphID(in.CityID)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.SupplierDto{
+		Name:           in.Name,
+		Surname:        graphString(in.Surname),
+		DocumentType:   graphString(in.DocumentType),
+		DocumentNumber: graphString(in.DocumentNumber),
+		CityID:         cityID,
+		Email:          graphString(in.Email),
+		AddressLine1:   graphString(in.AddressLine1),
+		PhoneNumber:    graphString(in.PhoneNumber),
+		RecordStatus:   graphInputStatus(in.Status),
+	}, nil
+}
+
+func (in userInput) dto() (*dtos.UserDto, error) {
+	cityID, err := graphOptionalID(in.CityID)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.UserDto{
+		Name:           in.Name,
+		Surname:        graphString(in.Surname),
+		DocumentType:   graphString(in.DocumentType),
+		DocumentNumber: graphString(in.DocumentNumber),
+		CityID:         cityID,
+		Email:          in.Email,
+		Password:       graphString(in.Password),
+		AddressLine1:   graphString(in.AddressLine1),
+		PhoneNumber:    graphString(in.PhoneNumber),
+		RecordStatus:   graphInputStatus(in.Status),
+	}, nil
+}
+
+func (in cropInput) dto() (*dtos.CropDto, error) {
+	cityID, err := graphID(in.CityID)
+	if err != nil {
+		return nil, err
+	}
+	variantID, err := graphOptionalID(in.VariantID)
+	if err != nil {
+		return nil, err
+	}
+	supplierID, err := graphOptionalID(in.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.CropDto{CityID: cityID, PlantingDate: in.PlantingDate.Time, HarvestDate: in.HarvestDate.Time, VariantID: variantID, SupplierID: supplierID}, nil
+}
+
+func (r *graphRoot) CreateCountry(ctx context.Context, args struct{ Input countryInput }) (*countryResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CountriesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	id, err := req.h.Countries.CreateCountry(ctx, args.Input.dto())
+	if err != nil {
+		return nil, err
+	}
+	country, err := req.h.Countries.FindCountryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return req.country(country), nil
+}
+
+func (r *graphRoot) UpdateCountry(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   countryInput
+	Version *int32
+}) (*countryResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CountriesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	country, err := req.h.Countries.UpdateCountryByID(ctx, string(args.ID), args.Input.dto(), versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.country(country), nil
+}
+
+func (r *graphRoot) DeleteCountry(ctx context.Context, args graphDeleteArgs) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.CountriesWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Countries.DeleteCountryByID(ctx, string(args.ID), versions)
+}
+
+func (r *graphRoot) AddState(ctx context.Context, args struct {
+	CountryID graphql.ID
+	Input     stateInput
+	Version   *int32
+}) (*countryResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CountriesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	country, err := req.h.Countries.AddState(ctx, string(args.CountryID), args.Input.dto(), versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.country(country), nil
+}
+
+func (r *graphRoot) UpdateState(ctx context.Context, args struct {
+	CountryID graphql.ID
+	StateID   graphql.ID
+	Input     stateInput
+	Version   *int32
+}) (*countryResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CountriesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	country, err := req.h.Countries.UpdateState(ctx, string(args.CountryID), string(args.StateID), args.Input.dto(), versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.country(country), nil
+}
+
+func (r *graphRoot) DeleteState(ctx context.Context, args struct {
+	CountryID graphql.ID
+	StateID   graphql.ID
+	Version   *int32
+}) (*countryResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CountriesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	country, err := req.h.Countries.DeleteState(ctx, string(args.CountryID), string(args.StateID), versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.country(country), nil
+}
+
+func (r *graphRoot) CreateCity(ctx context.Context, args struct {
+	StateID graphql.ID
+	Input   cityInput
+}) (*cityResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CitiesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	id, err := req.h.Cities.CreateCity(ctx, string(args.StateID), args.Input.dto())
+	if err != nil {
+		return nil, err
+	}
+	city, err := req.h.Cities.FindCityByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return req.city(city), nil
+}
+
+func (r *graphRoot) UpdateCity(ctx context.Context, args struct {
+	StateID graphql.ID
+	ID      graphql.ID
+	Input   cityInput
+	Version *int32
+}) (*cityResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CitiesWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	city, err := req.h.Cities.UpdateCityByID(ctx, string(args.StateID), string(args.ID), args.Input.dto(), versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.city(city), nil
+}
+
+func (r *graphRoot) DeleteCity(ctx context.Context, args struct {
+	StateID graphql.ID
+	ID      graphql.ID
+	Version *int32
+}) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.CitiesWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Cities.DeleteCityByID(ctx, string(args.StateID), string(args.ID), versions)
+}
+
+func (r *graphRoot) CreateItem(ctx context.Context, args struct{ Input nameInput }) (*itemResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.ItemsWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	id, err := req.h.Items.CreateItem(ctx, &dtos.ItemDto{Name: args.Input.Name, RecordStatus: graphInputStatus(args.Input.Status)})
+	if err != nil {
+		return nil, err
+	}
+	item, err := req.h.Items.FindItemByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return req.item(item), nil
+}
+
+func (r *graphRoot) UpdateItem(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   nameInput
+	Version *int32
+}) (*itemResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.ItemsWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	dto := &dtos.ItemDto{Name: args.Input.Name, RecordStatus: graphInputStatus(args.Input.Status)}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	item, err := req.h.Items.UpdateItemByID(ctx, string(args.ID), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.item(item), nil
+}
+
+func (r *graphRoot) DeleteItem(ctx context.Context, args graphDeleteArgs) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.ItemsWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Items.DeleteItemByID(ctx, string(args.ID), versions)
+}
+
+func (r *graphRoot) CreateVariant(ctx context.Context, args struct {
+	ItemID graphql.ID
+	Input  nameInput
+}) (*variantResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.ItemsWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	dto := &dtos.VariantDto{Name: args.Input.Name, RecordStatus: graphInputStatus(args.Input.Status)}
+	id, err := req.h.Variants.CreateVariant(ctx, string(args.ItemID), dto)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := req.h.Variants.FindVariantByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return req.variant(variant), nil
+}
+
+func (r *graphRoot) UpdateVariant(ctx context.Context, args struct {
+	ItemID  graphql.ID
+	ID      graphql.ID
+	Input   nameInput
+	Version *int32
+}) (*variantResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.ItemsWrite); err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	dto := &dtos.VariantDto{Name: args.Input.Name, RecordStatus: graphInputStatus(args.Input.Status)}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := req.h.Variants.UpdateVariant(ctx, string(args.ItemID), string(args.ID), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.variant(variant), nil
+}
+
+func (r *graphRoot) DeleteVariant(ctx context.Context, args struct {
+	ItemID  graphql.ID
+	ID      graphql.ID
+	Version *int32
+}) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.ItemsWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Variants.DeleteVariant(ctx, string(args.ItemID), string(args.ID), versions)
+}
+
+func (r *graphRoot) CreateSupplier(ctx context.Context, args struct{ Input supplierInput }) (*supplierResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.SuppliersWrite); err != nil {
+		return nil, err
+	}
+	dto, err := args.Input.dto()
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	supplier, err := req.h.Suppliers.CreateSupplier(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return req.supplier(supplier), nil
+}
+
+func (r *graphRoot) UpdateSupplier(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   supplierInput
+	Version *int32
+}) (*supplierResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.SuppliersWrite); err != nil {
+		return nil, err
+	}
+	dto, err := args.Input.dto()
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := req.h.Suppliers.UpdateSupplierByID(ctx, string(args.ID), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.supplier(supplier), nil
+}
+
+func (r *graphRoot) DeleteSupplier(ctx context.Context, args graphDeleteArgs) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.SuppliersWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Suppliers.DeleteSupplier(ctx, string(args.ID), versions)
+}
+
+func (r *graphRoot) CreateUser(ctx context.Context, args struct{ Input userInput }) (*userResolver, error) {
+	if err := authorizeGraphSignup(ctx, enums.UsersWrite); err != nil {
+		return nil, err
+	}
+	dto, err := args.Input.dto()
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	user, err := req.h.Users.Signup(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return req.user(user), nil
+}
+
+func (r *graphRoot) UpdateUser(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   userInput
+	Version *int32
+}) (*userResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.UsersWrite); err != nil {
+		return nil, err
+	}
+	dto, err := args.Input.dto()
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	user, err := req.h.Users.UpdateUserByID(ctx, string(args.ID), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.user(user), nil
+}
+
+func (r *graphRoot) DeleteUser(ctx context.Context, args graphDeleteArgs) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.UsersWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Users.DeleteUser(ctx, string(args.ID), versions)
+}
+
+func (r *graphRoot) CreateCrop(ctx context.Context, args struct{ Input cropInput }) (*cropResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CropsWrite); err != nil {
+		return nil, err
+	}
+	dto, err := args.Input.dto()
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	crop, err := req.h.Crops.CreateCrop(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	return req.crop(crop), nil
+}
+
+func (r *graphRoot) UpdateCrop(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   cropInput
+	Version *int32
+}) (*cropResolver, error) {
+	if err := authorizeGraphWrite(ctx, enums.CropsWrite); err != nil {
+		return nil, err
+	}
+	dto, err := args.Input.dto()
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return nil, err
+	}
+	crop, err := req.h.Crops.UpdateCropByID(ctx, string(args.ID), dto, versions)
+	if err != nil {
+		return nil, err
+	}
+	return req.crop(crop), nil
+}
+
+func (r *graphRoot) DeleteCrop(ctx context.Context, args graphDeleteArgs) (bool, error) {
+	if err := authorizeGraphWrite(ctx, enums.CropsWrite); err != nil {
+		return false, err
+	}
+	versions, err := graphVersions(ctx, args.Version)
+	if err != nil {
+		return false, err
+	}
+	return graphRequestFrom(ctx).h.Crops.DeleteCropByID(ctx, string(args.ID), versions)
+}
@@ -0,0 +1,875 @@
+package rest
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	graphql "github.com/graph-gophers/graphql-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// graphRoot resolves the root fields of the queries and the mutations
+type graphRoot struct{}
+
+type graphIDArgs struct {
+	ID graphql.ID
+}
+
+func (r *graphRoot) Country(ctx context.Context, args graphIDArgs) (*countryResolver, error) {
+	if err := authorizeGraph(ctx, enums.CountriesRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.countries.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.country(value.(*models.Country)), nil
+}
+
+func (r *graphRoot) Countries(ctx context.Context, args graphPageArgs) (*countryConnection, error) {
+	if err := authorizeGraph(ctx, enums.CountriesRead); err != nil {
+		return nil, err
+	}
+	var countries []*models.Country
+	connection, err := graphPage(ctx, models.ResourceCountry, args, &countries)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	result := &countryConnection{graphConnection: connection}
+	for _, country := range countries {
+		result.nodes = append(result.nodes, req.country(country))
+	}
+	return result, nil
+}
+
+func (r *graphRoot) City(ctx context.Context, args graphIDArgs) (*cityResolver, error) {
+	if err := authorizeGraph(ctx, enums.CitiesRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.cities.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.city(value.(*models.City)), nil
+}
+
+func (r *graphRoot) Item(ctx context.Context, args graphIDArgs) (*itemResolver, error) {
+	if err := authorizeGraph(ctx, enums.ItemsRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.items.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.item(value.(*models.Item)), nil
+}
+
+func (r *graphRoot) Items(ctx context.Context, args graphPageArgs) (*itemConnection, error) {
+	if err := authorizeGraph(ctx, enums.ItemsRead); err != nil {
+		return nil, err
+	}
+	var items []*models.Item
+	connection, err := graphPage(ctx, models.ResourceItem, args, &items)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	result := &itemConnection{graphConnection: connection}
+	for _, item := range items {
+		result.nodes = append(result.nodes, req.item(item))
+	}
+	return result, nil
+}
+
+func (r *graphRoot) Variant(ctx context.Context, args graphIDArgs) (*variantResolver, error) {
+	if err := authorizeGraph(ctx, enums.ItemsRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.variants.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.variant(value.(*models.Variant)), nil
+}
+
+func (r *graphRoot) Supplier(ctx context.Context, args graphIDArgs) (*supplierResolver, error) {
+	if err := authorizeGraph(ctx, enums.SuppliersRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.suppliers.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.supplier(value.(*models.Supplier)), nil
+}
+
+func (r *graphRoot) Suppliers(ctx context.Context, args graphPageArgs) (*supplierConnection, error) {
+	if err := authorizeGraph(ctx, enums.SuppliersRead); err != nil {
+		return nil, err
+	}
+	var suppliers []*models.Supplier
+	connection, err := graphPage(ctx, models.ResourceSupplier, args, &suppliers)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	result := &supplierConnection{graphConnection: connection}
+	for _, supplier := range suppliers {
+		result.nodes = append(result.nodes, req.supplier(supplier))
+	}
+	return result, nil
+}
+
+func (r *graphRoot) User(ctx context.Context, args graphIDArgs) (*userResolver, error) {
+	if err := authorizeGraph(ctx, enums.UsersRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.users.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.user(value.(*models.User)), nil
+}
+
+func (r *graphRoot) Users(ctx context.Context, args graphPageArgs) (*userConnection, error) {
+	if err := authorizeGraph(ctx, enums.UsersRead); err != nil {
+		return nil, err
+	}
+	var users []*models.User
+	connection, err := graphPage(ctx, models.ResourceUser, args, &users)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	result := &userConnection{graphConnection: connection}
+	for _, user := range users {
+		result.nodes = append(result.nodes, req.user(user))
+	}
+	return result, nil
+}
+
+func (r *graphRoot) Crop(ctx context.Context, args graphIDArgs) (*cropResolver, error) {
+	if err := authorizeGraph(ctx, enums.CropsRead); err != nil {
+		return nil, err
+	}
+	id, err := graphID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	value, err := req.crops.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.crop(value.(*models.Crop)), nil
+}
+
+func (r *graphRoot) Crops(ctx context.Context, args graphPageArgs) (*cropConnection, error) {
+	if err := authorizeGraph(ctx, enums.CropsRead); err != nil {
+		return nil, err
+	}
+	var crops []*models.Crop
+	connection, err := graphPage(ctx, models.ResourceCrop, args, &crops)
+	if err != nil {
+		return nil, err
+	}
+	req := graphRequestFrom(ctx)
+	result := &cropConnection{graphConnection: connection}
+	for _, crop := range crops {
+		result.nodes = append(result.nodes, req.crop(crop))
+	}
+	return result, nil
+}
+
+// graphStatus resolves a RecordStatus, the records without a status are active
+func graphStatus(status *enums.EnumRecordStatus) string {
+	if status == nil {
+		return enums.Active.String()
+	}
+	return status.String()
+}
+
+// The resolvers of the nodes are created through the request, which registers their relations
+// with the loaders
+
+func (req *graphRequest) country(country *models.Country) *countryResolver {
+	req.primeCountry(country)
+	return &countryResolver{req: req, country: country}
+}
+
+func (req *graphRequest) city(city *models.City) *cityResolver {
+	req.primeCity(city)
+	return &cityResolver{req: req, city: city}
+}
+
+func (req *graphRequest) item(item *models.Item) *itemResolver {
+	req.primeItem(item)
+	return &itemResolver{req: req, item: item}
+}
+
+func (req *graphRequest) variant(variant *models.Variant) *variantResolver {
+	req.primeVariant(variant)
+	return &variantResolver{req: req, variant: variant}
+}
+
+func (req *graphRequest) supplier(supplier *models.Supplier) *supplierResolver {
+	req.primeSupplier(supplier)
+	return &supplierResolver{req: req, supplier: supplier}
+}
+
+func (req *graphRequest) user(user *models.User) *userResolver {
+	req.primeUser(user)
+	return &userResolver{req: req, user: user}
+}
+
+func (req *graphRequest) crop(crop *models.Crop) *cropResolver {
+	req.primeCrop(crop)
+	return &cropResolver{req: req, crop: crop}
+}
+
+// countryResolver resolves a Country
+type countryResolver struct {
+	req     *graphRequest
+	country *models.Country
+}
+
+func (r *countryResolver) ID() graphql.ID {
+	return graphql.ID(r.country.ID.Hex())
+}
+
+func (r *countryResolver) Name() string {
+	return r.country.CountryName
+}
+
+func (r *countryResolver) Code() string {
+	return r.country.CountryCode
+}
+
+func (r *countryResolver) Currency() *string {
+	if r.country.CurrencyCode == "" {
+		return nil
+	}
+	return &r.country.CurrencyCode
+}
+
+func (r *countryResolver) Status() string {
+	return graphStatus(r.country.RecordStatus)
+}
+
+func (r *countryResolver) Version() int32 {
+	return int32(r.country.Version)
+}
+
+func (r *countryResolver) States() []*stateResolver {
+	states := make([]*stateResolver, 0, len(r.country.States))
+	for i := range r.country.States {
+		states = append(states, &stateResolver{req: r.req, country: r.country, state: &r.country.States[i]})
+	}
+	return states
+}
+
+// stateResolver resolves a State, the states are read with their country
+type stateResolver struct {
+	req     *graphRequest
+	country *models.Country
+	state   *models.CountryState
+}
+
+func (r *stateResolver) ID() graphql.ID {
+	return graphql.ID(r.state.ID.Hex())
+}
+
+func (r *stateResolver) Name() string {
+	return r.state.StateName
+}
+
+func (r *stateResolver) Code() string {
+	return r.state.StateCode
+}
+
+func (r *stateResolver) Status() string {
+	return graphStatus(r.state.RecordStatus)
+}
+
+func (r *stateResolver) Country() *countryResolver {
+	return r.req.country(r.country)
+}
+
+func (r *stateResolver) Cities(ctx context.Context) ([]*cityResolver, error) {
+	if err := authorizeGraph(ctx, enums.CitiesRead); err != nil {
+		return nil, err
+	}
+	value, err := r.req.citiesByState.load(ctx, r.state.ID)
+	if err != nil {
+		return nil, err
+	}
+	list, _ := value.([]*models.City)
+	cities := make([]*cityResolver, 0, len(list))
+	for _, city := range list {
+		cities = append(cities, r.req.city(city))
+	}
+	return cities, nil
+}
+
+// cityResolver resolves a City
+type cityResolver struct {
+	req  *graphRequest
+	city *models.City
+}
+
+func (r *cityResolver) ID() graphql.ID {
+	return graphql.ID(r.city.ID.Hex())
+}
+
+func (r *cityResolver) Name() string {
+	return r.city.CityName
+}
+
+func (r *cityResolver) Code() string {
+	return r.city.CityCode
+}
+
+func (r *cityResolver) Status() string {
+	return graphStatus(r.city.RecordStatus)
+}
+
+func (r *cityResolver) Version() int32 {
+	return int32(r.city.Version)
+}
+
+func (r *cityResolver) State(ctx context.Context) (*stateResolver, error) {
+	if err := authorizeGraph(ctx, enums.CountriesRead); err != nil {
+		return nil, err
+	}
+	value, err := r.req.countriesByState.load(ctx, r.city.CountryStateID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	country := value.(*models.Country)
+	for i := range country.States {
+		if country.States[i].ID == r.city.CountryStateID {
+			return &stateResolver{req: r.req, country: country, state: &country.States[i]}, nil
+		}
+	}
+	return nil, nil
+}
+
+// itemResolver resolves an Item
+type itemResolver struct {
+	req  *graphRequest
+	item *models.Item
+}
+
+func (r *itemResolver) ID() graphql.ID {
+	return graphql.ID(r.item.ID.Hex())
+}
+
+func (r *itemResolver) Name() string {
+	return r.item.Name
+}
+
+func (r *itemResolver) Status() string {
+	return graphStatus(r.item.RecordStatus)
+}
+
+func (r *itemResolver) Version() int32 {
+	return int32(r.item.Version)
+}
+
+func (r *itemResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.item.CreatedAt}
+}
+
+func (r *itemResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.item.UpdatedAt}
+}
+
+func (r *itemResolver) Variants(ctx context.Context) ([]*variantResolver, error) {
+	value, err := r.req.variantsByItem.load(ctx, r.item.ID)
+	if err != nil {
+		return nil, err
+	}
+	list, _ := value.([]*models.Variant)
+	variants := make([]*variantResolver, 0, len(list))
+	for _, variant := range list {
+		variants = append(variants, r.req.variant(variant))
+	}
+	return variants, nil
+}
+
+// variantResolver resolves a Variant
+type variantResolver struct {
+	req     *graphRequest
+	variant *models.Variant
+}
+
+func (r *variantResolver) ID() graphql.ID {
+	return graphql.ID(r.variant.ID.Hex())
+}
+
+func (r *variantResolver) Name() string {
+	return r.variant.Name
+}
+
+func (r *variantResolver) Status() string {
+	return graphStatus(r.variant.RecordStatus)
+}
+
+func (r *variantResolver) Version() int32 {
+	return int32(r.variant.Version)
+}
+
+func (r *variantResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.variant.CreatedAt}
+}
+
+func (r *variantResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.variant.UpdatedAt}
+}
+
+func (r *variantResolver) Item(ctx context.Context) (*itemResolver, error) {
+	value, err := r.req.items.load(ctx, r.variant.ItemID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return r.req.item(value.(*models.Item)), nil
+}
+
+func (r *variantResolver) Crops(ctx context.Context) ([]*cropResolver, error) {
+	return r.req.cropsOf(ctx, r.req.cropsByVariant, r.variant.ID)
+}
+
+// supplierResolver resolves a Supplier
+type supplierResolver struct {
+	req      *graphRequest
+	supplier *models.Supplier
+}
+
+func (r *supplierResolver) ID() graphql.ID {
+	return graphql.ID(r.supplier.ID.Hex())
+}
+
+func (r *supplierResolver) Name() string {
+	return r.supplier.Name
+}
+
+func (r *supplierResolver) Surname() string {
+	return r.supplier.Surname
+}
+
+func (r *supplierResolver) DocumentType() string {
+	return r.supplier.DocumentType
+}
+
+func (r *supplierResolver) DocumentNumber() string {
+	return r.supplier.DocumentNumber
+}
+
+func (r *supplierResolver) Email() string {
+	return r.supplier.Email
+}
+
+func (r *supplierResolver) AddressLine1() string {
+	return r.supplier.AddressLine1
+}
+
+func (r *supplierResolver) PhoneNumber() string {
+	return r.supplier.PhoneNumber
+}
+
+func (r *supplierResolver) Status() string {
+	return graphStatus(r.supplier.RecordStatus)
+}
+
+func (r *supplierResolver) Version() int32 {
+	return int32(r.supplier.Version)
+}
+
+func (r *supplierResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.supplier.CreatedAt}
+}
+
+func (r *supplierResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.supplier.UpdatedAt}
+}
+
+func (r *supplierResolver) City(ctx context.Context) (*cityResolver, error) {
+	return r.req.cityOf(ctx, r.supplier.CityID)
+}
+
+func (r *supplierResolver) Crops(ctx context.Context) ([]*cropResolver, error) {
+	return r.req.cropsOf(ctx, r.req.cropsBySupplier, r.supplier.ID)
+}
+
+// userResolver resolves a User
+type userResolver struct {
+	req  *graphRequest
+	user *models.User
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.ID.Hex())
+}
+
+func (r *userResolver) Name() string {
+	return r.user.Name
+}
+
+func (r *userResolver) Surname() string {
+	return r.user.Surname
+}
+
+func (r *userResolver) DocumentType() string {
+	return r.user.DocumentType
+}
+
+func (r *userResolver) DocumentNumber() string {
+	return r.user.DocumentNumber
+}
+
+func (r *userResolver) Email() string {
+	return r.user.Email
+}
+
+func (r *userResolver) AddressLine1() string {
+	return r.user.AddressLine1
+}
+
+func (r *userResolver) PhoneNumber() string {
+	return r.user.PhoneNumber
+}
+
+func (r *userResolver) Role() string {
+	return r.user.Role
+}
+
+func (r *userResolver) IsEmailVerified() bool {
+	return r.user.IsEmailVerified
+}
+
+func (r *userResolver) Status() string {
+	return graphStatus(r.user.RecordStatus)
+}
+
+func (r *userResolver) Version() int32 {
+	return int32(r.user.Version)
+}
+
+func (r *userResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.user.CreatedAt}
+}
+
+func (r *userResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.user.UpdatedAt}
+}
+
+func (r *userResolver) City(ctx context.Context) (*cityResolver, error) {
+	return r.req.cityOf(ctx, r.user.CityID)
+}
+
+func (r *userResolver) Crops(ctx context.Context) ([]*cropResolver, error) {
+	return r.req.cropsOf(ctx, r.req.cropsBySupplier, r.user.ID)
+}
+
+// cropResolver resolves a Crop
+type cropResolver struct {
+	req  *graphRequest
+	crop *models.Crop
+}
+
+func (r *cropResolver) ID() graphql.ID {
+	return graphql.ID(r.crop.ID.Hex())
+}
+
+func (r *cropResolver) PlantingDate() graphql.Time {
+	return graphql.Time{Time: r.crop.PlantingDate}
+}
+
+func (r *cropResolver) HarvestDate() graphql.Time {
+	return graphql.Time{Time: r.crop.HarvestDate}
+}
+
+func (r *cropResolver) Version() int32 {
+	return int32(r.crop.Version)
+}
+
+func (r *cropResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.crop.CreatedAt}
+}
+
+func (r *cropResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.crop.UpdatedAt}
+}
+
+func (r *cropResolver) City(ctx context.Context) (*cityResolver, error) {
+	return r.req.cityOf(ctx, r.crop.CityID)
+}
+
+func (r *cropResolver) Variant(ctx context.Context) (*variantResolver, error) {
+	if r.crop.VariantID == nil {
+		return nil, nil
+	}
+	if err := authorizeGraph(ctx, enums.ItemsRead); err != nil {
+		return nil, err
+	}
+	value, err := r.req.variants.load(ctx, *r.crop.VariantID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return r.req.variant(value.(*models.Variant)), nil
+}
+
+// Supplier resolves the grower of the crop, a supplier when one has its ID and a user otherwise
+func (r *cropResolver) Supplier(ctx context.Context) (*growerResolver, error) {
+	if r.crop.SupplierID == nil {
+		return nil, nil
+	}
+	value, err := r.req.suppliers.load(ctx, *r.crop.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		if err := authorizeGraph(ctx, enums.SuppliersRead); err != nil {
+			return nil, err
+		}
+		return &growerResolver{supplier: r.req.supplier(value.(*models.Supplier))}, nil
+	}
+	value, err = r.req.users.load(ctx, *r.crop.SupplierID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	if err := authorizeGraph(ctx, enums.UsersRead); err != nil {
+		return nil, err
+	}
+	return &growerResolver{user: r.req.user(value.(*models.User))}, nil
+}
+
+// growerResolver resolves the Grower union
+type growerResolver struct {
+	supplier *supplierResolver
+	user     *userResolver
+}
+
+func (r *growerResolver) ToSupplier() (*supplierResolver, bool) {
+	return r.supplier, r.supplier != nil
+}
+
+func (r *growerResolver) ToUser() (*userResolver, bool) {
+	return r.user, r.user != nil
+}
+
+// cityOf resolves the city of a record, nil when it has none
+func (req *graphRequest) cityOf(ctx context.Context, id *primitive.ObjectID) (*cityResolver, error) {
+	if id == nil {
+		return nil, nil
+	}
+	if err := authorizeGraph(ctx, enums.CitiesRead); err != nil {
+		return nil, err
+	}
+	value, err := req.cities.load(ctx, *id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return req.city(value.(*models.City)), nil
+}
+
+// cropsOf resolves the crops a loader holds for an ID
+func (req *graphRequest) cropsOf(ctx context.Context, loader *graphLoader, id primitive.ObjectID) ([]*cropResolver, error) {
+	if err := authorizeGraph(ctx, enums.CropsRead); err != nil {
+		return nil, err
+	}
+	value, err := loader.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	list, _ := value.([]*models.Crop)
+	crops := make([]*cropResolver, 0, len(list))
+	for _, crop := range list {
+		crops = append(crops, req.crop(crop))
+	}
+	return crops, nil
+}
+
+// The connections resolve the pages of the root lists, an edge pairs a node with its cursor
+
+type countryConnection struct {
+	*graphConnection
+	nodes []*countryResolver
+}
+
+type countryEdge struct {
+	cursor string
+	node   *countryResolver
+}
+
+func (c *countryConnection) Nodes() []*countryResolver {
+	return c.nodes
+}
+
+func (c *countryConnection) Edges() []*countryEdge {
+	edges := make([]*countryEdge, 0, len(c.nodes))
+	for i, node := range c.nodes {
+		edges = append(edges, &countryEdge{cursor: c.cursors[i], node: node})
+	}
+	return edges
+}
+
+func (e *countryEdge) Cursor() string {
+	return e.cursor
+}
+
+func (e *countryEdge) Node() *countryResolver {
+	return e.node
+}
+
+type itemConnection struct {
+	*graphConnection
+	nodes []*itemResolver
+}
+
+type itemEdge struct {
+	cursor string
+	node   *itemResolver
+}
+
+func (c *itemConnection) Nodes() []*itemResolver {
+	return c.nodes
+}
+
+func (c *itemConnection) Edges() []*itemEdge {
+	edges := make([]*itemEdge, 0, len(c.nodes))
+	for i, node := range c.nodes {
+		edges = append(edges, &itemEdge{cursor: c.cursors[i], node: node})
+	}
+	return edges
+}
+
+func (e *itemEdge) Cursor() string {
+	return e.cursor
+}
+
+func (e *itemEdge) Node() *itemResolver {
+	return e.node
+}
+
+type supplierConnection struct {
+	*graphConnection
+	nodes []*supplierResolver
+}
+
+type supplierEdge struct {
+	cursor string
+	node   *supplierResolver
+}
+
+func (c *supplierConnection) Nodes() []*supplierResolver {
+	return c.nodes
+}
+
+func (c *supplierConnection) Edges() []*supplierEdge {
+	edges := make([]*supplierEdge, 0, len(c.nodes))
+	for i, node := range c.nodes {
+		edges = append(edges, &supplierEdge{cursor: c.cursors[i], node: node})
+	}
+	return edges
+}
+
+func (e *supplierEdge) Cursor() string {
+	return e.cursor
+}
+
+func (e *supplierEdge) Node() *supplierResolver {
+	return e.node
+}
+
+type userConnection struct {
+	*graphConnection
+	nodes []*userResolver
+}
+
+type userEdge struct {
+	cursor string
+	node   *userResolver
+}
+
+func (c *userConnection) Nodes() []*userResolver {
+	return c.nodes
+}
+
+func (c *userConnection) Edges() []*userEdge {
+	edges := make([]*userEdge, 0, len(c.nodes))
+	for i, node := range c.nodes {
+		edges = append(edges, &userEdge{cursor: c.cursors[i], node: node})
+	}
+	return edges
+}
+
+func (e *userEdge) Cursor() string {
+	return e.cursor
+}
+
+func (e *userEdge) Node() *userResolver {
+	return e.node
+}
+
+type cropConnection struct {
+	*graphConnection
+	nodes []*cropResolver
+}
+
+type cropEdge struct {
+	cursor string
+	node   *cropResolver
+}
+
+func (c *cropConnection) Nodes() []*cropResolver {
+	return c.nodes
+}
+
+func (c *cropConnection) Edges() []*cropEdge {
+	edges := make([]*cropEdge, 0, len(c.nodes))
+	for i, node := range c.nodes {
+		edges = append(edges, &cropEdge{cursor: c.cursors[i], node: node})
+	}
+	return edges
+}
+
+func (e *cropEdge) Cursor() string {
+	return e.cursor
+}
+
+func (e *cropEdge) Node() *cropResolver {
+	return e.node
+}
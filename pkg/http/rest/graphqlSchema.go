@@ -0,0 +1,290 @@
+package rest
+
+// graphSchema is the GraphQL schema served at /graphql. The lists of the root fields are
+// paginated connections, the lists of the related records are read in batches for all the nodes
+// of a response. The fields need the scopes of the matching REST routes.
+const graphSchema = `
+schema {
+	query: Query
+	mutation: Mutation
+}
+
+scalar Time
+
+enum RecordStatus {
+	active
+	inactive
+}
+
+type PageInfo {
+	hasNextPage: Boolean!
+	endCursor: String
+}
+
+type Query {
+	country(id: ID!): Country
+	countries(first: Int, after: String): CountryConnection!
+	city(id: ID!): City
+	item(id: ID!): Item
+	items(first: Int, after: String): ItemConnection!
+	variant(id: ID!): Variant
+	supplier(id: ID!): Supplier
+	suppliers(first: Int, after: String): SupplierConnection!
+	user(id: ID!): User
+	users(first: Int, after: String): UserConnection!
+	crop(id: ID!): Crop
+	crops(first: Int, after: String): CropConnection!
+}
+
+type Country {
+	id: ID!
+	name: String!
+	code: String!
+	# ISO 4217 code of the currency of the country
+	currency: String
+	status: RecordStatus!
+	version: Int!
+	states: [State!]!
+}
+
+type State {
+	id: ID!
+	name: String!
+	code: String!
+	status: RecordStatus!
+	country: Country!
+	cities: [City!]!
+}
+
+type City {
+	id: ID!
+	name: String!
+	code: String!
+	status: RecordStatus!
+	version: Int!
+	state: State
+}
+
+type Item {
+	id: ID!
+	name: String!
+	status: RecordStatus!
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	variants: [Variant!]!
+}
+
+type Variant {
+	id: ID!
+	name: String!
+	status: RecordStatus!
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	item: Item
+	crops: [Crop!]!
+}
+
+type Supplier {
+	id: ID!
+	name: String!
+	surname: String!
+	documentType: String!
+	documentNumber: String!
+	email: String!
+	addressLine1: String!
+	phoneNumber: String!
+	status: RecordStatus!
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	city: City
+	crops: [Crop!]!
+}
+
+type User {
+	id: ID!
+	name: String!
+	surname: String!
+	documentType: String!
+	documentNumber: String!
+	email: String!
+	addressLine1: String!
+	phoneNumber: String!
+	role: String!
+	isEmailVerified: Boolean!
+	status: RecordStatus!
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	city: City
+	crops: [Crop!]!
+}
+
+# Grower is the supplier or the user growing a crop
+union Grower = Supplier | User
+
+type Crop {
+	id: ID!
+	plantingDate: Time!
+	harvestDate: Time!
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	city: City
+	variant: Variant
+	supplier: Grower
+}
+
+type CountryConnection {
+	edges: [CountryEdge!]!
+	nodes: [Country!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type CountryEdge {
+	cursor: String!
+	node: Country!
+}
+
+type ItemConnection {
+	edges: [ItemEdge!]!
+	nodes: [Item!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type ItemEdge {
+	cursor: String!
+	node: Item!
+}
+
+type SupplierConnection {
+	edges: [SupplierEdge!]!
+	nodes: [Supplier!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type SupplierEdge {
+	cursor: String!
+	node: Supplier!
+}
+
+type UserConnection {
+	edges: [UserEdge!]!
+	nodes: [User!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type UserEdge {
+	cursor: String!
+	node: User!
+}
+
+type CropConnection {
+	edges: [CropEdge!]!
+	nodes: [Crop!]!
+	pageInfo: PageInfo!
+	totalCount: Int!
+}
+
+type CropEdge {
+	cursor: String!
+	node: Crop!
+}
+
+# The version arguments make the writes conditional like the If-Match header of the REST API, they
+# are required when the server requires If-Match
+type Mutation {
+	createCountry(input: CountryInput!): Country!
+	updateCountry(id: ID!, input: CountryInput!, version: Int): Country!
+	deleteCountry(id: ID!, version: Int): Boolean!
+	addState(countryId: ID!, input: StateInput!, version: Int): Country!
+	updateState(countryId: ID!, stateId: ID!, input: StateInput!, version: Int): Country!
+	deleteState(countryId: ID!, stateId: ID!, version: Int): Country!
+	createCity(stateId: ID!, input: CityInput!): City!
+	updateCity(stateId: ID!, id: ID!, input: CityInput!, version: Int): City!
+	deleteCity(stateId: ID!, id: ID!, version: Int): Boolean!
+	createItem(input: ItemInput!): Item!
+	updateItem(id: ID!, input: ItemInput!, version: Int): Item!
+	deleteItem(id: ID!, version: Int): Boolean!
+	createVariant(itemId: ID!, input: VariantInput!): Variant!
+	updateVariant(itemId: ID!, id: ID!, input: VariantInput!, version: Int): Variant!
+	deleteVariant(itemId: ID!, id: ID!, version: Int): Boolean!
+	createSupplier(input: SupplierInput!): Supplier!
+	updateSupplier(id: ID!, input: SupplierInput!, version: Int): Supplier!
+	deleteSupplier(id: ID!, version: Int): Boolean!
+	createUser(input: UserInput!): User!
+	updateUser(id: ID!, input: UserInput!, version: Int): User!
+	deleteUser(id: ID!, version: Int): Boolean!
+	createCrop(input: CropInput!): Crop!
+	updateCrop(id: ID!, input: CropInput!, version: Int): Crop!
+	deleteCrop(id: ID!, version: Int): Boolean!
+}
+
+input CountryInput {
+	name: String!
+	code: String!
+	currency: String
+	status: RecordStatus
+}
+
+input StateInput {
+	name: String!
+	code: String
+	status: RecordStatus
+}
+
+input CityInput {
+	name: String!
+	code: String
+	status: RecordStatus
+}
+
+input ItemInput {
+	name: String!
+	status: RecordStatus
+}
+
+input VariantInput {
+	name: String!
+	status: RecordStatus
+}
+
+input SupplierInput {
+	name: String!
+	surname: String
+	documentType: String
+	documentNumber: String
+	cityId: ID!
+	email: String
+	addressLine1: String
+	phoneNumber: String
+	status: RecordStatus
+}
+
+input UserInput {
+	name: String!
+	surname: String
+	documentType: String
+	documentNumber: String
+	cityId: ID
+	email: String!
+	password: String
+	addressLine1: String
+	phoneNumber: String
+	status: RecordStatus
+}
+
+input CropInput {
+	cityId: ID!
+	plantingDate: Time!
+	harvestDate: Time!
+	variantId: ID
+	supplierId: ID
+}
+`
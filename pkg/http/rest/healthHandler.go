@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"net/http"
+
+	"futuagro.com/pkg/health"
+)
+
+// HealthHandler serves the liveness and readiness probes
+type HealthHandler struct {
+	Registry *health.Registry
+}
+
+// liveness answers as long as the process is able to serve requests, it checks no dependency so
+// that an unreachable database does not get the process restarted
+func (h *HealthHandler) liveness(w http.ResponseWriter, r *http.Request) error {
+	return writeJSON(w, http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+// readiness runs every registered check, it answers 503 with the failing checks when the process
+// must not receive traffic
+func (h *HealthHandler) readiness(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	report := h.Registry.Check(r.Context())
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	return writeJSON(w, status, report)
+}
+
+// Liveness returns the handler of the liveness probe
+func (h *HealthHandler) Liveness() http.Handler {
+	return rootHandler(h.liveness)
+}
+
+// Readiness returns the handler of the readiness probe
+func (h *HealthHandler) Readiness() http.Handler {
+	return rootHandler(h.readiness)
+}
@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/spreadsheet"
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultImportLimit = 20
+	maxImportLimit     = 100
+	// maxImportFileSize bounds the files uploaded, 10 MiB holds tens of thousands of rows, a larger
+	// file is rejected as a bad request
+	maxImportFileSize = 10 << 20
+)
+
+// ImportHandler return a handler for the Rest API used to import suppliers, crops and exchange rates
+// from CSV or XLSX files and to follow the progress of the imports
+type ImportHandler struct {
+	Service *services.ImportService
+}
+
+// NewRouter export a router configured with the import routes
+func (h *ImportHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Use(RequireScopes())
+
+	r.Method(http.MethodGet, "/", rootHandler(h.findRecentImportJobs))
+	r.With(RequireScopes(enums.SuppliersWrite)).Method(http.MethodPost, "/suppliers", rootHandler(h.importKind(enums.ImportSuppliers)))
+	r.With(RequireScopes(enums.CropsWrite)).Method(http.MethodPost, "/crops", rootHandler(h.importKind(enums.ImportCrops)))
+	r.With(RequireScopes(enums.ExchangeRatesAdmin)).Method(http.MethodPost, "/exchange-rates", rootHandler(h.importKind(enums.ImportExchangeRates)))
+	r.Method(http.MethodGet, "/{importID}", rootHandler(h.findImportJobByID))
+
+	return r
+}
+
+func (h *ImportHandler) findRecentImportJobs(w http.ResponseWriter, r *http.Request) error {
+	limit, _, err := parsePaging(r.URL.Query(), defaultImportLimit, maxImportLimit)
+	if err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+
+	results, err := h.Service.FindRecentImportJobs(limit)
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *ImportHandler) findImportJobByID(w http.ResponseWriter, r *http.Request) error {
+	importID := chi.URLParam(r, "importID")
+	job, err := h.Service.FindImportJobByID(importID)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, job)
+}
+
+// importKind reads the file sent as the "file" field of a multipart form or as the body of the
+// request. A dry run answers the validation report, otherwise the valid rows are committed in the
+// background and the job is answered with 202 Accepted.
+func (h *ImportHandler) importKind(kind enums.EnumImportKind) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		file, fileName, mediaType, err := importFile(r)
+		if err != nil {
+			return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+		}
+		defer file.Close()
+
+		format := spreadsheet.FormatOf(fileName, mediaType)
+		if format == "" {
+			return NewAPIError(nil, http.StatusUnsupportedMediaType, http.StatusUnsupportedMediaType, "Unsupported Media Type : send a .csv or .xlsx file.")
+		}
+		table, err := spreadsheet.Read(file, format)
+		if err != nil {
+			return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+		}
+
+		dto := &dtos.ImportDto{
+			Kind:     kind,
+			FileName: fileName,
+			Format:   format,
+			DryRun:   r.URL.Query().Get("dryRun") == "true",
+		}
+		job, err := h.Service.Import(r.Context(), dto, table, true)
+		if err != nil {
+			return err
+		}
+
+		if dto.DryRun {
+			return writeJSON(w, http.StatusOK, job)
+		}
+		w.Header().Set("Location", "/imports/"+job.ID.Hex())
+		return writeJSON(w, http.StatusAccepted, job)
+	}
+}
+
+// importFile returns the uploaded file with its name and media type, the name of a file sent as
+// the body of the request is read from the fileName query parameter
+func importFile(r *http.Request) (io.ReadCloser, string, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return r.Body, r.URL.Query().Get("fileName"), mediaType, nil
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, "", "", errors.New("send the file as the file field of the form")
+	}
+	partType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	return file, header.Filename, partType, nil
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+// InvitationHandler return a handler for the Rest API used by the invitees to accept the
+// invitations to join an organization
+type InvitationHandler struct {
+	Service *services.InvitationService
+}
+
+// NewRouter export a router configured with the invitation routes
+func (h *InvitationHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+
+	// The invitees are users, they prove who they are with their credentials instead of an API key
+	r.Method(http.MethodPost, "/accept", rootHandler(h.acceptInvitation))
+
+	return r
+}
+
+func (h *InvitationHandler) acceptInvitation(w http.ResponseWriter, r *http.Request) error {
+	var payload dtos.AcceptInvitationDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	organization, err := h.Service.AcceptInvitation(r.Context(), &payload)
+	if err != nil {
+		if errors.Cause(err) == services.ErrWrongCredentials {
+			return NewUnauthorizedError(err, "Authentication failed. Wrong user or password.")
+		}
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(organization.ID, organization.Version), organization)
+}
@@ -5,45 +5,56 @@ import (
 	"net/http"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
 
-// ItemHandler return a handler for the Rest API of a item
+// ItemHandler return a handler for the Rest API of a item, the items are answered with their name
+// in the locale the caller asks for
 type ItemHandler struct {
 	Service *services.ItemService
+	// DefaultLocale is the locale of the stored names of the items
+	DefaultLocale string
 }
 
 // NewRouter export a router configured with a supplier's routes
 func (h *ItemHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
-	r.Method(http.MethodGet, "/", rootHandler(h.findAllItems))
-	r.Method(http.MethodPost, "/", rootHandler(h.createItem))
+	r.With(RestrictScopes(enums.ItemsRead)).Method(http.MethodGet, "/", rootHandler(h.findAllItems))
+	r.With(RequireScopes(enums.ItemsWrite)).Method(http.MethodPost, "/", rootHandler(h.createItem))
 
 	// Subroutes:
 	r.Route("/{itemID}", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findItemByID))
-		r.Method(http.MethodPut, "/", rootHandler(h.updateItemID))
-		r.Method(http.MethodDelete, "/", rootHandler(h.deleteItemByID))
+		r.With(RestrictScopes(enums.ItemsRead)).Method(http.MethodGet, "/", rootHandler(h.findItemByID))
+		r.With(RequireScopes(enums.ItemsWrite)).Method(http.MethodPut, "/", rootHandler(h.updateItemID))
+		r.With(RequireScopes(enums.ItemsWrite)).Method(http.MethodDelete, "/", rootHandler(h.deleteItemByID))
 	})
 
 	return r
 }
 
+// findAllItems lists the items, or only those matching the q query parameter by any of their
+// names or synonyms
 func (h *ItemHandler) findAllItems(w http.ResponseWriter, r *http.Request) error {
-	items, err := h.Service.FindAllItems()
+	var items []*models.Item
+	var err error
+	if term := r.URL.Query().Get("q"); term != "" {
+		items, err = h.Service.SearchItems(term)
+	} else {
+		items, err = h.Service.FindAllItems()
+	}
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(items); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	locales := requestLocales(w, r)
+	for _, item := range items {
+		item.Localize(locales, h.DefaultLocale)
 	}
-
-	return nil
+	return respondWithCollection(w, r, items)
 }
 
 func (h *ItemHandler) createItem(w http.ResponseWriter, r *http.Request) error {
@@ -52,75 +63,56 @@ func (h *ItemHandler) createItem(w http.ResponseWriter, r *http.Request) error {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	result, err := h.Service.CreateItem(&payload)
+	result, err := h.Service.CreateItem(r.Context(), &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
 	supplier, err := h.Service.FindItemByID(result)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return h.respondWithItem(w, r, supplier)
 }
 
 func (h *ItemHandler) findItemByID(w http.ResponseWriter, r *http.Request) error {
 	itemID := chi.URLParam(r, "itemID")
 	item, err := h.Service.FindItemByID(itemID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if item == nil {
-		return NewNotFoundError(nil, "Item Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(item); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return h.respondWithItem(w, r, item)
 }
 
 func (h *ItemHandler) updateItemID(w http.ResponseWriter, r *http.Request) error {
 	itemID := chi.URLParam(r, "itemID")
+	versions, err := ifMatchVersions(r, itemID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.ItemDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	supplier, err := h.Service.UpdateItemByID(itemID, &payload)
+	supplier, err := h.Service.UpdateItemByID(r.Context(), itemID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if supplier == nil {
-		return NewNotFoundError(nil, "Item Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return h.respondWithItem(w, r, supplier)
 }
 
 func (h *ItemHandler) deleteItemByID(w http.ResponseWriter, r *http.Request) error {
 	itemID := chi.URLParam(r, "itemID")
-	result, err := h.Service.DeleteItemByID(itemID)
+	versions, err := ifMatchVersions(r, itemID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "Item Not Found")
+	if _, err := h.Service.DeleteItemByID(r.Context(), itemID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -128,3 +120,10 @@ func (h *ItemHandler) deleteItemByID(w http.ResponseWriter, r *http.Request) err
 
 	return nil
 }
+
+// respondWithItem answers an item with its name in the locale the caller asks for
+func (h *ItemHandler) respondWithItem(w http.ResponseWriter, r *http.Request, item *models.Item) error {
+	item.Localize(requestLocales(w, r), h.DefaultLocale)
+	w.Header().Set("Content-Language", item.Locale)
+	return respondWithEntity(w, r, entityETag(item.ID, item.Version), item)
+}
@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"net/http"
+
+	"futuagro.com/pkg/i18n"
+)
+
+// requestLocales returns the locales a request asks for, most preferred first, from the lang query
+// parameter or else from the Accept-Language header. The responses that depend on them vary on
+// that header.
+func requestLocales(w http.ResponseWriter, r *http.Request) []string {
+	w.Header().Add("Vary", "Accept-Language")
+	return i18n.Preferred(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+}
+
+// localizeError translates the message of an API error into the language that best serves the
+// locales, from the key of the error of the domain it answers when it has one
+func localizeError(locales []string, e *APIError) {
+	if e.key == "" {
+		e.Message = i18n.TranslateMessage(locales, e.Message)
+		return
+	}
+	e.Message = i18n.TranslateMessage(locales, e.prefix) + i18n.Translate(locales, e.key, e.args...)
+}
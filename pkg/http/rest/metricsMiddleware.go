@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"futuagro.com/pkg/metrics"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// Metrics is a middleware that records the count and the latency of the requests by chi route
+// pattern and status. The route pattern is only known once the request has been routed, so it
+// is read after the request is served.
+func Metrics(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		defer func() {
+			route := ""
+			if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+				route = routeContext.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			metrics.ObserveRequest(r.Method, route, status, time.Since(start))
+		}()
+
+		next.ServeHTTP(ww, r)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,193 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"futuagro.com/pkg/http/openapi"
+	"github.com/pkg/errors"
+)
+
+// OpenAPIHandler serves the OpenAPI document of the API and the documentation page rendering it
+type OpenAPIHandler struct {
+	body []byte
+}
+
+// NewOpenAPIHandler returns a handler serving doc, the document is encoded once
+func NewOpenAPIHandler(doc *openapi.Document) (*OpenAPIHandler, error) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error encoding the OpenAPI document")
+	}
+	return &OpenAPIHandler{body: body}, nil
+}
+
+// Document returns the handler of the OpenAPI document
+func (h *OpenAPIHandler) Document() http.Handler {
+	return rootHandler(func(w http.ResponseWriter, r *http.Request) error {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(h.body)
+		return nil
+	})
+}
+
+// Docs returns the handler of the documentation page, the page has no external dependency
+func (h *OpenAPIHandler) Docs() http.Handler {
+	return rootHandler(func(w http.ResponseWriter, r *http.Request) error {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(docsPage))
+		return nil
+	})
+}
+
+// docsPage renders /openapi.json: the operations grouped by tag with their parameters, payloads
+// and responses, and a form to send requests with an API key
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Futuagro API</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #222; }
+header { background: #2e7d32; color: #fff; padding: 12px 24px; display: flex; align-items: center; gap: 16px; }
+header h1 { font-size: 20px; margin: 0; flex: 1; }
+header input { width: 320px; padding: 6px; }
+nav { position: fixed; top: 56px; bottom: 0; width: 220px; overflow: auto; border-right: 1px solid #ddd; padding: 12px; box-sizing: border-box; }
+nav a { display: block; color: #2e7d32; text-decoration: none; padding: 3px 0; }
+main { margin-left: 220px; padding: 12px 24px; }
+details.op { border: 1px solid #ddd; border-radius: 4px; margin: 8px 0; }
+details.op summary { padding: 8px; cursor: pointer; }
+details.op > div { padding: 8px 16px; border-top: 1px solid #ddd; }
+.method { display: inline-block; width: 64px; text-align: center; color: #fff; border-radius: 3px; font-weight: bold; font-size: 12px; padding: 2px 0; }
+.get { background: #1976d2; } .post { background: #388e3c; } .put { background: #f57c00; } .delete { background: #d32f2f; } .patch { background: #7b1fa2; }
+code, pre { font-family: Menlo, Consolas, monospace; font-size: 12px; }
+pre { background: #f6f8fa; padding: 8px; overflow: auto; }
+table { border-collapse: collapse; } td, th { border: 1px solid #ddd; padding: 4px 8px; text-align: left; font-size: 13px; }
+textarea { width: 100%; height: 120px; font-family: Menlo, Consolas, monospace; }
+.scopes { color: #666; font-size: 12px; }
+</style>
+</head>
+<body>
+<header><h1>Futuagro API</h1><label>API key <input id="apiKey" type="password" placeholder="X-API-Key"></label></header>
+<nav id="nav"></nav>
+<main id="main">Loading /openapi.json…</main>
+<script>
+(function () {
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (k) { node.setAttribute(k, attrs[k]); });
+    (children || []).forEach(function (c) { node.appendChild(typeof c === "string" ? document.createTextNode(c) : c); });
+    return node;
+  }
+  function resolve(doc, schema) {
+    if (schema && schema.$ref) { return doc.components.schemas[schema.$ref.split("/").pop()]; }
+    return schema;
+  }
+  function example(doc, schema, depth) {
+    schema = resolve(doc, schema) || {};
+    if (depth > 4) { return null; }
+    if (schema.enum) { return schema.enum[0]; }
+    switch (schema.type) {
+    case "object":
+      var value = {};
+      Object.keys(schema.properties || {}).forEach(function (k) { value[k] = example(doc, schema.properties[k], depth + 1); });
+      return value;
+    case "array": return [example(doc, schema.items, depth + 1)];
+    case "integer": case "number": return 0;
+    case "boolean": return false;
+    case "string": return schema.format === "date-time" ? new Date().toISOString() : "";
+    }
+    return null;
+  }
+  function operation(doc, path, method, op) {
+    var body = el("div");
+    if (op.description) { body.appendChild(el("p", {}, [op.description])); }
+    if (op["x-required-scopes"]) { body.appendChild(el("p", {"class": "scopes"}, ["Scopes: " + op["x-required-scopes"].join(", ")])); }
+    var inputs = {};
+    if (op.parameters && op.parameters.length) {
+      var table = el("table", {}, [el("tr", {}, [el("th", {}, ["Name"]), el("th", {}, ["In"]), el("th", {}, ["Description"]), el("th", {}, ["Value"])])]);
+      op.parameters.forEach(function (p) {
+        var input = el("input", {placeholder: p.schema.enum ? p.schema.enum.join(" | ") : (p.schema.format || p.schema.type)});
+        inputs[p.in + ":" + p.name] = input;
+        table.appendChild(el("tr", {}, [el("td", {}, [p.name + (p.required ? " *" : "")]), el("td", {}, [p.in]), el("td", {}, [p.description || ""]), el("td", {}, [input])]));
+      });
+      body.appendChild(table);
+    }
+    var payload;
+    if (op.requestBody) {
+      var schema = op.requestBody.content["application/json"].schema;
+      body.appendChild(el("h4", {}, ["Request body " + (schema.$ref ? schema.$ref.split("/").pop() : "")]));
+      payload = el("textarea");
+      payload.value = JSON.stringify(example(doc, schema, 0), null, 2);
+      body.appendChild(payload);
+    }
+    body.appendChild(el("h4", {}, ["Responses"]));
+    Object.keys(op.responses).sort().forEach(function (status) {
+      var response = op.responses[status];
+      var content = response.content && response.content[Object.keys(response.content)[0]];
+      var ref = content && content.schema && (content.schema.$ref || (content.schema.items && content.schema.items.$ref));
+      body.appendChild(el("div", {}, [el("code", {}, [status]), " " + response.description + (ref ? " — " + (content.schema.items ? "array of " : "") + ref.split("/").pop() : "")]));
+    });
+    var output = el("pre");
+    var send = el("button", {}, ["Send"]);
+    send.onclick = function () {
+      var url = path, query = [], headers = {};
+      (op.parameters || []).forEach(function (p) {
+        var value = inputs[p.in + ":" + p.name].value;
+        if (!value) { return; }
+        if (p.in === "path") { url = url.replace("{" + p.name + "}", encodeURIComponent(value)); }
+        if (p.in === "query") { query.push(encodeURIComponent(p.name) + "=" + encodeURIComponent(value)); }
+        if (p.in === "header") { headers[p.name] = value; }
+      });
+      var key = document.getElementById("apiKey").value;
+      if (key) { headers["X-API-Key"] = key; }
+      if (payload) { headers["Content-Type"] = "application/json"; }
+      output.textContent = "…";
+      fetch(url + (query.length ? "?" + query.join("&") : ""), {method: method.toUpperCase(), headers: headers, body: payload ? payload.value : undefined})
+        .then(function (res) {
+          return res.text().then(function (text) {
+            try { text = JSON.stringify(JSON.parse(text), null, 2); } catch (e) {}
+            output.textContent = res.status + " " + res.statusText + "\n" + text;
+          });
+        })
+        .catch(function (err) { output.textContent = String(err); });
+    };
+    if (!/event-stream/.test(JSON.stringify(op.responses))) { body.appendChild(el("p", {}, [send])); body.appendChild(output); }
+    return el("details", {"class": "op"}, [
+      el("summary", {}, [el("span", {"class": "method " + method}, [method.toUpperCase()]), " ", el("code", {}, [path]), " " + (op.summary || "")]),
+      body
+    ]);
+  }
+  fetch("openapi.json").then(function (res) { return res.json(); }).then(function (doc) {
+    var main = document.getElementById("main"), nav = document.getElementById("nav");
+    main.textContent = "";
+    main.appendChild(el("p", {}, [doc.info.description || ""]));
+    var byTag = {};
+    Object.keys(doc.paths).sort().forEach(function (path) {
+      ["get", "post", "put", "patch", "delete"].forEach(function (method) {
+        var op = doc.paths[path][method];
+        if (!op) { return; }
+        var tag = (op.tags || ["default"])[0];
+        (byTag[tag] = byTag[tag] || []).push(operation(doc, path, method, op));
+      });
+    });
+    (doc.tags || []).forEach(function (tag) {
+      if (!byTag[tag.name]) { return; }
+      nav.appendChild(el("a", {href: "#" + tag.name}, [tag.name]));
+      main.appendChild(el("h2", {id: tag.name}, [tag.name]));
+      main.appendChild(el("p", {}, [tag.description || ""]));
+      byTag[tag.name].forEach(function (op) { main.appendChild(op); });
+    });
+    nav.appendChild(el("a", {href: "#schemas"}, ["schemas"]));
+    main.appendChild(el("h2", {id: "schemas"}, ["Schemas"]));
+    Object.keys(doc.components.schemas).sort().forEach(function (name) {
+      main.appendChild(el("details", {"class": "op"}, [el("summary", {}, [el("code", {}, [name])]), el("div", {}, [el("pre", {}, [JSON.stringify(doc.components.schemas[name], null, 2)])])]));
+    });
+  }).catch(function (err) { document.getElementById("main").textContent = "Error loading /openapi.json: " + err; });
+})();
+</script>
+</body>
+</html>
+`
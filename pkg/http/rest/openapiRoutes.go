@@ -0,0 +1,426 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/health"
+	"futuagro.com/pkg/http/openapi"
+	"futuagro.com/pkg/spreadsheet"
+)
+
+// NewOpenAPISpec returns the spec of every route of the API. A route mounted on the router must be
+// registered here, go test ./pkg/http and cmd/openapi -check fail otherwise.
+func NewOpenAPISpec() *openapi.Spec {
+	spec := openapi.New(openapi.Info{
+		Title:       "Futuagro API",
+		Description: "Marketplace connecting farmers and their crops with buyers. The messages of the errors are answered in the language of the lang query parameter or else of the Accept-Language header, in English by default.",
+		Version:     "1.0.0",
+	}, &APIError{})
+
+	spec.SecurityScheme("apiKey", openapi.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key", Description: "API key of an API client"})
+	spec.SecurityScheme("bearer", openapi.SecurityScheme{Type: "http", Scheme: "bearer", Description: "API key of an API client, or token of the session opened by the login of a user, sent as a bearer token"})
+
+	spec.Enum(enums.EnumRecordStatus(""), string(enums.Active), string(enums.Inactive))
+	spec.Enum(enums.EnumAuditAction(""), string(enums.AuditCreate), string(enums.AuditUpdate), string(enums.AuditDelete))
+	spec.Enum(enums.EnumDeliveryStatus(""), string(enums.DeliveryPending), string(enums.DeliveryRetrying), string(enums.DeliverySucceeded), string(enums.DeliveryDead))
+	spec.Enum(enums.EnumOutboxStatus(""), string(enums.OutboxPending), string(enums.OutboxDispatching), string(enums.OutboxDispatched))
+	spec.Enum(enums.EnumImportKind(""), string(enums.ImportSuppliers), string(enums.ImportCrops), string(enums.ImportExchangeRates))
+	spec.Enum(enums.EnumImportStatus(""), string(enums.ImportValidated), string(enums.ImportRunning), string(enums.ImportCompleted), string(enums.ImportFailed))
+	spec.Enum(enums.EnumOrganizationKind(""), string(enums.BuyerOrganization), string(enums.Cooperative))
+	spec.Enum(enums.EnumMemberRole(""),
+		string(enums.MemberOwner), string(enums.MemberAdmin), string(enums.MemberBuyer),
+		string(enums.MemberApprover), string(enums.MemberAccountant), string(enums.MemberFarmer),
+	)
+	spec.Enum(enums.EnumScope(""), scopeNames(
+		enums.AllScopes, enums.SuppliersRead, enums.SuppliersWrite, enums.CountriesRead, enums.CountriesWrite,
+		enums.CitiesRead, enums.CitiesWrite, enums.ItemsRead, enums.ItemsWrite, enums.CropsRead, enums.CropsWrite,
+		enums.UsersRead, enums.UsersWrite, enums.APIClientsAdmin, enums.AuditRead, enums.WebhooksAdmin,
+		enums.OrganizationsRead, enums.OrganizationsWrite, enums.ExchangeRatesRead, enums.ExchangeRatesAdmin,
+	)...)
+	spec.Enum(enums.EnumEventType(""),
+		string(enums.AllEvents),
+		string(enums.SupplierCreated), string(enums.SupplierUpdated), string(enums.SupplierDeleted),
+		string(enums.CountryCreated), string(enums.CountryUpdated), string(enums.CountryDeleted),
+		string(enums.CityCreated), string(enums.CityUpdated), string(enums.CityDeleted),
+		string(enums.ItemCreated), string(enums.ItemUpdated), string(enums.ItemDeleted),
+		string(enums.VariantCreated), string(enums.VariantUpdated), string(enums.VariantDeleted),
+		string(enums.CropCreated), string(enums.CropUpdated), string(enums.CropHarvestDateChanged), string(enums.CropDeleted),
+	)
+
+	spec.Tag("suppliers", "Farmers selling their crops")
+	spec.Tag("countries", "Countries and their states")
+	spec.Tag("cities", "Cities of a country state")
+	spec.Tag("items", "Catalog of items and their variants")
+	spec.Tag("crops", "Crops registered by the suppliers")
+	spec.Tag("users", "Users of the platform")
+	spec.Tag("auth", "Authentication of users")
+	spec.Tag("api-clients", "API clients of machine to machine integrations")
+	spec.Tag("organizations", "Buyer organizations and cooperatives with their members")
+	spec.Tag("reports", "Reports of the organization the caller acts for")
+	spec.Tag("exchange-rates", "Exchange rates between currencies and conversions of amounts")
+	spec.Tag("imports", "Imports of suppliers, crops and exchange rates from spreadsheets")
+	spec.Tag("audit", "Audit trail of the mutations")
+	spec.Tag("webhooks", "Webhook subscriptions and their deliveries")
+	spec.Tag("events", "Live stream of the changes")
+	spec.Tag("graphql", "GraphQL API over the records and their relations")
+	spec.Tag("operations", "Probes, metrics and documentation")
+
+	for _, routes := range [][]openapi.Route{
+		supplierRoutes(),
+		countryRoutes(),
+		cityRoutes(),
+		itemRoutes(),
+		cropRoutes(),
+		userRoutes(),
+		apiClientRoutes(),
+		organizationRoutes(),
+		reportRoutes(),
+		exchangeRateRoutes(),
+		importRoutes(),
+		auditRoutes(),
+		webhookRoutes(),
+		eventRoutes(),
+		graphQLRoutes(),
+		operationRoutes(),
+	} {
+		spec.Add(exportable(routes)...)
+	}
+	return spec
+}
+
+// exportable documents the export formats of the routes listing a collection, see
+// respondWithCollection
+func exportable(routes []openapi.Route) []openapi.Route {
+	formats := []string{formatJSON, formatNDJSON, spreadsheet.CSV, spreadsheet.XLSX}
+	for i, route := range routes {
+		if route.Method != http.MethodGet || route.Response == nil || reflect.TypeOf(route.Response).Kind() != reflect.Slice {
+			continue
+		}
+		routes[i].Query = append(route.Query, queryParam("format", "Format of the list, it can also be negotiated with the Accept header", &openapi.Schema{Type: "string", Enum: formats}))
+		for _, format := range formats[1:] {
+			routes[i].Downloads = append(routes[i].Downloads, exportMediaTypes[format])
+		}
+		routes[i].Errors = append(route.Errors, http.StatusBadRequest)
+	}
+	return routes
+}
+
+func supplierRoutes() []openapi.Route {
+	read, write := scopeNames(enums.SuppliersRead), scopeNames(enums.SuppliersWrite)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/suppliers", OperationID: "findAllSuppliers", Tag: "suppliers", Summary: "List the suppliers", Scopes: read, Response: []models.Supplier{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/suppliers", OperationID: "createSupplier", Tag: "suppliers", Summary: "Create a supplier", Scopes: write, Authenticated: true, Request: dtos.SupplierDto{}, Response: models.Supplier{}, Errors: []int{http.StatusConflict}},
+		{Method: http.MethodGet, Path: "/suppliers/{supplierID}", OperationID: "findSupplierByID", Tag: "suppliers", Summary: "Get a supplier with its city and crops", Scopes: read, Response: models.Supplier{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/suppliers/{supplierID}", OperationID: "updateSupplierByID", Tag: "suppliers", Summary: "Update a supplier", Scopes: write, Authenticated: true, Request: dtos.SupplierDto{}, Response: models.Supplier{}, Errors: []int{http.StatusConflict}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/suppliers/{supplierID}", OperationID: "deleteSupplierByID", Tag: "suppliers", Summary: "Delete a supplier", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusConflict}, Conditional: true},
+	}
+}
+
+func countryRoutes() []openapi.Route {
+	read, write := scopeNames(enums.CountriesRead), scopeNames(enums.CountriesWrite)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/countries", OperationID: "findAllCountries", Tag: "countries", Summary: "List the countries", Scopes: read, Response: []models.Country{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/countries", OperationID: "createCountry", Tag: "countries", Summary: "Create a country", Scopes: write, Authenticated: true, Request: dtos.CountryDto{}, Response: models.Country{}, Errors: []int{http.StatusConflict}},
+		{Method: http.MethodGet, Path: "/countries/{countryID}", OperationID: "findCountryByID", Tag: "countries", Summary: "Get a country with its states", Scopes: read, Response: models.Country{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/countries/{countryID}", OperationID: "updateCountryByID", Tag: "countries", Summary: "Update a country", Scopes: write, Authenticated: true, Request: dtos.CountryDto{}, Response: models.Country{}, Errors: []int{http.StatusConflict}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/countries/{countryID}", OperationID: "deleteCountryByID", Tag: "countries", Summary: "Delete a country", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusConflict}, Conditional: true},
+		{Method: http.MethodPost, Path: "/countries/{countryID}/country-states", OperationID: "createState", Tag: "countries", Summary: "Add a state to a country", Scopes: write, Authenticated: true, Request: dtos.CountryStateDto{}, Response: models.Country{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/countries/{countryID}/country-states/{stateID}", OperationID: "updateState", Tag: "countries", Summary: "Update a state of a country", Scopes: write, Authenticated: true, Request: dtos.CountryStateDto{}, Response: models.Country{}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/countries/{countryID}/country-states/{stateID}", OperationID: "deleteState", Tag: "countries", Summary: "Remove a state from a country", Scopes: write, Authenticated: true, Response: models.Country{}, Errors: []int{http.StatusConflict}, Conditional: true},
+	}
+}
+
+func cityRoutes() []openapi.Route {
+	read, write := scopeNames(enums.CitiesRead), scopeNames(enums.CitiesWrite)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/country-states/{stateID}/cities", OperationID: "findAllCitiesByState", Tag: "cities", Summary: "List the cities of a country state", Scopes: read, Response: []models.City{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/country-states/{stateID}/cities", OperationID: "createCity", Tag: "cities", Summary: "Create a city in a country state", Scopes: write, Authenticated: true, Request: dtos.CityDto{}, Response: models.City{}, Errors: []int{http.StatusConflict}},
+		{Method: http.MethodGet, Path: "/country-states/{stateID}/cities/{cityID}", OperationID: "findCityByID", Tag: "cities", Summary: "Get a city of a country state", Scopes: read, Response: models.City{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/country-states/{stateID}/cities/{cityID}", OperationID: "updateCityByID", Tag: "cities", Summary: "Update a city", Scopes: write, Authenticated: true, Request: dtos.CityDto{}, Response: models.City{}, Errors: []int{http.StatusConflict}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/country-states/{stateID}/cities/{cityID}", OperationID: "deleteCityByID", Tag: "cities", Summary: "Delete a city", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusConflict}, Conditional: true},
+	}
+}
+
+func itemRoutes() []openapi.Route {
+	read, write := scopeNames(enums.ItemsRead), scopeNames(enums.ItemsWrite)
+	localized := "The name is answered in the locale of the lang query parameter or else of the Accept-Language header: the same locale, its parents, " +
+		"then another region of its language, and else the default locale of the catalog. The locale of the name is answered in locale."
+	lang := queryParam("lang", "Locales of the names, comma separated, most preferred first, it takes precedence over the Accept-Language header", stringSchema())
+	search := []openapi.Parameter{lang, queryParam("q", "Only the records with a name or a synonym in any locale with a word starting with q, whatever its case and accents", stringSchema())}
+	names := "The names replace the localized names and their synonyms, they are kept when absent."
+	query := []openapi.Parameter{lang}
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/items", OperationID: "findAllItems", Tag: "items", Summary: "List the items of the catalog", Description: localized, Scopes: read, Query: search, Response: []models.Item{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/items", OperationID: "createItem", Tag: "items", Summary: "Create an item", Description: localized, Scopes: write, Authenticated: true, Query: query, Request: dtos.ItemDto{}, Response: models.Item{}},
+		{Method: http.MethodGet, Path: "/items/{itemID}", OperationID: "findItemByID", Tag: "items", Summary: "Get an item", Description: localized, Scopes: read, Query: query, Response: models.Item{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/items/{itemID}", OperationID: "updateItemByID", Tag: "items", Summary: "Update an item", Description: names + " " + localized, Scopes: write, Authenticated: true, Query: query, Request: dtos.ItemDto{}, Response: models.Item{}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/items/{itemID}", OperationID: "deleteItemByID", Tag: "items", Summary: "Delete an item", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusConflict}, Conditional: true},
+		{Method: http.MethodGet, Path: "/items/{itemID}/variants", OperationID: "findVariantsByItemID", Tag: "items", Summary: "List the variants of an item", Description: localized, Scopes: read, Query: search, Response: []models.Variant{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/items/{itemID}/variants", OperationID: "createVariant", Tag: "items", Summary: "Create a variant of an item", Description: localized, Scopes: write, Authenticated: true, Query: query, Request: dtos.VariantDto{}, Response: models.Variant{}},
+		{Method: http.MethodGet, Path: "/items/{itemID}/variants/{variantID}", OperationID: "findVariantByID", Tag: "items", Summary: "Get a variant of an item", Description: localized, Scopes: read, Query: query, Response: models.Variant{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/items/{itemID}/variants/{variantID}", OperationID: "updateVariant", Tag: "items", Summary: "Update a variant of an item", Description: names + " " + localized, Scopes: write, Authenticated: true, Query: query, Request: dtos.VariantDto{}, Response: models.Variant{}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/items/{itemID}/variants/{variantID}", OperationID: "deleteVariant", Tag: "items", Summary: "Delete a variant of an item", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusConflict}, Conditional: true},
+	}
+}
+
+func cropRoutes() []openapi.Route {
+	read, write := scopeNames(enums.CropsRead), scopeNames(enums.CropsWrite)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/crops", OperationID: "findAllCrops", Tag: "crops", Summary: "List the crops", Scopes: read, Response: []models.Crop{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/crops", OperationID: "createCrop", Tag: "crops", Summary: "Register a crop", Scopes: write, Authenticated: true, Request: dtos.CropDto{}, Response: models.Crop{}},
+		{Method: http.MethodGet, Path: "/crops/{cropID}", OperationID: "findCropByID", Tag: "crops", Summary: "Get a crop with its city, variant and supplier", Scopes: read, Response: models.Crop{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/crops/{cropID}", OperationID: "updateCropByID", Tag: "crops", Summary: "Update a crop", Scopes: write, Authenticated: true, Request: dtos.CropDto{}, Response: models.Crop{}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/crops/{cropID}", OperationID: "deleteCropByID", Tag: "crops", Summary: "Delete a crop", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Conditional: true},
+	}
+}
+
+func userRoutes() []openapi.Route {
+	read, write := scopeNames(enums.UsersRead), scopeNames(enums.UsersWrite)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/users", OperationID: "findAllUsers", Tag: "users", Summary: "List the users", Scopes: read, Response: []models.User{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/users", OperationID: "signup", Tag: "users", Summary: "Sign up a user", Description: "Open to anonymous callers, the API clients signing users up need the write scope.", Scopes: write, Request: dtos.UserDto{}, Response: models.User{}, Errors: []int{http.StatusConflict}},
+		{Method: http.MethodGet, Path: "/users/{userID}", OperationID: "findUserByID", Tag: "users", Summary: "Get a user", Scopes: read, Response: models.User{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/users/{userID}", OperationID: "updateUserByID", Tag: "users", Summary: "Update a user", Scopes: write, Authenticated: true, Request: dtos.UserDto{}, Response: models.User{}, Errors: []int{http.StatusConflict}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/users/{userID}", OperationID: "deleteUserByID", Tag: "users", Summary: "Delete a user", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusConflict}, Conditional: true},
+		{
+			Method: http.MethodPost, Path: "/auth/login", OperationID: "login", Tag: "auth", Summary: "Log a user in with an email and a password",
+			Description: "Opens a session, its token is sent as a bearer token by the calls of the user until it expires. The token is only returned by this call.",
+			Request:     dtos.LoginDto{}, Response: models.IssuedSession{}, Errors: []int{http.StatusUnauthorized},
+		},
+		{Method: http.MethodPost, Path: "/auth/logout", OperationID: "logout", Tag: "auth", Summary: "Close the session of the token sent as a bearer token", Authenticated: true, Status: http.StatusNoContent},
+	}
+}
+
+func apiClientRoutes() []openapi.Route {
+	admin := scopeNames(enums.APIClientsAdmin)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/api-clients", OperationID: "findAllAPIClients", Tag: "api-clients", Summary: "List the API clients", Scopes: admin, Authenticated: true, Response: []models.APIClient{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/api-clients", OperationID: "createAPIClient", Tag: "api-clients", Summary: "Create an API client", Description: "The API key is only returned by this call and by the key rotation.", Scopes: admin, Authenticated: true, Request: dtos.APIClientDto{}, Status: http.StatusCreated, Response: models.IssuedAPIKey{}},
+		{Method: http.MethodGet, Path: "/api-clients/{apiClientID}", OperationID: "findAPIClientByID", Tag: "api-clients", Summary: "Get an API client", Scopes: admin, Authenticated: true, Response: models.APIClient{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/api-clients/{apiClientID}", OperationID: "updateAPIClientByID", Tag: "api-clients", Summary: "Update an API client", Scopes: admin, Authenticated: true, Request: dtos.APIClientDto{}, Response: models.APIClient{}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/api-clients/{apiClientID}", OperationID: "deleteAPIClientByID", Tag: "api-clients", Summary: "Delete an API client", Scopes: admin, Authenticated: true, Status: http.StatusNoContent, Conditional: true},
+		{Method: http.MethodPost, Path: "/api-clients/{apiClientID}/rotate-key", OperationID: "rotateAPIKey", Tag: "api-clients", Summary: "Issue a new API key, revoking the current one", Scopes: admin, Authenticated: true, Response: models.IssuedAPIKey{}, Conditional: true},
+	}
+}
+
+func organizationRoutes() []openapi.Route {
+	read, write := scopeNames(enums.OrganizationsRead), scopeNames(enums.OrganizationsWrite)
+	members := "The members are written with the ETag of the organization, only its owners and admins manage them and only its owners grant the owner role."
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/organizations", OperationID: "findAllOrganizations", Tag: "organizations", Summary: "List the organizations the caller is a member of",
+			Description: "An API client or a user acts for one of its organizations by sending its ID in the X-Organization-ID header.",
+			Scopes:      read, Authenticated: true, Response: []models.Organization{}, Conditional: true,
+		},
+		{Method: http.MethodPost, Path: "/organizations", OperationID: "createOrganization", Tag: "organizations", Summary: "Create an organization, the calling API client or user is its owner", Scopes: write, Authenticated: true, Request: dtos.OrganizationDto{}, Status: http.StatusCreated, Response: models.Organization{}},
+		{Method: http.MethodGet, Path: "/organizations/{organizationID}", OperationID: "findOrganizationByID", Tag: "organizations", Summary: "Get an organization with its members", Scopes: read, Authenticated: true, Response: models.Organization{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/organizations/{organizationID}", OperationID: "updateOrganizationByID", Tag: "organizations", Summary: "Update an organization", Scopes: write, Authenticated: true, Request: dtos.OrganizationDto{}, Response: models.Organization{}, Errors: []int{http.StatusForbidden}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/organizations/{organizationID}", OperationID: "deleteOrganizationByID", Tag: "organizations", Summary: "Delete an organization and its invitations", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusForbidden}, Conditional: true},
+		{Method: http.MethodPost, Path: "/organizations/{organizationID}/members", OperationID: "addMember", Tag: "organizations", Summary: "Add a user or an API client to an organization", Description: members, Scopes: write, Authenticated: true, Request: dtos.MembershipDto{}, Response: models.Organization{}, Errors: []int{http.StatusForbidden, http.StatusConflict}, Conditional: true},
+		{Method: http.MethodPut, Path: "/organizations/{organizationID}/members/{memberID}", OperationID: "updateMember", Tag: "organizations", Summary: "Change the role of a member", Description: members, Scopes: write, Authenticated: true, Request: dtos.MemberRoleDto{}, Response: models.Organization{}, Errors: []int{http.StatusForbidden, http.StatusConflict}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/organizations/{organizationID}/members/{memberID}", OperationID: "removeMember", Tag: "organizations", Summary: "Remove a member, a member can also leave", Description: members + " The last owner cannot be removed.", Scopes: write, Authenticated: true, Response: models.Organization{}, Errors: []int{http.StatusForbidden, http.StatusConflict}, Conditional: true},
+		{Method: http.MethodGet, Path: "/organizations/{organizationID}/invitations", OperationID: "findInvitations", Tag: "organizations", Summary: "List the invitations to join an organization", Scopes: write, Authenticated: true, Response: []models.Invitation{}, Errors: []int{http.StatusForbidden}},
+		{Method: http.MethodPost, Path: "/organizations/{organizationID}/invitations", OperationID: "createInvitation", Tag: "organizations", Summary: "Invite an email to join an organization", Description: "The token is only returned by this call, the inviter sends it to the invitee.", Scopes: write, Authenticated: true, Request: dtos.InvitationDto{}, Status: http.StatusCreated, Response: models.IssuedInvitation{}, Errors: []int{http.StatusForbidden}},
+		{Method: http.MethodDelete, Path: "/organizations/{organizationID}/invitations/{invitationID}", OperationID: "revokeInvitation", Tag: "organizations", Summary: "Revoke an invitation", Scopes: write, Authenticated: true, Status: http.StatusNoContent, Errors: []int{http.StatusForbidden}},
+		{Method: http.MethodPost, Path: "/invitations/accept", OperationID: "acceptInvitation", Tag: "organizations", Summary: "Accept an invitation with the credentials of the invited user", Request: dtos.AcceptInvitationDto{}, Response: models.Organization{}, Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict}},
+	}
+}
+
+func reportRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/reports/harvest", OperationID: "harvestReport", Tag: "reports", Summary: "Count the crops harvested by month and variant",
+			Description: "Scoped to the crops supplied or created by the members of the organization sent in the X-Organization-ID header, or else of the only organization the caller is a member of. The operators acting for no organization count every crop.",
+			Scopes:      scopeNames(enums.CropsRead), Authenticated: true,
+			Query: []openapi.Parameter{
+				queryParam("from", "Oldest harvest, RFC 3339 timestamp, the start of the current year by default", &openapi.Schema{Type: "string", Format: "date-time"}),
+				queryParam("to", "Newest harvest, RFC 3339 timestamp, the end of the current year by default", &openapi.Schema{Type: "string", Format: "date-time"}),
+			},
+			Response: []models.HarvestReportRow{}, Errors: []int{http.StatusBadRequest, http.StatusForbidden},
+		},
+	}
+}
+
+func exchangeRateRoutes() []openapi.Route {
+	read, admin := scopeNames(enums.ExchangeRatesRead), scopeNames(enums.ExchangeRatesAdmin)
+	currency := &openapi.Schema{Type: "string", Pattern: "^[A-Za-z]{3}$"}
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/exchange-rates", OperationID: "findExchangeRates", Tag: "exchange-rates", Summary: "List the exchange rates by pair, newest first",
+			Scopes: read,
+			Query: []openapi.Parameter{
+				queryParam("base", "Only the rates of this base currency, ISO 4217 code", currency),
+				queryParam("quote", "Only the rates of this quote currency, ISO 4217 code", currency),
+			},
+			Response: []models.ExchangeRate{}, Conditional: true,
+		},
+		{
+			Method: http.MethodPost, Path: "/exchange-rates", OperationID: "createExchangeRate", Tag: "exchange-rates", Summary: "Record an exchange rate",
+			Description: "The rate is the value of one unit of base in units of quote, written as a decimal. It applies from effectiveAt until the next rate of the pair.",
+			Scopes:      admin, Authenticated: true, Request: dtos.ExchangeRateDto{}, Status: http.StatusCreated, Response: models.ExchangeRate{}, Errors: []int{http.StatusConflict},
+		},
+		{
+			Method: http.MethodGet, Path: "/exchange-rates/convert", OperationID: "convertAmount", Tag: "exchange-rates", Summary: "Convert an amount into another currency",
+			Description: "Amounts are integers in the minor units of their currency, cents for USD. The rate of the pair in effect at the given time is used, " +
+				"or else the rate of the opposite pair inverted, and the result is rounded half away from zero. The rate used is answered with the amount, " +
+				"and its ID and effective time with the converted amount.",
+			Scopes: read,
+			Query: []openapi.Parameter{
+				queryParam("amount", "Amount in the minor units of the currency from", &openapi.Schema{Type: "integer", Format: "int64"}),
+				queryParam("from", "Currency of the amount, ISO 4217 code", currency),
+				queryParam("currency", "Currency of the result, ISO 4217 code", currency),
+				queryParam("to", "Currency of the result when currency is not sent, ISO 4217 code", currency),
+				queryParam("at", "Time of the rate, RFC 3339 timestamp, now by default", &openapi.Schema{Type: "string", Format: "date-time"}),
+			},
+			Response: models.Conversion{}, Errors: []int{http.StatusBadRequest},
+		},
+		{Method: http.MethodGet, Path: "/exchange-rates/{rateID}", OperationID: "findExchangeRateByID", Tag: "exchange-rates", Summary: "Get an exchange rate", Scopes: read, Response: models.ExchangeRate{}},
+		{Method: http.MethodDelete, Path: "/exchange-rates/{rateID}", OperationID: "deleteExchangeRate", Tag: "exchange-rates", Summary: "Delete an exchange rate, the previous rate of its pair applies again", Scopes: admin, Authenticated: true, Status: http.StatusNoContent},
+	}
+}
+
+func importRoutes() []openapi.Route {
+	upload := "Send the file as the file field of a multipart form, or as the body with a text/csv or spreadsheetml Content-Type and its name in fileName. " +
+		"The columns are matched whatever their case and separators. A dry run answers the validation report, otherwise the valid rows are committed in the background."
+	query := []openapi.Parameter{
+		queryParam("dryRun", "Only validate the rows when true", &openapi.Schema{Type: "boolean"}),
+		queryParam("fileName", "Name of a file sent as the body", stringSchema()),
+	}
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/imports", OperationID: "findRecentImportJobs", Tag: "imports", Summary: "List the most recent imports without their row errors", Authenticated: true, Query: []openapi.Parameter{queryParam("limit", "Number of imports, at most 100", &openapi.Schema{Type: "integer", Format: "int32"})}, Response: []models.ImportJob{}, Conditional: true},
+		{Method: http.MethodGet, Path: "/imports/{importID}", OperationID: "findImportJobByID", Tag: "imports", Summary: "Get an import with its progress and row errors", Authenticated: true, Response: models.ImportJob{}},
+		{
+			Method: http.MethodPost, Path: "/imports/suppliers", OperationID: "importSuppliers", Tag: "imports", Summary: "Import suppliers from a CSV or XLSX file",
+			Description: upload + " Columns: name, surname, documentType, documentNumber, cityCode or city with an optional state, email, addressLine1, phoneNumber.",
+			Scopes:      scopeNames(enums.SuppliersWrite), Authenticated: true, Query: query,
+			Status: http.StatusAccepted, Response: models.ImportJob{}, Errors: []int{http.StatusBadRequest, http.StatusUnsupportedMediaType},
+		},
+		{
+			Method: http.MethodPost, Path: "/imports/crops", OperationID: "importCrops", Tag: "imports", Summary: "Import crops of registered suppliers from a CSV or XLSX file",
+			Description: upload + " Columns: supplierDocumentNumber with an optional supplierDocumentType, cityCode or city with an optional state, item, variant, plantingDate, harvestDate (YYYY-MM-DD).",
+			Scopes:      scopeNames(enums.CropsWrite), Authenticated: true, Query: query,
+			Status: http.StatusAccepted, Response: models.ImportJob{}, Errors: []int{http.StatusBadRequest, http.StatusUnsupportedMediaType},
+		},
+		{
+			Method: http.MethodPost, Path: "/imports/exchange-rates", OperationID: "importExchangeRates", Tag: "imports", Summary: "Import exchange rates from a CSV or XLSX file",
+			Description: upload + " Columns: base, quote (ISO 4217 codes), rate (decimal value of one base in quote), effectiveAt (YYYY-MM-DD or RFC 3339).",
+			Scopes:      scopeNames(enums.ExchangeRatesAdmin), Authenticated: true, Query: query,
+			Status: http.StatusAccepted, Response: models.ImportJob{}, Errors: []int{http.StatusBadRequest, http.StatusUnsupportedMediaType},
+		},
+	}
+}
+
+func auditRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/audit-logs", OperationID: "findAuditEntries", Tag: "audit", Summary: "Search the audit trail",
+			Scopes: scopeNames(enums.AuditRead), Authenticated: true,
+			Query: []openapi.Parameter{
+				queryParam("resourceType", "Type of the changed resource", stringSchema()),
+				queryParam("resourceId", "ID of the changed resource", stringSchema()),
+				queryParam("actorId", "ID of the user or API client that made the change", stringSchema()),
+				queryParam("from", "Oldest change, RFC 3339 timestamp", &openapi.Schema{Type: "string", Format: "date-time"}),
+				queryParam("to", "Newest change, RFC 3339 timestamp", &openapi.Schema{Type: "string", Format: "date-time"}),
+				queryParam("limit", "Number of entries, at most 1000", &openapi.Schema{Type: "integer", Format: "int32"}),
+				queryParam("skip", "Number of entries skipped", &openapi.Schema{Type: "integer", Format: "int32"}),
+			},
+			Response: []models.AuditEntry{}, Conditional: true,
+		},
+	}
+}
+
+func webhookRoutes() []openapi.Route {
+	admin := scopeNames(enums.WebhooksAdmin)
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/webhooks/subscriptions", OperationID: "findAllSubscriptions", Tag: "webhooks", Summary: "List the webhook subscriptions", Scopes: admin, Authenticated: true, Response: []models.WebhookSubscription{}, Conditional: true},
+		{Method: http.MethodPost, Path: "/webhooks/subscriptions", OperationID: "createSubscription", Tag: "webhooks", Summary: "Subscribe a URL to domain events", Description: "The signing secret is only returned by this call and by the secret rotation.", Scopes: admin, Authenticated: true, Request: dtos.WebhookSubscriptionDto{}, Status: http.StatusCreated, Response: models.IssuedWebhookSecret{}},
+		{Method: http.MethodGet, Path: "/webhooks/subscriptions/{subscriptionID}", OperationID: "findSubscriptionByID", Tag: "webhooks", Summary: "Get a webhook subscription", Scopes: admin, Authenticated: true, Response: models.WebhookSubscription{}, Conditional: true},
+		{Method: http.MethodPut, Path: "/webhooks/subscriptions/{subscriptionID}", OperationID: "updateSubscriptionByID", Tag: "webhooks", Summary: "Update a webhook subscription", Scopes: admin, Authenticated: true, Request: dtos.WebhookSubscriptionDto{}, Response: models.WebhookSubscription{}, Conditional: true},
+		{Method: http.MethodDelete, Path: "/webhooks/subscriptions/{subscriptionID}", OperationID: "deleteSubscriptionByID", Tag: "webhooks", Summary: "Delete a webhook subscription", Scopes: admin, Authenticated: true, Status: http.StatusNoContent, Conditional: true},
+		{Method: http.MethodPost, Path: "/webhooks/subscriptions/{subscriptionID}/rotate-secret", OperationID: "rotateSecret", Tag: "webhooks", Summary: "Issue a new signing secret", Scopes: admin, Authenticated: true, Response: models.IssuedWebhookSecret{}, Conditional: true},
+		{
+			Method: http.MethodGet, Path: "/webhooks/deliveries", OperationID: "findDeliveries", Tag: "webhooks", Summary: "Search the webhook deliveries",
+			Scopes: admin, Authenticated: true,
+			Query: []openapi.Parameter{
+				queryParam("subscriptionId", "ID of the subscription", stringSchema()),
+				queryParam("eventId", "ID of the delivered event", stringSchema()),
+				queryParam("status", "Status of the delivery", &openapi.Schema{Type: "string", Enum: []string{string(enums.DeliveryPending), string(enums.DeliveryRetrying), string(enums.DeliverySucceeded), string(enums.DeliveryDead)}}),
+				queryParam("limit", "Number of deliveries", &openapi.Schema{Type: "integer", Format: "int32"}),
+				queryParam("skip", "Number of deliveries skipped", &openapi.Schema{Type: "integer", Format: "int32"}),
+			},
+			Response: []models.WebhookDelivery{}, Errors: []int{http.StatusBadRequest}, Conditional: true,
+		},
+		{Method: http.MethodGet, Path: "/webhooks/deliveries/{deliveryID}", OperationID: "findDeliveryByID", Tag: "webhooks", Summary: "Get a webhook delivery", Scopes: admin, Authenticated: true, Response: models.WebhookDelivery{}},
+		{Method: http.MethodPost, Path: "/webhooks/deliveries/{deliveryID}/replay", OperationID: "replayDelivery", Tag: "webhooks", Summary: "Deliver a webhook again", Scopes: admin, Authenticated: true, Status: http.StatusAccepted, Response: models.WebhookDelivery{}},
+		{Method: http.MethodPost, Path: "/webhooks/events/{eventID}/replay", OperationID: "replayEvent", Tag: "webhooks", Summary: "Deliver an event again to every matching subscription", Scopes: admin, Authenticated: true, Status: http.StatusAccepted, Response: models.OutboxEvent{}},
+	}
+}
+
+func eventRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/events", OperationID: "streamEvents", Tag: "events", Summary: "Stream the changes as Server-Sent Events",
+			Description: "Each event carries an opaque position in the stream as id, the event type as event and a DomainEvent as JSON data. " +
+				"A stream resumed after a position sends the events committed after it, an event may be sent again after a reconnection. " +
+				"Only the events of the resources the caller can read are sent, a comment is sent as heartbeat every 15 seconds.",
+			Query: []openapi.Parameter{
+				queryParam("resourceType", "Resource types to stream, repeatable or comma separated", stringSchema()),
+				queryParam("resourceId", "Resource IDs to stream, repeatable or comma separated", stringSchema()),
+				queryParam("lastEventId", "Resume after this event, the Last-Event-ID header takes precedence", stringSchema()),
+			},
+			Response: "", ContentType: "text/event-stream", Errors: []int{http.StatusBadRequest, http.StatusConflict},
+		},
+	}
+}
+
+func graphQLRoutes() []openapi.Route {
+	description := "The schema is served by introspection. The fields need the read scopes of the matching REST routes and the mutations their write scopes, " +
+		"the errors of the fields are answered in the errors of the response with the status and the code of the REST errors as extensions. " +
+		"The root lists are connections paginated with first and after, at most 100 nodes a page."
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/graphql", OperationID: "graphQLQuery", Tag: "graphql", Summary: "Run a GraphQL query",
+			Description: description + " Mutations are rejected, send them with POST.",
+			Query: []openapi.Parameter{
+				queryParam("query", "GraphQL document", stringSchema()),
+				queryParam("operationName", "Operation of the document to run", stringSchema()),
+				queryParam("variables", "Variables of the operation as a JSON object", stringSchema()),
+			},
+			Response: map[string]interface{}{},
+		},
+		{
+			Method: http.MethodPost, Path: "/graphql", OperationID: "graphQLOperation", Tag: "graphql", Summary: "Run a GraphQL query or mutation",
+			Description: description, Request: GraphQLRequest{}, Response: map[string]interface{}{},
+		},
+	}
+}
+
+func operationRoutes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/healthz", OperationID: "liveness", Tag: "operations", Summary: "Liveness probe", Response: map[string]string{}},
+		{Method: http.MethodGet, Path: "/readyz", OperationID: "readiness", Tag: "operations", Summary: "Readiness probe, answers 503 with the failing checks", Response: health.Report{}, Errors: []int{http.StatusServiceUnavailable}},
+		{Method: http.MethodGet, Path: "/metrics", OperationID: "metrics", Tag: "operations", Summary: "Prometheus metrics", Response: "", ContentType: "text/plain"},
+		{Method: http.MethodGet, Path: "/openapi.json", OperationID: "openAPI", Tag: "operations", Summary: "This OpenAPI document", Response: map[string]interface{}{}},
+		{Method: http.MethodGet, Path: "/docs", OperationID: "docs", Tag: "operations", Summary: "Interactive documentation of the API", Response: "", ContentType: "text/html"},
+	}
+}
+
+func scopeNames(scopes ...enums.EnumScope) []string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return names
+}
+
+func queryParam(name string, description string, schema *openapi.Schema) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func stringSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string"}
+}
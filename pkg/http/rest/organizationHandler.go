@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+)
+
+// OrganizationHandler return a handler for the Rest API of the organizations, their members and
+// their invitations. The callers only see the organizations they are members of.
+type OrganizationHandler struct {
+	Service     *services.OrganizationService
+	Invitations *services.InvitationService
+}
+
+// NewRouter export a router configured with the organization routes
+func (h *OrganizationHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+	read := RequireScopes(enums.OrganizationsRead)
+	write := RequireScopes(enums.OrganizationsWrite)
+
+	r.With(read).Method(http.MethodGet, "/", rootHandler(h.findOrganizations))
+	r.With(write).Method(http.MethodPost, "/", rootHandler(h.createOrganization))
+
+	// Subroutes:
+	r.Route("/{organizationID}", func(r chi.Router) {
+		r.With(read).Method(http.MethodGet, "/", rootHandler(h.findOrganizationByID))
+		r.With(write).Method(http.MethodPut, "/", rootHandler(h.updateOrganizationByID))
+		r.With(write).Method(http.MethodDelete, "/", rootHandler(h.deleteOrganizationByID))
+
+		r.With(write).Method(http.MethodPost, "/members", rootHandler(h.addMember))
+		r.With(write).Method(http.MethodPut, "/members/{memberID}", rootHandler(h.updateMember))
+		r.With(write).Method(http.MethodDelete, "/members/{memberID}", rootHandler(h.removeMember))
+
+		r.With(write).Method(http.MethodGet, "/invitations", rootHandler(h.findInvitations))
+		r.With(write).Method(http.MethodPost, "/invitations", rootHandler(h.createInvitation))
+		r.With(write).Method(http.MethodDelete, "/invitations/{invitationID}", rootHandler(h.revokeInvitation))
+	})
+
+	return r
+}
+
+func (h *OrganizationHandler) findOrganizations(w http.ResponseWriter, r *http.Request) error {
+	results, err := h.Service.FindOrganizations(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *OrganizationHandler) createOrganization(w http.ResponseWriter, r *http.Request) error {
+	var payload dtos.OrganizationDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	organization, err := h.Service.CreateOrganization(r.Context(), &payload)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", entityETag(organization.ID, organization.Version))
+	return writeJSON(w, http.StatusCreated, organization)
+}
+
+func (h *OrganizationHandler) findOrganizationByID(w http.ResponseWriter, r *http.Request) error {
+	organizationID := chi.URLParam(r, "organizationID")
+	organization, err := h.Service.FindOrganizationByID(r.Context(), organizationID)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(organization.ID, organization.Version), organization)
+}
+
+func (h *OrganizationHandler) updateOrganizationByID(w http.ResponseWriter, r *http.Request) error {
+	organizationID := chi.URLParam(r, "organizationID")
+	versions, err := ifMatchVersions(r, organizationID)
+	if err != nil {
+		return err
+	}
+	var payload dtos.OrganizationDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	organization, err := h.Service.UpdateOrganization(r.Context(), organizationID, &payload, versions)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(organization.ID, organization.Version), organization)
+}
+
+func (h *OrganizationHandler) deleteOrganizationByID(w http.ResponseWriter, r *http.Request) error {
+	organizationID := chi.URLParam(r, "organizationID")
+	versions, err := ifMatchVersions(r, organizationID)
+	if err != nil {
+		return err
+	}
+	if _, err := h.Service.DeleteOrganization(r.Context(), organizationID, versions); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// addMember adds a user or an API client to an organization, the members write the organization
+// so they are conditioned on its ETag
+func (h *OrganizationHandler) addMember(w http.ResponseWriter, r *http.Request) error {
+	organizationID := chi.URLParam(r, "organizationID")
+	versions, err := ifMatchVersions(r, organizationID)
+	if err != nil {
+		return err
+	}
+	var payload dtos.MembershipDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	organization, err := h.Service.AddMember(r.Context(), organizationID, &payload, versions)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(organization.ID, organization.Version), organization)
+}
+
+func (h *OrganizationHandler) updateMember(w http.ResponseWriter, r *http.Request) error {
+	organizationID := chi.URLParam(r, "organizationID")
+	versions, err := ifMatchVersions(r, organizationID)
+	if err != nil {
+		return err
+	}
+	var payload dtos.MemberRoleDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	organization, err := h.Service.UpdateMember(r.Context(), organizationID, chi.URLParam(r, "memberID"), &payload, versions)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(organization.ID, organization.Version), organization)
+}
+
+func (h *OrganizationHandler) removeMember(w http.ResponseWriter, r *http.Request) error {
+	organizationID := chi.URLParam(r, "organizationID")
+	versions, err := ifMatchVersions(r, organizationID)
+	if err != nil {
+		return err
+	}
+
+	organization, err := h.Service.RemoveMember(r.Context(), organizationID, chi.URLParam(r, "memberID"), versions)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(organization.ID, organization.Version), organization)
+}
+
+func (h *OrganizationHandler) findInvitations(w http.ResponseWriter, r *http.Request) error {
+	results, err := h.Invitations.FindInvitations(r.Context(), chi.URLParam(r, "organizationID"))
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *OrganizationHandler) createInvitation(w http.ResponseWriter, r *http.Request) error {
+	var payload dtos.InvitationDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	issued, err := h.Invitations.CreateInvitation(r.Context(), chi.URLParam(r, "organizationID"), &payload)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusCreated, issued)
+}
+
+func (h *OrganizationHandler) revokeInvitation(w http.ResponseWriter, r *http.Request) error {
+	organizationID, invitationID := chi.URLParam(r, "organizationID"), chi.URLParam(r, "invitationID")
+	if _, err := h.Invitations.RevokeInvitation(r.Context(), organizationID, invitationID); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
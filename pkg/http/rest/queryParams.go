@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// parsePaging reads the limit and skip query parameters of a search, limit defaults to
+// defaultLimit and cannot exceed maxLimit
+func parsePaging(values url.Values, defaultLimit int64, maxLimit int64) (int64, int64, error) {
+	limit, skip := defaultLimit, int64(0)
+	var err error
+	if value := values.Get("limit"); value != "" {
+		if limit, err = strconv.ParseInt(value, 10, 64); err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, errInvalidParam("limit")
+		}
+	}
+	if value := values.Get("skip"); value != "" {
+		if skip, err = strconv.ParseInt(value, 10, 64); err != nil || skip < 0 {
+			return 0, 0, errInvalidParam("skip")
+		}
+	}
+	return limit, skip, nil
+}
+
+// parseTimeParam reads an optional RFC 3339 timestamp from the query string
+func parseTimeParam(values url.Values, name string) (*time.Time, error) {
+	value := values.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errInvalidParam(name)
+	}
+	return &t, nil
+}
+
+func errInvalidParam(name string) error {
+	return errors.Errorf("invalid query parameter %s", name)
+}
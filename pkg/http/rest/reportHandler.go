@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+)
+
+// ReportHandler return a handler for the Rest API of the reports, they are scoped to the
+// organization the caller acts for
+type ReportHandler struct {
+	Service *services.ReportService
+}
+
+// NewRouter export a router configured with the report routes
+func (h *ReportHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(RequireScopes(enums.CropsRead)).Method(http.MethodGet, "/harvest", rootHandler(h.harvestReport))
+
+	return r
+}
+
+// harvestReport counts the crops harvested between the from and to query parameters, RFC 3339
+// timestamps, the current year by default
+func (h *ReportHandler) harvestReport(w http.ResponseWriter, r *http.Request) error {
+	values := r.URL.Query()
+	year := time.Date(time.Now().Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	from, to := year, year.AddDate(1, 0, 0)
+	if t, err := parseTimeParam(values, "from"); err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	} else if t != nil {
+		from = *t
+	}
+	if t, err := parseTimeParam(values, "to"); err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	} else if t != nil {
+		to = *t
+	}
+
+	report, err := h.Service.HarvestReport(r.Context(), from, to)
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, report)
+}
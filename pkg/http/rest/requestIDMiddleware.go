@@ -0,0 +1,24 @@
+package rest
+
+import (
+	"net/http"
+
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi/middleware"
+)
+
+// RequestID is a middleware that hands the ID assigned to the request by chi's RequestID
+// middleware over to the services, so that the audit trail and the logs can tie entries to a
+// request. Clients and gateways may pick the ID by sending an X-Request-Id header.
+// The ID is echoed back in the X-Request-Id response header.
+func RequestID(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		requestID := middleware.GetReqID(r.Context())
+		if requestID != "" {
+			w.Header().Set("X-Request-Id", requestID)
+			r = r.WithContext(services.WithRequestID(r.Context(), requestID))
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// RequestLogger is a middleware that gives every request a log entry tagged with its request ID,
+// writes an access log line once the request is served and recovers from panics, answering 500.
+// It must run after RequestID.
+func RequestLogger(logger *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := logger.WithFields(logrus.Fields{
+				"requestId":  services.RequestIDFromContext(r.Context()),
+				"method":     r.Method,
+				"path":       r.URL.Path,
+				"remoteAddr": r.RemoteAddr,
+				"userAgent":  r.UserAgent(),
+			})
+			ctx := logging.NewContext(r.Context(), entry)
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					if rvr == http.ErrAbortHandler {
+						panic(rvr)
+					}
+					logging.FromContext(ctx).
+						WithField("stack", string(debug.Stack())).
+						Error(fmt.Sprintf("Panic serving the request: %v", rvr))
+					if ww.Status() == 0 {
+						ww.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+
+				fields := logrus.Fields{
+					"status":     ww.Status(),
+					"bytes":      ww.BytesWritten(),
+					"durationMs": float64(time.Since(start).Nanoseconds()) / 1e6,
+				}
+				if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+					fields["route"] = routeContext.RoutePattern()
+				}
+				logging.FromContext(ctx).WithFields(fields).Info("Request served")
+			}()
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
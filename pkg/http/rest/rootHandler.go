@@ -1,8 +1,13 @@
 package rest
 
 import (
-	"log"
 	"net/http"
+	"strconv"
+
+	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/sirupsen/logrus"
 )
 
 // Use rootHandler as wrapper around handler functions
@@ -14,17 +19,38 @@ func (fn rootHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Error handling
-	log.Println(err)
-
+	logger := logging.FromContext(r.Context())
 	clientError, ok := err.(ClientError)
 	if !ok {
-		// If the error is not ClientError, assume that it is ServerError.
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		// The errors returned by the services are mapped from their kind, the others are server errors
+		err = newDomainError(err)
+		clientError = err.(ClientError)
+	}
+	if apiError, ok := err.(*APIError); ok {
+		apiError.RequestID = services.RequestIDFromContext(r.Context())
+		metrics.APIErrors.WithLabelValues(strconv.Itoa(apiError.Code)).Inc()
+		logger = logger.WithFields(logrus.Fields{"status": apiError.Status, "code": apiError.Code})
+		if apiError.Cause != nil {
+			logger = logger.WithField("cause", apiError.Cause.Error())
+		}
+		if apiError.Status >= http.StatusInternalServerError {
+			logger.Error(apiError.Message)
+		} else {
+			logger.Info(apiError.Message)
+		}
+	} else {
+		status, _ := clientError.ResponseHeaders()
+		metrics.APIErrors.WithLabelValues(strconv.Itoa(status)).Inc()
+		logger.WithError(err).Info("Request rejected")
+	}
+
+	// The message is logged in English and answered in the language of the caller
+	if apiError, ok := err.(*APIError); ok {
+		localizeError(requestLocales(w, r), apiError)
 	}
 	body, err := clientError.ResponseBody()
 	if err != nil {
-		log.Printf("An error ocurred: %+v", err)
+		logger.WithError(err).Error("Error encoding an error response")
 		w.WriteHeader(500)
 		return
 	}
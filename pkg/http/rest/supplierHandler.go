@@ -3,8 +3,11 @@ package rest
 import (
 	"encoding/json"
 	"net/http"
+	"reflect"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
@@ -18,32 +21,37 @@ type SupplierHandler struct {
 func (h *SupplierHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
-	r.Method(http.MethodGet, "/", rootHandler(h.findAllSuppliers))
-	r.Method(http.MethodPost, "/", rootHandler(h.createSupplier))
+	r.With(RestrictScopes(enums.SuppliersRead)).Method(http.MethodGet, "/", rootHandler(h.findAllSuppliers))
+	r.With(RequireScopes(enums.SuppliersWrite)).Method(http.MethodPost, "/", rootHandler(h.createSupplier))
 
 	// Subroutes:
 	r.Route("/{supplierID}", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findSupplierByID))
-		r.Method(http.MethodPut, "/", rootHandler(h.updateSupplierByID))
-		r.Method(http.MethodDelete, "/", rootHandler(h.deleteSupplierByID))
+		r.With(RestrictScopes(enums.SuppliersRead)).Method(http.MethodGet, "/", rootHandler(h.findSupplierByID))
+		r.With(RequireScopes(enums.SuppliersWrite)).Method(http.MethodPut, "/", rootHandler(h.updateSupplierByID))
+		r.With(RequireScopes(enums.SuppliersWrite)).Method(http.MethodDelete, "/", rootHandler(h.deleteSupplierByID))
 	})
 
 	return r
 }
 
 func (h *SupplierHandler) findAllSuppliers(w http.ResponseWriter, r *http.Request) error {
-	suppliers, err := h.Service.FindAllSuppliers()
+	// The exports are streamed from the database instead of being read at once
+	format, err := exportFormat(r)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+	if format != formatJSON {
+		return respondWithExport(w, r, format, reflect.TypeOf(models.Supplier{}), func(write func(interface{}) error) error {
+			return h.Service.EachSupplier(r.Context(), func(supplier *models.Supplier) error { return write(supplier) })
+		})
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(suppliers); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	suppliers, err := h.Service.FindAllSuppliers()
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return respondWithCollection(w, r, suppliers)
 }
 
 func (h *SupplierHandler) createSupplier(w http.ResponseWriter, r *http.Request) error {
@@ -52,70 +60,51 @@ func (h *SupplierHandler) createSupplier(w http.ResponseWriter, r *http.Request)
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	supplier, err := h.Service.CreateSupplier(&payload)
+	supplier, err := h.Service.CreateSupplier(r.Context(), &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(supplier.ID, supplier.Version), supplier)
 }
 
 func (h *SupplierHandler) findSupplierByID(w http.ResponseWriter, r *http.Request) error {
 	supplierID := chi.URLParam(r, "supplierID")
 	supplier, err := h.Service.PopulateSupplierByID(supplierID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if supplier == nil {
-		return NewNotFoundError(nil, "Supplier Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(supplier.ID, supplier.Version), supplier)
 }
 
 func (h *SupplierHandler) updateSupplierByID(w http.ResponseWriter, r *http.Request) error {
 	supplierID := chi.URLParam(r, "supplierID")
+	versions, err := ifMatchVersions(r, supplierID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.SupplierDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	supplier, err := h.Service.UpdateSupplierByID(supplierID, &payload)
+	supplier, err := h.Service.UpdateSupplierByID(r.Context(), supplierID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if supplier == nil {
-		return NewNotFoundError(nil, "Supplier Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(supplier.ID, supplier.Version), supplier)
 }
 
 func (h *SupplierHandler) deleteSupplierByID(w http.ResponseWriter, r *http.Request) error {
 	supplierID := chi.URLParam(r, "supplierID")
-	result, err := h.Service.DeleteSupplier(supplierID)
+	versions, err := ifMatchVersions(r, supplierID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "Supplier Not Found")
+	if _, err := h.Service.DeleteSupplier(r.Context(), supplierID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
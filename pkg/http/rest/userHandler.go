@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
@@ -18,14 +19,15 @@ type UserHandler struct {
 func (h *UserHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
-	r.Method(http.MethodGet, "/", rootHandler(h.findAllUsers))
-	r.Method(http.MethodPost, "/", rootHandler(h.signup))
+	r.With(RestrictScopes(enums.UsersRead)).Method(http.MethodGet, "/", rootHandler(h.findAllUsers))
+	// The people sign up by themselves, the API clients signing users up need the write scope
+	r.With(RestrictScopes(enums.UsersWrite)).Method(http.MethodPost, "/", rootHandler(h.signup))
 
 	// Subroutes:
 	r.Route("/{userID}", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findUserByID))
-		r.Method(http.MethodPut, "/", rootHandler(h.updateUserByID))
-		r.Method(http.MethodDelete, "/", rootHandler(h.deleteUserByID))
+		r.With(RestrictScopes(enums.UsersRead)).Method(http.MethodGet, "/", rootHandler(h.findUserByID))
+		r.With(RequireScopes(enums.UsersWrite)).Method(http.MethodPut, "/", rootHandler(h.updateUserByID))
+		r.With(RequireScopes(enums.UsersWrite)).Method(http.MethodDelete, "/", rootHandler(h.deleteUserByID))
 	})
 
 	return r
@@ -34,16 +36,10 @@ func (h *UserHandler) NewRouter() chi.Router {
 func (h *UserHandler) findAllUsers(w http.ResponseWriter, r *http.Request) error {
 	users, err := h.Service.FindAllUsers()
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(users); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	return nil
+	return respondWithCollection(w, r, users)
 }
 
 func (h *UserHandler) signup(w http.ResponseWriter, r *http.Request) error {
@@ -52,70 +48,51 @@ func (h *UserHandler) signup(w http.ResponseWriter, r *http.Request) error {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	user, err := h.Service.Signup(&payload)
+	user, err := h.Service.Signup(r.Context(), &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(user); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(user.ID, user.Version), user)
 }
 
 func (h *UserHandler) findUserByID(w http.ResponseWriter, r *http.Request) error {
 	userID := chi.URLParam(r, "userID")
 	user, err := h.Service.PopulateUserByID(userID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if user == nil {
-		return NewNotFoundError(nil, "User Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(user); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(user.ID, user.Version), user)
 }
 
 func (h *UserHandler) updateUserByID(w http.ResponseWriter, r *http.Request) error {
 	userID := chi.URLParam(r, "userID")
+	versions, err := ifMatchVersions(r, userID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.UserDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	user, err := h.Service.UpdateUserByID(userID, &payload)
+	user, err := h.Service.UpdateUserByID(r.Context(), userID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	if user == nil {
-		return NewNotFoundError(nil, "User Not Found")
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(user); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return respondWithEntity(w, r, entityETag(user.ID, user.Version), user)
 }
 
 func (h *UserHandler) deleteUserByID(w http.ResponseWriter, r *http.Request) error {
 	userID := chi.URLParam(r, "userID")
-	result, err := h.Service.DeleteUser(userID)
+	versions, err := ifMatchVersions(r, userID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "User Not Found")
+	if _, err := h.Service.DeleteUser(r.Context(), userID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
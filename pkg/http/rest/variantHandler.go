@@ -5,46 +5,57 @@ import (
 	"net/http"
 
 	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
 	"futuagro.com/pkg/domain/services"
 	"github.com/go-chi/chi"
 )
 
-// VariantHandler return a handler for the Rest API of a item
+// VariantHandler return a handler for the Rest API of a item, the variants are answered with their
+// name in the locale the caller asks for
 type VariantHandler struct {
 	Service *services.VariantService
+	// DefaultLocale is the locale of the stored names of the variants
+	DefaultLocale string
 }
 
 // NewRouter export a router configured with a supplier's routes
 func (h *VariantHandler) NewRouter() chi.Router {
 	r := chi.NewRouter()
 
-	r.Method(http.MethodPost, "/", rootHandler(h.createVariant))
-	r.Method(http.MethodGet, "/", rootHandler(h.findVariantsByItemID))
+	r.With(RequireScopes(enums.ItemsWrite)).Method(http.MethodPost, "/", rootHandler(h.createVariant))
+	r.With(RestrictScopes(enums.ItemsRead)).Method(http.MethodGet, "/", rootHandler(h.findVariantsByItemID))
 
 	// Subroutes:
 	r.Route("/{variantID}", func(r chi.Router) {
-		r.Method(http.MethodGet, "/", rootHandler(h.findOneVariantByItemID))
-		r.Method(http.MethodPut, "/", rootHandler(h.updateVariant))
-		r.Method(http.MethodDelete, "/", rootHandler(h.deleteVariant))
+		r.With(RestrictScopes(enums.ItemsRead)).Method(http.MethodGet, "/", rootHandler(h.findOneVariantByItemID))
+		r.With(RequireScopes(enums.ItemsWrite)).Method(http.MethodPut, "/", rootHandler(h.updateVariant))
+		r.With(RequireScopes(enums.ItemsWrite)).Method(http.MethodDelete, "/", rootHandler(h.deleteVariant))
 	})
 
 	return r
 }
 
+// findVariantsByItemID lists the variants of an item, or only those matching the q query parameter
+// by any of their names or synonyms
 func (h *VariantHandler) findVariantsByItemID(w http.ResponseWriter, r *http.Request) error {
 	itemID := chi.URLParam(r, "itemID")
-	variants, err := h.Service.FindVariantsByItemID(itemID)
+	var variants []*models.Variant
+	var err error
+	if term := r.URL.Query().Get("q"); term != "" {
+		variants, err = h.Service.SearchVariants(itemID, term)
+	} else {
+		variants, err = h.Service.FindVariantsByItemID(itemID)
+	}
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(variants); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	locales := requestLocales(w, r)
+	for _, variant := range variants {
+		variant.Localize(locales, h.DefaultLocale)
 	}
-
-	return nil
+	return respondWithCollection(w, r, variants)
 }
 
 func (h *VariantHandler) createVariant(w http.ResponseWriter, r *http.Request) error {
@@ -54,22 +65,17 @@ func (h *VariantHandler) createVariant(w http.ResponseWriter, r *http.Request) e
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	result, err := h.Service.CreateVariant(itemID, &payload)
+	result, err := h.Service.CreateVariant(r.Context(), itemID, &payload)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
 	supplier, err := h.Service.FindVariantByID(result)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return h.respondWithVariant(w, r, supplier)
 }
 
 func (h *VariantHandler) findOneVariantByItemID(w http.ResponseWriter, r *http.Request) error {
@@ -77,55 +83,41 @@ func (h *VariantHandler) findOneVariantByItemID(w http.ResponseWriter, r *http.R
 	variantID := chi.URLParam(r, "variantID")
 	variant, err := h.Service.FindOneVariantByItemID(itemID, variantID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if variant == nil {
-		return NewNotFoundError(nil, "Variant Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(variant); err != nil {
-		return NewAPIError(err, 500, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return h.respondWithVariant(w, r, variant)
 }
 
 func (h *VariantHandler) updateVariant(w http.ResponseWriter, r *http.Request) error {
 	itemID := chi.URLParam(r, "itemID")
 	variantID := chi.URLParam(r, "variantID")
+	versions, err := ifMatchVersions(r, variantID)
+	if err != nil {
+		return err
+	}
 	var payload dtos.VariantDto
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
 	}
 
-	supplier, err := h.Service.UpdateVariant(itemID, variantID, &payload)
+	supplier, err := h.Service.UpdateVariant(r.Context(), itemID, variantID, &payload, versions)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-
-	if supplier == nil {
-		return NewNotFoundError(nil, "Variant Not Found")
+		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(supplier); err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-	}
-	return nil
+	return h.respondWithVariant(w, r, supplier)
 }
 
 func (h *VariantHandler) deleteVariant(w http.ResponseWriter, r *http.Request) error {
 	itemID := chi.URLParam(r, "itemID")
 	variantID := chi.URLParam(r, "variantID")
-	result, err := h.Service.DeleteVariant(itemID, variantID)
+	versions, err := ifMatchVersions(r, variantID)
 	if err != nil {
-		return NewAPIError(err, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return err
 	}
-	if result == false {
-		return NewNotFoundError(nil, "Variant Not Found")
+	if _, err := h.Service.DeleteVariant(r.Context(), itemID, variantID, versions); err != nil {
+		return err
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -133,3 +125,10 @@ func (h *VariantHandler) deleteVariant(w http.ResponseWriter, r *http.Request) e
 
 	return nil
 }
+
+// respondWithVariant answers a variant with its name in the locale the caller asks for
+func (h *VariantHandler) respondWithVariant(w http.ResponseWriter, r *http.Request, variant *models.Variant) error {
+	variant.Localize(requestLocales(w, r), h.DefaultLocale)
+	w.Header().Set("Content-Language", variant.Locale)
+	return respondWithEntity(w, r, entityETag(variant.ID, variant.Version), variant)
+}
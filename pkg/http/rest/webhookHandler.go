@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/services"
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultDeliveryLimit = 100
+	maxDeliveryLimit     = 1000
+)
+
+// WebhookHandler return a handler for the Rest API used by administrators to manage webhook
+// subscriptions and inspect or replay their deliveries
+type WebhookHandler struct {
+	Service *services.WebhookService
+}
+
+// NewRouter export a router configured with the webhook routes
+func (h *WebhookHandler) NewRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Use(RequireScopes(enums.WebhooksAdmin))
+
+	r.Route("/subscriptions", func(r chi.Router) {
+		r.Method(http.MethodGet, "/", rootHandler(h.findAllSubscriptions))
+		r.Method(http.MethodPost, "/", rootHandler(h.createSubscription))
+		r.Route("/{subscriptionID}", func(r chi.Router) {
+			r.Method(http.MethodGet, "/", rootHandler(h.findSubscriptionByID))
+			r.Method(http.MethodPut, "/", rootHandler(h.updateSubscriptionByID))
+			r.Method(http.MethodDelete, "/", rootHandler(h.deleteSubscriptionByID))
+			r.Method(http.MethodPost, "/rotate-secret", rootHandler(h.rotateSecret))
+		})
+	})
+
+	r.Route("/deliveries", func(r chi.Router) {
+		r.Method(http.MethodGet, "/", rootHandler(h.findDeliveries))
+		r.Route("/{deliveryID}", func(r chi.Router) {
+			r.Method(http.MethodGet, "/", rootHandler(h.findDeliveryByID))
+			r.Method(http.MethodPost, "/replay", rootHandler(h.replayDelivery))
+		})
+	})
+
+	r.Method(http.MethodPost, "/events/{eventID}/replay", rootHandler(h.replayEvent))
+
+	return r
+}
+
+func (h *WebhookHandler) findAllSubscriptions(w http.ResponseWriter, r *http.Request) error {
+	results, err := h.Service.FindAllSubscriptions()
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *WebhookHandler) createSubscription(w http.ResponseWriter, r *http.Request) error {
+	var payload dtos.WebhookSubscriptionDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	issued, err := h.Service.CreateSubscription(r.Context(), &payload)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", entityETag(issued.Subscription.ID, issued.Subscription.Version))
+	return writeJSON(w, http.StatusCreated, issued)
+}
+
+func (h *WebhookHandler) findSubscriptionByID(w http.ResponseWriter, r *http.Request) error {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	subscription, err := h.Service.FindSubscriptionByID(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(subscription.ID, subscription.Version), subscription)
+}
+
+func (h *WebhookHandler) updateSubscriptionByID(w http.ResponseWriter, r *http.Request) error {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	versions, err := ifMatchVersions(r, subscriptionID)
+	if err != nil {
+		return err
+	}
+	var payload dtos.WebhookSubscriptionDto
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : invalid JSON.")
+	}
+
+	subscription, err := h.Service.UpdateSubscription(r.Context(), subscriptionID, &payload, versions)
+	if err != nil {
+		return err
+	}
+
+	return respondWithEntity(w, r, entityETag(subscription.ID, subscription.Version), subscription)
+}
+
+func (h *WebhookHandler) rotateSecret(w http.ResponseWriter, r *http.Request) error {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	versions, err := ifMatchVersions(r, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	issued, err := h.Service.RotateSecret(r.Context(), subscriptionID, versions)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", entityETag(issued.Subscription.ID, issued.Subscription.Version))
+	return writeJSON(w, http.StatusOK, issued)
+}
+
+func (h *WebhookHandler) deleteSubscriptionByID(w http.ResponseWriter, r *http.Request) error {
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	versions, err := ifMatchVersions(r, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if _, err := h.Service.DeleteSubscription(r.Context(), subscriptionID, versions); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// findDeliveries searches the webhook deliveries, the query string accepts subscriptionId,
+// eventId, status, limit and skip
+func (h *WebhookHandler) findDeliveries(w http.ResponseWriter, r *http.Request) error {
+	values := r.URL.Query()
+	query := &dtos.WebhookDeliveryQueryDto{
+		SubscriptionID: values.Get("subscriptionId"),
+		EventID:        values.Get("eventId"),
+		Status:         enums.EnumDeliveryStatus(values.Get("status")),
+	}
+	if query.Status != "" && !query.Status.IsValid() {
+		return NewAPIError(nil, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+errInvalidParam("status").Error())
+	}
+	var err error
+	if query.Limit, query.Skip, err = parsePaging(values, defaultDeliveryLimit, maxDeliveryLimit); err != nil {
+		return NewAPIError(err, http.StatusBadRequest, http.StatusBadRequest, "Bad request : "+err.Error())
+	}
+
+	results, err := h.Service.FindDeliveries(query)
+	if err != nil {
+		return err
+	}
+
+	return respondWithCollection(w, r, results)
+}
+
+func (h *WebhookHandler) findDeliveryByID(w http.ResponseWriter, r *http.Request) error {
+	deliveryID := chi.URLParam(r, "deliveryID")
+	delivery, err := h.Service.FindDeliveryByID(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, delivery)
+}
+
+func (h *WebhookHandler) replayDelivery(w http.ResponseWriter, r *http.Request) error {
+	deliveryID := chi.URLParam(r, "deliveryID")
+	delivery, err := h.Service.ReplayDelivery(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusAccepted, delivery)
+}
+
+func (h *WebhookHandler) replayEvent(w http.ResponseWriter, r *http.Request) error {
+	eventID := chi.URLParam(r, "eventID")
+	event, err := h.Service.ReplayEvent(eventID)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusAccepted, event)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) error {
+	var encoded bytes.Buffer
+	if err := json.NewEncoder(&encoded).Encode(body); err != nil {
+		return errors.Wrap(err, "Error encoding a response")
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(encoded.Bytes())
+	return nil
+}
@@ -1,42 +1,202 @@
 package http
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/services"
+	"futuagro.com/pkg/health"
+	"futuagro.com/pkg/http/openapi"
 	"futuagro.com/pkg/http/rest"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
 )
 
 // Server holds the dependencies for a HTTP server.
 type Server struct {
-	config          *config.Config
-	supplierService *services.SupplierService
-	countryService  *services.CountryService
-	cityService     *services.CityService
-	itemService     *services.ItemService
-	variantService  *services.VariantService
-	cropService     *services.CropService
-	userService     *services.UserService
-	authService     *services.AuthService
-	router          chi.Router
+	config              *config.Config
+	logger              *logrus.Logger
+	supplierService     *services.SupplierService
+	countryService      *services.CountryService
+	cityService         *services.CityService
+	itemService         *services.ItemService
+	variantService      *services.VariantService
+	cropService         *services.CropService
+	userService         *services.UserService
+	authService         *services.AuthService
+	apiClientService    *services.APIClientService
+	auditService        *services.AuditService
+	webhookService      *services.WebhookService
+	importService       *services.ImportService
+	lookupService       *services.LookupService
+	organizationService *services.OrganizationService
+	invitationService   *services.InvitationService
+	reportService       *services.ReportService
+	exchangeRateService *services.ExchangeRateService
+	eventSource         services.EventSource
+	eventHandler        *rest.EventHandler
+	health              *health.Registry
+	openAPI             *openapi.Document
+	router              *chi.Mux
+	grpcServer          *grpc.Server
+	workers             []func(ctx context.Context)
+	// businessMetrics holds the gauges computed from the services of this server, they are served
+	// along with the collectors of metrics.Registry
+	businessMetrics *prometheus.Registry
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.grpcServer != nil && r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		s.grpcServer.ServeHTTP(w, r)
+		return
+	}
 	s.router.ServeHTTP(w, r)
 }
 
-// Run starts a http server
+// ServeGRPC serves a gRPC server next to the routes: the gRPC calls sent to the HTTP port with
+// HTTP/2, cleartext included, are handed to it, and Run also serves it on server.grpcPort when
+// it is set
+func (s *Server) ServeGRPC(grpcServer *grpc.Server) {
+	s.grpcServer = grpcServer
+}
+
+// RunWorker runs a background task along with the server, Run starts it and stops it on shutdown
+// by cancelling its ctx once the requests in flight are over, then waits for it to return
+func (s *Server) RunWorker(run func(ctx context.Context)) {
+	s.workers = append(s.workers, run)
+}
+
+// Router returns the chi router serving every route of the API
+func (s *Server) Router() *chi.Mux {
+	return s.router
+}
+
+// OpenAPI returns the OpenAPI document served at /openapi.json
+func (s *Server) OpenAPI() *openapi.Document {
+	return s.openAPI
+}
+
+// Run starts a http server and shuts it down gracefully on SIGINT or SIGTERM: the readiness fails
+// first so that load balancers stop routing requests here, then the server stops accepting
+// connections and waits for the requests in flight, and the workers are stopped last
 func (s *Server) Run() {
+	var handler http.Handler = s
+	if s.grpcServer != nil {
+		// The gRPC clients of the internal network speak HTTP/2 without TLS
+		handler = h2c.NewHandler(s, &http2.Server{IdleTimeout: s.config.Server.IdleTimeout})
+	}
 	httpServer := &http.Server{
-		Addr:    ":" + s.config.Port,
-		Handler: s,
+		Addr:              ":" + s.config.Server.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: s.config.Server.ReadHeaderTimeout,
+		IdleTimeout:       s.config.Server.IdleTimeout,
+	}
+	// Shutdown waits for the requests in flight, the event streams last until the client leaves
+	httpServer.RegisterOnShutdown(s.eventHandler.Close)
+
+	if s.grpcServer != nil && s.config.Server.GRPCPort != "" {
+		listener, err := net.Listen("tcp", ":"+s.config.Server.GRPCPort)
+		if err != nil {
+			s.logger.WithError(err).Error("Error listening for gRPC calls")
+			return
+		}
+		go func() {
+			s.logger.WithField("port", s.config.Server.GRPCPort).Info("Listening for gRPC calls")
+			if err := s.grpcServer.Serve(listener); err != nil {
+				s.logger.WithError(err).Error("The gRPC server stopped")
+			}
+		}()
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, run := range s.workers {
+		workers.Add(1)
+		go func(run func(ctx context.Context)) {
+			defer workers.Done()
+			run(workersCtx)
+		}(run)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		sig := <-signals
+
+		s.logger.WithField("signal", sig.String()).Info("Shutting down, the readiness now fails")
+		s.health.SetShuttingDown()
+		time.Sleep(s.config.Server.ShutdownDelay)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			s.logger.WithError(err).Error("Error waiting for the requests in flight")
+		}
+		if s.grpcServer != nil {
+			s.stopGRPC(ctx)
+		}
+		s.stopWorkers(ctx, stopWorkers, &workers)
+	}()
+
+	s.logger.WithField("port", s.config.Server.Port).Info("Listening for HTTP requests")
+	if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		s.logger.WithError(err).Error("The HTTP server stopped")
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
+		defer cancel()
+		s.stopWorkers(ctx, stopWorkers, &workers)
+		return
+	}
+	<-stopped
+	s.logger.Info("The HTTP server stopped")
+}
+
+// stopWorkers cancels the ctx of the workers and waits for them to return until ctx is done
+func (s *Server) stopWorkers(ctx context.Context, cancel context.CancelFunc, workers *sync.WaitGroup) {
+	cancel()
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.logger.Error("Error waiting for the workers to stop")
+	}
+}
+
+// stopGRPC waits for the gRPC calls in flight until ctx is done, then closes their connections
+func (s *Server) stopGRPC(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.logger.Error("Error waiting for the gRPC calls in flight")
+		s.grpcServer.Stop()
 	}
-	httpServer.ListenAndServe()
 }
 
 // AllowOriginFunc Definie which origins our http servers accepts request from
@@ -48,6 +208,7 @@ func AllowOriginFunc(r *http.Request, origin string) bool {
 // NewServer returns a new HTTP server.
 func NewServer(
 	confPtr *config.Config,
+	logger *logrus.Logger,
 	supplierServ *services.SupplierService,
 	countryServ *services.CountryService,
 	cityServ *services.CityService,
@@ -56,57 +217,172 @@ func NewServer(
 	cropServ *services.CropService,
 	userServ *services.UserService,
 	authServ *services.AuthService,
+	apiClientServ *services.APIClientService,
+	auditServ *services.AuditService,
+	webhookServ *services.WebhookService,
+	importServ *services.ImportService,
+	lookupServ *services.LookupService,
+	organizationServ *services.OrganizationService,
+	invitationServ *services.InvitationService,
+	reportServ *services.ReportService,
+	exchangeRateServ *services.ExchangeRateService,
+	eventSource services.EventSource,
+	healthRegistry *health.Registry,
 ) *Server {
 	server := &Server{
-		config:          confPtr,
-		supplierService: supplierServ,
-		countryService:  countryServ,
-		cityService:     cityServ,
-		itemService:     itemServ,
-		variantService:  variantServ,
-		cropService:     cropServ,
-		userService:     userServ,
-		authService:     authServ,
+		config:              confPtr,
+		logger:              logger,
+		supplierService:     supplierServ,
+		countryService:      countryServ,
+		cityService:         cityServ,
+		itemService:         itemServ,
+		variantService:      variantServ,
+		cropService:         cropServ,
+		userService:         userServ,
+		authService:         authServ,
+		apiClientService:    apiClientServ,
+		auditService:        auditServ,
+		webhookService:      webhookServ,
+		importService:       importServ,
+		lookupService:       lookupServ,
+		organizationService: organizationServ,
+		invitationService:   invitationServ,
+		reportService:       reportServ,
+		exchangeRateService: exchangeRateServ,
+		eventSource:         eventSource,
+		health:              healthRegistry,
+		businessMetrics:     prometheus.NewRegistry(),
 	}
 
 	r := chi.NewRouter()
 	// Setup CORS
 	cors := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
+		AllowedOrigins:   confPtr.Server.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"ETag", "Link", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-OAuth-Scopes", "X-Accepted-OAuth-Scopes"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match", "Last-Event-ID", "X-API-Key", "X-Organization-ID"},
+		ExposedHeaders:   []string{"ETag", "Link", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-OAuth-Scopes", "X-Accepted-OAuth-Scopes", "X-Organization-ID", "X-Request-Id"},
 		AllowCredentials: true,
 		MaxAge:           3600, // Maximum value not ignored by any of major browsers
 	})
 
 	r.Use(cors.Handler)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-
-	// Set a timeout value on the request context (ctx), that will signal
-	// through ctx.Done() that the request has timed out and further
-	// processing should be stopped.
-	r.Use(middleware.Timeout(5 * time.Minute))
-
-	rSupplier := rest.SupplierHandler{Service: supplierServ}
-	rCountry := rest.CountryHandler{Service: countryServ}
-	rCity := rest.CityHandler{Service: cityServ}
-	rItem := rest.ItemHandler{Service: itemServ}
-	rVariant := rest.VariantHandler{Service: variantServ}
-	rCrop := rest.CropHandler{Service: cropServ}
-	rUser := rest.UserHandler{Service: userServ}
-	rAuth := rest.AuthHandler{Service: authServ}
-
-	r.Mount("/suppliers", rSupplier.NewRouter())
-	r.Mount("/countries", rCountry.NewRouter())
-	r.Mount("/country-states", rCity.NewRouter())
-	r.Mount("/items", rItem.NewRouter())
-	r.Mount("/items/{itemID}/variants", rVariant.NewRouter())
-	r.Mount("/crops", rCrop.NewRouter())
-	r.Mount("/users", rUser.NewRouter())
-	r.Mount("/auth", rAuth.NewRouter())
+	r.Use(middleware.RequestID)
+	r.Use(rest.RequestID)
+	r.Use(rest.RequestLogger(logger))
+	r.Use(rest.Metrics)
+
+	if confPtr.Server.RequireIfMatch {
+		r.Use(rest.RequireIfMatch)
+	}
+
+	authenticator := rest.Authenticator{Service: apiClientServ, Sessions: authServ, Organizations: organizationServ, AdminKey: confPtr.Auth.AdminAPIKey}
+	r.Use(authenticator.Handler)
+
+	// The event stream lasts as long as the client stays connected, it is mounted apart from the
+	// routes bound by the request timeout
+	server.eventHandler = &rest.EventHandler{Source: eventSource}
+	r.Mount("/events", server.eventHandler.NewRouter())
+
+	r.Group(func(r chi.Router) {
+		// Set a timeout value on the request context (ctx), that will signal
+		// through ctx.Done() that the request has timed out and further
+		// processing should be stopped.
+		r.Use(middleware.Timeout(confPtr.Server.RequestTimeout))
+
+		// The metrics and the probes are requested from inside the network by anonymous callers
+		r.Method(http.MethodGet, "/metrics", promhttp.HandlerFor(prometheus.Gatherers{metrics.Registry, server.businessMetrics}, promhttp.HandlerOpts{}))
+		rHealth := rest.HealthHandler{Registry: healthRegistry}
+		r.Method(http.MethodGet, "/healthz", rHealth.Liveness())
+		r.Method(http.MethodGet, "/readyz", rHealth.Readiness())
+
+		server.openAPI = rest.NewOpenAPISpec().Document()
+		if rOpenAPI, err := rest.NewOpenAPIHandler(server.openAPI); err != nil {
+			logger.WithError(err).Error("Error serving the OpenAPI document")
+		} else {
+			r.Method(http.MethodGet, "/openapi.json", rOpenAPI.Document())
+			r.Method(http.MethodGet, "/docs", rOpenAPI.Docs())
+		}
+		registerBusinessMetrics(server.businessMetrics, cropServ, supplierServ)
+
+		rSupplier := rest.SupplierHandler{Service: supplierServ}
+		rCountry := rest.CountryHandler{Service: countryServ}
+		rCity := rest.CityHandler{Service: cityServ}
+		rItem := rest.ItemHandler{Service: itemServ, DefaultLocale: confPtr.Catalog.DefaultLocale}
+		rVariant := rest.VariantHandler{Service: variantServ, DefaultLocale: confPtr.Catalog.DefaultLocale}
+		rCrop := rest.CropHandler{Service: cropServ}
+		rUser := rest.UserHandler{Service: userServ}
+		rAuth := rest.AuthHandler{Service: authServ}
+		rAPIClient := rest.APIClientHandler{Service: apiClientServ}
+		rAudit := rest.AuditHandler{Service: auditServ}
+		rWebhook := rest.WebhookHandler{Service: webhookServ}
+		rImport := rest.ImportHandler{Service: importServ}
+		rOrganization := rest.OrganizationHandler{Service: organizationServ, Invitations: invitationServ}
+		rInvitation := rest.InvitationHandler{Service: invitationServ}
+		rReport := rest.ReportHandler{Service: reportServ}
+		rExchangeRate := rest.ExchangeRateHandler{Service: exchangeRateServ}
+		rGraphQL := rest.GraphQLHandler{
+			Lookup:    lookupServ,
+			Countries: countryServ,
+			Cities:    cityServ,
+			Items:     itemServ,
+			Variants:  variantServ,
+			Suppliers: supplierServ,
+			Users:     userServ,
+			Crops:     cropServ,
+
+			RequireVersion: confPtr.Server.RequireIfMatch,
+		}
+
+		r.Mount("/suppliers", rSupplier.NewRouter())
+		r.Mount("/countries", rCountry.NewRouter())
+		r.Mount("/country-states", rCity.NewRouter())
+		r.Mount("/items", rItem.NewRouter())
+		r.Mount("/items/{itemID}/variants", rVariant.NewRouter())
+		r.Mount("/crops", rCrop.NewRouter())
+		r.Mount("/users", rUser.NewRouter())
+		r.Mount("/auth", rAuth.NewRouter())
+		r.Mount("/api-clients", rAPIClient.NewRouter())
+		r.Mount("/audit-logs", rAudit.NewRouter())
+		r.Mount("/webhooks", rWebhook.NewRouter())
+		r.Mount("/imports", rImport.NewRouter())
+		r.Mount("/organizations", rOrganization.NewRouter())
+		r.Mount("/invitations", rInvitation.NewRouter())
+		r.Mount("/reports", rReport.NewRouter())
+		r.Mount("/exchange-rates", rExchangeRate.NewRouter())
+		r.Mount("/graphql", rGraphQL.NewRouter())
+	})
+
+	// Every route must be documented, TestRoutesDocumented in server_test.go fails otherwise and the
+	// server only warns about the routes missing
+	if missing, _, err := openapi.Diff(r, server.openAPI); err != nil {
+		logger.WithError(err).Error("Error comparing the routes with the OpenAPI document")
+	} else if len(missing) > 0 {
+		logger.WithField("routes", missing).Warn("Routes missing from the OpenAPI document")
+	}
 
 	server.router = r
 	return server
 }
+
+// registerBusinessMetrics exposes the business gauges on the registry of a server, they are
+// computed at most once a minute
+func registerBusinessMetrics(registry *prometheus.Registry, cropServ *services.CropService, supplierServ *services.SupplierService) {
+	gauges := []struct {
+		name  string
+		help  string
+		value func() (int64, error)
+	}{
+		{"active_crops", "Number of crops that have not been harvested yet.", cropServ.CountActiveCrops},
+		{"active_suppliers", "Number of suppliers whose record status is active.", supplierServ.CountActiveSuppliers},
+	}
+	for _, gauge := range gauges {
+		value := gauge.value
+		err := registry.Register(metrics.NewBusinessGauge(gauge.name, gauge.help, time.Minute, func() (float64, error) {
+			count, err := value()
+			return float64(count), err
+		}))
+		if err != nil {
+			logging.Default().WithError(err).WithField("metric", gauge.name).Warn("Error registering a business metric")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/http/openapi"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+)
+
+// TestRoutesDocumented fails when a route mounted on the router is missing from the OpenAPI
+// document or a documented route is not mounted
+func TestRoutesDocumented(t *testing.T) {
+	conf := config.Defaults()
+	logger, err := logging.New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The routes are only walked, the services behind them are never called
+	server := NewServer(conf, logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	missing, stale, err := openapi.Diff(server.Router(), server.OpenAPI())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, route := range missing {
+		t.Errorf("not documented: %s", route)
+	}
+	for _, route := range stale {
+		t.Errorf("documented but not mounted: %s", route)
+	}
+}
+
+// TestNewServerTwice fails when a server registers its business gauges where a second server
+// cannot register its own
+func TestNewServerTwice(t *testing.T) {
+	conf := config.Defaults()
+	logger, err := logging.New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		server := NewServer(conf, logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		gauge := metrics.NewBusinessGauge("active_crops", "Number of crops that have not been harvested yet.", time.Minute, nil)
+		if err := server.businessMetrics.Register(gauge); err == nil {
+			t.Errorf("server %d: the business gauges are not registered on its registry", i+1)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Package i18n negotiates the locale of the responses, translates the messages of the errors and
+// folds the names of the catalog for searches. Locales are BCP 47 tags such as es, es-AR or en-US.
+package i18n
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ParseLocale returns the canonical form of a locale, es-ar becomes es-AR
+func ParseLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil || tag == language.Und {
+		return "", errors.Errorf("Invalid locale %q", locale)
+	}
+	return tag.String(), nil
+}
+
+// Preferred returns the locales a caller asks for, most preferred first. lang is a comma
+// separated list of locales that takes precedence over acceptLanguage, the value of an
+// Accept-Language header. The invalid locales are ignored.
+func Preferred(lang string, acceptLanguage string) []string {
+	var locales []string
+	for _, value := range strings.Split(lang, ",") {
+		if locale, err := ParseLocale(value); err == nil {
+			locales = append(locales, locale)
+		}
+	}
+	if len(locales) > 0 || acceptLanguage == "" {
+		return locales
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+	for _, tag := range tags {
+		if tag != language.Und {
+			locales = append(locales, tag.String())
+		}
+	}
+	return locales
+}
+
+// Match returns the index of the available locale that best serves the preferred ones, -1 when
+// none does. Each preferred locale is tried in turn: the same locale, then its parents, es-419
+// then es for es-AR, then any other region of its language, es-MX for es-AR.
+func Match(preferred []string, available []string) int {
+	tags := make([]language.Tag, len(available))
+	for i, locale := range available {
+		tags[i], _ = language.Parse(locale)
+	}
+	for _, locale := range preferred {
+		want, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		for parent := want; ; parent = parent.Parent() {
+			for i, tag := range tags {
+				if tag.String() == parent.String() {
+					return i
+				}
+			}
+			if parent.IsRoot() {
+				break
+			}
+		}
+		base, _ := want.Base()
+		for i, tag := range tags {
+			if other, _ := tag.Base(); other == base {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Language returns the language of a locale, es for es-AR
+func Language(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return ""
+	}
+	base, _ := tag.Base()
+	return base.String()
+}
+
+// Fold returns the form of a name compared by the searches, in lower case, without accents and
+// with single spaces, maracuyá and Maracuya fold the same
+func Fold(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		folded = name
+	}
+	return strings.Join(strings.Fields(strings.ToLower(folded)), " ")
+}
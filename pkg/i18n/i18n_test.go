@@ -0,0 +1,75 @@
+package i18n
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []string
+		available []string
+		expected  int
+	}{
+		{"same locale", []string{"es-AR"}, []string{"en", "es-AR"}, 1},
+		{"parent region", []string{"es-AR"}, []string{"es", "es-419"}, 1},
+		{"language", []string{"es-AR"}, []string{"en", "es"}, 1},
+		{"other region of the language", []string{"es-AR"}, []string{"en-US", "es-MX"}, 1},
+		{"parent before other region", []string{"es-AR"}, []string{"es-MX", "es"}, 1},
+		{"first preferred served first", []string{"fr", "en"}, []string{"en", "fr-CA"}, 1},
+		{"next preferred", []string{"fr", "en"}, []string{"es", "en-GB"}, 1},
+		{"invalid preferred skipped", []string{"@@", "en"}, []string{"en"}, 0},
+		{"none", []string{"fr"}, []string{"es", "en"}, -1},
+		{"nothing preferred", nil, []string{"es"}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if index := Match(tt.preferred, tt.available); index != tt.expected {
+				t.Errorf("got %d, expected %d", index, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Maracuyá", "maracuya"},
+		{"maracuya", "maracuya"},
+		{"  Café   de  Colombia ", "cafe de colombia"},
+		{"Ñame", "name"},
+		{"São Paulo", "sao paulo"},
+		{"Pitahaya\tAmarilla", "pitahaya amarilla"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if folded := Fold(tt.name); folded != tt.expected {
+			t.Errorf("Fold(%q): got %q, expected %q", tt.name, folded, tt.expected)
+		}
+	}
+}
+
+func TestPreferred(t *testing.T) {
+	tests := []struct {
+		lang           string
+		acceptLanguage string
+		expected       []string
+	}{
+		{"", "", nil},
+		{"es-ar", "en", []string{"es-AR"}},
+		{"", "en-US,es;q=0.8", []string{"en-US", "es"}},
+		{"@@", "es", []string{"es"}},
+	}
+	for _, tt := range tests {
+		locales := Preferred(tt.lang, tt.acceptLanguage)
+		if len(locales) != len(tt.expected) {
+			t.Errorf("Preferred(%q, %q): got %v, expected %v", tt.lang, tt.acceptLanguage, locales, tt.expected)
+			continue
+		}
+		for i := range locales {
+			if locales[i] != tt.expected[i] {
+				t.Errorf("Preferred(%q, %q): got %v, expected %v", tt.lang, tt.acceptLanguage, locales, tt.expected)
+			}
+		}
+	}
+}
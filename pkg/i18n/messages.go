@@ -0,0 +1,161 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// messagePrefixes introduce the details of the messages, they are translated apart from the
+// details
+var messagePrefixes = []string{
+	"Bad request : ",
+	"Conflict : ",
+	"Forbidden : ",
+	"Precondition Failed : ",
+	"Precondition Required : ",
+	"Unsupported Media Type : ",
+	"Method Not Allowed : ",
+	"missing scope ",
+	"invalid query parameter ",
+	"Invalid ID ",
+}
+
+// messages are the translations of the messages of the errors, of their keys and of their
+// prefixes by language, English is the language of the messages. A message without translation is
+// answered in English.
+var messages = map[string]map[string]string{
+	"es": {
+		"Bad request : ":            "Solicitud inválida : ",
+		"Conflict : ":               "Conflicto : ",
+		"Forbidden : ":              "Prohibido : ",
+		"Precondition Failed : ":    "Precondición fallida : ",
+		"Precondition Required : ":  "Precondición requerida : ",
+		"Unsupported Media Type : ": "Tipo de contenido no soportado : ",
+		"Method Not Allowed : ":     "Método no permitido : ",
+		"missing scope ":            "falta el permiso ",
+		"invalid query parameter ":  "parámetro de consulta inválido ",
+		"Invalid ID ":               "ID inválido ",
+
+		"invalid JSON.":                             "JSON inválido.",
+		"missing query.":                            "falta la consulta.",
+		"invalid variables.":                        "variables inválidas.",
+		"send an If-Match header.":                  "envíe una cabecera If-Match.",
+		"send the version argument.":                "envíe el argumento version.",
+		"send a .csv or .xlsx file.":                "envíe un archivo .csv o .xlsx.",
+		"send mutations with POST.":                 "envíe las mutaciones con POST.",
+		"The resource has been modified":            "El recurso ha sido modificado",
+		"If-Match does not match the resource.":     "If-Match no corresponde al recurso.",
+		"Authentication required. Send an API key.": "Autenticación requerida. Envíe una clave de API.",
+		"Authentication required. Send an API key to act for an organization.": "Autenticación requerida. Envíe una clave de API para actuar por una organización.",
+		"Authentication failed. Invalid API key.":                              "Autenticación fallida. Clave de API inválida.",
+		"Authentication failed. Wrong user or password.":                       "Autenticación fallida. Usuario o contraseña incorrectos.",
+		"Internal Server Error":                                                "Error interno del servidor",
+
+		"API Client Not Found":           "Cliente de API no encontrado",
+		"City Not Found":                 "Ciudad no encontrada",
+		"Country Not Found":              "País no encontrado",
+		"CountryState Not Found":         "Estado no encontrado",
+		"Crop Not Found":                 "Cultivo no encontrado",
+		"Event Not Found":                "Evento no encontrado",
+		"Exchange Rate Not Found":        "Tasa de cambio no encontrada",
+		"Import Job Not Found":           "Importación no encontrada",
+		"Invitation Not Found":           "Invitación no encontrada",
+		"Item Not Found":                 "Producto no encontrado",
+		"Member Not Found":               "Miembro no encontrado",
+		"Organization Not Found":         "Organización no encontrada",
+		"Supplier Not Found":             "Proveedor no encontrado",
+		"User Not Found":                 "Usuario no encontrado",
+		"Variant Not Found":              "Variedad no encontrada",
+		"Webhook Delivery Not Found":     "Entrega de webhook no encontrada",
+		"Webhook Subscription Not Found": "Suscripción de webhook no encontrada",
+
+		"Invalid API client scopes":                                                    "Permisos de cliente de API inválidos",
+		"Invalid invitation email":                                                     "Correo de invitación inválido",
+		"Invalid member role":                                                          "Rol de miembro inválido",
+		"Invalid webhook URL":                                                          "URL de webhook inválida",
+		"Invalid webhook event types":                                                  "Tipos de evento de webhook inválidos",
+		"An exchange rate needs the time it takes effect":                              "Una tasa de cambio necesita la fecha en que entra en vigor",
+		"The base and quote currencies of an exchange rate must differ":                "Las monedas base y cotizada de una tasa de cambio deben ser distintas",
+		"The invitation has expired":                                                   "La invitación ha expirado",
+		"The invitation has already been accepted":                                     "La invitación ya fue aceptada",
+		"The invitation was sent to another email":                                     "La invitación fue enviada a otro correo",
+		"The member already belongs to the organization":                               "El miembro ya pertenece a la organización",
+		"An organization keeps at least one owner":                                     "Una organización conserva al menos un propietario",
+		"Invalid member type, it must be user or api-client":                           "Tipo de miembro inválido, debe ser user o api-client",
+		"Invalid organization, it needs a name and a kind among buyer and cooperative": "Organización inválida, necesita un nombre y un tipo entre buyer y cooperative",
+		"Only the owners and the admins can manage an organization":                    "Solo los propietarios y los administradores pueden gestionar una organización",
+		"Only the owners and the admins can manage the members of an organization":     "Solo los propietarios y los administradores pueden gestionar los miembros de una organización",
+		"Only the owners and the admins can remove the members of an organization":     "Solo los propietarios y los administradores pueden retirar los miembros de una organización",
+		"Only the owners can delete an organization":                                   "Solo los propietarios pueden eliminar una organización",
+		"Only the owners can grant the owner role":                                     "Solo los propietarios pueden otorgar el rol de propietario",
+		"Only the owners can remove an owner":                                          "Solo los propietarios pueden retirar a un propietario",
+		"%s is already in use":                                                         "%s ya está en uso",
+		"%s does not reference an active %s":                                           "%s no referencia un %s activo",
+		"%s is not a valid timestamp":                                                  "%s no es una fecha válida",
+		"Invalid ID %q":                                                                "ID inválido %q",
+		"Invalid import kind %q":                                                       "Tipo de importación inválido %q",
+		"Invalid locale %q of a localized name":                                        "Locale %q inválido en un nombre localizado",
+		"Several localized names have the locale %s":                                   "Varios nombres localizados tienen el locale %s",
+		"The localized name %s has no name":                                            "El nombre localizado %s no tiene nombre",
+		"The role must be %s or %s":                                                    "El rol debe ser %s o %s",
+		"The password must have from %d to %d characters":                              "La contraseña debe tener de %d a %d caracteres",
+		"The %s is referenced by %d %s records, delete them first":                     "El registro %s está referenciado por %d registros %s, elimínelos primero",
+		"not a member of the organization %s":                                          "no es miembro de la organización %s",
+		"the organization %s is inactive":                                              "la organización %s está inactiva",
+		"invalid cursor %s":                                                            "cursor inválido %s",
+		"Only the owners can revoke the owner role":                                    "Solo los propietarios pueden revocar el rol de propietario",
+		"The reports are scoped to an organization, select one with X-Organization-ID": "Los informes se limitan a una organización, seleccione una con X-Organization-ID",
+	},
+}
+
+// Translate formats the message of a key with its arguments in the language that best serves the
+// locales, in English when none of them has a translation of the key
+func Translate(locales []string, key string, args ...interface{}) string {
+	if translated, ok := catalog(locales)[key]; ok {
+		key = translated
+	}
+	if len(args) == 0 {
+		return key
+	}
+	return fmt.Sprintf(key, args...)
+}
+
+// TranslateMessage translates a message into the language that best serves the locales, the whole
+// message or else its prefix and then the rest of it. The message is returned as is when none of
+// them has a translation.
+func TranslateMessage(locales []string, message string) string {
+	translations := catalog(locales)
+	if translations == nil {
+		return message
+	}
+	return translateMessage(translations, message)
+}
+
+func translateMessage(translations map[string]string, message string) string {
+	if translated, ok := translations[message]; ok {
+		return translated
+	}
+	for _, prefix := range messagePrefixes {
+		if strings.HasPrefix(message, prefix) {
+			if translated, ok := translations[prefix]; ok {
+				return translated + translateMessage(translations, strings.TrimPrefix(message, prefix))
+			}
+		}
+	}
+	return message
+}
+
+// catalog returns the translations of the language that best serves the locales, nil for English
+func catalog(locales []string) map[string]string {
+	languages := make([]string, 0, len(messages))
+	for language := range messages {
+		languages = append(languages, language)
+	}
+	// English comes last so that a caller preferring it over the translations gets the messages
+	// as written
+	i := Match(locales, append(languages, "en"))
+	if i < 0 || i == len(languages) {
+		return nil
+	}
+	return messages[languages[i]]
+}
@@ -0,0 +1,93 @@
+// Package logging builds the structured logger of the application and carries the request
+// scoped log entries through contexts.
+package logging
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+
+	"futuagro.com/pkg/config"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	defaultMutex  sync.RWMutex
+	defaultLogger = logrus.New()
+)
+
+// New returns a logger configured with the level and format of the configuration
+func New(confPtr *config.Config) (*logrus.Logger, error) {
+	level, err := logrus.ParseLevel(confPtr.Log.Level)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid log level")
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stdout)
+	logger.SetLevel(level)
+	switch confPtr.Log.Format {
+	case "json":
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		return nil, errors.Errorf("Invalid log format %q", confPtr.Log.Format)
+	}
+	return logger, nil
+}
+
+// SetDefault makes logger the one used where no request is being served, the standard library
+// log package is redirected to it as well
+func SetDefault(logger *logrus.Logger) {
+	defaultMutex.Lock()
+	defaultLogger = logger
+	defaultMutex.Unlock()
+
+	log.SetFlags(0)
+	log.SetOutput(logger.WriterLevel(logrus.InfoLevel))
+}
+
+// Default returns the logger of the application
+func Default() *logrus.Logger {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+	return defaultLogger
+}
+
+type contextKey string
+
+const entryKey contextKey = "logEntry"
+
+// entryHolder lets the layers serving a request add fields to the entry of the request, so
+// that they show up on the access log line written once the request is over
+type entryHolder struct {
+	mutex sync.RWMutex
+	entry *logrus.Entry
+}
+
+// NewContext returns a copy of ctx that carries the log entry of a request
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey, &entryHolder{entry: entry})
+}
+
+// FromContext returns the log entry carried by ctx, or an entry of the default logger
+func FromContext(ctx context.Context) *logrus.Entry {
+	if holder, ok := ctx.Value(entryKey).(*entryHolder); ok {
+		holder.mutex.RLock()
+		defer holder.mutex.RUnlock()
+		return holder.entry
+	}
+	return logrus.NewEntry(Default())
+}
+
+// AddFields adds fields to the log entry carried by ctx, it does nothing when ctx has no entry
+func AddFields(ctx context.Context, fields logrus.Fields) {
+	if holder, ok := ctx.Value(entryKey).(*entryHolder); ok {
+		holder.mutex.Lock()
+		holder.entry = holder.entry.WithFields(fields)
+		holder.mutex.Unlock()
+	}
+}
@@ -0,0 +1,154 @@
+// Package metrics defines the Prometheus collectors of the application and the registry they
+// are exposed from.
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"futuagro.com/pkg/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "futuagro"
+
+// Registry holds every collector of the application, it is served at /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests counts the requests served by route pattern and status
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests served, by method, chi route pattern and status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes the latency of the requests by route pattern and status
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests, by method, chi route pattern and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// APIErrors counts the errors answered to clients by error code
+	APIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "api_errors_total",
+		Help:      "Number of API errors answered, by error code.",
+	}, []string{"code"})
+
+	// MongoOperationDuration observes the time spent in each repository method
+	MongoOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of the repository operations, by repository, method and collection.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 15},
+	}, []string{"repository", "method", "collection"})
+
+	// MongoCommandDuration observes the commands sent to MongoDB by the driver
+	MongoCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "command_duration_seconds",
+		Help:      "Duration of the commands sent to MongoDB, by command and outcome.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 15},
+	}, []string{"command", "outcome"})
+
+	// MongoCommandsInFlight is the number of commands waiting for a reply, each one holds a
+	// connection of the pool
+	MongoCommandsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "commands_in_flight",
+		Help:      "Number of commands sent to MongoDB that are waiting for a reply, each one holds a pooled connection.",
+	})
+
+	// MongoPoolMaxSize is the maximum number of connections of the pool of the client
+	MongoPoolMaxSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_max_size",
+		Help:      "Maximum number of connections per server in the pool of the MongoDB client.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		APIErrors,
+		MongoOperationDuration,
+		MongoCommandDuration,
+		MongoCommandsInFlight,
+		MongoPoolMaxSize,
+	)
+}
+
+// ObserveRequest records a served HTTP request
+func ObserveRequest(method string, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	statusLabel := strconv.Itoa(status)
+	HTTPRequests.WithLabelValues(method, route, statusLabel).Inc()
+	HTTPRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveMongoOperation starts timing a repository method, the returned function records the
+// duration and is meant to be deferred:
+//
+//	defer metrics.ObserveMongoOperation("MongoCropRepository", "FindByID", cropCollection)()
+func ObserveMongoOperation(repository string, method string, collection string) func() {
+	start := time.Now()
+	return func() {
+		MongoOperationDuration.WithLabelValues(repository, method, collection).Observe(time.Since(start).Seconds())
+	}
+}
+
+// NewBusinessGauge returns a collector exposing a business figure computed by value, value is
+// called at most once every ttl so that scrapes do not load the database. It is registered on the
+// registry of the server reading the figures, not on Registry, since each server has its own.
+func NewBusinessGauge(name string, help string, ttl time.Duration, value func() (float64, error)) prometheus.Collector {
+	return &cachedGauge{
+		desc:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, nil, nil),
+		ttl:   ttl,
+		value: value,
+	}
+}
+
+type cachedGauge struct {
+	desc  *prometheus.Desc
+	ttl   time.Duration
+	value func() (float64, error)
+
+	mutex     sync.Mutex
+	last      float64
+	updatedAt time.Time
+}
+
+func (g *cachedGauge) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+func (g *cachedGauge) Collect(ch chan<- prometheus.Metric) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if time.Since(g.updatedAt) >= g.ttl {
+		value, err := g.value()
+		if err != nil {
+			logging.Default().WithError(err).WithField("metric", g.desc.String()).Error("Error computing a business metric")
+			ch <- prometheus.NewInvalidMetric(g.desc, err)
+			return
+		}
+		g.last, g.updatedAt = value, time.Now()
+	}
+	ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, g.last)
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// NewMongoCommandMonitor returns a driver command monitor that feeds the MongoDB command metrics
+func NewMongoCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			MongoCommandsInFlight.Inc()
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			MongoCommandsInFlight.Dec()
+			MongoCommandDuration.WithLabelValues(e.CommandName, "success").Observe(time.Duration(e.DurationNanos).Seconds())
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			MongoCommandsInFlight.Dec()
+			MongoCommandDuration.WithLabelValues(e.CommandName, "failure").Observe(time.Duration(e.DurationNanos).Seconds())
+		},
+	}
+}
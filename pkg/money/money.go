@@ -0,0 +1,70 @@
+// Package money validates the ISO 4217 currencies and converts the amounts written in their minor
+// units, cents for USD, with exchange rates written as decimals.
+package money
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// rateFormat is a positive decimal number such as 4012.5 or 0.00025
+var rateFormat = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ParseCurrency returns the ISO 4217 code of an active currency in upper case, cop becomes COP
+func ParseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := currencies[code]; !ok {
+		return "", errors.Errorf("Invalid currency %q, expected an ISO 4217 code such as USD", code)
+	}
+	return code, nil
+}
+
+// MinorUnits returns the number of decimals of a currency, 2 for USD and 0 for JPY
+func MinorUnits(code string) int {
+	if digits, ok := currencies[code]; ok {
+		return digits
+	}
+	return 2
+}
+
+// ParseRate returns the value of an exchange rate written as a positive decimal number
+func ParseRate(rate string) (*big.Rat, error) {
+	rate = strings.TrimSpace(rate)
+	value, ok := new(big.Rat).SetString(rate)
+	if !rateFormat.MatchString(rate) || !ok || value.Sign() <= 0 {
+		return nil, errors.Errorf("Invalid rate %q, expected a positive decimal number such as 4012.5", rate)
+	}
+	return value, nil
+}
+
+// Convert converts an amount in the minor units of the currency from into the minor units of the
+// currency to, one unit of from being worth rate units of to. The result is rounded half away from
+// zero.
+func Convert(amount int64, from string, to string, rate *big.Rat) (int64, error) {
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(MinorUnits(to)-MinorUnits(from)))), nil))
+	if MinorUnits(to) >= MinorUnits(from) {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+	if !quotient.IsInt64() {
+		return 0, errors.Errorf("The conversion of %d %s into %s overflows", amount, from, to)
+	}
+	return quotient.Int64(), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,94 @@
+package money
+
+import "testing"
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		from     string
+		to       string
+		rate     string
+		expected int64
+	}{
+		{"same exponent", 1000, "USD", "EUR", "0.9", 900},
+		{"to more decimals", 1000, "JPY", "USD", "0.0067", 670},
+		{"to fewer decimals", 1000, "USD", "JPY", "149.5", 1495},
+		{"three decimals", 1000, "USD", "KWD", "0.307", 3070},
+		{"rounded down", 1, "USD", "EUR", "0.4", 0},
+		{"half rounded up", 1, "USD", "EUR", "0.5", 1},
+		{"half away from zero", -1, "USD", "EUR", "0.5", -1},
+		{"negative rounded down", -1, "USD", "EUR", "0.4", 0},
+		{"half of a minor unit lost", 5, "USD", "JPY", "1", 0},
+		{"half of a minor unit kept", 50, "USD", "JPY", "1", 1},
+		{"small rate", 100000000, "COP", "USD", "0.00025", 25000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			converted, err := Convert(tt.amount, tt.from, tt.to, rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if converted != tt.expected {
+				t.Errorf("got %d, expected %d", converted, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertOverflow(t *testing.T) {
+	rate, _ := ParseRate("1000000")
+	if _, err := Convert(1<<62, "USD", "EUR", rate); err == nil {
+		t.Error("expected an overflow error")
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	for _, rate := range []string{"4012.5", "0.00025", " 1 "} {
+		if _, err := ParseRate(rate); err != nil {
+			t.Errorf("ParseRate(%q): %v", rate, err)
+		}
+	}
+	for _, rate := range []string{"", "0", "-1", "1e3", "1/3", ".5", "abc"} {
+		if _, err := ParseRate(rate); err == nil {
+			t.Errorf("ParseRate(%q): expected an error", rate)
+		}
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+		invalid  bool
+	}{
+		{"USD", "USD", false},
+		{" cop ", "COP", false},
+		{"ZWG", "ZWG", false},
+		{"DEM", "", true},
+		{"HRK", "", true},
+		{"XAU", "", true},
+		{"XTS", "", true},
+		{"US", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		code, err := ParseCurrency(tt.code)
+		if tt.invalid != (err != nil) || code != tt.expected {
+			t.Errorf("ParseCurrency(%q): got %q, %v", tt.code, code, err)
+		}
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := map[string]int{"USD": 2, "COP": 2, "JPY": 0, "KWD": 3, "CLF": 4, "IRR": 2}
+	for code, expected := range tests {
+		if digits := MinorUnits(code); digits != expected {
+			t.Errorf("MinorUnits(%s): got %d, expected %d", code, digits, expected)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// hexer is implemented by the ObjectIDs, they are written as their hexadecimal string
+type hexer interface {
+	Hex() string
+}
+
+var hexerType = reflect.TypeOf((*hexer)(nil)).Elem()
+
+// zeroer is implemented by the times and the ObjectIDs, their zero values are written empty
+type zeroer interface {
+	IsZero() bool
+}
+
+// Flattener turns the values of a struct into rows of a table. Every scalar field is a column
+// named after its JSON name, the fields of a nested struct, like the populated city of a
+// supplier, are columns named after their path: "city.cityName". Lists of structs and the fields
+// tagged `export:"-"` are left out.
+type Flattener struct {
+	columns []string
+	paths   [][]int
+}
+
+// NewFlattener returns the flattener of a struct type or of a pointer to a struct type
+func NewFlattener(t reflect.Type) *Flattener {
+	f := &Flattener{}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		f.walk(t, "", nil, map[reflect.Type]bool{t: true})
+	}
+	return f
+}
+
+// Columns returns the names of the columns
+func (f *Flattener) Columns() []string {
+	return f.columns
+}
+
+// Row returns the values of the columns for v, a value of the flattened type or a pointer to it.
+// The columns of a nil nested struct are empty.
+func (f *Flattener) Row(v interface{}) []string {
+	values := make([]string, len(f.paths))
+	root := reflect.ValueOf(v)
+	for i, path := range f.paths {
+		if field, ok := fieldByPath(root, path); ok {
+			values[i] = format(field)
+		}
+	}
+	return values
+}
+
+// walk adds the columns of the fields of t, visiting keeps a struct from being nested in itself
+func (f *Flattener) walk(t reflect.Type, prefix string, path []int, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("export") == "-" {
+			continue
+		}
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+		fieldPath := append(append([]int{}, path...), i)
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft == timeType || reflect.PtrTo(ft).Implements(hexerType) || ft.Implements(hexerType):
+		case ft.Kind() == reflect.Struct:
+			if visiting[ft] {
+				continue
+			}
+			visiting[ft] = true
+			nestedPrefix := prefix + name + "."
+			if field.Anonymous {
+				nestedPrefix = prefix
+			}
+			f.walk(ft, nestedPrefix, fieldPath, visiting)
+			delete(visiting, ft)
+			continue
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.String:
+			continue
+		case ft.Kind() == reflect.Map || ft.Kind() == reflect.Interface || ft.Kind() == reflect.Func || ft.Kind() == reflect.Chan:
+			continue
+		}
+		f.columns = append(f.columns, prefix+name)
+		f.paths = append(f.paths, fieldPath)
+	}
+}
+
+func fieldByPath(v reflect.Value, path []int) (reflect.Value, bool) {
+	for _, i := range path {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// format writes the times as RFC 3339 timestamps, the lists of strings separated by semicolons
+// and the other values as JSON
+func format(v reflect.Value) string {
+	if z, ok := v.Interface().(zeroer); ok && z.IsZero() {
+		return ""
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).UTC().Format(time.RFC3339)
+	}
+	if h, ok := v.Interface().(hexer); ok {
+		return h.Hex()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).String()
+		}
+		return strings.Join(values, ";")
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
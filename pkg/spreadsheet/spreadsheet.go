@@ -0,0 +1,173 @@
+// Package spreadsheet reads the tables of CSV and XLSX files.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tealeg/xlsx"
+)
+
+const (
+	// CSV is the format of comma separated values files
+	CSV = "csv"
+	// XLSX is the format of Office Open XML workbooks
+	XLSX = "xlsx"
+)
+
+// Row is a record of a table, its values are indexed like the columns of the table
+type Row struct {
+	// Line is the number of the row in the file counting the header as the line 1, the empty
+	// lines of a CSV file are not counted
+	Line   int
+	Values []string
+}
+
+// Table is the content of a file, its first row names the columns
+type Table struct {
+	Columns []string
+	Rows    []Row
+}
+
+// Value returns the value of a row for the first column whose name matches one of names once
+// normalized, an empty string when the table has none of those columns
+func (t *Table) Value(row Row, names ...string) string {
+	for _, name := range names {
+		for i, column := range t.Columns {
+			if normalize(column) == normalize(name) && i < len(row.Values) {
+				return row.Values[i]
+			}
+		}
+	}
+	return ""
+}
+
+// HasColumn tells whether the table has a column matching one of names once normalized
+func (t *Table) HasColumn(names ...string) bool {
+	for _, name := range names {
+		for _, column := range t.Columns {
+			if normalize(column) == normalize(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalize makes the names of the columns match whatever their case and separators, so that
+// "Document Number", "document_number" and "documentNumber" name the same column
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FormatOf returns the format of a file from its name or its media type, an empty string when
+// neither is known
+func FormatOf(fileName string, mediaType string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return CSV
+	case ".xlsx":
+		return XLSX
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/csv"):
+		return CSV
+	case strings.HasPrefix(mediaType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"):
+		return XLSX
+	}
+	return ""
+}
+
+// Read returns the table of a file, the first sheet of a workbook. The blank rows are skipped
+// and the values are trimmed.
+func Read(r io.Reader, format string) (*Table, error) {
+	var records [][]string
+	switch format {
+	case CSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		var err error
+		if records, err = reader.ReadAll(); err != nil {
+			return nil, errors.Wrap(err, "Error reading the CSV file")
+		}
+	case XLSX:
+		var err error
+		if records, err = readWorkbook(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("Unsupported format %q, expected csv or xlsx", format)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("The file is empty, its first row must name the columns")
+	}
+	table := &Table{}
+	for i, column := range records[0] {
+		column = strings.TrimSpace(column)
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	for i, record := range records[1:] {
+		blank := true
+		for j := range record {
+			record[j] = strings.TrimSpace(record[j])
+			blank = blank && record[j] == ""
+		}
+		if !blank {
+			table.Rows = append(table.Rows, Row{Line: i + 2, Values: record})
+		}
+	}
+	return table, nil
+}
+
+// readWorkbook returns the values of the first sheet of a workbook, the dates are written as
+// YYYY-MM-DD like in CSV files
+func readWorkbook(r io.Reader) ([][]string, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the XLSX file")
+	}
+	file, err := xlsx.OpenReaderAt(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the XLSX file")
+	}
+	if len(file.Sheets) == 0 {
+		return nil, nil
+	}
+
+	var records [][]string
+	for _, row := range file.Sheets[0].Rows {
+		if row == nil {
+			records = append(records, nil)
+			continue
+		}
+		record := make([]string, len(row.Cells))
+		for i, cell := range row.Cells {
+			if cell.IsTime() {
+				if t, err := cell.GetTime(file.Date1904); err == nil {
+					record[i] = t.Format("2006-01-02")
+					continue
+				}
+			}
+			if record[i], err = cell.FormattedValue(); err != nil {
+				record[i] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,78 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/tealeg/xlsx"
+)
+
+// Writer writes the rows of a table one after the other, every row holds a value per column
+type Writer interface {
+	Write(values []string) error
+	// Close flushes the rows written, the file is incomplete until it is closed
+	Close() error
+}
+
+// NewWriter returns a writer of a CSV file or of a workbook of one sheet, the columns are
+// written first
+func NewWriter(w io.Writer, format string, columns []string) (Writer, error) {
+	switch format {
+	case CSV:
+		writer := &csvWriter{writer: csv.NewWriter(w)}
+		if err := writer.Write(columns); err != nil {
+			return nil, err
+		}
+		return writer, nil
+	case XLSX:
+		builder := xlsx.NewStreamFileBuilder(w)
+		if err := builder.AddSheet("Sheet1", columns, nil); err != nil {
+			return nil, errors.Wrap(err, "Error writing the XLSX file")
+		}
+		file, err := builder.Build()
+		if err != nil {
+			return nil, errors.Wrap(err, "Error writing the XLSX file")
+		}
+		return &workbookWriter{file: file}, nil
+	}
+	return nil, errors.Errorf("Unsupported format %q, expected csv or xlsx", format)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvWriter) Write(values []string) error {
+	if err := w.writer.Write(values); err != nil {
+		return errors.Wrap(err, "Error writing the CSV file")
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return errors.Wrap(err, "Error writing the CSV file")
+	}
+	return nil
+}
+
+// workbookWriter streams the rows into the sheet, the workbook is only valid once closed
+type workbookWriter struct {
+	file *xlsx.StreamFile
+}
+
+func (w *workbookWriter) Write(values []string) error {
+	if err := w.file.Write(values); err != nil {
+		return errors.Wrap(err, "Error writing the XLSX file")
+	}
+	return nil
+}
+
+func (w *workbookWriter) Close() error {
+	if err := w.file.Close(); err != nil {
+		return errors.Wrap(err, "Error writing the XLSX file")
+	}
+	return nil
+}
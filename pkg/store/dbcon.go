@@ -2,10 +2,10 @@ package store
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -13,10 +13,12 @@ import (
 
 // NewDB return a mongodb connection
 func NewDB(confPtr *config.Config) (*mongo.Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), confPtr.Database.ConnectTimeout)
 	defer cancel()
 	clientOptions := options.Client().ApplyURI(confPtr.Database.URI)
 	clientOptions.SetMaxPoolSize(confPtr.Database.PoolSize)
+	clientOptions.SetMonitor(metrics.NewMongoCommandMonitor())
+	metrics.MongoPoolMaxSize.Set(float64(confPtr.Database.PoolSize))
 	client, err := mongo.NewClient(clientOptions)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error creating a mongoDB client")
@@ -29,6 +31,6 @@ func NewDB(confPtr *config.Config) (*mongo.Client, error) {
 		return nil, errors.Wrapf(err, "Error connecting (Ping) to MongoDB")
 	}
 
-	fmt.Printf("Connected to database MongoDB :%s \n", confPtr.Database.URI)
+	logging.Default().WithField("database", confPtr.Database.Name).Info("Connected to MongoDB")
 	return client, nil
 }
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"regexp"
+
+	"futuagro.com/pkg/domain/errs"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// duplicateKeyCode is the code of the server errors raised by a write that violates a unique index
+const duplicateKeyCode = 11000
+
+// duplicateKeyIndexPattern extracts the name of the violated index from the message of a duplicate
+// key error: "E11000 duplicate key error collection: futuagro.users index: lemail_unique dup key: ..."
+var duplicateKeyIndexPattern = regexp.MustCompile(`index: (\S+) dup key`)
+
+// parseObjectID parses the hexadecimal ID of a document, a malformed ID is an errs.KindInvalidID
+// error so that callers answer it like a client error
+func parseObjectID(id string) (primitive.ObjectID, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, errs.InvalidID(id, err)
+	}
+	return objID, nil
+}
+
+// writeError wraps the error of a write into collection with message, unless it is the violation
+// of a unique index declared with a field, which is returned as an errs.Duplicate error about it
+func writeError(err error, collection string, message string) error {
+	for _, serverMessage := range duplicateKeyMessages(err) {
+		match := duplicateKeyIndexPattern.FindStringSubmatch(serverMessage)
+		if match == nil {
+			continue
+		}
+		if index, ok := indexByName(collection, match[1]); ok && index.Field != "" {
+			return errs.Duplicate(index.Field, err)
+		}
+	}
+	return errors.Wrap(err, message)
+}
+
+// duplicateKeyMessages returns the messages of the duplicate key errors the driver reports
+func duplicateKeyMessages(err error) []string {
+	var messages []string
+	switch e := err.(type) {
+	case mongo.CommandError:
+		if e.Code == duplicateKeyCode {
+			messages = append(messages, e.Message)
+		}
+	case mongo.WriteException:
+		for _, writeError := range e.WriteErrors {
+			if writeError.Code == duplicateKeyCode {
+				messages = append(messages, writeError.Message)
+			}
+		}
+	case mongo.BulkWriteException:
+		for _, writeError := range e.WriteErrors {
+			if writeError.Code == duplicateKeyCode {
+				messages = append(messages, writeError.Message)
+			}
+		}
+	}
+	return messages
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/health"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// NewPingCheck returns a readiness check verifying that the primary of the database answers
+func NewPingCheck(client *mongo.Client) health.CheckFunc {
+	return func(ctx context.Context) error {
+		if err := client.Ping(ctx, readpref.Primary()); err != nil {
+			return errors.Wrap(err, "Error pinging MongoDB")
+		}
+		return nil
+	}
+}
+
+// NewIndexCheck returns a readiness check verifying that every index declared by the repositories
+// exists, the migrate command creates the missing ones
+func NewIndexCheck(confPtr *config.Config, client *mongo.Client) health.CheckFunc {
+	return func(ctx context.Context) error {
+		missing, err := missingIndexes(ctx, client.Database(confPtr.Database.Name))
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			names := make([]string, len(missing))
+			for i, index := range missing {
+				names[i] = index.Collection + "." + index.Name
+			}
+			return errors.Errorf("Missing indexes: %s", strings.Join(names, ", "))
+		}
+		return nil
+	}
+}
+
+func listIndexNames(ctx context.Context, collection *mongo.Collection) (map[string]bool, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error listing the indexes of %s", collection.Name())
+	}
+	defer cursor.Close(ctx)
+
+	names := map[string]bool{}
+	for cursor.Next(ctx) {
+		var index struct {
+			Name string `bson:"name"`
+		}
+		if err := cursor.Decode(&index); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding an index of %s", collection.Name())
+		}
+		names[index.Name] = true
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrapf(err, "Error listing the indexes of %s", collection.Name())
+	}
+	return names, nil
+}
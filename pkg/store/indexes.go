@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index declares an index a repository relies on, each repository declares its indexes next to
+// its collection and the migrate command creates the missing ones
+type Index struct {
+	Collection string
+	// Name identifies the index in the database, an index is only created when no index of the
+	// collection has that name
+	Name   string
+	Keys   bson.D
+	Unique bool
+	// Partial restricts the index to the documents matching it, like the suppliers that have a
+	// document number
+	Partial bson.D
+	// Expires makes a TTL index of an index on a date, the documents are deleted once that date
+	// is past
+	Expires bool
+	// Field names the attribute a unique index guards, a write that would duplicate it is an
+	// errs.KindConflict error about that field
+	Field string
+}
+
+// Indexes returns the indexes declared by every repository
+func Indexes() []Index {
+	var indexes []Index
+	for _, declared := range [][]Index{
+		userIndexes,
+		supplierIndexes,
+		countryIndexes,
+		cityIndexes,
+		itemIndexes,
+		variantIndexes,
+		cropIndexes,
+		apiClientIndexes,
+		auditIndexes,
+		outboxIndexes,
+		webhookDeliveryIndexes,
+		importJobIndexes,
+		organizationIndexes,
+		invitationIndexes,
+		sessionIndexes,
+		exchangeRateIndexes,
+	} {
+		indexes = append(indexes, declared...)
+	}
+	return indexes
+}
+
+// missingIndexes returns the declared indexes that do not exist in the database
+func missingIndexes(ctx context.Context, database *mongo.Database) ([]Index, error) {
+	existingByCollection := map[string]map[string]bool{}
+	var missing []Index
+	for _, index := range Indexes() {
+		existing, ok := existingByCollection[index.Collection]
+		if !ok {
+			var err error
+			if existing, err = listIndexNames(ctx, database.Collection(index.Collection)); err != nil {
+				return nil, err
+			}
+			existingByCollection[index.Collection] = existing
+		}
+		if !existing[index.Name] {
+			missing = append(missing, index)
+		}
+	}
+	sort.SliceStable(missing, func(i, j int) bool { return missing[i].Collection < missing[j].Collection })
+	return missing, nil
+}
+
+// createIndex creates an index, MongoDB does nothing when an identical index already exists
+func createIndex(ctx context.Context, database *mongo.Database, index Index) error {
+	indexOptions := options.Index().SetName(index.Name).SetUnique(index.Unique)
+	if index.Partial != nil {
+		indexOptions.SetPartialFilterExpression(index.Partial)
+	}
+	if index.Expires {
+		indexOptions.SetExpireAfterSeconds(0)
+	}
+	model := mongo.IndexModel{Keys: index.Keys, Options: indexOptions}
+	if _, err := database.Collection(index.Collection).Indexes().CreateOne(ctx, model); err != nil {
+		return errors.Wrapf(err, "Error creating the index %s.%s", index.Collection, index.Name)
+	}
+	return nil
+}
+
+// indexByName returns the declared index of a collection that has the given name
+func indexByName(collection string, name string) (Index, bool) {
+	for _, index := range Indexes() {
+		if index.Collection == collection && index.Name == name {
+			return index, true
+		}
+	}
+	return Index{}, false
+}
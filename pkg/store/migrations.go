@@ -0,0 +1,455 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/logging"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const migrationCollection = "migrations"
+
+// Migration is a versioned change of the stored data. Up must be idempotent: a migration
+// interrupted before being recorded is applied again by the next run.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, database *mongo.Database) error
+}
+
+// migrations are applied by increasing version, a released migration must never be changed,
+// a new one is appended instead
+var migrations = []Migration{
+	{Version: 1, Description: "Store the lowercase email of the users", Up: storeLowercaseEmails},
+	{Version: 2, Description: "Store the lowercase name of the cities", Up: storeLowercaseCityNames},
+	{Version: 3, Description: "Store the country of the suppliers", Up: storeSupplierCountries},
+	{Version: 4, Description: "Check that no two countries share a code", Up: checkCountryCodes},
+	{Version: 5, Description: "Store the search terms of the items and variants", Up: storeCatalogSearchTerms},
+}
+
+// MigrationStatus tells whether a migration has been applied to the database
+type MigrationStatus struct {
+	Version     int
+	Description string
+	// AppliedAt is nil while the migration is pending
+	AppliedAt *time.Time
+	// Unknown is true for a migration recorded in the database by a newer version of the API
+	Unknown bool
+}
+
+// MigrationPlan lists what applying the migrations does to the database
+type MigrationPlan struct {
+	Migrations []Migration
+	Indexes    []Index
+}
+
+// Empty tells whether the database is up to date
+func (p *MigrationPlan) Empty() bool {
+	return len(p.Migrations) == 0 && len(p.Indexes) == 0
+}
+
+type migrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+	DurationMs  int64     `bson:"durationMs"`
+}
+
+// Migrator applies the pending migrations and creates the missing indexes of a database, the
+// applied migrations are recorded in the migrations collection
+type Migrator struct {
+	database *mongo.Database
+}
+
+// List returns the status of every migration by increasing version
+func (m *Migrator) List(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := m.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []MigrationStatus
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if record, ok := records[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			delete(records, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range records {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Description: record.Description, AppliedAt: &appliedAt, Unknown: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Plan returns the pending migrations and the missing indexes without changing the database
+func (m *Migrator) Plan(ctx context.Context) (*MigrationPlan, error) {
+	if err := checkMigrationOrder(); err != nil {
+		return nil, err
+	}
+	records, err := m.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan := &MigrationPlan{}
+	for _, migration := range migrations {
+		if _, ok := records[migration.Version]; !ok {
+			plan.Migrations = append(plan.Migrations, migration)
+		}
+	}
+	if plan.Indexes, err = missingIndexes(ctx, m.database); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Apply runs the pending migrations in order, then creates the missing indexes, so that an
+// index can rely on the data fixed by a migration. It stops at the first error and returns what
+// has been applied until then.
+func (m *Migrator) Apply(ctx context.Context) (*MigrationPlan, error) {
+	plan, err := m.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := &MigrationPlan{}
+	collection := m.database.Collection(migrationCollection)
+	for _, migration := range plan.Migrations {
+		logger := logging.Default().WithField("version", migration.Version)
+		logger.WithField("description", migration.Description).Info("Applying a migration")
+		start := time.Now()
+		if err := migration.Up(ctx, m.database); err != nil {
+			return applied, errors.Wrapf(err, "Error applying the migration %d", migration.Version)
+		}
+		record := migrationRecord{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+			DurationMs:  time.Since(start).Nanoseconds() / 1e6,
+		}
+		filter := bson.D{primitive.E{Key: "_id", Value: migration.Version}}
+		update := bson.D{primitive.E{Key: "$setOnInsert", Value: record}}
+		if _, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+			return applied, errors.Wrapf(err, "Error recording the migration %d", migration.Version)
+		}
+		applied.Migrations = append(applied.Migrations, migration)
+		logger.WithField("duration_ms", record.DurationMs).Info("Applied a migration")
+	}
+	for _, index := range plan.Indexes {
+		logging.Default().WithField("collection", index.Collection).WithField("index", index.Name).Info("Creating an index")
+		if err := createIndex(ctx, m.database, index); err != nil {
+			return applied, err
+		}
+		applied.Indexes = append(applied.Indexes, index)
+	}
+	return applied, nil
+}
+
+// CheckApplied fails while a migration is pending, the repositories read the data in the shape the
+// migrations leave it, like the users looked up by their lowercase email, so the API must not
+// serve a database the migrate command has not been run on
+func (m *Migrator) CheckApplied(ctx context.Context) error {
+	records, err := m.records(ctx)
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, migration := range migrations {
+		if _, ok := records[migration.Version]; !ok {
+			pending = append(pending, fmt.Sprintf("%d (%s)", migration.Version, migration.Description))
+		}
+	}
+	if len(pending) > 0 {
+		return errors.Errorf("Pending migrations: %s, apply them with the migrate command", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+func (m *Migrator) records(ctx context.Context) (map[int]migrationRecord, error) {
+	cursor, err := m.database.Collection(migrationCollection).Find(ctx, bson.D{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error listing the applied migrations")
+	}
+	defer cursor.Close(ctx)
+
+	records := map[int]migrationRecord{}
+	for cursor.Next(ctx) {
+		var record migrationRecord
+		if err := cursor.Decode(&record); err != nil {
+			return nil, errors.Wrap(err, "Error decoding an applied migration")
+		}
+		records[record.Version] = record
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error listing the applied migrations")
+	}
+	return records, nil
+}
+
+func checkMigrationOrder() error {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			return errors.Errorf("The migration %d is declared after the migration %d", migrations[i].Version, migrations[i-1].Version)
+		}
+	}
+	return nil
+}
+
+// NewMigrator returns a migrator for the database of the configuration
+func NewMigrator(confPtr *config.Config, client *mongo.Client) *Migrator {
+	return &Migrator{database: client.Database(confPtr.Database.Name)}
+}
+
+// storeLowercaseEmails fills the lemail attribute the users are looked up by, then fails while
+// two users share an email whatever its case, the unique index on lemail could not be created
+func storeLowercaseEmails(ctx context.Context, database *mongo.Database) error {
+	collection := database.Collection(userCollection)
+	filter := bson.D{primitive.E{Key: "email", Value: bson.D{primitive.E{Key: "$type", Value: "string"}}}}
+	projection := options.Find().SetProjection(bson.D{primitive.E{Key: "email", Value: 1}, primitive.E{Key: "lemail", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, projection)
+	if err != nil {
+		return errors.Wrap(err, "Error listing the users")
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var user struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Email  string             `bson:"email"`
+			LEmail string             `bson:"lemail"`
+		}
+		if err := cursor.Decode(&user); err != nil {
+			return errors.Wrap(err, "Error decoding an user")
+		}
+		if user.LEmail == strings.ToLower(user.Email) {
+			continue
+		}
+		update := bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "lemail", Value: strings.ToLower(user.Email)}}}}
+		if _, err := collection.UpdateOne(ctx, bson.D{primitive.E{Key: "_id", Value: user.ID}}, update); err != nil {
+			return errors.Wrapf(err, "Error storing the lowercase email of the user %s", user.ID.Hex())
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error listing the users")
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"lemail": bson.M{"$type": "string"}}},
+		bson.M{"$group": bson.M{"_id": "$lemail", "count": bson.M{"$sum": 1}}},
+		bson.M{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+	}
+	duplicates, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return errors.Wrap(err, "Error looking for duplicate emails")
+	}
+	defer duplicates.Close(ctx)
+	var emails []string
+	for duplicates.Next(ctx) {
+		var duplicate struct {
+			Email string `bson:"_id"`
+		}
+		if err := duplicates.Decode(&duplicate); err != nil {
+			return errors.Wrap(err, "Error decoding a duplicate email")
+		}
+		emails = append(emails, duplicate.Email)
+	}
+	if err := duplicates.Err(); err != nil {
+		return errors.Wrap(err, "Error looking for duplicate emails")
+	}
+	if len(emails) > 0 {
+		return errors.Errorf("Several users share the emails %s, merge or rename them and run the migration again", strings.Join(emails, ", "))
+	}
+	return nil
+}
+
+// storeLowercaseCityNames fills the lcityName attribute of the cities, then fails while two cities
+// of a state share a name whatever its case, the unique index on it could not be created
+func storeLowercaseCityNames(ctx context.Context, database *mongo.Database) error {
+	collection := database.Collection(cityCollection)
+	filter := bson.D{primitive.E{Key: "cityName", Value: bson.D{primitive.E{Key: "$type", Value: "string"}}}}
+	projection := options.Find().SetProjection(bson.D{primitive.E{Key: "cityName", Value: 1}, primitive.E{Key: "lcityName", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, projection)
+	if err != nil {
+		return errors.Wrap(err, "Error listing the cities")
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var city struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			CityName  string             `bson:"cityName"`
+			LCityName string             `bson:"lcityName"`
+		}
+		if err := cursor.Decode(&city); err != nil {
+			return errors.Wrap(err, "Error decoding a city")
+		}
+		if city.LCityName == strings.ToLower(city.CityName) {
+			continue
+		}
+		update := bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "lcityName", Value: strings.ToLower(city.CityName)}}}}
+		if _, err := collection.UpdateOne(ctx, bson.D{primitive.E{Key: "_id", Value: city.ID}}, update); err != nil {
+			return errors.Wrapf(err, "Error storing the lowercase name of the city %s", city.ID.Hex())
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error listing the cities")
+	}
+
+	duplicates, err := findDuplicates(ctx, collection, bson.M{"lcityName": bson.M{"$type": "string"}}, "countryStateId", "lcityName")
+	if err != nil {
+		return err
+	}
+	if len(duplicates) > 0 {
+		return errors.Errorf("Several cities of a state share the names %s, merge or rename them and run the migration again", strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// storeSupplierCountries fills the countryId attribute of the suppliers from their city, then
+// fails while two suppliers of a country share a document
+func storeSupplierCountries(ctx context.Context, database *mongo.Database) error {
+	collection := database.Collection(supplierCollection)
+	filter := bson.D{primitive.E{Key: "cityId", Value: bson.D{primitive.E{Key: "$type", Value: "objectId"}}}}
+	projection := options.Find().SetProjection(bson.D{primitive.E{Key: "cityId", Value: 1}, primitive.E{Key: "countryId", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, projection)
+	if err != nil {
+		return errors.Wrap(err, "Error listing the suppliers")
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var supplier struct {
+			ID        primitive.ObjectID  `bson:"_id"`
+			CityID    primitive.ObjectID  `bson:"cityId"`
+			CountryID *primitive.ObjectID `bson:"countryId"`
+		}
+		if err := cursor.Decode(&supplier); err != nil {
+			return errors.Wrap(err, "Error decoding a supplier")
+		}
+		countryID, err := countryOfCity(ctx, database, supplier.CityID)
+		if err != nil {
+			return err
+		}
+		if countryID == nil || (supplier.CountryID != nil && *supplier.CountryID == *countryID) {
+			continue
+		}
+		update := bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "countryId", Value: countryID}}}}
+		if _, err := collection.UpdateOne(ctx, bson.D{primitive.E{Key: "_id", Value: supplier.ID}}, update); err != nil {
+			return errors.Wrapf(err, "Error storing the country of the supplier %s", supplier.ID.Hex())
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error listing the suppliers")
+	}
+
+	duplicates, err := findDuplicates(ctx, collection, bson.M{"documentNumber": bson.M{"$gt": ""}}, "countryId", "documentType", "documentNumber")
+	if err != nil {
+		return err
+	}
+	if len(duplicates) > 0 {
+		return errors.Errorf("Several suppliers of a country share the documents %s, merge them and run the migration again", strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// checkCountryCodes fails while two countries share a code, the unique index on it could not be
+// created
+func checkCountryCodes(ctx context.Context, database *mongo.Database) error {
+	duplicates, err := findDuplicates(ctx, database.Collection(countryCollection), bson.M{"countryCode": bson.M{"$gt": ""}}, "countryCode")
+	if err != nil {
+		return err
+	}
+	if len(duplicates) > 0 {
+		return errors.Errorf("Several countries share the codes %s, merge or recode them and run the migration again", strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// storeCatalogSearchTerms fills the terms attribute of the items and variants stored before they had
+// localized names, their only term is their folded name
+func storeCatalogSearchTerms(ctx context.Context, database *mongo.Database) error {
+	for _, name := range []string{itemCollection, variantCollection} {
+		collection := database.Collection(name)
+		filter := bson.D{
+			primitive.E{Key: "name", Value: bson.D{primitive.E{Key: "$type", Value: "string"}}},
+			primitive.E{Key: "terms", Value: bson.D{primitive.E{Key: "$exists", Value: false}}},
+		}
+		projection := options.Find().SetProjection(bson.D{primitive.E{Key: "name", Value: 1}, primitive.E{Key: "names", Value: 1}})
+		cursor, err := collection.Find(ctx, filter, projection)
+		if err != nil {
+			return errors.Wrapf(err, "Error listing the %s", name)
+		}
+		for cursor.Next(ctx) {
+			var record struct {
+				ID    primitive.ObjectID      `bson:"_id"`
+				Name  string                  `bson:"name"`
+				Names []dtos.LocalizedNameDto `bson:"names"`
+			}
+			if err := cursor.Decode(&record); err != nil {
+				cursor.Close(ctx)
+				return errors.Wrapf(err, "Error decoding a record of %s", name)
+			}
+			update := bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "terms", Value: searchTerms(record.Name, record.Names)}}}}
+			if _, err := collection.UpdateOne(ctx, bson.D{primitive.E{Key: "_id", Value: record.ID}}, update); err != nil {
+				cursor.Close(ctx)
+				return errors.Wrapf(err, "Error storing the search terms of %s %s", name, record.ID.Hex())
+			}
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "Error listing the %s", name)
+		}
+	}
+	return nil
+}
+
+// findDuplicates returns the values of fields shared by several documents matching match, the
+// values of a duplicate are separated by slashes
+func findDuplicates(ctx context.Context, collection *mongo.Collection, match bson.M, fields ...string) ([]string, error) {
+	key := bson.D{}
+	for _, field := range fields {
+		key = append(key, primitive.E{Key: field, Value: "$" + field})
+	}
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$group": bson.M{"_id": key, "count": bson.M{"$sum": 1}}},
+		bson.M{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error looking for duplicates in %s", collection.Name())
+	}
+	defer cursor.Close(ctx)
+	var duplicates []string
+	for cursor.Next(ctx) {
+		var duplicate struct {
+			Key bson.M `bson:"_id"`
+		}
+		if err := cursor.Decode(&duplicate); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding a duplicate in %s", collection.Name())
+		}
+		values := make([]string, len(fields))
+		for i, field := range fields {
+			if id, ok := duplicate.Key[field].(primitive.ObjectID); ok {
+				values[i] = id.Hex()
+			} else {
+				values[i] = fmt.Sprint(duplicate.Key[field])
+			}
+		}
+		duplicates = append(duplicates, strings.Join(values, "/"))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrapf(err, "Error looking for duplicates in %s", collection.Name())
+	}
+	return duplicates, nil
+}
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const apiClientCollection = "apiClients"
+
+// apiClientIndexes are the indexes of the API clients, a key is authenticated by its prefix
+var apiClientIndexes = []Index{
+	{Collection: apiClientCollection, Name: "keyPrefix_unique", Keys: bson.D{primitive.E{Key: "keyPrefix", Value: 1}}, Unique: true},
+}
+
+// MongoAPIClientRepository a repository for saving API clients and their hashed keys into a mongo database
+type MongoAPIClientRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns an API client by its ID from mongodb
+func (repo *MongoAPIClientRepository) FindByID(ctx context.Context, id string) (*models.APIClient, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "FindByID", apiClientCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	return repo.findOneAPIClient(ctx, filter)
+}
+
+// FindByKeyPrefix returns the API client that owns the key with the given public prefix
+func (repo *MongoAPIClientRepository) FindByKeyPrefix(prefix string) (*models.APIClient, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "FindByKeyPrefix", apiClientCollection)()
+	filter := bson.D{primitive.E{Key: "keyPrefix", Value: prefix}}
+	return repo.findOneAPIClient(context.Background(), filter)
+}
+
+func (repo *MongoAPIClientRepository) findOneAPIClient(ctx context.Context, filter interface{}) (*models.APIClient, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(apiClientCollection)
+	result := collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var apiClient *models.APIClient
+	if err := result.Decode(&apiClient); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding an API client")
+	}
+	return apiClient, nil
+}
+
+// FindAll returns a list of API clients from mongodb
+func (repo *MongoAPIClientRepository) FindAll() ([]*models.APIClient, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "FindAll", apiClientCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(apiClientCollection)
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "name", Value: 1}})
+	cursor, err := collection.Find(context.Background(), bson.D{}, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding all API clients")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.APIClient = []*models.APIClient{}
+	for cursor.Next(context.TODO()) {
+		var apiClient models.APIClient
+		if err := cursor.Decode(&apiClient); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an API client on FindAll()")
+		} else {
+			results = append(results, &apiClient)
+		}
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding all API clients")
+	}
+	return results, nil
+}
+
+// Insert a new API client into mongodb, only the hash of its key is stored
+func (repo *MongoAPIClientRepository) Insert(ctx context.Context, dto *dtos.APIClientDto, keyPrefix string, hashedKey string) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "Insert", apiClientCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(apiClientCollection)
+	now := primitive.DateTime(time.Now().UnixNano() / 1e6)
+	data := bson.D{
+		primitive.E{Key: "name", Value: dto.Name},
+		primitive.E{Key: "keyPrefix", Value: keyPrefix},
+		primitive.E{Key: "hashedKey", Value: hashedKey},
+		primitive.E{Key: "scopes", Value: dto.Scopes},
+		primitive.E{Key: "usageCount", Value: int64(0)},
+		primitive.E{Key: "recordStatus", Value: enums.Active},
+		primitive.E{Key: "createdAt", Value: now},
+		primitive.E{Key: "updatedAt", Value: now},
+		primitive.E{Key: "version", Value: int64(1)},
+	}
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new API client")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// Update an API client by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoAPIClientRepository) Update(ctx context.Context, id string, dto *dtos.APIClientDto, versions []int64) (*models.APIClient, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "Update", apiClientCollection)()
+	data := bson.D{
+		primitive.E{Key: "name", Value: dto.Name},
+		primitive.E{Key: "scopes", Value: dto.Scopes},
+		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	if dto.RecordStatus != nil {
+		data = append(data, primitive.E{Key: "recordStatus", Value: dto.RecordStatus})
+	}
+	return repo.updateAPIClient(ctx, id, data, versions)
+}
+
+// UpdateKey replaces the key of an API client, the previous key stops working immediately
+func (repo *MongoAPIClientRepository) UpdateKey(ctx context.Context, id string, keyPrefix string, hashedKey string, versions []int64) (*models.APIClient, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "UpdateKey", apiClientCollection)()
+	data := bson.D{
+		primitive.E{Key: "keyPrefix", Value: keyPrefix},
+		primitive.E{Key: "hashedKey", Value: hashedKey},
+		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	return repo.updateAPIClient(ctx, id, data, versions)
+}
+
+func (repo *MongoAPIClientRepository) updateAPIClient(ctx context.Context, id string, data bson.D, versions []int64) (*models.APIClient, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(apiClientCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	update := bson.D{primitive.E{Key: "$set", Value: data}, incVersion()}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+	var updatedAPIClient *models.APIClient
+	if err := result.Decode(&updatedAPIClient); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, versionConflict(ctx, collection, filter, versions)
+		}
+		return nil, errors.Wrap(err, "Error decoding an API client")
+	}
+	return updatedAPIClient, nil
+}
+
+// RegisterUsage records that an API client has just authenticated a request. It does not bump
+// the document version, usage is not an edit of the client.
+func (repo *MongoAPIClientRepository) RegisterUsage(id primitive.ObjectID, usedAt time.Time) error {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "RegisterUsage", apiClientCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(apiClientCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "lastUsedAt", Value: usedAt}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "usageCount", Value: int64(1)}}},
+	}
+	if _, err := collection.UpdateOne(context.TODO(), filter, update); err != nil {
+		return errors.Wrap(err, "Error registering the usage of an API client")
+	}
+	return nil
+}
+
+// Delete an API client document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoAPIClientRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoAPIClientRepository", "Delete", apiClientCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(apiClientCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
+	if err != nil {
+		return false, errors.Wrap(err, "Error deleting an API client")
+	}
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
+}
+
+// NewMongoAPIClientRepository returns a new instance of a MongoDB API client repo.
+func NewMongoAPIClientRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoAPIClientRepository {
+	return &MongoAPIClientRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const auditCollection = "auditLog"
+
+// auditIndexes are the indexes of the audit log, its entries are listed newest first by
+// resource or by actor
+var auditIndexes = []Index{
+	{Collection: auditCollection, Name: "timestamp", Keys: bson.D{primitive.E{Key: "timestamp", Value: -1}}},
+	{Collection: auditCollection, Name: "resource_timestamp", Keys: bson.D{
+		primitive.E{Key: "resourceType", Value: 1},
+		primitive.E{Key: "resourceId", Value: 1},
+		primitive.E{Key: "timestamp", Value: -1},
+	}},
+	{Collection: auditCollection, Name: "actor_timestamp", Keys: bson.D{
+		primitive.E{Key: "actor.id", Value: 1},
+		primitive.E{Key: "timestamp", Value: -1},
+	}},
+}
+
+// MongoAuditRepository a repo for appending entries to the audit trail in a mongo database
+type MongoAuditRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// Insert appends a new entry to the audit trail
+func (repo *MongoAuditRepository) Insert(ctx context.Context, entry *models.AuditEntry) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoAuditRepository", "Insert", auditCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(auditCollection)
+	result, err := collection.InsertOne(ctx, entry)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new audit entry")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// Find returns the audit entries matching a query, most recent first
+func (repo *MongoAuditRepository) Find(query *dtos.AuditQueryDto) ([]*models.AuditEntry, error) {
+	defer metrics.ObserveMongoOperation("MongoAuditRepository", "Find", auditCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(auditCollection)
+	filter := bson.D{}
+	if query.ResourceType != "" {
+		filter = append(filter, primitive.E{Key: "resourceType", Value: query.ResourceType})
+	}
+	if query.ResourceID != "" {
+		filter = append(filter, primitive.E{Key: "resourceId", Value: query.ResourceID})
+	}
+	if query.ActorID != "" {
+		filter = append(filter, primitive.E{Key: "actor.id", Value: query.ActorID})
+	}
+	if query.From != nil || query.To != nil {
+		timestamp := bson.D{}
+		if query.From != nil {
+			timestamp = append(timestamp, primitive.E{Key: "$gte", Value: *query.From})
+		}
+		if query.To != nil {
+			timestamp = append(timestamp, primitive.E{Key: "$lt", Value: *query.To})
+		}
+		filter = append(filter, primitive.E{Key: "timestamp", Value: timestamp})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{primitive.E{Key: "timestamp", Value: -1}}).
+		SetSkip(query.Skip).
+		SetLimit(query.Limit)
+	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	defer cancel()
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding audit entries")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.AuditEntry = []*models.AuditEntry{}
+	for cursor.Next(context.TODO()) {
+		var entry models.AuditEntry
+		if err := cursor.Decode(&entry); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an audit entry on Find()")
+		} else {
+			results = append(results, &entry)
+		}
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding audit entries")
+	}
+	return results, nil
+}
+
+// NewMongoAuditRepository returns a new instance of a MongoDB audit repo.
+func NewMongoAuditRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoAuditRepository {
+	return &MongoAuditRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
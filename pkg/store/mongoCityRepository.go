@@ -2,13 +2,14 @@ package store
 
 import (
 	"context"
-	"log"
+	"strings"
 	"time"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,6 +19,19 @@ import (
 
 const cityCollection = "cities"
 
+// cityIndexes are the indexes of the cities, they are listed by state and a name identifies a
+// single city of a state whatever its case
+var cityIndexes = []Index{
+	{Collection: cityCollection, Name: "countryStateId", Keys: bson.D{primitive.E{Key: "countryStateId", Value: 1}}},
+	{
+		Collection: cityCollection,
+		Name:       "countryStateId_lcityName_unique",
+		Keys:       bson.D{primitive.E{Key: "countryStateId", Value: 1}, primitive.E{Key: "lcityName", Value: 1}},
+		Unique:     true,
+		Field:      "cityName",
+	},
+}
+
 // MongoCityRepository a repository that implements the basic CRUD operations for saving cities into a mongo database
 type MongoCityRepository struct {
 	databaseName string
@@ -25,14 +39,15 @@ type MongoCityRepository struct {
 }
 
 // FindByID returns a city by its ID from mongodb
-func (repo *MongoCityRepository) FindByID(id string) (*models.City, error) {
+func (repo *MongoCityRepository) FindByID(ctx context.Context, id string) (*models.City, error) {
+	defer metrics.ObserveMongoOperation("MongoCityRepository", "FindByID", cityCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cityCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result := collection.FindOne(context.TODO(), filter)
+	result := collection.FindOne(ctx, filter)
 	if result.Err() != nil {
 		return nil, result.Err()
 	}
@@ -49,12 +64,13 @@ func (repo *MongoCityRepository) FindByID(id string) (*models.City, error) {
 
 // FindAll returns a list of cities from mongodb
 func (repo *MongoCityRepository) FindAll() ([]*models.City, error) {
+	defer metrics.ObserveMongoOperation("MongoCityRepository", "FindAll", cityCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cityCollection)
 	cursor, err := collection.Find(context.Background(), bson.D{})
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all cities")
 	}
+	defer cursor.Close(context.TODO())
 	cities, err := parseListOfCityDocs(cursor)
 	if err != nil {
 		return nil, err
@@ -64,10 +80,11 @@ func (repo *MongoCityRepository) FindAll() ([]*models.City, error) {
 
 //FindCitiesByCountryState find a list of cities by a country state ID
 func (repo *MongoCityRepository) FindCitiesByCountryState(stateID string) ([]*models.City, error) {
+	defer metrics.ObserveMongoOperation("MongoCityRepository", "FindCitiesByCountryState", cityCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cityCollection)
-	objID, err := primitive.ObjectIDFromHex(stateID)
+	objID, err := parseObjectID(stateID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "countryStateId", Value: objID}}
 	cursor, err := collection.Find(context.Background(), filter)
@@ -79,90 +96,107 @@ func (repo *MongoCityRepository) FindCitiesByCountryState(stateID string) ([]*mo
 }
 
 // Insert a new city into mongodb
-func (repo *MongoCityRepository) Insert(stateID string, dto *dtos.CityDto) (string, error) {
+func (repo *MongoCityRepository) Insert(ctx context.Context, stateID string, dto *dtos.CityDto) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoCityRepository", "Insert", cityCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cityCollection)
-	objStateID, err := primitive.ObjectIDFromHex(stateID)
+	objStateID, err := parseObjectID(stateID)
 	if err != nil {
-		return string(""), errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return string(""), err
 	}
 	active := enums.Active
 	data := bson.D{
 		primitive.E{Key: "cityName", Value: dto.CityName},
+		primitive.E{Key: "lcityName", Value: strings.ToLower(dto.CityName)},
 		primitive.E{Key: "countryStateId", Value: objStateID},
 		primitive.E{Key: "recordStatus", Value: &active},
+		primitive.E{Key: "version", Value: int64(1)},
+	}
+	if dto.CityCode != "" {
+		data = append(data, primitive.E{Key: "cityCode", Value: dto.CityCode})
 	}
 
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
-		return string(""), errors.Wrap(err, "Inserting a new city")
+		return string(""), writeError(err, cityCollection, "Inserting a new city")
 	}
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update a city's document by its id in mongodb
-func (repo *MongoCityRepository) Update(stateID string, cityID string, cityDto *dtos.CityDto) (*models.City, error) {
+// Update a city's document by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoCityRepository) Update(ctx context.Context, stateID string, cityID string, cityDto *dtos.CityDto, versions []int64) (*models.City, error) {
+	defer metrics.ObserveMongoOperation("MongoCityRepository", "Update", cityCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cityCollection)
-	objStateID, err := primitive.ObjectIDFromHex(stateID)
+	objStateID, err := parseObjectID(stateID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
-	objCityID, err := primitive.ObjectIDFromHex(cityID)
+	objCityID, err := parseObjectID(cityID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: objCityID},
-		primitive.E{Key: "countryState", Value: objStateID},
+		primitive.E{Key: "countryStateId", Value: objStateID},
 	}
 	data := bson.D{
 		primitive.E{Key: "cityName", Value: cityDto.CityName},
+		primitive.E{Key: "lcityName", Value: strings.ToLower(cityDto.CityName)},
 	}
 	if cityDto.RecordStatus != nil {
 		data = append(data, primitive.E{Key: "recordStatus", Value: cityDto.RecordStatus})
 	}
+	if cityDto.CityCode != "" {
+		data = append(data, primitive.E{Key: "cityCode", Value: cityDto.CityCode})
+	}
 
 	update := bson.D{primitive.E{
 		Key:   "$set",
 		Value: data,
-	}}
+	}, incVersion()}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, writeError(result.Err(), cityCollection, "Error updating a city")
 	}
 	var updatedCity *models.City
 	if err := result.Decode(&updatedCity); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a city")
 	}
 	return updatedCity, nil
 }
 
-// Delete a city document from mongodb
-func (repo *MongoCityRepository) Delete(stateID string, cityID string) (bool, error) {
+// Delete a city document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoCityRepository) Delete(ctx context.Context, stateID string, cityID string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoCityRepository", "Delete", cityCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cityCollection)
-	objStateID, err := primitive.ObjectIDFromHex(stateID)
+	objStateID, err := parseObjectID(stateID)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
-	objCityID, err := primitive.ObjectIDFromHex(cityID)
+	objCityID, err := parseObjectID(cityID)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: objCityID},
 		primitive.E{Key: "countryStateId", Value: objStateID},
 	}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting a city")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
 func parseListOfCityDocs(cursor *mongo.Cursor) ([]*models.City, error) {
@@ -170,7 +204,7 @@ func parseListOfCityDocs(cursor *mongo.Cursor) ([]*models.City, error) {
 	for cursor.Next(context.TODO()) {
 		var city models.City
 		if err := cursor.Decode(&city); err != nil {
-			log.Printf("Error decoding a city: %v", err)
+			return nil, errors.Wrap(err, "Error decoding a city")
 		} else {
 			results = append(results, &city)
 		}
@@ -2,13 +2,14 @@ package store
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,6 +19,20 @@ import (
 
 const countryCollection = "countries"
 
+// countryIndexes are the indexes of the countries, a code identifies a single country and the
+// country of a state is looked up by the state
+var countryIndexes = []Index{
+	{
+		Collection: countryCollection,
+		Name:       "countryCode_unique",
+		Keys:       bson.D{primitive.E{Key: "countryCode", Value: 1}},
+		Unique:     true,
+		Partial:    bson.D{primitive.E{Key: "countryCode", Value: bson.D{primitive.E{Key: "$gt", Value: ""}}}},
+		Field:      "countryCode",
+	},
+	{Collection: countryCollection, Name: "states._id", Keys: bson.D{primitive.E{Key: "states._id", Value: 1}}},
+}
+
 // MongoCountryRepository a repository that implements the basic CRUD operations for saving countries into a mongo database
 type MongoCountryRepository struct {
 	databaseName string
@@ -25,14 +40,15 @@ type MongoCountryRepository struct {
 }
 
 // FindByID returns a country by its ID from mongodb
-func (repo *MongoCountryRepository) FindByID(id string) (*models.Country, error) {
+func (repo *MongoCountryRepository) FindByID(ctx context.Context, id string) (*models.Country, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "FindByID", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result := collection.FindOne(context.TODO(), filter)
+	result := collection.FindOne(ctx, filter)
 	if result.Err() != nil {
 		return nil, result.Err()
 	}
@@ -49,22 +65,23 @@ func (repo *MongoCountryRepository) FindByID(id string) (*models.Country, error)
 
 // FindAll returns a list of countries from mongodb
 func (repo *MongoCountryRepository) FindAll() ([]*models.Country, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "FindAll", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
 	opts := options.Find().SetSort(bson.D{
 		primitive.E{Key: "countryName", Value: 1},
 		primitive.E{Key: "states.stateName", Value: 1},
 	})
 	cursor, err := collection.Find(context.Background(), bson.D{}, opts)
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all countries")
 	}
+	defer cursor.Close(context.TODO())
 
 	var results []*models.Country = []*models.Country{}
 	for cursor.Next(context.TODO()) {
 		var country models.Country
 		if err := cursor.Decode(&country); err != nil {
-			log.Printf("Error decoding a country on findAll(): %v", err)
+			logging.Default().WithError(err).Error("Error decoding a country on findAll()")
 		} else {
 			results = append(results, &country)
 		}
@@ -77,7 +94,8 @@ func (repo *MongoCountryRepository) FindAll() ([]*models.Country, error) {
 }
 
 // Insert a new country into mongodb
-func (repo *MongoCountryRepository) Insert(country *dtos.CountryDto) (string, error) {
+func (repo *MongoCountryRepository) Insert(ctx context.Context, country *dtos.CountryDto) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "Insert", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
 	var recordStatus = enums.Active.String()
 	if country.RecordStatus != nil {
@@ -87,21 +105,27 @@ func (repo *MongoCountryRepository) Insert(country *dtos.CountryDto) (string, er
 		primitive.E{Key: "countryName", Value: country.CountryName},
 		primitive.E{Key: "countryCode", Value: country.CountryCode},
 		primitive.E{Key: "recordStatus", Value: recordStatus},
+		primitive.E{Key: "version", Value: int64(1)},
+	}
+	if country.CurrencyCode != "" {
+		data = append(data, primitive.E{Key: "currencyCode", Value: country.CurrencyCode})
 	}
 
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
-		return string(""), errors.Wrap(err, "Inserting a new country")
+		return string(""), writeError(err, countryCollection, "Inserting a new country")
 	}
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update a country by its id in mongodb
-func (repo *MongoCountryRepository) Update(id string, country *dtos.CountryDto) (*models.Country, error) {
+// Update a country by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoCountryRepository) Update(ctx context.Context, id string, country *dtos.CountryDto, versions []int64) (*models.Country, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "Update", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
 	update := bson.D{primitive.E{
@@ -109,48 +133,55 @@ func (repo *MongoCountryRepository) Update(id string, country *dtos.CountryDto)
 		Value: bson.D{
 			primitive.E{Key: "countryName", Value: country.CountryName},
 			primitive.E{Key: "countryCode", Value: country.CountryCode},
+			primitive.E{Key: "currencyCode", Value: country.CurrencyCode},
 			primitive.E{Key: "recordStatus", Value: country.RecordStatus},
 		},
-	}}
+	}, incVersion()}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, writeError(result.Err(), countryCollection, "Error updating a country")
 	}
 	var updatedCountry *models.Country
 	if err := result.Decode(&updatedCountry); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a country")
 	}
 	return updatedCountry, nil
 }
 
-// Delete a country document from mongodb
-func (repo *MongoCountryRepository) Delete(id string) (bool, error) {
+// Delete a country document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoCountryRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "Delete", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting a country")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
-// InsertCountryState add a new state to a country
-func (repo *MongoCountryRepository) InsertCountryState(countryID string, stateDto dtos.CountryStateDto) (*models.Country, error) {
+// InsertCountryState add a new state to a country, bumping the version of the country document
+func (repo *MongoCountryRepository) InsertCountryState(ctx context.Context, countryID string, stateDto dtos.CountryStateDto, versions []int64) (*models.Country, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "InsertCountryState", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
-	objID, err := primitive.ObjectIDFromHex(countryID)
+	objID, err := parseObjectID(countryID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	recordStatus := enums.Active
 	if stateDto.RecordStatus != nil {
@@ -162,6 +193,9 @@ func (repo *MongoCountryRepository) InsertCountryState(countryID string, stateDt
 		primitive.E{Key: "stateName", Value: stateDto.StateName},
 		primitive.E{Key: "recordStatus", Value: recordStatus},
 	}
+	if stateDto.StateCode != "" {
+		data = append(data, primitive.E{Key: "stateCode", Value: stateDto.StateCode})
+	}
 	update := bson.D{
 		primitive.E{
 			Key: "$push",
@@ -169,34 +203,36 @@ func (repo *MongoCountryRepository) InsertCountryState(countryID string, stateDt
 				primitive.E{Key: "states", Value: data},
 			},
 		},
+		incVersion(),
 	}
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, writeError(result.Err(), countryCollection, "Error updating a country")
 	}
 	var updatedCountry *models.Country
 	if err := result.Decode(&updatedCountry); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a country")
 	}
 	return updatedCountry, nil
 }
 
-// UpdateCountryState update the data of a country state
-func (repo *MongoCountryRepository) UpdateCountryState(countryID string, stateID string, stateDto dtos.CountryStateDto) (*models.Country, error) {
+// UpdateCountryState update the data of a country state, bumping the version of the country document
+func (repo *MongoCountryRepository) UpdateCountryState(ctx context.Context, countryID string, stateID string, stateDto dtos.CountryStateDto, versions []int64) (*models.Country, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "UpdateCountryState", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
-	countryObjID, err := primitive.ObjectIDFromHex(countryID)
+	countryObjID, err := parseObjectID(countryID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
-	stateObjID, err := primitive.ObjectIDFromHex(stateID)
+	stateObjID, err := parseObjectID(stateID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: countryObjID},
@@ -208,37 +244,41 @@ func (repo *MongoCountryRepository) UpdateCountryState(countryID string, stateID
 	if stateDto.RecordStatus != nil {
 		data = append(data, primitive.E{Key: "states.$.recordStatus", Value: stateDto.RecordStatus})
 	}
+	if stateDto.StateCode != "" {
+		data = append(data, primitive.E{Key: "states.$.stateCode", Value: stateDto.StateCode})
+	}
 	update := bson.D{primitive.E{
 		Key:   "$set",
 		Value: data,
-	}}
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	}, incVersion()}
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, writeError(result.Err(), countryCollection, "Error updating a country")
 	}
 	var updatedCountry *models.Country
 	if err := result.Decode(&updatedCountry); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a country")
 	}
 	return updatedCountry, nil
 }
 
-// DeleteCountryState remove a state from a country
-func (repo *MongoCountryRepository) DeleteCountryState(countryID string, stateID string) (*models.Country, error) {
+// DeleteCountryState remove a state from a country, bumping the version of the country document
+func (repo *MongoCountryRepository) DeleteCountryState(ctx context.Context, countryID string, stateID string, versions []int64) (*models.Country, error) {
+	defer metrics.ObserveMongoOperation("MongoCountryRepository", "DeleteCountryState", countryCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(countryCollection)
-	countryObjID, err := primitive.ObjectIDFromHex(countryID)
+	countryObjID, err := parseObjectID(countryID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
-	stateObjID, err := primitive.ObjectIDFromHex(stateID)
+	stateObjID, err := parseObjectID(stateID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: countryObjID},
@@ -251,18 +291,18 @@ func (repo *MongoCountryRepository) DeleteCountryState(countryID string, stateID
 		Value: bson.D{
 			primitive.E{Key: "states", Value: stateData},
 		},
-	}}
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	}, incVersion()}
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, writeError(result.Err(), countryCollection, "Error updating a country")
 	}
 	var updatedCountry *models.Country
 	if err := result.Decode(&updatedCountry); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a country")
 	}
@@ -273,3 +313,32 @@ func (repo *MongoCountryRepository) DeleteCountryState(countryID string, stateID
 func NewMongoCountryRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoCountryRepository {
 	return &MongoCountryRepository{databaseName: confPtr.Database.Name, client: clientPtr}
 }
+
+// countryOfCity returns the ID of the country a city belongs to through its state, nil when the
+// city or its state does not exist
+func countryOfCity(ctx context.Context, database *mongo.Database, cityID primitive.ObjectID) (*primitive.ObjectID, error) {
+	var city struct {
+		CountryStateID primitive.ObjectID `bson:"countryStateId"`
+	}
+	cityFilter := bson.D{primitive.E{Key: "_id", Value: cityID}}
+	cityProjection := options.FindOne().SetProjection(bson.D{primitive.E{Key: "countryStateId", Value: 1}})
+	if err := database.Collection(cityCollection).FindOne(ctx, cityFilter, cityProjection).Decode(&city); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error finding the state of a city")
+	}
+
+	var country struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	countryFilter := bson.D{primitive.E{Key: "states._id", Value: city.CountryStateID}}
+	countryProjection := options.FindOne().SetProjection(bson.D{primitive.E{Key: "_id", Value: 1}})
+	if err := database.Collection(countryCollection).FindOne(ctx, countryFilter, countryProjection).Decode(&country); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error finding the country of a state")
+	}
+	return &country.ID, nil
+}
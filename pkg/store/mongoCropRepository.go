@@ -2,12 +2,13 @@ package store
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,6 +18,16 @@ import (
 
 const cropCollection string = "crops"
 
+// cropIndexes are the indexes of the crops, they are looked up by supplier, by variant, by city and
+// by creator and counted by harvest date
+var cropIndexes = []Index{
+	{Collection: cropCollection, Name: "supplierId", Keys: bson.D{primitive.E{Key: "supplierId", Value: 1}}},
+	{Collection: cropCollection, Name: "createdBy", Keys: bson.D{primitive.E{Key: "createdBy", Value: 1}}},
+	{Collection: cropCollection, Name: "variantId", Keys: bson.D{primitive.E{Key: "variantId", Value: 1}}},
+	{Collection: cropCollection, Name: "cityId", Keys: bson.D{primitive.E{Key: "cityId", Value: 1}}},
+	{Collection: cropCollection, Name: "harvestDate", Keys: bson.D{primitive.E{Key: "harvestDate", Value: 1}}},
+}
+
 //MongoCropRepository a repo for saving crops into a mongo database
 type MongoCropRepository struct {
 	databaseName string
@@ -24,25 +35,30 @@ type MongoCropRepository struct {
 }
 
 // FindByID returns a crop by its ID from mongodb
-func (repo *MongoCropRepository) FindByID(id string) (*models.Crop, error) {
+func (repo *MongoCropRepository) FindByID(ctx context.Context, id string) (*models.Crop, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "FindByID", cropCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	var pipeline = []bson.M{
 		bson.M{"$match": bson.M{"_id": objID}},
 	}
 	pipeline = append(pipeline, buildStandardCropPipeline()...)
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding a crop")
+	}
+	defer cursor.Close(ctx)
 
 	var crop *models.Crop
-	for cursor.Next(context.TODO()) {
+	for cursor.Next(ctx) {
 		if err := cursor.Decode(&crop); err != nil {
-			log.Printf("Error decoding a crop: %v", err)
+			return nil, errors.Wrap(err, "Error decoding a crop")
 		}
 	}
 	err = cursor.Err()
@@ -57,21 +73,22 @@ func (repo *MongoCropRepository) FindByID(id string) (*models.Crop, error) {
 
 // FindAll returns a list of crops from mongodb
 func (repo *MongoCropRepository) FindAll() ([]*models.Crop, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "FindAll", cropCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
 	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
 	defer cancel()
 	var pipeline = buildStandardCropPipeline()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all crops")
 	}
+	defer cursor.Close(context.TODO())
 
 	var results []*models.Crop
 	for cursor.Next(context.TODO()) {
 		var crop models.Crop
 		if err := cursor.Decode(&crop); err != nil {
-			log.Printf("Error decoding a crop on FindAll(): %v", err)
+			logging.Default().WithError(err).Error("Error decoding a crop on FindAll()")
 		} else {
 			results = append(results, &crop)
 		}
@@ -84,8 +101,102 @@ func (repo *MongoCropRepository) FindAll() ([]*models.Crop, error) {
 	return results, nil
 }
 
-// Insert a new crop into mongodb
-func (repo *MongoCropRepository) Insert(dto *dtos.CropDto) (string, error) {
+// Each calls fn with every crop and its populated relations, read one after the other from the
+// cursor so that the whole collection is never held in memory. It stops at the first error
+// returned by fn.
+func (repo *MongoCropRepository) Each(ctx context.Context, fn func(*models.Crop) error) error {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "Each", cropCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
+	cursor, err := collection.Aggregate(ctx, buildStandardCropPipeline())
+	if err != nil {
+		return errors.Wrap(err, "Error reading the crops")
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(ctx) {
+		var crop models.Crop
+		if err := cursor.Decode(&crop); err != nil {
+			logging.Default().WithError(err).Error("Error decoding a crop on Each()")
+			continue
+		}
+		if err := fn(&crop); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error reading the crops")
+	}
+	return nil
+}
+
+// HarvestReport counts the crops whose harvest date is within [from, to) by month of harvest and
+// variant, sorted by month then variant name. When memberIDs is not nil only the crops supplied or
+// created by these users and API clients are counted.
+func (repo *MongoCropRepository) HarvestReport(ctx context.Context, from time.Time, to time.Time, memberIDs []primitive.ObjectID) ([]*models.HarvestReportRow, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "HarvestReport", cropCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
+	match := bson.M{"harvestDate": bson.M{"$gte": from, "$lt": to}}
+	if memberIDs != nil {
+		match["$or"] = bson.A{
+			bson.M{"supplierId": bson.M{"$in": memberIDs}},
+			bson.M{"createdBy": bson.M{"$in": memberIDs}},
+		}
+	}
+	var pipeline = []bson.M{
+		bson.M{"$match": match},
+		bson.M{"$group": bson.M{
+			"_id": bson.M{
+				"month":     bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$harvestDate"}},
+				"variantId": "$variantId",
+			},
+			"crops":     bson.M{"$sum": 1},
+			"suppliers": bson.M{"$addToSet": "$supplierId"},
+		}},
+		bson.M{"$lookup": bson.M{
+			"from":         variantCollection,
+			"localField":   "_id.variantId",
+			"foreignField": "_id",
+			"as":           "variant",
+		}},
+		bson.M{"$project": bson.M{
+			"_id":       0,
+			"month":     "$_id.month",
+			"variantId": "$_id.variantId",
+			"variant":   bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$variant.name", 0}}, ""}},
+			"crops":     1,
+			"suppliers": bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$suppliers",
+				"cond":  bson.M{"$ne": bson.A{"$$this", nil}},
+			}}},
+		}},
+		bson.M{"$sort": bson.D{primitive.E{Key: "month", Value: 1}, primitive.E{Key: "variant", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error computing the harvest report")
+	}
+	defer cursor.Close(ctx)
+
+	report := []*models.HarvestReportRow{}
+	for cursor.Next(ctx) {
+		var row models.HarvestReportRow
+		if err := cursor.Decode(&row); err != nil {
+			return nil, errors.Wrap(err, "Error decoding a harvest report row")
+		}
+		report = append(report, &row)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error computing the harvest report")
+	}
+	return report, nil
+}
+
+// Insert a new crop into mongodb, createdBy is the user or the API client creating it when known
+func (repo *MongoCropRepository) Insert(ctx context.Context, dto *dtos.CropDto, createdBy *primitive.ObjectID) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "Insert", cropCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
 	now := primitive.DateTime(time.Now().UnixNano() / 1e6)
 	data := bson.D{
@@ -94,63 +205,88 @@ func (repo *MongoCropRepository) Insert(dto *dtos.CropDto) (string, error) {
 		primitive.E{Key: "harvestDate", Value: dto.HarvestDate},
 		primitive.E{Key: "variantId", Value: dto.VariantID},
 		primitive.E{Key: "supplierId", Value: dto.SupplierID},
+		primitive.E{Key: "createdBy", Value: createdBy},
 		primitive.E{Key: "createdAt", Value: now},
 		primitive.E{Key: "updatedAt", Value: now},
+		primitive.E{Key: "version", Value: int64(1)},
 	}
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
 		return string(""), errors.Wrap(err, "Inserting a new crop")
 	}
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update a crop document by its id in mongodb
-func (repo *MongoCropRepository) Update(id string, dto *dtos.CropDto) (*models.Crop, error) {
+// Update a crop document by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoCropRepository) Update(ctx context.Context, id string, dto *dtos.CropDto, versions []int64) (*models.Crop, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "Update", cropCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	update := bson.M{"$set": bson.M{
-		"cityId":       dto.CityID,
-		"plantingDate": dto.PlantingDate,
-		"harvestDate":  dto.HarvestDate,
-		"variantId":    dto.VariantID,
-		"supplierId":   dto.SupplierID,
-		"updatedAt":    time.Now(),
-	}}
+	update := bson.M{
+		"$set": bson.M{
+			"cityId":       dto.CityID,
+			"plantingDate": dto.PlantingDate,
+			"harvestDate":  dto.HarvestDate,
+			"variantId":    dto.VariantID,
+			"supplierId":   dto.SupplierID,
+			"updatedAt":    time.Now(),
+		},
+		"$inc": bson.M{"version": int64(1)},
+	}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
 		return nil, result.Err()
 	}
 	var updatedCrop *models.Crop
 	if err := result.Decode(&updatedCrop); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a crop")
 	}
 	return updatedCrop, nil
 }
 
-// Delete a supliers document from mongodb
-func (repo *MongoCropRepository) Delete(id string) (bool, error) {
+// CountActive returns the number of crops not harvested yet at the given time
+func (repo *MongoCropRepository) CountActive(now time.Time) (int64, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "CountActive", cropCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	count, err := collection.CountDocuments(ctx, bson.M{"harvestDate": bson.M{"$gte": now}})
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return 0, errors.Wrap(err, "Error counting the active crops")
+	}
+	return count, nil
+}
+
+// Delete a crop document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoCropRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoCropRepository", "Delete", cropCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(cropCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting a crop")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
 func buildStandardCropPipeline() []bson.M {
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const exchangeRateCollection = "exchangeRates"
+
+// exchangeRateIndexes are the indexes of the exchange rates, a pair has a single rate effective
+// from a given time and the rate in effect is the latest one before the time of a conversion
+var exchangeRateIndexes = []Index{
+	{
+		Collection: exchangeRateCollection,
+		Name:       "base_quote_effectiveAt_unique",
+		Keys: bson.D{
+			primitive.E{Key: "base", Value: 1},
+			primitive.E{Key: "quote", Value: 1},
+			primitive.E{Key: "effectiveAt", Value: -1},
+		},
+		Unique: true,
+		Field:  "effectiveAt",
+	},
+}
+
+// MongoExchangeRateRepository a repository for saving the exchange rates into a mongo database
+type MongoExchangeRateRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns an exchange rate by its ID from mongodb
+func (repo *MongoExchangeRateRepository) FindByID(ctx context.Context, id string) (*models.ExchangeRate, error) {
+	defer metrics.ObserveMongoOperation("MongoExchangeRateRepository", "FindByID", exchangeRateCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(exchangeRateCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	var rate *models.ExchangeRate
+	if err := collection.FindOne(ctx, filter).Decode(&rate); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding an exchange rate")
+	}
+	return rate, nil
+}
+
+// Find returns the exchange rates from mongodb by pair and then newest first, base and quote
+// restrict them to a currency when they are not empty
+func (repo *MongoExchangeRateRepository) Find(base string, quote string) ([]*models.ExchangeRate, error) {
+	defer metrics.ObserveMongoOperation("MongoExchangeRateRepository", "Find", exchangeRateCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(exchangeRateCollection)
+	filter := bson.D{}
+	if base != "" {
+		filter = append(filter, primitive.E{Key: "base", Value: base})
+	}
+	if quote != "" {
+		filter = append(filter, primitive.E{Key: "quote", Value: quote})
+	}
+	opts := options.Find().SetSort(bson.D{
+		primitive.E{Key: "base", Value: 1},
+		primitive.E{Key: "quote", Value: 1},
+		primitive.E{Key: "effectiveAt", Value: -1},
+	})
+	cursor, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding exchange rates")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.ExchangeRate = []*models.ExchangeRate{}
+	for cursor.Next(context.TODO()) {
+		var rate models.ExchangeRate
+		if err := cursor.Decode(&rate); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an exchange rate on Find()")
+		} else {
+			results = append(results, &rate)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error finding exchange rates")
+	}
+	return results, nil
+}
+
+// FindEffective returns the rate of a pair in effect at a time from mongodb, the latest one
+// effective at or before it, nil when the pair has none
+func (repo *MongoExchangeRateRepository) FindEffective(base string, quote string, at time.Time) (*models.ExchangeRate, error) {
+	defer metrics.ObserveMongoOperation("MongoExchangeRateRepository", "FindEffective", exchangeRateCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(exchangeRateCollection)
+	filter := bson.D{
+		primitive.E{Key: "base", Value: base},
+		primitive.E{Key: "quote", Value: quote},
+		primitive.E{Key: "effectiveAt", Value: bson.D{primitive.E{Key: "$lte", Value: at}}},
+	}
+	opts := options.FindOne().SetSort(bson.D{primitive.E{Key: "effectiveAt", Value: -1}})
+	var rate *models.ExchangeRate
+	if err := collection.FindOne(context.TODO(), filter, opts).Decode(&rate); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error finding the effective exchange rate")
+	}
+	return rate, nil
+}
+
+// Insert a new exchange rate into mongodb, its ID is set
+func (repo *MongoExchangeRateRepository) Insert(ctx context.Context, rate *models.ExchangeRate) error {
+	defer metrics.ObserveMongoOperation("MongoExchangeRateRepository", "Insert", exchangeRateCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(exchangeRateCollection)
+	rate.ID = primitive.NewObjectID()
+	if _, err := collection.InsertOne(ctx, rate); err != nil {
+		return writeError(err, exchangeRateCollection, "Inserting a new exchange rate")
+	}
+	return nil
+}
+
+// Delete an exchange rate document from mongodb, false when it does not exist
+func (repo *MongoExchangeRateRepository) Delete(ctx context.Context, id string) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoExchangeRateRepository", "Delete", exchangeRateCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(exchangeRateCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	result, err := collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, errors.Wrap(err, "Error deleting an exchange rate")
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// NewMongoExchangeRateRepository returns a new instance of a MongoDB exchange rate repository.
+func NewMongoExchangeRateRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoExchangeRateRepository {
+	return &MongoExchangeRateRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const importJobCollection = "importJobs"
+
+// importJobIndexes are the indexes of the import jobs, they are listed newest first
+var importJobIndexes = []Index{
+	{Collection: importJobCollection, Name: "createdAt", Keys: bson.D{primitive.E{Key: "createdAt", Value: -1}}},
+}
+
+// MongoImportJobRepository a repository for saving the import jobs into a mongo database
+type MongoImportJobRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns an import job by its ID from mongodb
+func (repo *MongoImportJobRepository) FindByID(id string) (*models.ImportJob, error) {
+	defer metrics.ObserveMongoOperation("MongoImportJobRepository", "FindByID", importJobCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(importJobCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	result := collection.FindOne(context.TODO(), filter)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var job *models.ImportJob
+	if err := result.Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding an import job")
+	}
+	return job, nil
+}
+
+// FindRecent returns the most recent import jobs from mongodb, newest first
+func (repo *MongoImportJobRepository) FindRecent(limit int64) ([]*models.ImportJob, error) {
+	defer metrics.ObserveMongoOperation("MongoImportJobRepository", "FindRecent", importJobCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(importJobCollection)
+	opts := options.Find().
+		SetSort(bson.D{primitive.E{Key: "createdAt", Value: -1}}).
+		SetLimit(limit).
+		SetProjection(bson.D{primitive.E{Key: "errors", Value: 0}})
+	cursor, err := collection.Find(context.Background(), bson.D{}, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding import jobs")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.ImportJob = []*models.ImportJob{}
+	for cursor.Next(context.TODO()) {
+		var job models.ImportJob
+		if err := cursor.Decode(&job); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an import job on FindRecent()")
+		} else {
+			results = append(results, &job)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error finding import jobs")
+	}
+	return results, nil
+}
+
+// Insert a new import job into mongodb, its ID is set
+func (repo *MongoImportJobRepository) Insert(job *models.ImportJob) error {
+	defer metrics.ObserveMongoOperation("MongoImportJobRepository", "Insert", importJobCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(importJobCollection)
+	job.ID = primitive.NewObjectID()
+	if _, err := collection.InsertOne(context.TODO(), job); err != nil {
+		return errors.Wrap(err, "Inserting a new import job")
+	}
+	return nil
+}
+
+// Save replaces the stored import job with its current progress, a job is only written by the
+// process running it
+func (repo *MongoImportJobRepository) Save(job *models.ImportJob) error {
+	defer metrics.ObserveMongoOperation("MongoImportJobRepository", "Save", importJobCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(importJobCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: job.ID}}
+	if _, err := collection.ReplaceOne(context.TODO(), filter, job); err != nil {
+		return errors.Wrap(err, "Error saving an import job")
+	}
+	return nil
+}
+
+// NewMongoImportJobRepository returns a new instance of a MongoDB import job repository.
+func NewMongoImportJobRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoImportJobRepository {
+	return &MongoImportJobRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
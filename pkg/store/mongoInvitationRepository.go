@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const invitationCollection = "invitations"
+
+// invitationIndexes are the indexes of the invitations, an invitation is accepted by its token
+var invitationIndexes = []Index{
+	{Collection: invitationCollection, Name: "hashedToken_unique", Keys: bson.D{primitive.E{Key: "hashedToken", Value: 1}}, Unique: true},
+	{Collection: invitationCollection, Name: "organizationId_createdAt", Keys: bson.D{primitive.E{Key: "organizationId", Value: 1}, primitive.E{Key: "createdAt", Value: -1}}},
+}
+
+// MongoInvitationRepository a repository for saving the invitations to join an organization into a mongo database
+type MongoInvitationRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns an invitation by its ID from mongodb
+func (repo *MongoInvitationRepository) FindByID(ctx context.Context, id string) (*models.Invitation, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "FindByID", invitationCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	return repo.findOneInvitation(ctx, bson.D{primitive.E{Key: "_id", Value: objID}})
+}
+
+// FindByHashedToken returns the invitation issued with the token of the given hash
+func (repo *MongoInvitationRepository) FindByHashedToken(ctx context.Context, hashedToken string) (*models.Invitation, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "FindByHashedToken", invitationCollection)()
+	return repo.findOneInvitation(ctx, bson.D{primitive.E{Key: "hashedToken", Value: hashedToken}})
+}
+
+func (repo *MongoInvitationRepository) findOneInvitation(ctx context.Context, filter bson.D) (*models.Invitation, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(invitationCollection)
+	result := collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var invitation *models.Invitation
+	if err := result.Decode(&invitation); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding an invitation")
+	}
+	return invitation, nil
+}
+
+// FindByOrganization returns the invitations to join an organization, most recent first
+func (repo *MongoInvitationRepository) FindByOrganization(ctx context.Context, organizationID primitive.ObjectID) ([]*models.Invitation, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "FindByOrganization", invitationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(invitationCollection)
+	filter := bson.D{primitive.E{Key: "organizationId", Value: organizationID}}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "createdAt", Value: -1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding the invitations of an organization")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.Invitation = []*models.Invitation{}
+	for cursor.Next(ctx) {
+		var invitation models.Invitation
+		if err := cursor.Decode(&invitation); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an invitation on FindByOrganization()")
+		} else {
+			results = append(results, &invitation)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error finding the invitations of an organization")
+	}
+	return results, nil
+}
+
+// Insert a new invitation into mongodb, only the hash of its token is stored
+func (repo *MongoInvitationRepository) Insert(ctx context.Context, invitation *models.Invitation) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "Insert", invitationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(invitationCollection)
+	data := bson.D{
+		primitive.E{Key: "organizationId", Value: invitation.OrganizationID},
+		primitive.E{Key: "email", Value: invitation.Email},
+		primitive.E{Key: "role", Value: invitation.Role},
+		primitive.E{Key: "hashedToken", Value: invitation.HashedToken},
+		primitive.E{Key: "invitedBy", Value: invitation.InvitedBy},
+		primitive.E{Key: "expiresAt", Value: invitation.ExpiresAt},
+		primitive.E{Key: "createdAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new invitation")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// MarkAccepted records that a user accepted an invitation, it returns false when the invitation
+// has already been accepted or has expired by now
+func (repo *MongoInvitationRepository) MarkAccepted(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID, now time.Time) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "MarkAccepted", invitationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(invitationCollection)
+	filter := bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "acceptedAt", Value: bson.D{primitive.E{Key: "$exists", Value: false}}},
+		primitive.E{Key: "expiresAt", Value: bson.D{primitive.E{Key: "$gt", Value: now}}},
+	}
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "acceptedAt", Value: now},
+		primitive.E{Key: "acceptedBy", Value: userID},
+	}}}
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errors.Wrap(err, "Error accepting an invitation")
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// Delete an invitation document from mongodb, its token stops working immediately
+func (repo *MongoInvitationRepository) Delete(ctx context.Context, id string) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "Delete", invitationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(invitationCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
+	}
+	result, err := collection.DeleteOne(ctx, bson.D{primitive.E{Key: "_id", Value: objID}})
+	if err != nil {
+		return false, errors.Wrap(err, "Error deleting an invitation")
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// DeleteByOrganization deletes every invitation to join an organization
+func (repo *MongoInvitationRepository) DeleteByOrganization(ctx context.Context, organizationID primitive.ObjectID) (int64, error) {
+	defer metrics.ObserveMongoOperation("MongoInvitationRepository", "DeleteByOrganization", invitationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(invitationCollection)
+	result, err := collection.DeleteMany(ctx, bson.D{primitive.E{Key: "organizationId", Value: organizationID}})
+	if err != nil {
+		return 0, errors.Wrap(err, "Error deleting the invitations of an organization")
+	}
+	return result.DeletedCount, nil
+}
+
+// NewMongoInvitationRepository returns a new instance of a MongoDB invitation repo.
+func NewMongoInvitationRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoInvitationRepository {
+	return &MongoInvitationRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
@@ -2,7 +2,7 @@ package store
 
 import (
 	"context"
-	"log"
+	"regexp"
 	"strings"
 	"time"
 
@@ -10,6 +10,9 @@ import (
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/i18n"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,6 +22,11 @@ import (
 
 const itemCollection = "items"
 
+// itemIndexes are the indexes of the items, they are searched by any of their names
+var itemIndexes = []Index{
+	{Collection: itemCollection, Name: "terms", Keys: bson.D{primitive.E{Key: "terms", Value: 1}}},
+}
+
 // MongoItemRepository a repository that implements the basic CRUD operations for saving items into a mongo database
 type MongoItemRepository struct {
 	databaseName string
@@ -26,11 +34,12 @@ type MongoItemRepository struct {
 }
 
 // FindByID returns an Item by its ID from mongodb
-func (repo *MongoItemRepository) FindByID(id string) (*models.Item, error) {
+func (repo *MongoItemRepository) FindByID(ctx context.Context, id string) (*models.Item, error) {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "FindByID", itemCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(itemCollection)
-	objdID, err := primitive.ObjectIDFromHex(id)
+	objdID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 
 	var pipeline = []bson.M{
@@ -38,14 +47,18 @@ func (repo *MongoItemRepository) FindByID(id string) (*models.Item, error) {
 	}
 	pipeline = append(pipeline, buildStandardItemPipeline()...)
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding an item")
+	}
+	defer cursor.Close(ctx)
 
 	var item *models.Item
-	for cursor.Next(context.TODO()) {
+	for cursor.Next(ctx) {
 		if err := cursor.Decode(&item); err != nil {
-			log.Printf("Error decoding an item: %v", err)
+			return nil, errors.Wrap(err, "Error decoding an item")
 		}
 	}
 	err = cursor.Err()
@@ -60,21 +73,35 @@ func (repo *MongoItemRepository) FindByID(id string) (*models.Item, error) {
 
 // FindAll return a list of items from mongodb
 func (repo *MongoItemRepository) FindAll() ([]*models.Item, error) {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "FindAll", itemCollection)()
+	return repo.aggregate(buildStandardItemPipeline())
+}
+
+// FindByTerm returns the items having a name or a synonym in any locale with a word starting with
+// term, whatever its case and accents
+func (repo *MongoItemRepository) FindByTerm(term string) ([]*models.Item, error) {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "FindByTerm", itemCollection)()
+	pipeline := []bson.M{
+		bson.M{"$match": bson.D{termFilter(term)}},
+	}
+	return repo.aggregate(append(pipeline, buildStandardItemPipeline()...))
+}
+
+func (repo *MongoItemRepository) aggregate(pipeline []bson.M) ([]*models.Item, error) {
 	collection := repo.client.Database(repo.databaseName).Collection(itemCollection)
 	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
 	defer cancel()
-	var pipeline = buildStandardItemPipeline()
 
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all items")
 	}
+	defer cursor.Close(context.TODO())
 	var results []*models.Item = []*models.Item{}
 	for cursor.Next(context.TODO()) {
 		var item models.Item
 		if err := cursor.Decode(&item); err != nil {
-			log.Printf("Error decoding an Item on FindAll(): %v", err)
+			logging.Default().WithError(err).Error("Error decoding an Item on FindAll()")
 		} else {
 			results = append(results, &item)
 		}
@@ -87,20 +114,51 @@ func (repo *MongoItemRepository) FindAll() ([]*models.Item, error) {
 	return results, nil
 }
 
+// Each calls fn with every item and its variants, read one after the other from the cursor so
+// that the whole collection is never held in memory. It stops at the first error returned by fn.
+func (repo *MongoItemRepository) Each(ctx context.Context, fn func(*models.Item) error) error {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "Each", itemCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(itemCollection)
+	cursor, err := collection.Aggregate(ctx, buildStandardItemPipeline())
+	if err != nil {
+		return errors.Wrap(err, "Error reading the items")
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(ctx) {
+		var item models.Item
+		if err := cursor.Decode(&item); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an item on Each()")
+			continue
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error reading the items")
+	}
+	return nil
+}
+
 // Insert a new Item into mongodb
-func (repo *MongoItemRepository) Insert(itemDto *dtos.ItemDto) (string, error) {
+func (repo *MongoItemRepository) Insert(ctx context.Context, itemDto *dtos.ItemDto) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "Insert", itemCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(itemCollection)
 	createdAt := primitive.DateTime(time.Now().UnixNano() / 1e6)
 	active := enums.Active
 	data := bson.D{
 		primitive.E{Key: "name", Value: itemDto.Name},
 		primitive.E{Key: "lname", Value: strings.ToLower(itemDto.Name)},
+		primitive.E{Key: "names", Value: localizedNames(itemDto.Names)},
+		primitive.E{Key: "terms", Value: searchTerms(itemDto.Name, itemDto.Names)},
 		primitive.E{Key: "createdAt", Value: createdAt},
 		primitive.E{Key: "updatedAt", Value: createdAt},
 		primitive.E{Key: "recordStatus", Value: active},
+		primitive.E{Key: "version", Value: int64(1)},
 	}
 
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
 		return string(""), errors.Wrap(err, "Error inserting a new Item")
 	}
@@ -108,17 +166,21 @@ func (repo *MongoItemRepository) Insert(itemDto *dtos.ItemDto) (string, error) {
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update an item's data by its id in mongodb
-func (repo *MongoItemRepository) Update(id string, itemDto *dtos.ItemDto) (*models.Item, error) {
+// Update an item's data by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoItemRepository) Update(ctx context.Context, id string, itemDto *dtos.ItemDto, versions []int64) (*models.Item, error) {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "Update", itemCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(itemCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
 	data := bson.D{
 		primitive.E{Key: "name", Value: itemDto.Name},
 		primitive.E{Key: "lname", Value: strings.ToLower(itemDto.Name)},
+		primitive.E{Key: "names", Value: localizedNames(itemDto.Names)},
+		primitive.E{Key: "terms", Value: searchTerms(itemDto.Name, itemDto.Names)},
 		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
 	}
 	if itemDto.RecordStatus != nil {
@@ -127,40 +189,45 @@ func (repo *MongoItemRepository) Update(id string, itemDto *dtos.ItemDto) (*mode
 	update := bson.D{primitive.E{
 		Key:   "$set",
 		Value: data,
-	}}
+	}, incVersion()}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
 		return nil, errors.Wrap(result.Err(), "Error updating an Item")
 	}
 	var updatedItem *models.Item
 	if err := result.Decode(&updatedItem); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding an Item")
 	}
 	return updatedItem, nil
 }
 
-// Delete an item document from mongodb
-func (repo *MongoItemRepository) Delete(id string) (bool, error) {
+// Delete an item document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoItemRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoItemRepository", "Delete", itemCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(itemCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
 	filter := primitive.D{
 		primitive.E{Key: "_id", Value: objID},
 	}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting an item")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
 func buildStandardItemPipeline() []bson.M {
@@ -174,6 +241,40 @@ func buildStandardItemPipeline() []bson.M {
 	}
 }
 
+// localizedNames returns the localized names to store, an empty list rather than nil
+func localizedNames(names []dtos.LocalizedNameDto) []dtos.LocalizedNameDto {
+	if names == nil {
+		return []dtos.LocalizedNameDto{}
+	}
+	return names
+}
+
+// searchTerms returns the folded forms of the name of an item or a variant, of its localized names
+// and of their synonyms, each of them once
+func searchTerms(name string, names []dtos.LocalizedNameDto) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+	add := func(value string) {
+		if term := i18n.Fold(value); term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	add(name)
+	for _, localized := range names {
+		add(localized.Name)
+		for _, synonym := range localized.Synonyms {
+			add(synonym)
+		}
+	}
+	return terms
+}
+
+// termFilter matches the documents having a search term with a word starting with term
+func termFilter(term string) primitive.E {
+	return primitive.E{Key: "terms", Value: bson.D{primitive.E{Key: "$regex", Value: "(^| )" + regexp.QuoteMeta(i18n.Fold(term))}}}
+}
+
 // NewMongoItemRepository returns a new instance of a mongodb repository for items.
 func NewMongoItemRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoItemRepository {
 	return &MongoItemRepository{databaseName: confPtr.Database.Name, client: clientPtr}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// lookupCollections are the collections of the resource types read by the lookups
+var lookupCollections = map[string]string{
+	models.ResourceCountry:  countryCollection,
+	models.ResourceSupplier: supplierCollection,
+	models.ResourceCity:     cityCollection,
+	models.ResourceItem:     itemCollection,
+	models.ResourceVariant:  variantCollection,
+	models.ResourceCrop:     cropCollection,
+	models.ResourceUser:     userCollection,
+}
+
+// MongoLookupRepository reads the stored documents of any resource type without joining their
+// relations, by pages or by the values of a field, so that the relations of many records are
+// read with a single query
+type MongoLookupRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// Page decodes into results the documents of a resource type following the after ID in the order
+// of their IDs, at most limit of them. results is a pointer to a slice of pointers to the model
+// of the resource type.
+func (repo *MongoLookupRepository) Page(ctx context.Context, resource string, after *primitive.ObjectID, limit int64, results interface{}) error {
+	filter := bson.D{}
+	if after != nil {
+		filter = bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$gt", Value: *after}}}}
+	}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "_id", Value: 1}}).SetLimit(limit)
+	return repo.find(ctx, resource, "Page", filter, opts, results)
+}
+
+// FindIn decodes into results the documents of a resource type whose field holds one of the IDs,
+// in the order of their IDs. results is a pointer to a slice of pointers to the model of the
+// resource type.
+func (repo *MongoLookupRepository) FindIn(ctx context.Context, resource string, field string, ids []primitive.ObjectID, results interface{}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	filter := bson.D{primitive.E{Key: field, Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "_id", Value: 1}})
+	return repo.find(ctx, resource, "FindIn", filter, opts, results)
+}
+
+// Count returns the number of documents of a resource type
+func (repo *MongoLookupRepository) Count(ctx context.Context, resource string) (int64, error) {
+	name, err := lookupCollection(resource)
+	if err != nil {
+		return 0, err
+	}
+	defer metrics.ObserveMongoOperation("MongoLookupRepository", "Count", name)()
+	collection := repo.client.Database(repo.databaseName).Collection(name)
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	count, err := collection.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error counting the %s records", resource)
+	}
+	return count, nil
+}
+
+func (repo *MongoLookupRepository) find(ctx context.Context, resource string, operation string, filter bson.D, opts *options.FindOptions, results interface{}) error {
+	name, err := lookupCollection(resource)
+	if err != nil {
+		return err
+	}
+	defer metrics.ObserveMongoOperation("MongoLookupRepository", operation, name)()
+	collection := repo.client.Database(repo.databaseName).Collection(name)
+
+	slice := reflect.ValueOf(results).Elem()
+	model := slice.Type().Elem().Elem()
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return errors.Wrapf(err, "Error finding the %s records", resource)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		record := reflect.New(model)
+		if err := cursor.Decode(record.Interface()); err != nil {
+			return errors.Wrapf(err, "Error decoding a %s record", resource)
+		}
+		slice.Set(reflect.Append(slice, record))
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrapf(err, "Error finding the %s records", resource)
+	}
+	return nil
+}
+
+func lookupCollection(resource string) (string, error) {
+	name, ok := lookupCollections[resource]
+	if !ok {
+		return "", errors.Errorf("The %s records cannot be looked up", resource)
+	}
+	return name, nil
+}
+
+// NewMongoLookupRepository returns a new instance of a MongoDB lookup repo.
+func NewMongoLookupRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoLookupRepository {
+	return &MongoLookupRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
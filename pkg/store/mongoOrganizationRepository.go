@@ -0,0 +1,231 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const organizationCollection = "organizations"
+
+// organizationIndexes are the indexes of the organizations, the organizations of a member are
+// looked up to switch the organization it acts for
+var organizationIndexes = []Index{
+	{
+		Collection: organizationCollection,
+		Name:       "members_memberId_memberType",
+		Keys:       bson.D{primitive.E{Key: "members.memberId", Value: 1}, primitive.E{Key: "members.memberType", Value: 1}},
+	},
+}
+
+// MongoOrganizationRepository a repository for saving organizations and their members into a mongo database
+type MongoOrganizationRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns an organization by its ID from mongodb
+func (repo *MongoOrganizationRepository) FindByID(ctx context.Context, id string) (*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "FindByID", organizationCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(organizationCollection)
+	result := collection.FindOne(ctx, bson.D{primitive.E{Key: "_id", Value: objID}})
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var organization *models.Organization
+	if err := result.Decode(&organization); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding an organization")
+	}
+	return organization, nil
+}
+
+// FindAll returns a list of organizations from mongodb
+func (repo *MongoOrganizationRepository) FindAll(ctx context.Context) ([]*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "FindAll", organizationCollection)()
+	return repo.find(ctx, bson.D{})
+}
+
+// FindByMember returns the organizations a user or an API client is a member of
+func (repo *MongoOrganizationRepository) FindByMember(ctx context.Context, memberType string, memberID primitive.ObjectID) ([]*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "FindByMember", organizationCollection)()
+	return repo.find(ctx, bson.D{primitive.E{Key: "members", Value: bson.D{primitive.E{Key: "$elemMatch", Value: bson.D{
+		primitive.E{Key: "memberId", Value: memberID},
+		primitive.E{Key: "memberType", Value: memberType},
+	}}}}})
+}
+
+func (repo *MongoOrganizationRepository) find(ctx context.Context, filter bson.D) ([]*models.Organization, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(organizationCollection)
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "name", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding organizations")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.Organization = []*models.Organization{}
+	for cursor.Next(ctx) {
+		var organization models.Organization
+		if err := cursor.Decode(&organization); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an organization")
+		} else {
+			results = append(results, &organization)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error finding organizations")
+	}
+	return results, nil
+}
+
+// Insert a new organization into mongodb, owner is its first member when it is not nil
+func (repo *MongoOrganizationRepository) Insert(ctx context.Context, dto *dtos.OrganizationDto, owner *models.Membership) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "Insert", organizationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(organizationCollection)
+	members := []models.Membership{}
+	if owner != nil {
+		members = append(members, *owner)
+	}
+	now := primitive.DateTime(time.Now().UnixNano() / 1e6)
+	data := bson.D{
+		primitive.E{Key: "name", Value: dto.Name},
+		primitive.E{Key: "kind", Value: dto.Kind},
+		primitive.E{Key: "members", Value: members},
+		primitive.E{Key: "recordStatus", Value: enums.Active},
+		primitive.E{Key: "createdAt", Value: now},
+		primitive.E{Key: "updatedAt", Value: now},
+		primitive.E{Key: "version", Value: int64(1)},
+	}
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new organization")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// Update an organization by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoOrganizationRepository) Update(ctx context.Context, id string, dto *dtos.OrganizationDto, versions []int64) (*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "Update", organizationCollection)()
+	data := bson.D{
+		primitive.E{Key: "name", Value: dto.Name},
+		primitive.E{Key: "kind", Value: dto.Kind},
+		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	if dto.RecordStatus != nil {
+		data = append(data, primitive.E{Key: "recordStatus", Value: dto.RecordStatus})
+	}
+	return repo.updateOrganization(ctx, id, nil, bson.D{primitive.E{Key: "$set", Value: data}, incVersion()}, versions)
+}
+
+// AddMember adds a member to an organization, the organization is left as is and nil is returned
+// when the member already belongs to it
+func (repo *MongoOrganizationRepository) AddMember(ctx context.Context, id string, membership *models.Membership, versions []int64) (*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "AddMember", organizationCollection)()
+	notMember := bson.D{primitive.E{Key: "members", Value: bson.D{primitive.E{Key: "$not", Value: bson.D{primitive.E{Key: "$elemMatch", Value: bson.D{
+		primitive.E{Key: "memberId", Value: membership.MemberID},
+		primitive.E{Key: "memberType", Value: membership.MemberType},
+	}}}}}}}
+	update := bson.D{
+		primitive.E{Key: "$push", Value: bson.D{primitive.E{Key: "members", Value: membership}}},
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)}}},
+		incVersion(),
+	}
+	return repo.updateOrganization(ctx, id, notMember, update, versions)
+}
+
+// UpdateMember changes the role of a member of an organization, nil is returned when the member
+// does not belong to it
+func (repo *MongoOrganizationRepository) UpdateMember(ctx context.Context, id string, memberID primitive.ObjectID, role enums.EnumMemberRole, versions []int64) (*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "UpdateMember", organizationCollection)()
+	member := bson.D{primitive.E{Key: "members.memberId", Value: memberID}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "members.$.role", Value: role},
+			primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+		}},
+		incVersion(),
+	}
+	return repo.updateOrganization(ctx, id, member, update, versions)
+}
+
+// RemoveMember removes a member from an organization, nil is returned when the member does not
+// belong to it
+func (repo *MongoOrganizationRepository) RemoveMember(ctx context.Context, id string, memberID primitive.ObjectID, versions []int64) (*models.Organization, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "RemoveMember", organizationCollection)()
+	member := bson.D{primitive.E{Key: "members.memberId", Value: memberID}}
+	update := bson.D{
+		primitive.E{Key: "$pull", Value: bson.D{primitive.E{Key: "members", Value: bson.D{primitive.E{Key: "memberId", Value: memberID}}}}},
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)}}},
+		incVersion(),
+	}
+	return repo.updateOrganization(ctx, id, member, update, versions)
+}
+
+// updateOrganization applies update to an organization matching condition, nil is returned when
+// no organization matches
+func (repo *MongoOrganizationRepository) updateOrganization(ctx context.Context, id string, condition bson.D, update bson.D, versions []int64) (*models.Organization, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(organizationCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := collection.FindOneAndUpdate(ctx, append(withVersions(filter, versions), condition...), update, updateOpts)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+	var updatedOrganization *models.Organization
+	if err := result.Decode(&updatedOrganization); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, versionConflict(ctx, collection, append(filter, condition...), versions)
+		}
+		return nil, errors.Wrap(err, "Error decoding an organization")
+	}
+	return updatedOrganization, nil
+}
+
+// Delete an organization document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoOrganizationRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoOrganizationRepository", "Delete", organizationCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(organizationCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
+	if err != nil {
+		return false, errors.Wrap(err, "Error deleting an organization")
+	}
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
+}
+
+// NewMongoOrganizationRepository returns a new instance of a MongoDB organization repo.
+func NewMongoOrganizationRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoOrganizationRepository {
+	return &MongoOrganizationRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
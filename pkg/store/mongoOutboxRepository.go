@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const outboxCollection = "outbox"
+
+// outboxIndexes are the indexes of the outbox, the oldest event waiting to be fanned out is
+// claimed first
+var outboxIndexes = []Index{
+	{Collection: outboxCollection, Name: "status_createdAt", Keys: bson.D{
+		primitive.E{Key: "status", Value: 1},
+		primitive.E{Key: "createdAt", Value: 1},
+	}},
+}
+
+// MongoOutboxRepository a repository for the domain events waiting to be dispatched to webhooks
+type MongoOutboxRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// Insert appends a domain event to the outbox
+func (repo *MongoOutboxRepository) Insert(ctx context.Context, event *models.OutboxEvent) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "Insert", outboxCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	result, err := collection.InsertOne(ctx, event)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new outbox event")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// FindByID returns an outbox event by its ID from mongodb
+func (repo *MongoOutboxRepository) FindByID(id string) (*models.OutboxEvent, error) {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "FindByID", outboxCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	return decodeOutboxEvent(collection.FindOne(context.TODO(), filter))
+}
+
+// ClaimPending takes the oldest event waiting to be fanned out and leases it to the caller until
+// leaseUntil, events whose lease expired before being marked as dispatched are claimed again
+func (repo *MongoOutboxRepository) ClaimPending(now time.Time, leaseUntil time.Time) (*models.OutboxEvent, error) {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "ClaimPending", outboxCollection)()
+	filter := bson.D{primitive.E{Key: "$or", Value: bson.A{
+		bson.D{primitive.E{Key: "status", Value: enums.OutboxPending}},
+		bson.D{
+			primitive.E{Key: "status", Value: enums.OutboxDispatching},
+			primitive.E{Key: "leaseUntil", Value: bson.D{primitive.E{Key: "$lte", Value: now}}},
+		},
+	}}}
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "status", Value: enums.OutboxDispatching},
+		primitive.E{Key: "leaseUntil", Value: leaseUntil},
+	}}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{primitive.E{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	return decodeOutboxEvent(collection.FindOneAndUpdate(context.TODO(), filter, update, opts))
+}
+
+// MarkDispatched records that an event has a delivery for every subscription that matched it
+func (repo *MongoOutboxRepository) MarkDispatched(id primitive.ObjectID, dispatchedAt time.Time) error {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "MarkDispatched", outboxCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: enums.OutboxDispatched},
+			primitive.E{Key: "dispatchedAt", Value: dispatchedAt},
+		}},
+		primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "leaseUntil", Value: ""}}},
+	}
+	if _, err := collection.UpdateOne(context.TODO(), filter, update); err != nil {
+		return errors.Wrap(err, "Error marking an outbox event as dispatched")
+	}
+	return nil
+}
+
+// Requeue puts an event back in the outbox so that it is fanned out again, subscriptions that
+// already have a delivery for it keep that delivery
+func (repo *MongoOutboxRepository) Requeue(id string) (*models.OutboxEvent, error) {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "Requeue", outboxCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "status", Value: enums.OutboxPending}}},
+		primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "leaseUntil", Value: ""},
+			primitive.E{Key: "dispatchedAt", Value: ""},
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	return decodeOutboxEvent(collection.FindOneAndUpdate(context.TODO(), filter, update, opts))
+}
+
+// FindAfter returns up to limit events written to the outbox after the event afterID, oldest first
+func (repo *MongoOutboxRepository) FindAfter(afterID primitive.ObjectID, limit int64) ([]*models.OutboxEvent, error) {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "FindAfter", outboxCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$gt", Value: afterID}}}}
+	opts := options.Find().
+		SetSort(bson.D{primitive.E{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	defer cancel()
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding outbox events")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.OutboxEvent = []*models.OutboxEvent{}
+	for cursor.Next(context.TODO()) {
+		var event models.OutboxEvent
+		if err := cursor.Decode(&event); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an outbox event on FindAfter()")
+		} else {
+			results = append(results, &event)
+		}
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding outbox events")
+	}
+	return results, nil
+}
+
+// OutboxWatcher iterates over the events inserted in the outbox after it was opened
+type OutboxWatcher struct {
+	stream *mongo.ChangeStream
+}
+
+// Next blocks until an event is inserted in the outbox and returns it
+func (w *OutboxWatcher) Next(ctx context.Context) (*models.OutboxEvent, error) {
+	if !w.stream.Next(ctx) {
+		if err := w.stream.Err(); err != nil {
+			return nil, errors.Wrap(err, "Error watching the outbox")
+		}
+		return nil, ctx.Err()
+	}
+	var change struct {
+		ResumeToken  bson.Raw           `bson:"_id"`
+		FullDocument models.OutboxEvent `bson:"fullDocument"`
+	}
+	if err := w.stream.Decode(&change); err != nil {
+		return nil, errors.Wrap(err, "Error decoding an outbox change")
+	}
+	change.FullDocument.StreamID = base64.RawURLEncoding.EncodeToString(change.ResumeToken)
+	return &change.FullDocument, nil
+}
+
+// Close stops watching the outbox
+func (w *OutboxWatcher) Close() error {
+	return w.stream.Close(context.TODO())
+}
+
+// Watch opens a change stream over the insertions in the outbox, change streams are only
+// available on replica sets and sharded clusters. The stream starts now, or right after the
+// event of a resume token when it is not nil. The events are streamed in the order they were
+// committed in and the StreamID of each one is its resume token, see ParseResumeToken.
+func (repo *MongoOutboxRepository) Watch(ctx context.Context, resumeToken bson.Raw) (*OutboxWatcher, error) {
+	defer metrics.ObserveMongoOperation("MongoOutboxRepository", "Watch", outboxCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(outboxCollection)
+	pipeline := mongo.Pipeline{bson.D{primitive.E{Key: "$match", Value: bson.D{
+		primitive.E{Key: "operationType", Value: "insert"},
+	}}}}
+	opts := options.ChangeStream()
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+	stream, err := collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error watching the outbox")
+	}
+	return &OutboxWatcher{stream}, nil
+}
+
+// ParseResumeToken returns the resume token of a StreamID set by an OutboxWatcher
+func ParseResumeToken(streamID string) (bson.Raw, error) {
+	token, err := base64.RawURLEncoding.DecodeString(streamID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid resume token")
+	}
+	if err := bson.Raw(token).Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid resume token")
+	}
+	return bson.Raw(token), nil
+}
+
+// ObjectIDFromTime returns the smallest ObjectID generated at t, it sorts before the IDs of the
+// documents inserted from t on
+func ObjectIDFromTime(t time.Time) primitive.ObjectID {
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[0:4], uint32(t.Unix()))
+	return id
+}
+
+// TimeFromObjectID returns the time an ObjectID was generated at, to the second
+func TimeFromObjectID(id primitive.ObjectID) time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(id[0:4])), 0)
+}
+
+func decodeOutboxEvent(result *mongo.SingleResult) (*models.OutboxEvent, error) {
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var event *models.OutboxEvent
+	if err := result.Decode(&event); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding an outbox event")
+	}
+	return event, nil
+}
+
+// NewMongoOutboxRepository returns a new instance of a MongoDB outbox repo.
+func NewMongoOutboxRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoOutboxRepository {
+	return &MongoOutboxRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// referenceCollections are the collections of the resource types records can reference, the
+// states are stored within the countries
+var referenceCollections = map[string]string{
+	models.ResourceSupplier:  supplierCollection,
+	models.ResourceCity:      cityCollection,
+	models.ResourceItem:      itemCollection,
+	models.ResourceVariant:   variantCollection,
+	models.ResourceCrop:      cropCollection,
+	models.ResourceUser:      userCollection,
+	models.ResourceAPIClient: apiClientCollection,
+}
+
+// referenceModels returns the models the records of the resource types are decoded into
+var referenceModels = map[string]func() interface{}{
+	models.ResourceSupplier:  func() interface{} { return &models.Supplier{} },
+	models.ResourceCity:      func() interface{} { return &models.City{} },
+	models.ResourceItem:      func() interface{} { return &models.Item{} },
+	models.ResourceVariant:   func() interface{} { return &models.Variant{} },
+	models.ResourceCrop:      func() interface{} { return &models.Crop{} },
+	models.ResourceUser:      func() interface{} { return &models.User{} },
+	models.ResourceAPIClient: func() interface{} { return &models.APIClient{} },
+}
+
+// MongoReferenceRepository looks up and updates the records referencing other records, whatever
+// their type, to keep the references between collections consistent
+type MongoReferenceRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// LockActive tells whether a record of a resource type exists and is not inactive, the records
+// without a record status are active. The record is written to, so that a transaction deleting
+// or deactivating it conflicts with the transaction of ctx instead of leaving it referenced.
+func (repo *MongoReferenceRepository) LockActive(ctx context.Context, resource string, id primitive.ObjectID) (bool, error) {
+	database := repo.client.Database(repo.databaseName)
+	notInactive := bson.D{primitive.E{Key: "$ne", Value: enums.Inactive.String()}}
+	var collection *mongo.Collection
+	var filter bson.D
+	if resource == models.ResourceCountryState {
+		collection = database.Collection(countryCollection)
+		filter = bson.D{
+			primitive.E{Key: "states", Value: bson.D{primitive.E{Key: "$elemMatch", Value: bson.D{
+				primitive.E{Key: "_id", Value: id},
+				primitive.E{Key: "recordStatus", Value: notInactive},
+			}}}},
+			primitive.E{Key: "recordStatus", Value: notInactive},
+		}
+	} else {
+		name, err := referenceCollection(resource)
+		if err != nil {
+			return false, err
+		}
+		collection = database.Collection(name)
+		filter = bson.D{primitive.E{Key: "_id", Value: id}, primitive.E{Key: "recordStatus", Value: notInactive}}
+	}
+	defer metrics.ObserveMongoOperation("MongoReferenceRepository", "LockActive", collection.Name())()
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "referenceLock", Value: primitive.NewObjectID()}}}}
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errors.Wrapf(err, "Error looking up the %s %s", resource, id.Hex())
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// FindReferencing returns the IDs of the records of a resource type whose field references one of
+// the given IDs
+func (repo *MongoReferenceRepository) FindReferencing(ctx context.Context, resource string, field string, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	name, err := referenceCollection(resource)
+	if err != nil {
+		return nil, err
+	}
+	defer metrics.ObserveMongoOperation("MongoReferenceRepository", "FindReferencing", name)()
+	collection := repo.client.Database(repo.databaseName).Collection(name)
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	filter := bson.D{primitive.E{Key: field, Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	projection := options.Find().SetProjection(bson.D{primitive.E{Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, projection)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error finding the %s records referencing through %s", resource, field)
+	}
+	defer cursor.Close(ctx)
+
+	var referencing []primitive.ObjectID
+	for cursor.Next(ctx) {
+		var record struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&record); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding a %s record", resource)
+		}
+		referencing = append(referencing, record.ID)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrapf(err, "Error finding the %s records referencing through %s", resource, field)
+	}
+	return referencing, nil
+}
+
+// Find returns the records of a resource type with the given IDs, by ID
+func (repo *MongoReferenceRepository) Find(ctx context.Context, resource string, ids []primitive.ObjectID) (map[primitive.ObjectID]interface{}, error) {
+	name, err := referenceCollection(resource)
+	if err != nil {
+		return nil, err
+	}
+	defer metrics.ObserveMongoOperation("MongoReferenceRepository", "Find", name)()
+	collection := repo.client.Database(repo.databaseName).Collection(name)
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error finding %s records", resource)
+	}
+	defer cursor.Close(ctx)
+
+	records := map[primitive.ObjectID]interface{}{}
+	for cursor.Next(ctx) {
+		record := referenceModels[resource]()
+		if err := cursor.Decode(record); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding a %s record", resource)
+		}
+		records[cursor.Current.Lookup("_id").ObjectID()] = record
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrapf(err, "Error finding %s records", resource)
+	}
+	return records, nil
+}
+
+// Delete removes the records of a resource type with the given IDs
+func (repo *MongoReferenceRepository) Delete(ctx context.Context, resource string, ids []primitive.ObjectID) (int64, error) {
+	name, err := referenceCollection(resource)
+	if err != nil {
+		return 0, err
+	}
+	defer metrics.ObserveMongoOperation("MongoReferenceRepository", "Delete", name)()
+	collection := repo.client.Database(repo.databaseName).Collection(name)
+
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error deleting %s records", resource)
+	}
+	return result.DeletedCount, nil
+}
+
+// Nullify removes the reference held by field from the records of a resource type with the given
+// IDs, their version is bumped
+func (repo *MongoReferenceRepository) Nullify(ctx context.Context, resource string, field string, ids []primitive.ObjectID) (int64, error) {
+	return repo.set(ctx, resource, "Nullify", ids, primitive.E{Key: field, Value: nil})
+}
+
+// Deactivate sets the record status of the records of a resource type with the given IDs to
+// inactive, their version is bumped
+func (repo *MongoReferenceRepository) Deactivate(ctx context.Context, resource string, ids []primitive.ObjectID) (int64, error) {
+	return repo.set(ctx, resource, "Deactivate", ids, primitive.E{Key: "recordStatus", Value: enums.Inactive.String()})
+}
+
+func (repo *MongoReferenceRepository) set(ctx context.Context, resource string, operation string, ids []primitive.ObjectID, value primitive.E) (int64, error) {
+	name, err := referenceCollection(resource)
+	if err != nil {
+		return 0, err
+	}
+	defer metrics.ObserveMongoOperation("MongoReferenceRepository", operation, name)()
+	collection := repo.client.Database(repo.databaseName).Collection(name)
+
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{value}}, incVersion()}
+	result, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error updating %s records", resource)
+	}
+	return result.ModifiedCount, nil
+}
+
+func referenceCollection(resource string) (string, error) {
+	name, ok := referenceCollections[resource]
+	if !ok {
+		return "", errors.Errorf("The %s records cannot be referenced", resource)
+	}
+	return name, nil
+}
+
+// NewMongoReferenceRepository returns a new instance of a MongoDB reference repo.
+func NewMongoReferenceRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoReferenceRepository {
+	return &MongoReferenceRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
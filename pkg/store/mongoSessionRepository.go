@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const sessionCollection = "sessions"
+
+// sessionIndexes are the indexes of the sessions, a session is authenticated by its token and
+// MongoDB deletes it once it has expired
+var sessionIndexes = []Index{
+	{Collection: sessionCollection, Name: "hashedToken_unique", Keys: bson.D{primitive.E{Key: "hashedToken", Value: 1}}, Unique: true},
+	{Collection: sessionCollection, Name: "expiresAt_ttl", Keys: bson.D{primitive.E{Key: "expiresAt", Value: 1}}, Expires: true},
+}
+
+// MongoSessionRepository a repository for saving the sessions of the users into a mongo database
+type MongoSessionRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByHashedToken returns the session opened with the token of the given hash, or nil when it
+// does not exist or has expired at now
+func (repo *MongoSessionRepository) FindByHashedToken(ctx context.Context, hashedToken string, now time.Time) (*models.Session, error) {
+	defer metrics.ObserveMongoOperation("MongoSessionRepository", "FindByHashedToken", sessionCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(sessionCollection)
+	filter := bson.D{
+		primitive.E{Key: "hashedToken", Value: hashedToken},
+		// The TTL monitor only runs once a minute
+		primitive.E{Key: "expiresAt", Value: bson.M{"$gt": now}},
+	}
+	result := collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var session *models.Session
+	if err := result.Decode(&session); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding a session")
+	}
+	return session, nil
+}
+
+// Insert a new session into mongodb, only the hash of its token is stored
+func (repo *MongoSessionRepository) Insert(ctx context.Context, session *models.Session) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoSessionRepository", "Insert", sessionCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(sessionCollection)
+	data := bson.D{
+		primitive.E{Key: "userId", Value: session.UserID},
+		primitive.E{Key: "hashedToken", Value: session.HashedToken},
+		primitive.E{Key: "expiresAt", Value: session.ExpiresAt},
+		primitive.E{Key: "createdAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new session")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// DeleteByHashedToken deletes the session opened with the token of the given hash, it tells
+// whether such a session existed
+func (repo *MongoSessionRepository) DeleteByHashedToken(ctx context.Context, hashedToken string) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoSessionRepository", "DeleteByHashedToken", sessionCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(sessionCollection)
+	result, err := collection.DeleteOne(ctx, bson.D{primitive.E{Key: "hashedToken", Value: hashedToken}})
+	if err != nil {
+		return false, errors.Wrap(err, "Error deleting a session")
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// NewMongoSessionRepository returns a new instance of a MongoDB session repo.
+func NewMongoSessionRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoSessionRepository {
+	return &MongoSessionRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
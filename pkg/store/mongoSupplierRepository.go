@@ -2,13 +2,14 @@ package store
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,6 +19,24 @@ import (
 
 const supplierCollection string = "suppliers"
 
+// supplierIndexes are the indexes of the suppliers, a document identifies a single supplier of a
+// country and the suppliers of a city are looked up before deleting it
+var supplierIndexes = []Index{
+	{Collection: supplierCollection, Name: "cityId", Keys: bson.D{primitive.E{Key: "cityId", Value: 1}}},
+	{
+		Collection: supplierCollection,
+		Name:       "countryId_documentType_documentNumber_unique",
+		Keys: bson.D{
+			primitive.E{Key: "countryId", Value: 1},
+			primitive.E{Key: "documentType", Value: 1},
+			primitive.E{Key: "documentNumber", Value: 1},
+		},
+		Unique:  true,
+		Partial: bson.D{primitive.E{Key: "documentNumber", Value: bson.D{primitive.E{Key: "$gt", Value: ""}}}},
+		Field:   "documentNumber",
+	},
+}
+
 //MongoSupplierRepository a repo for saving suppliers into a mongo database
 type MongoSupplierRepository struct {
 	databaseName string
@@ -25,14 +44,15 @@ type MongoSupplierRepository struct {
 }
 
 // FindByID returns a supplier by its ID from mongodb
-func (repo *MongoSupplierRepository) FindByID(id string) (*models.Supplier, error) {
+func (repo *MongoSupplierRepository) FindByID(ctx context.Context, id string) (*models.Supplier, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "FindByID", supplierCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result := collection.FindOne(context.TODO(), filter)
+	result := collection.FindOne(ctx, filter)
 	if result.Err() != nil {
 		return nil, result.Err()
 	}
@@ -48,25 +68,30 @@ func (repo *MongoSupplierRepository) FindByID(id string) (*models.Supplier, erro
 }
 
 // PopulateSupplierByID return a supplier with the crops property populated with the variant data
-func (repo *MongoSupplierRepository) PopulateSupplierByID(id string) (*models.Supplier, error) {
+func (repo *MongoSupplierRepository) PopulateSupplierByID(ctx context.Context, id string) (*models.Supplier, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "PopulateSupplierByID", supplierCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	var pipeline = []bson.M{
 		bson.M{"$match": bson.M{"_id": objID}},
 	}
 	pipeline = append(pipeline, buildStandardSupplierPipeline()...)
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding a supplier")
+	}
+	defer cursor.Close(ctx)
 
 	var supplier *models.Supplier
-	for cursor.Next(context.TODO()) {
+	for cursor.Next(ctx) {
 		if err := cursor.Decode(&supplier); err != nil {
-			log.Printf("Error decoding a supplier: %v", err)
+			return nil, errors.Wrap(err, "Error decoding a supplier")
 		}
 	}
 	err = cursor.Err()
@@ -81,21 +106,22 @@ func (repo *MongoSupplierRepository) PopulateSupplierByID(id string) (*models.Su
 
 // FindAll returns a list of suppliers from mongodb
 func (repo *MongoSupplierRepository) FindAll() ([]*models.Supplier, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "FindAll", supplierCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
 	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
 	defer cancel()
 	var pipeline = buildStandardSupplierPipeline()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all suppliers")
 	}
+	defer cursor.Close(context.TODO())
 
 	var results []*models.Supplier
 	for cursor.Next(context.TODO()) {
 		var supplier models.Supplier
 		if err := cursor.Decode(&supplier); err != nil {
-			log.Printf("Error decoding a supplier on FindAll(): %v", err)
+			logging.Default().WithError(err).Error("Error decoding a supplier on FindAll()")
 		} else {
 			results = append(results, &supplier)
 		}
@@ -107,9 +133,54 @@ func (repo *MongoSupplierRepository) FindAll() ([]*models.Supplier, error) {
 	return results, nil
 }
 
-// Insert a new supplier into mongodb
-func (repo *MongoSupplierRepository) Insert(supplier *dtos.SupplierDto) (string, error) {
+// Each calls fn with every supplier and its city, read one after the other from the cursor so that
+// the whole collection is never held in memory. It stops at the first error returned by fn.
+func (repo *MongoSupplierRepository) Each(ctx context.Context, fn func(*models.Supplier) error) error {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "Each", supplierCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
+	pipeline := []bson.M{
+		bson.M{"$lookup": bson.M{
+			"from":         "cities",
+			"localField":   "cityId",
+			"foreignField": "_id",
+			"as":           "city",
+		}},
+		bson.M{"$unwind": bson.M{
+			"path":                       "$city",
+			"preserveNullAndEmptyArrays": true,
+		}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return errors.Wrap(err, "Error reading the suppliers")
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(ctx) {
+		var supplier models.Supplier
+		if err := cursor.Decode(&supplier); err != nil {
+			logging.Default().WithError(err).Error("Error decoding a supplier on Each()")
+			continue
+		}
+		if err := fn(&supplier); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error reading the suppliers")
+	}
+	return nil
+}
+
+// Insert a new supplier into mongodb
+func (repo *MongoSupplierRepository) Insert(ctx context.Context, supplier *dtos.SupplierDto) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "Insert", supplierCollection)()
+	database := repo.client.Database(repo.databaseName)
+	collection := database.Collection(supplierCollection)
+	countryID, err := countryOfCity(ctx, database, supplier.CityID)
+	if err != nil {
+		return string(""), err
+	}
 	now := primitive.DateTime(time.Now().UnixNano() / 1e6)
 	data := bson.D{
 		primitive.E{Key: "name", Value: supplier.Name},
@@ -117,26 +188,35 @@ func (repo *MongoSupplierRepository) Insert(supplier *dtos.SupplierDto) (string,
 		primitive.E{Key: "documentType", Value: supplier.DocumentType},
 		primitive.E{Key: "documentNumber", Value: supplier.DocumentNumber},
 		primitive.E{Key: "cityId", Value: supplier.CityID},
+		primitive.E{Key: "countryId", Value: countryID},
 		primitive.E{Key: "email", Value: supplier.Email},
 		primitive.E{Key: "addressLine1", Value: supplier.AddressLine1},
 		primitive.E{Key: "phoneNumber", Value: supplier.PhoneNumber},
 		primitive.E{Key: "createdAt", Value: now},
 		primitive.E{Key: "updatedAt", Value: now},
 		primitive.E{Key: "recordStatus", Value: enums.Active},
+		primitive.E{Key: "version", Value: int64(1)},
 	}
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
-		return string(""), errors.Wrap(err, "Inserting a new supplier")
+		return string(""), writeError(err, supplierCollection, "Inserting a new supplier")
 	}
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update a supplier's document by its id in mongodb
-func (repo *MongoSupplierRepository) Update(id string, supplier *dtos.SupplierDto) (*models.Supplier, error) {
-	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+// Update a supplier's document by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoSupplierRepository) Update(ctx context.Context, id string, supplier *dtos.SupplierDto, versions []int64) (*models.Supplier, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "Update", supplierCollection)()
+	database := repo.client.Database(repo.databaseName)
+	collection := database.Collection(supplierCollection)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
+	}
+	countryID, err := countryOfCity(ctx, database, supplier.CityID)
+	if err != nil {
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
 	update := bson.D{primitive.E{
@@ -147,43 +227,97 @@ func (repo *MongoSupplierRepository) Update(id string, supplier *dtos.SupplierDt
 			primitive.E{Key: "documentType", Value: supplier.DocumentType},
 			primitive.E{Key: "documentNumber", Value: supplier.DocumentNumber},
 			primitive.E{Key: "cityId", Value: supplier.CityID},
+			primitive.E{Key: "countryId", Value: countryID},
 			primitive.E{Key: "email", Value: supplier.Email},
 			primitive.E{Key: "addressLine1", Value: supplier.AddressLine1},
 			primitive.E{Key: "phoneNumber", Value: supplier.PhoneNumber},
 			primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
 		},
-	}}
+	}, incVersion()}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, writeError(result.Err(), supplierCollection, "Error updating a supplier")
 	}
 	var updatedSupplier *models.Supplier
 	if err := result.Decode(&updatedSupplier); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
+		}
+		return nil, errors.Wrap(err, "Error decoding a supplier")
+	}
+	return updatedSupplier, nil
+}
+
+// SetRecordStatus sets the record status of a supplier's document by its id in mongodb, when
+// versions is not nil the write only applies if the stored version is one of them
+func (repo *MongoSupplierRepository) SetRecordStatus(ctx context.Context, id string, status enums.EnumRecordStatus, versions []int64) (*models.Supplier, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "SetRecordStatus", supplierCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	update := bson.D{primitive.E{
+		Key: "$set",
+		Value: bson.D{
+			primitive.E{Key: "recordStatus", Value: status},
+			primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+		},
+	}, incVersion()}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
+	if result.Err() != nil {
+		return nil, errors.Wrap(result.Err(), "Error setting the record status of a supplier")
+	}
+	var updatedSupplier *models.Supplier
+	if err := result.Decode(&updatedSupplier); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a supplier")
 	}
 	return updatedSupplier, nil
 }
 
-// Delete a supliers document from mongodb
-func (repo *MongoSupplierRepository) Delete(id string) (bool, error) {
+// CountActive returns the number of suppliers whose record status is active
+func (repo *MongoSupplierRepository) CountActive() (int64, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "CountActive", supplierCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	count, err := collection.CountDocuments(ctx, bson.M{"recordStatus": enums.Active})
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return 0, errors.Wrap(err, "Error counting the active suppliers")
+	}
+	return count, nil
+}
+
+// Delete a supliers document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoSupplierRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoSupplierRepository", "Delete", supplierCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(supplierCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting a supplier")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
 func buildStandardSupplierPipeline() []bson.M {
@@ -267,6 +401,9 @@ func buildStandardSupplierPipeline() []bson.M {
 			"recordStatus": bson.M{
 				"$first": "$recordStatus",
 			},
+			"version": bson.M{
+				"$first": "$version",
+			},
 			"hasCrops": bson.M{
 				"$first": "$hasCrops",
 			},
@@ -287,6 +424,7 @@ func buildStandardSupplierPipeline() []bson.M {
 			"createdAt":      1,
 			"updatedAt":      1,
 			"recordStatus":   1,
+			"version":        1,
 			"crops": bson.M{
 				"$cond": bson.A{bson.M{"$eq": bson.A{"$hasCrops", 0}}, bson.A{}, "$crops"},
 			},
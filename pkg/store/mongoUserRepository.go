@@ -2,13 +2,15 @@ package store
 
 import (
 	"context"
-	"log"
+	"strings"
 	"time"
 
 	"futuagro.com/pkg/config"
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,6 +21,13 @@ import (
 
 const userCollection string = "users"
 
+// userIndexes are the indexes of the users, an email identifies a single user whatever its case
+// and the users of a city are looked up before deleting it
+var userIndexes = []Index{
+	{Collection: userCollection, Name: "lemail_unique", Keys: bson.D{primitive.E{Key: "lemail", Value: 1}}, Unique: true, Field: "email"},
+	{Collection: userCollection, Name: "cityId", Keys: bson.D{primitive.E{Key: "cityId", Value: 1}}},
+}
+
 //MongoUserRepository a repo for saving users into a mongo database
 type MongoUserRepository struct {
 	databaseName string
@@ -26,33 +35,38 @@ type MongoUserRepository struct {
 }
 
 // FindByID returns an user by its ID from mongodb
-func (repo *MongoUserRepository) FindByID(id string) (*models.User, error) {
-	objID, err := primitive.ObjectIDFromHex(id)
+func (repo *MongoUserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "FindByID", userCollection)()
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	user, err := repo.findOneUserBy(filter)
+	user, err := repo.findOneUserBy(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 	if user != nil {
 		user.HashedPassword = ""
 	}
 	return user, nil
 }
 
-// FindByEmail returns an user by its ID from mongodb
-func (repo *MongoUserRepository) FindByEmail(email string) (*models.User, error) {
+// FindByEmail returns an user by its email from mongodb, whatever the case of the email
+func (repo *MongoUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "FindByEmail", userCollection)()
 
-	filter := bson.D{primitive.E{Key: "email", Value: email}}
-	user, err := repo.findOneUserBy(filter)
+	filter := bson.D{primitive.E{Key: "lemail", Value: strings.ToLower(email)}}
+	user, err := repo.findOneUserBy(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
-func (repo *MongoUserRepository) findOneUserBy(filter interface{}) (*models.User, error) {
+func (repo *MongoUserRepository) findOneUserBy(ctx context.Context, filter interface{}) (*models.User, error) {
 	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
-	result := collection.FindOne(context.TODO(), filter)
+	result := collection.FindOne(ctx, filter)
 	if result.Err() != nil {
 		return nil, result.Err()
 	}
@@ -68,25 +82,30 @@ func (repo *MongoUserRepository) findOneUserBy(filter interface{}) (*models.User
 }
 
 // PopulateUserByID return an user with the crops property populated with the variants data
-func (repo *MongoUserRepository) PopulateUserByID(id string) (*models.User, error) {
+func (repo *MongoUserRepository) PopulateUserByID(ctx context.Context, id string) (*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "PopulateUserByID", userCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	var pipeline = []bson.M{
 		bson.M{"$match": bson.M{"_id": objID}},
 	}
 	pipeline = append(pipeline, buildStandardUserPipeline()...)
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding an user")
+	}
+	defer cursor.Close(ctx)
 
 	var user *models.User
-	for cursor.Next(context.TODO()) {
+	for cursor.Next(ctx) {
 		if err := cursor.Decode(&user); err != nil {
-			log.Printf("Error decoding an user: %v", err)
+			return nil, errors.Wrap(err, "Error decoding an user")
 		}
 	}
 	err = cursor.Err()
@@ -101,21 +120,22 @@ func (repo *MongoUserRepository) PopulateUserByID(id string) (*models.User, erro
 
 // FindAll returns a list of users from mongodb
 func (repo *MongoUserRepository) FindAll() ([]*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "FindAll", userCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
 	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
 	defer cancel()
 	var pipeline = buildStandardUserPipeline()
 	cursor, err := collection.Aggregate(ctx, pipeline, nil)
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all users")
 	}
+	defer cursor.Close(context.TODO())
 
 	var results []*models.User
 	for cursor.Next(context.TODO()) {
 		var user models.User
 		if err := cursor.Decode(&user); err != nil {
-			log.Printf("Error decoding an user on FindAll(): %v", err)
+			logging.Default().WithError(err).Error("Error decoding an user on FindAll()")
 		} else {
 			results = append(results, &user)
 		}
@@ -127,8 +147,37 @@ func (repo *MongoUserRepository) FindAll() ([]*models.User, error) {
 	return results, nil
 }
 
+// Each calls fn with every user and its populated relations, read one after the other from the
+// cursor so that the whole collection is never held in memory. It stops at the first error
+// returned by fn.
+func (repo *MongoUserRepository) Each(ctx context.Context, fn func(*models.User) error) error {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "Each", userCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
+	cursor, err := collection.Aggregate(ctx, buildStandardUserPipeline())
+	if err != nil {
+		return errors.Wrap(err, "Error reading the users")
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			logging.Default().WithError(err).Error("Error decoding an user on Each()")
+			continue
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(err, "Error reading the users")
+	}
+	return nil
+}
+
 // Insert a new user into mongodb
-func (repo *MongoUserRepository) Insert(dto *dtos.UserDto) (string, error) {
+func (repo *MongoUserRepository) Insert(ctx context.Context, dto *dtos.UserDto) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "Insert", userCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
 	now := primitive.DateTime(time.Now().UnixNano() / 1e6)
 
@@ -144,27 +193,31 @@ func (repo *MongoUserRepository) Insert(dto *dtos.UserDto) (string, error) {
 		primitive.E{Key: "documentNumber", Value: dto.DocumentNumber},
 		primitive.E{Key: "cityId", Value: dto.CityID},
 		primitive.E{Key: "email", Value: dto.Email},
+		primitive.E{Key: "lemail", Value: strings.ToLower(dto.Email)},
 		primitive.E{Key: "hashedPassword", Value: string(hashedPwdInBytes)},
 		primitive.E{Key: "addressLine1", Value: dto.AddressLine1},
 		primitive.E{Key: "phoneNumber", Value: dto.PhoneNumber},
-		primitive.E{Key: "role", Value: "user"},
+		primitive.E{Key: "role", Value: models.RoleUser},
 		primitive.E{Key: "createdAt", Value: now},
 		primitive.E{Key: "updatedAt", Value: now},
 		primitive.E{Key: "recordStatus", Value: enums.Active},
+		primitive.E{Key: "version", Value: int64(1)},
 	}
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
-		return string(""), errors.Wrap(err, "Inserting a new user")
+		return string(""), writeError(err, userCollection, "Inserting a new user")
 	}
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update an user document by its id in mongodb
-func (repo *MongoUserRepository) Update(id string, dto *dtos.UserDto) (*models.User, error) {
+// Update an user document by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoUserRepository) Update(ctx context.Context, id string, dto *dtos.UserDto, versions []int64) (*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "Update", userCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return nil, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
 	update := bson.D{primitive.E{
@@ -176,18 +229,68 @@ func (repo *MongoUserRepository) Update(id string, dto *dtos.UserDto) (*models.U
 			primitive.E{Key: "documentNumber", Value: dto.DocumentNumber},
 			primitive.E{Key: "cityId", Value: dto.CityID},
 			primitive.E{Key: "email", Value: dto.Email},
+			primitive.E{Key: "lemail", Value: strings.ToLower(dto.Email)},
 			primitive.E{Key: "addressLine1", Value: dto.AddressLine1},
 			primitive.E{Key: "phoneNumber", Value: dto.PhoneNumber},
 			primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
 		},
-	}}
+	}, incVersion()}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
+	if result.Err() != nil {
+		return nil, writeError(result.Err(), userCollection, "Error updating an user")
+	}
+	var updatedUser *models.User
+	if err := result.Decode(&updatedUser); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, versionConflict(ctx, collection, filter, versions)
+		}
+		return nil, errors.Wrap(err, "Error decoding an user")
+	}
+	return updatedUser, nil
+}
+
+// SetRole sets the role of an user document by its id in mongodb
+func (repo *MongoUserRepository) SetRole(ctx context.Context, id string, role string) (*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "SetRole", userCollection)()
+	return repo.set(ctx, id, primitive.E{Key: "role", Value: role}, "Error setting the role of an user")
+}
+
+// SetPassword hashes a password and sets it as the password of an user document by its id in
+// mongodb
+func (repo *MongoUserRepository) SetPassword(ctx context.Context, id string, password string) (*models.User, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "SetPassword", userCollection)()
+	hashedPwdInBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.Wrap(err, "hashing a password")
+	}
+	return repo.set(ctx, id, primitive.E{Key: "hashedPassword", Value: string(hashedPwdInBytes)}, "Error setting the password of an user")
+}
+
+func (repo *MongoUserRepository) set(ctx context.Context, id string, value primitive.E, message string) (*models.User, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	update := bson.D{primitive.E{
+		Key: "$set",
+		Value: bson.D{
+			value,
+			primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+		},
+	}, incVersion()}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, errors.Wrap(result.Err(), message)
 	}
 	var updatedUser *models.User
 	if err := result.Decode(&updatedUser); err != nil {
@@ -199,19 +302,24 @@ func (repo *MongoUserRepository) Update(id string, dto *dtos.UserDto) (*models.U
 	return updatedUser, nil
 }
 
-// Delete a supliers document from mongodb
-func (repo *MongoUserRepository) Delete(id string) (bool, error) {
+// Delete an user document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoUserRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoUserRepository", "Delete", userCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(userCollection)
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
 	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting an user")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
 func buildStandardUserPipeline() []bson.M {
@@ -295,6 +403,9 @@ func buildStandardUserPipeline() []bson.M {
 			"recordStatus": bson.M{
 				"$first": "$recordStatus",
 			},
+			"version": bson.M{
+				"$first": "$version",
+			},
 			"hasCrops": bson.M{
 				"$first": "$hasCrops",
 			},
@@ -319,6 +430,7 @@ func buildStandardUserPipeline() []bson.M {
 			"updatedAt":      1,
 			"recordStatus":   1,
 			"role":           1,
+			"version":        1,
 			"crops": bson.M{
 				"$cond": bson.A{bson.M{"$eq": bson.A{"$hasCrops", 0}}, bson.A{}, "$crops"},
 			},
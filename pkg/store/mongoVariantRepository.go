@@ -2,7 +2,6 @@ package store
 
 import (
 	"context"
-	"log"
 	"strings"
 	"time"
 
@@ -10,6 +9,7 @@ import (
 	"futuagro.com/pkg/domain/dtos"
 	"futuagro.com/pkg/domain/enums"
 	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/metrics"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,6 +19,12 @@ import (
 
 const variantCollection = "variants"
 
+// variantIndexes are the indexes of the variants, they are listed, looked up and searched by item
+var variantIndexes = []Index{
+	{Collection: variantCollection, Name: "itemId", Keys: bson.D{primitive.E{Key: "itemId", Value: 1}}},
+	{Collection: variantCollection, Name: "itemId_terms", Keys: bson.D{primitive.E{Key: "itemId", Value: 1}, primitive.E{Key: "terms", Value: 1}}},
+}
+
 // MongoVariantRepository a repository that implements the basic CRUD operations for saving variants into a mongo database
 type MongoVariantRepository struct {
 	databaseName string
@@ -26,37 +32,39 @@ type MongoVariantRepository struct {
 }
 
 // FindVariantByID returns a Variant by its ID from mongodb
-func (repo *MongoVariantRepository) FindVariantByID(ID string) (*models.Variant, error) {
-	objID, err := primitive.ObjectIDFromHex(ID)
+func (repo *MongoVariantRepository) FindVariantByID(ctx context.Context, ID string) (*models.Variant, error) {
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "FindVariantByID", variantCollection)()
+	objID, err := parseObjectID(ID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: objID},
 	}
-	return repo.findOneVariant(filter)
+	return repo.findOneVariant(ctx, filter)
 }
 
 // FindOneVariantByItemID returns a Variant by its ID and Item ID from mongodb
-func (repo *MongoVariantRepository) FindOneVariantByItemID(itemID string, variantID string) (*models.Variant, error) {
-	objItemdID, err := primitive.ObjectIDFromHex(itemID)
+func (repo *MongoVariantRepository) FindOneVariantByItemID(ctx context.Context, itemID string, variantID string) (*models.Variant, error) {
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "FindOneVariantByItemID", variantCollection)()
+	objItemdID, err := parseObjectID(itemID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
-	objVariantID, err := primitive.ObjectIDFromHex(variantID)
+	objVariantID, err := parseObjectID(variantID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: objVariantID},
 		primitive.E{Key: "itemId", Value: objItemdID},
 	}
-	return repo.findOneVariant(filter)
+	return repo.findOneVariant(ctx, filter)
 }
 
-func (repo *MongoVariantRepository) findOneVariant(filter interface{}) (*models.Variant, error) {
+func (repo *MongoVariantRepository) findOneVariant(ctx context.Context, filter interface{}) (*models.Variant, error) {
 	collection := repo.client.Database(repo.databaseName).Collection(variantCollection)
-	result := collection.FindOne(context.TODO(), filter)
+	result := collection.FindOne(ctx, filter)
 
 	if result.Err() != nil {
 		return nil, result.Err()
@@ -74,22 +82,37 @@ func (repo *MongoVariantRepository) findOneVariant(filter interface{}) (*models.
 
 // FindVariantsByItemID return a list of variants that belongs to a product from mongodb
 func (repo *MongoVariantRepository) FindVariantsByItemID(itemID string) ([]*models.Variant, error) {
-	collection := repo.client.Database(repo.databaseName).Collection(variantCollection)
-	objID, err := primitive.ObjectIDFromHex(itemID)
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "FindVariantsByItemID", variantCollection)()
+	objID, err := parseObjectID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	return repo.findVariants(bson.D{primitive.E{Key: "itemId", Value: objID}})
+}
+
+// FindVariantsByTerm returns the variants of a product having a name or a synonym in any locale
+// with a word starting with term, whatever its case and accents
+func (repo *MongoVariantRepository) FindVariantsByTerm(itemID string, term string) ([]*models.Variant, error) {
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "FindVariantsByTerm", variantCollection)()
+	objID, err := parseObjectID(itemID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
-	filter := bson.D{primitive.E{Key: "itemId", Value: objID}}
+	return repo.findVariants(bson.D{primitive.E{Key: "itemId", Value: objID}, termFilter(term)})
+}
+
+func (repo *MongoVariantRepository) findVariants(filter bson.D) ([]*models.Variant, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(variantCollection)
 	cursor, err := collection.Find(context.Background(), filter)
-	defer cursor.Close(context.TODO())
 	if err != nil {
 		return nil, errors.Wrap(err, "Error finding all variants")
 	}
+	defer cursor.Close(context.TODO())
 	var results []*models.Variant = []*models.Variant{}
 	for cursor.Next(context.TODO()) {
 		var variant models.Variant
 		if err := cursor.Decode(&variant); err != nil {
-			log.Printf("Error decoding a Variant: %v", err)
+			return nil, errors.Wrap(err, "Error decoding a Variant")
 		} else {
 			results = append(results, &variant)
 		}
@@ -97,30 +120,34 @@ func (repo *MongoVariantRepository) FindVariantsByItemID(itemID string) ([]*mode
 
 	err = cursor.Err()
 	if err != nil {
-		return nil, errors.Wrap(err, "Error finding variants by itemId "+itemID)
+		return nil, errors.Wrap(err, "Error finding the variants of an item")
 	}
 	return results, nil
 }
 
 // Insert a new variant into mongodb
-func (repo *MongoVariantRepository) Insert(itemID string, variantDto *dtos.VariantDto) (string, error) {
+func (repo *MongoVariantRepository) Insert(ctx context.Context, itemID string, variantDto *dtos.VariantDto) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "Insert", variantCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(variantCollection)
-	objItemID, err := primitive.ObjectIDFromHex(itemID)
+	objItemID, err := parseObjectID(itemID)
 	if err != nil {
-		return string(""), errors.Wrap(err, "Error parsing ObjectID from hex")
+		return string(""), err
 	}
 	createdAt := primitive.DateTime(time.Now().UnixNano() / 1e6)
 	active := enums.Active
 	data := bson.D{
 		primitive.E{Key: "name", Value: variantDto.Name},
 		primitive.E{Key: "lname", Value: strings.ToLower(variantDto.Name)},
+		primitive.E{Key: "names", Value: localizedNames(variantDto.Names)},
+		primitive.E{Key: "terms", Value: searchTerms(variantDto.Name, variantDto.Names)},
 		primitive.E{Key: "itemId", Value: objItemID},
 		primitive.E{Key: "createdAt", Value: createdAt},
 		primitive.E{Key: "updatedAt", Value: createdAt},
 		primitive.E{Key: "recordStatus", Value: active},
+		primitive.E{Key: "version", Value: int64(1)},
 	}
 
-	result, err := collection.InsertOne(context.TODO(), data)
+	result, err := collection.InsertOne(ctx, data)
 	if err != nil {
 		return string(""), errors.Wrap(err, "Error inserting a new variant")
 	}
@@ -128,16 +155,18 @@ func (repo *MongoVariantRepository) Insert(itemID string, variantDto *dtos.Varia
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
-// Update a variant's data by its id in mongodb
-func (repo *MongoVariantRepository) Update(itemID string, variantID string, variantDto *dtos.VariantDto) (*models.Variant, error) {
+// Update a variant's data by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoVariantRepository) Update(ctx context.Context, itemID string, variantID string, variantDto *dtos.VariantDto, versions []int64) (*models.Variant, error) {
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "Update", variantCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(variantCollection)
-	objItemdID, err := primitive.ObjectIDFromHex(itemID)
+	objItemdID, err := parseObjectID(itemID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
-	objVariantID, err := primitive.ObjectIDFromHex(variantID)
+	objVariantID, err := parseObjectID(variantID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error parsing ObjectID from hex")
+		return nil, err
 	}
 	filter := bson.D{
 		primitive.E{Key: "_id", Value: objVariantID},
@@ -146,6 +175,8 @@ func (repo *MongoVariantRepository) Update(itemID string, variantID string, vari
 	data := bson.D{
 		primitive.E{Key: "name", Value: variantDto.Name},
 		primitive.E{Key: "lname", Value: strings.ToLower(variantDto.Name)},
+		primitive.E{Key: "names", Value: localizedNames(variantDto.Names)},
+		primitive.E{Key: "terms", Value: searchTerms(variantDto.Name, variantDto.Names)},
 		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
 	}
 	if variantDto.RecordStatus != nil {
@@ -154,45 +185,50 @@ func (repo *MongoVariantRepository) Update(itemID string, variantID string, vari
 	update := bson.D{primitive.E{
 		Key:   "$set",
 		Value: data,
-	}}
+	}, incVersion()}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, updateOpts)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
 	if result.Err() != nil {
 		return nil, errors.Wrap(result.Err(), "Error updating a Variant")
 	}
 	var updatedVariant *models.Variant
 	if err := result.Decode(&updatedVariant); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, versionConflict(ctx, collection, filter, versions)
 		}
 		return nil, errors.Wrap(err, "Error decoding a Variant")
 	}
 	return updatedVariant, nil
 }
 
-// Delete a variant document from mongodb
-func (repo *MongoVariantRepository) Delete(itemID string, variantID string) (bool, error) {
+// Delete a variant document from mongodb, when versions is not nil the document is only
+// removed if its stored version is one of them
+func (repo *MongoVariantRepository) Delete(ctx context.Context, itemID string, variantID string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoVariantRepository", "Delete", variantCollection)()
 	collection := repo.client.Database(repo.databaseName).Collection(variantCollection)
-	objItemID, err := primitive.ObjectIDFromHex(itemID)
+	objItemID, err := parseObjectID(itemID)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
-	objVariantID, err := primitive.ObjectIDFromHex(variantID)
+	objVariantID, err := parseObjectID(variantID)
 	if err != nil {
-		return false, errors.Wrap(err, "Error parsing ObjectID from Hex")
+		return false, err
 	}
 	filter := primitive.D{
 		primitive.E{Key: "_id", Value: objVariantID},
 		primitive.E{Key: "itemId", Value: objItemID},
 	}
-	result, err := collection.DeleteOne(context.Background(), filter)
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
 	if err != nil {
 		return false, errors.Wrap(err, "Error deleting a Variant")
 	}
-	return result.DeletedCount > 0, nil
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
 }
 
 // NewMongoVariantRepository returns a new instance of a mongodb repository for variants.
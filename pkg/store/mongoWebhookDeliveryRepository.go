@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const webhookDeliveryCollection = "webhookDeliveries"
+
+// webhookDeliveryIndexes are the indexes of the webhook deliveries, an event has a single
+// delivery per subscription and the oldest due delivery is claimed first
+var webhookDeliveryIndexes = []Index{
+	{Collection: webhookDeliveryCollection, Name: "subscriptionId_eventId_unique", Keys: bson.D{
+		primitive.E{Key: "subscriptionId", Value: 1},
+		primitive.E{Key: "eventId", Value: 1},
+	}, Unique: true},
+	{Collection: webhookDeliveryCollection, Name: "eventId", Keys: bson.D{primitive.E{Key: "eventId", Value: 1}}},
+	{Collection: webhookDeliveryCollection, Name: "status_nextAttemptAt", Keys: bson.D{
+		primitive.E{Key: "status", Value: 1},
+		primitive.E{Key: "nextAttemptAt", Value: 1},
+	}},
+	{Collection: webhookDeliveryCollection, Name: "subscriptionId_createdAt", Keys: bson.D{
+		primitive.E{Key: "subscriptionId", Value: 1},
+		primitive.E{Key: "createdAt", Value: -1},
+	}},
+}
+
+// MongoWebhookDeliveryRepository a repository for the deliveries of domain events to webhook
+// subscriptions in a mongo database
+type MongoWebhookDeliveryRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns a webhook delivery by its ID from mongodb
+func (repo *MongoWebhookDeliveryRepository) FindByID(id string) (*models.WebhookDelivery, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "FindByID", webhookDeliveryCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	return decodeWebhookDelivery(collection.FindOne(context.TODO(), filter))
+}
+
+// Find returns the webhook deliveries matching a query, most recent first
+func (repo *MongoWebhookDeliveryRepository) Find(query *dtos.WebhookDeliveryQueryDto) ([]*models.WebhookDelivery, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "Find", webhookDeliveryCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	filter := bson.D{}
+	if query.SubscriptionID != "" {
+		objID, err := parseObjectID(query.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, primitive.E{Key: "subscriptionId", Value: objID})
+	}
+	if query.EventID != "" {
+		objID, err := parseObjectID(query.EventID)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, primitive.E{Key: "eventId", Value: objID})
+	}
+	if query.Status != "" {
+		filter = append(filter, primitive.E{Key: "status", Value: query.Status})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{primitive.E{Key: "createdAt", Value: -1}}).
+		SetSkip(query.Skip).
+		SetLimit(query.Limit)
+	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	defer cancel()
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding webhook deliveries")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.WebhookDelivery = []*models.WebhookDelivery{}
+	for cursor.Next(context.TODO()) {
+		var delivery models.WebhookDelivery
+		if err := cursor.Decode(&delivery); err != nil {
+			logging.Default().WithError(err).Error("Error decoding a webhook delivery on Find()")
+		} else {
+			results = append(results, &delivery)
+		}
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding webhook deliveries")
+	}
+	return results, nil
+}
+
+// Enqueue creates the delivery of an event to a subscription, it does nothing when the
+// subscription already has a delivery for that event so that fanning out twice is harmless
+func (repo *MongoWebhookDeliveryRepository) Enqueue(subscriptionID primitive.ObjectID, event *models.OutboxEvent, now time.Time) error {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "Enqueue", webhookDeliveryCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	filter := bson.D{
+		primitive.E{Key: "subscriptionId", Value: subscriptionID},
+		primitive.E{Key: "eventId", Value: event.ID},
+	}
+	update := bson.D{primitive.E{Key: "$setOnInsert", Value: bson.D{
+		primitive.E{Key: "eventType", Value: event.Type},
+		primitive.E{Key: "payload", Value: event.Payload},
+		primitive.E{Key: "status", Value: enums.DeliveryPending},
+		primitive.E{Key: "attempts", Value: 0},
+		primitive.E{Key: "nextAttemptAt", Value: now},
+		primitive.E{Key: "createdAt", Value: now},
+		primitive.E{Key: "updatedAt", Value: now},
+	}}}
+	opts := options.Update().SetUpsert(true)
+	if _, err := collection.UpdateOne(context.TODO(), filter, update, opts); err != nil {
+		return errors.Wrap(err, "Error enqueuing a webhook delivery")
+	}
+	return nil
+}
+
+// ClaimDue takes the oldest delivery whose next attempt is due and postpones it until
+// leaseUntil, so that no other dispatcher attempts it while it is in flight
+func (repo *MongoWebhookDeliveryRepository) ClaimDue(now time.Time, leaseUntil time.Time) (*models.WebhookDelivery, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "ClaimDue", webhookDeliveryCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	filter := bson.D{
+		primitive.E{Key: "status", Value: bson.D{primitive.E{Key: "$in", Value: bson.A{enums.DeliveryPending, enums.DeliveryRetrying}}}},
+		primitive.E{Key: "nextAttemptAt", Value: bson.D{primitive.E{Key: "$lte", Value: now}}},
+	}
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "nextAttemptAt", Value: leaseUntil},
+	}}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{primitive.E{Key: "nextAttemptAt", Value: 1}}).
+		SetReturnDocument(options.After)
+	return decodeWebhookDelivery(collection.FindOneAndUpdate(context.TODO(), filter, update, opts))
+}
+
+// RecordAttempt saves the outcome of an attempt to deliver
+func (repo *MongoWebhookDeliveryRepository) RecordAttempt(delivery *models.WebhookDelivery) error {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "RecordAttempt", webhookDeliveryCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: delivery.ID}}
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "status", Value: delivery.Status},
+		primitive.E{Key: "attempts", Value: delivery.Attempts},
+		primitive.E{Key: "nextAttemptAt", Value: delivery.NextAttemptAt},
+		primitive.E{Key: "lastAttemptAt", Value: delivery.LastAttemptAt},
+		primitive.E{Key: "lastStatusCode", Value: delivery.LastStatusCode},
+		primitive.E{Key: "lastError", Value: delivery.LastError},
+		primitive.E{Key: "updatedAt", Value: time.Now()},
+	}}}
+	if _, err := collection.UpdateOne(context.TODO(), filter, update); err != nil {
+		return errors.Wrap(err, "Error recording a webhook delivery attempt")
+	}
+	return nil
+}
+
+// Replay schedules a delivery to be attempted again right away with a fresh set of attempts
+func (repo *MongoWebhookDeliveryRepository) Replay(id string) (*models.WebhookDelivery, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "Replay", webhookDeliveryCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	return decodeWebhookDelivery(collection.FindOneAndUpdate(context.TODO(), filter, replayUpdate(), opts))
+}
+
+// ReplayEvent schedules every delivery of an event to be attempted again right away
+func (repo *MongoWebhookDeliveryRepository) ReplayEvent(eventID primitive.ObjectID) (int64, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookDeliveryRepository", "ReplayEvent", webhookDeliveryCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookDeliveryCollection)
+	filter := bson.D{primitive.E{Key: "eventId", Value: eventID}}
+	result, err := collection.UpdateMany(context.TODO(), filter, replayUpdate())
+	if err != nil {
+		return 0, errors.Wrap(err, "Error replaying the webhook deliveries of an event")
+	}
+	return result.ModifiedCount, nil
+}
+
+func replayUpdate() bson.D {
+	now := time.Now()
+	return bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "status", Value: enums.DeliveryPending},
+			primitive.E{Key: "attempts", Value: 0},
+			primitive.E{Key: "nextAttemptAt", Value: now},
+			primitive.E{Key: "updatedAt", Value: now},
+		}},
+		primitive.E{Key: "$unset", Value: bson.D{
+			primitive.E{Key: "lastStatusCode", Value: ""},
+			primitive.E{Key: "lastError", Value: ""},
+		}},
+	}
+}
+
+func decodeWebhookDelivery(result *mongo.SingleResult) (*models.WebhookDelivery, error) {
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var delivery *models.WebhookDelivery
+	if err := result.Decode(&delivery); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding a webhook delivery")
+	}
+	return delivery, nil
+}
+
+// NewMongoWebhookDeliveryRepository returns a new instance of a MongoDB webhook delivery repo.
+func NewMongoWebhookDeliveryRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoWebhookDeliveryRepository {
+	return &MongoWebhookDeliveryRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"futuagro.com/pkg/config"
+	"futuagro.com/pkg/domain/dtos"
+	"futuagro.com/pkg/domain/enums"
+	"futuagro.com/pkg/domain/models"
+	"futuagro.com/pkg/logging"
+	"futuagro.com/pkg/metrics"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const webhookSubscriptionCollection = "webhookSubscriptions"
+
+// MongoWebhookRepository a repository for saving webhook subscriptions into a mongo database
+type MongoWebhookRepository struct {
+	databaseName string
+	client       *mongo.Client
+}
+
+// FindByID returns a webhook subscription by its ID from mongodb
+func (repo *MongoWebhookRepository) FindByID(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "FindByID", webhookSubscriptionCollection)()
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection := repo.client.Database(repo.databaseName).Collection(webhookSubscriptionCollection)
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	result := collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	var subscription *models.WebhookSubscription
+	if err := result.Decode(&subscription); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Error decoding a webhook subscription")
+	}
+	return subscription, nil
+}
+
+// FindAll returns a list of webhook subscriptions from mongodb
+func (repo *MongoWebhookRepository) FindAll() ([]*models.WebhookSubscription, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "FindAll", webhookSubscriptionCollection)()
+	return repo.findSubscriptions(bson.D{})
+}
+
+// FindActiveByEventType returns the active subscriptions to an event type, including the ones
+// subscribed to every event
+func (repo *MongoWebhookRepository) FindActiveByEventType(eventType enums.EnumEventType) ([]*models.WebhookSubscription, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "FindActiveByEventType", webhookSubscriptionCollection)()
+	filter := bson.D{
+		primitive.E{Key: "eventTypes", Value: bson.D{primitive.E{Key: "$in", Value: bson.A{eventType, enums.AllEvents}}}},
+		primitive.E{Key: "recordStatus", Value: enums.Active},
+	}
+	return repo.findSubscriptions(filter)
+}
+
+func (repo *MongoWebhookRepository) findSubscriptions(filter interface{}) ([]*models.WebhookSubscription, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(webhookSubscriptionCollection)
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "createdAt", Value: 1}})
+	cursor, err := collection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding webhook subscriptions")
+	}
+	defer cursor.Close(context.TODO())
+
+	var results []*models.WebhookSubscription = []*models.WebhookSubscription{}
+	for cursor.Next(context.TODO()) {
+		var subscription models.WebhookSubscription
+		if err := cursor.Decode(&subscription); err != nil {
+			logging.Default().WithError(err).Error("Error decoding a webhook subscription on findSubscriptions()")
+		} else {
+			results = append(results, &subscription)
+		}
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding webhook subscriptions")
+	}
+	return results, nil
+}
+
+// Insert a new webhook subscription into mongodb along with its signing secret
+func (repo *MongoWebhookRepository) Insert(ctx context.Context, dto *dtos.WebhookSubscriptionDto, secret string) (string, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "Insert", webhookSubscriptionCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookSubscriptionCollection)
+	now := primitive.DateTime(time.Now().UnixNano() / 1e6)
+	data := bson.D{
+		primitive.E{Key: "url", Value: dto.URL},
+		primitive.E{Key: "description", Value: dto.Description},
+		primitive.E{Key: "eventTypes", Value: dto.EventTypes},
+		primitive.E{Key: "secret", Value: secret},
+		primitive.E{Key: "recordStatus", Value: enums.Active},
+		primitive.E{Key: "createdAt", Value: now},
+		primitive.E{Key: "updatedAt", Value: now},
+		primitive.E{Key: "version", Value: int64(1)},
+	}
+	result, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return string(""), errors.Wrap(err, "Inserting a new webhook subscription")
+	}
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// Update a webhook subscription by its id in mongodb, when versions is not nil the write only
+// applies if the stored version is one of them
+func (repo *MongoWebhookRepository) Update(ctx context.Context, id string, dto *dtos.WebhookSubscriptionDto, versions []int64) (*models.WebhookSubscription, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "Update", webhookSubscriptionCollection)()
+	data := bson.D{
+		primitive.E{Key: "url", Value: dto.URL},
+		primitive.E{Key: "description", Value: dto.Description},
+		primitive.E{Key: "eventTypes", Value: dto.EventTypes},
+		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	if dto.RecordStatus != nil {
+		data = append(data, primitive.E{Key: "recordStatus", Value: dto.RecordStatus})
+	}
+	return repo.updateSubscription(ctx, id, data, versions)
+}
+
+// UpdateSecret replaces the signing secret of a webhook subscription
+func (repo *MongoWebhookRepository) UpdateSecret(ctx context.Context, id string, secret string, versions []int64) (*models.WebhookSubscription, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "UpdateSecret", webhookSubscriptionCollection)()
+	data := bson.D{
+		primitive.E{Key: "secret", Value: secret},
+		primitive.E{Key: "updatedAt", Value: primitive.DateTime(time.Now().UnixNano() / 1e6)},
+	}
+	return repo.updateSubscription(ctx, id, data, versions)
+}
+
+func (repo *MongoWebhookRepository) updateSubscription(ctx context.Context, id string, data bson.D, versions []int64) (*models.WebhookSubscription, error) {
+	collection := repo.client.Database(repo.databaseName).Collection(webhookSubscriptionCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	update := bson.D{primitive.E{Key: "$set", Value: data}, incVersion()}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := collection.FindOneAndUpdate(ctx, withVersions(filter, versions), update, updateOpts)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+	var updatedSubscription *models.WebhookSubscription
+	if err := result.Decode(&updatedSubscription); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, versionConflict(ctx, collection, filter, versions)
+		}
+		return nil, errors.Wrap(err, "Error decoding a webhook subscription")
+	}
+	return updatedSubscription, nil
+}
+
+// Delete a webhook subscription document from mongodb, when versions is not nil the document
+// is only removed if its stored version is one of them
+func (repo *MongoWebhookRepository) Delete(ctx context.Context, id string, versions []int64) (bool, error) {
+	defer metrics.ObserveMongoOperation("MongoWebhookRepository", "Delete", webhookSubscriptionCollection)()
+	collection := repo.client.Database(repo.databaseName).Collection(webhookSubscriptionCollection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.D{primitive.E{Key: "_id", Value: objID}}
+	result, err := collection.DeleteOne(ctx, withVersions(filter, versions))
+	if err != nil {
+		return false, errors.Wrap(err, "Error deleting a webhook subscription")
+	}
+	if result.DeletedCount == 0 {
+		return false, versionConflict(ctx, collection, filter, versions)
+	}
+	return true, nil
+}
+
+// NewMongoWebhookRepository returns a new instance of a MongoDB webhook subscription repo.
+func NewMongoWebhookRepository(confPtr *config.Config, clientPtr *mongo.Client) *MongoWebhookRepository {
+	return &MongoWebhookRepository{databaseName: confPtr.Database.Name, client: clientPtr}
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"futuagro.com/pkg/logging"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// maxTransactionAttempts bounds the runs of a transaction aborted by transient errors, and
+	// the commits of a transaction whose outcome is unknown
+	maxTransactionAttempts = 5
+	// detectTimeout bounds the detection of the deployment, it is run apart from the context of
+	// the write that triggers it
+	detectTimeout = 10 * time.Second
+
+	transientTransactionError      = "TransientTransactionError"
+	unknownTransactionCommitResult = "UnknownTransactionCommitResult"
+)
+
+// MongoTransactor runs functions in multi-document transactions, the repositories called with the
+// context given to the functions join the transaction. Standalone servers have no transactions,
+// the functions are run without one and each write stands on its own.
+type MongoTransactor struct {
+	client *mongo.Client
+
+	mu        sync.Mutex
+	detected  bool
+	supported bool
+}
+
+// RunInTransaction runs fn in a transaction committed when fn returns nil and aborted otherwise.
+// fn is run again when the transaction is aborted by a transient error, e.g. a write conflict or
+// a primary election, it must not have effects outside the database.
+func (t *MongoTransactor) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	supported, err := t.isSupported(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return errors.Wrap(err, "Error starting a MongoDB session")
+	}
+	defer session.EndSession(ctx)
+
+	return mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+		var err error
+		for attempt := 1; attempt <= maxTransactionAttempts; attempt++ {
+			if err = runTransaction(sc, fn); !hasErrorLabel(err, transientTransactionError) {
+				return err
+			}
+			logging.FromContext(ctx).WithError(err).WithField("attempt", attempt).Warn("Retrying a transaction")
+		}
+		return err
+	})
+}
+
+func runTransaction(sc mongo.SessionContext, fn func(ctx context.Context) error) error {
+	if err := sc.StartTransaction(); err != nil {
+		return errors.Wrap(err, "Error starting a transaction")
+	}
+	if err := fn(sc); err != nil {
+		sc.AbortTransaction(context.Background())
+		return err
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxTransactionAttempts; attempt++ {
+		if err = sc.CommitTransaction(sc); !hasErrorLabel(err, unknownTransactionCommitResult) {
+			break
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "Error committing a transaction")
+	}
+	return nil
+}
+
+// isSupported tells whether the deployment is a replica set or a sharded cluster, the deployments
+// with transactions. It is asked until an answer is obtained, the writes fail meanwhile rather than
+// running without a transaction on a deployment that has them.
+func (t *MongoTransactor) isSupported(ctx context.Context) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.detected {
+		return t.supported, nil
+	}
+
+	detectCtx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+	defer cancel()
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	command := bson.D{primitive.E{Key: "isMaster", Value: 1}}
+	if err := t.client.Database("admin").RunCommand(detectCtx, command).Decode(&hello); err != nil {
+		return false, errors.Wrap(err, "Error detecting the MongoDB deployment")
+	}
+	t.detected = true
+	t.supported = hello.SetName != "" || hello.Msg == "isdbgrid"
+	if !t.supported {
+		logging.FromContext(ctx).Info("MongoDB is a standalone server, running without transactions")
+	}
+	return t.supported, nil
+}
+
+func hasErrorLabel(err error, label string) bool {
+	commandError, ok := errors.Cause(err).(mongo.CommandError)
+	return ok && commandError.HasErrorLabel(label)
+}
+
+// NewMongoTransactor returns a transactor running transactions on the client
+func NewMongoTransactor(clientPtr *mongo.Client) *MongoTransactor {
+	return &MongoTransactor{client: clientPtr}
+}
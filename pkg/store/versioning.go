@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+
+	"futuagro.com/pkg/domain/errs"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrVersionMismatch is returned when a conditional write targets a document whose stored
+// version is not one of the versions expected by the caller
+var ErrVersionMismatch = errs.PreconditionFailed("The resource has been modified")
+
+// withVersions restricts a filter to the given document versions, a nil slice leaves it unconditional.
+// Documents written before versioning have no version field and are matched as version 0.
+func withVersions(filter bson.D, versions []int64) bson.D {
+	if versions == nil {
+		return filter
+	}
+	values := bson.A{}
+	for _, v := range versions {
+		values = append(values, v)
+		if v == 0 {
+			values = append(values, nil)
+		}
+	}
+	conditional := append(bson.D{}, filter...)
+	return append(conditional, primitive.E{Key: "version", Value: bson.M{"$in": values}})
+}
+
+// incVersion is the update operator that bumps the version of a document on every write
+func incVersion() primitive.E {
+	return primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: int64(1)}}}
+}
+
+// versionConflict tells apart a missing document from a stale version after a conditional write
+// matched nothing, it returns ErrVersionMismatch when the document still exists. ctx is the one of
+// the write, the document is counted in its transaction.
+func versionConflict(ctx context.Context, collection *mongo.Collection, filter bson.D, versions []int64) error {
+	if versions == nil {
+		return nil
+	}
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, "Error checking a document version")
+	}
+	if count > 0 {
+		return ErrVersionMismatch
+	}
+	return nil
+}